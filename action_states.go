@@ -0,0 +1,216 @@
+package slippi
+
+// Action state IDs and ranges, as stored in the ActionStateID field of frame
+// updates. Ranges match those used by slippi-js.
+const (
+	// dying
+	StateDyingStart = 0x0
+	StateDeadDown   = 0x0
+	StateDeadLeft   = 0x1
+	StateDeadRight  = 0x2
+	StateDyingEnd   = 0xA
+
+	// grounded control
+	StateGroundedControlStart = 0xE
+	StateGroundedControlEnd   = 0x18
+	StateWait                 = 0xE
+	StateWalkSlow             = 0xF
+	StateTurn                 = 0x12
+	StateDash                 = 0x14
+	StateRun                  = 0x15
+	StateKneeBend             = 0x18
+	StateJumpForward          = 0x19
+	StateJumpBackward         = 0x1A
+	StateFallForward          = 0x1E
+	StateFallBackward         = 0x1F
+	StateFallAerialBackward   = 0x22
+	StateDamageFall           = 0x26
+
+	// crouching
+	StateSquatStart = 0x27
+	StateSquatEnd   = 0x29
+
+	// landing
+	StateLanding            = 0x2A
+	StateLandingFallSpecial = 0x2B
+
+	// grounded attacks
+	StateGroundAttackStart = 0x2C
+	StateJab1              = 0x2C
+	StateJab2              = 0x2D
+	StateJab3              = 0x2E
+	StateRapidJabStart     = 0x2F
+	StateDashAttack        = 0x32
+	StateForwardTiltStart  = 0x33
+	StateForwardTiltEnd    = 0x37
+	StateUpTilt            = 0x38
+	StateDownTilt          = 0x39
+	StateForwardSmashStart = 0x3A
+	StateForwardSmashEnd   = 0x3E
+	StateUpSmash           = 0x3F
+	StateDownSmash         = 0x40
+	StateGroundAttackEnd   = 0x40
+
+	// aerial attacks
+	StateAerialAttackStart  = 0x41
+	StateNair               = 0x41
+	StateFair               = 0x42
+	StateBair               = 0x43
+	StateUair               = 0x44
+	StateDair               = 0x45
+	StateAerialAttackEnd    = 0x45
+	StateAerialLandingStart = 0x46
+	StateNairLanding        = 0x46
+	StateFairLanding        = 0x47
+	StateBairLanding        = 0x48
+	StateUairLanding        = 0x49
+	StateDairLanding        = 0x4A
+	StateAerialLandingEnd   = 0x4A
+
+	// damage
+	StateDamageStart = 0x4B
+	StateDamageEnd   = 0x5B
+
+	// shield
+	StateGuardStart      = 0xB2
+	StateGuardOn         = 0xB2
+	StateGuard           = 0xB3
+	StateGuardOff        = 0xB4
+	StateGuardSetOff     = 0xB5
+	StateGuardReflect    = 0xB6
+	StateGuardEnd        = 0xB6
+	StateGuardBreakStart = 0xCD
+	StateGuardBreakEnd   = 0xD3
+
+	// knockdowns and techs
+	StateDownStart       = 0xB7
+	StateTechMissUp      = 0xB7
+	StateJabResetUp      = 0xB9
+	StateDownStandUp     = 0xBA
+	StateDownAttackUp    = 0xBB
+	StateDownForwardUp   = 0xBC
+	StateDownBackUp      = 0xBD
+	StateTechMissDown    = 0xBF
+	StateJabResetDown    = 0xC1
+	StateDownStandDown   = 0xC2
+	StateDownAttackDown  = 0xC3
+	StateDownForwardDown = 0xC4
+	StateDownBackDown    = 0xC5
+	StateDownEnd         = 0xC6
+	StateTechStart       = 0xC7
+	StateNeutralTech     = 0xC7
+	StateForwardTech     = 0xC8
+	StateBackwardTech    = 0xC9
+	StateWallTech        = 0xCA
+	StateTechEnd         = 0xCC
+	StateMissedWallTech  = 0xF7
+
+	// grabs
+	StateGrab         = 0xD4
+	StateGrabPull     = 0xD5
+	StateDashGrab     = 0xD6
+	StateDashGrabPull = 0xD7
+	StateGrabWait     = 0xD8
+	StatePummel       = 0xD9
+	StateThrowForward = 0xDB
+	StateThrowBack    = 0xDC
+	StateThrowUp      = 0xDD
+	StateThrowDown    = 0xDE
+
+	// grabbed
+	StateCaptureStart = 0xDF
+	StateCaptureEnd   = 0xE8
+
+	// dodges
+	StateRollForward  = 0xE9
+	StateRollBackward = 0xEA
+	StateSpotDodge    = 0xEB
+	StateAirDodge     = 0xEC
+
+	// ledge
+	StateCliffCatch = 0xFC
+	StateCliffWait  = 0xFD
+
+	// command grabs
+	StateCommandGrabRange1Start = 0x10A
+	StateCommandGrabRange1End   = 0x130
+	StateBarrelWait             = 0x125
+	StateCommandGrabRange2Start = 0x147
+	StateCommandGrabRange2End   = 0x152
+
+	// states from here on differ for each character
+	StateCharacterSpecificStart = 0x155
+)
+
+// IsDead returns whether the action state is a dying state.
+func IsDead(actionStateID uint16) bool {
+	return actionStateID <= StateDyingEnd
+}
+
+// IsDamaged returns whether the action state is a damaged (hitstun) state.
+func IsDamaged(actionStateID uint16) bool {
+	return actionStateID >= StateDamageStart && actionStateID <= StateDamageEnd
+}
+
+// IsGrabbed returns whether the action state is a state of being held by a
+// grab.
+func IsGrabbed(actionStateID uint16) bool {
+	return actionStateID >= StateCaptureStart && actionStateID <= StateCaptureEnd
+}
+
+// IsCommandGrabbed returns whether the action state is a state of being held
+// by a command grab, such as Bowser's side-B.
+func IsCommandGrabbed(actionStateID uint16) bool {
+	inRange := (actionStateID >= StateCommandGrabRange1Start && actionStateID <= StateCommandGrabRange1End) ||
+		(actionStateID >= StateCommandGrabRange2Start && actionStateID <= StateCommandGrabRange2End)
+	return inRange && actionStateID != StateBarrelWait
+}
+
+// IsCharacterSpecific returns whether the action state is one whose meaning
+// depends on the character, such as a special move.
+func IsCharacterSpecific(actionStateID uint16) bool {
+	return actionStateID >= StateCharacterSpecificStart
+}
+
+// IsInControl returns whether the action state is one in which the player is
+// actionable on the ground.
+func IsInControl(actionStateID uint16) bool {
+	ground := actionStateID >= StateGroundedControlStart && actionStateID <= StateGroundedControlEnd
+	squat := actionStateID >= StateSquatStart && actionStateID <= StateSquatEnd
+	groundAttack := actionStateID > StateGroundAttackStart && actionStateID <= StateGroundAttackEnd
+	guard := actionStateID == StateGuardStart
+	return ground || squat || groundAttack || guard
+}
+
+// IsTeching returns whether the action state is a tech or missed tech state.
+func IsTeching(actionStateID uint16) bool {
+	return (actionStateID >= StateTechStart && actionStateID <= StateTechEnd) ||
+		actionStateID == StateJabResetUp || actionStateID == StateJabResetDown
+}
+
+// IsDown returns whether the action state is a knockdown state.
+func IsDown(actionStateID uint16) bool {
+	return actionStateID >= StateDownStart && actionStateID <= StateDownEnd
+}
+
+// IsShielding returns whether the action state is a shield state.
+func IsShielding(actionStateID uint16) bool {
+	return actionStateID >= StateGuardStart && actionStateID <= StateGuardEnd
+}
+
+// IsOnLedge returns whether the action state is a ledge hang state.
+func IsOnLedge(actionStateID uint16) bool {
+	return actionStateID == StateCliffCatch || actionStateID == StateCliffWait
+}
+
+// An ActionStateChange is a change in the action state of a player, sent by a
+// SlpParser with the ActionStateChanged event once the frame it happened on is
+// finalized.
+type ActionStateChange struct {
+	PlayerIndex uint8 `json:"playerIndex"`
+	Frame       int32 `json:"frame"`
+	// PreviousState is the action state of the player on the frame before,
+	// and State their action state as of the frame.
+	PreviousState uint16 `json:"previousState"`
+	State         uint16 `json:"state"`
+}
@@ -0,0 +1,56 @@
+package slippi
+
+import (
+	"os"
+	"testing"
+)
+
+func TestActionStateChanges(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game, err := NewSlpGameFromBytes(b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer game.Close()
+
+	frames, err := game.GetFrames()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := make(map[uint8]int)
+	for _, frameNumber := range sortedFrameNumbers(frames) {
+		prev, ok := frames[frameNumber-1]
+		if !ok {
+			continue
+		}
+
+		for index, updates := range frames[frameNumber].Players {
+			if prevPost := prev.Players[index].Post; prevPost != nil && updates.Post.ActionStateID != prevPost.ActionStateID {
+				expected[index]++
+			}
+		}
+	}
+
+	counts := make(map[uint8]int)
+	for _, payload := range collectEvents(t, b, ActionStateChanged) {
+		change := payload.(ActionStateChange)
+		counts[change.PlayerIndex]++
+
+		frame := frames[change.Frame]
+		prev := frames[change.Frame-1]
+		if frame.Players[change.PlayerIndex].Post.ActionStateID != change.State || prev.Players[change.PlayerIndex].Post.ActionStateID != change.PreviousState {
+			t.Fatalf("expected change %+v to match frames %d and %d", change, change.Frame-1, change.Frame)
+		}
+	}
+
+	for index, count := range expected {
+		if count == 0 || counts[index] != count {
+			t.Errorf("expected %d action state changes for player %d, got %d", count, index, counts[index])
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package slippi
+
+import "fmt"
+
+// AttackID enumerates the attack IDs in Melee, as stored in the
+// LastHittingAttackID field of the PostFrameUpdate event.
+type AttackID uint8
+
+// AttackIDs
+const (
+	NoAttack AttackID = iota
+	MiscAttack
+	Jab1
+	Jab2
+	Jab3
+	RapidJabs
+	DashAttack
+	ForwardTilt
+	UpTilt
+	DownTilt
+	ForwardSmash
+	UpSmash
+	DownSmash
+	NeutralAir
+	ForwardAir
+	BackAir
+	UpAir
+	DownAir
+	NeutralSpecial
+	SideSpecial
+	UpSpecial
+	DownSpecial
+	GetupAttackSlow AttackID = iota + 28
+	GetupAttack
+	Pummel
+	ForwardThrow
+	BackThrow
+	UpThrow
+	DownThrow
+	CargoForwardThrow
+	CargoBackThrow
+	CargoUpThrow
+	CargoDownThrow
+	EdgeAttackSlow
+	EdgeAttack
+	BeamSwordJab
+	BeamSwordTiltSwing
+	BeamSwordSmashSwing
+	BeamSwordDashSwing
+	HomeRunBatJab
+	HomeRunBatTiltSwing
+	HomeRunBatSmashSwing
+	HomeRunBatDashSwing
+	ParasolJab
+	ParasolTiltSwing
+	ParasolSmashSwing
+	ParasolDashSwing
+	FanJab
+	FanTiltSwing
+	FanSmashSwing
+	FanDashSwing
+	StarRodJab
+	StarRodTiltSwing
+	StarRodSmashSwing
+	StarRodDashSwing
+	LipsStickJab
+	LipsStickTiltSwing
+	LipsStickSmashSwing
+	LipsStickDashSwing
+	OpenParasol
+	RayGunShoot
+	FireFlowerShoot
+	ScrewAttack
+	SuperScopeRapid
+	SuperScopeCharged
+	HammerSwing
+)
+
+type attackInfo struct {
+	name      string
+	shortName string
+}
+
+var attacks = map[AttackID]attackInfo{
+	MiscAttack:           {"Miscellaneous", "misc"},
+	Jab1:                 {"Jab", "jab"},
+	Jab2:                 {"Jab", "jab"},
+	Jab3:                 {"Jab", "jab"},
+	RapidJabs:            {"Rapid Jabs", "rapid-jabs"},
+	DashAttack:           {"Dash Attack", "dash"},
+	ForwardTilt:          {"Forward Tilt", "ftilt"},
+	UpTilt:               {"Up Tilt", "utilt"},
+	DownTilt:             {"Down Tilt", "dtilt"},
+	ForwardSmash:         {"Forward Smash", "fsmash"},
+	UpSmash:              {"Up Smash", "usmash"},
+	DownSmash:            {"Down Smash", "dsmash"},
+	NeutralAir:           {"Neutral Air", "nair"},
+	ForwardAir:           {"Forward Air", "fair"},
+	BackAir:              {"Back Air", "bair"},
+	UpAir:                {"Up Air", "uair"},
+	DownAir:              {"Down Air", "dair"},
+	NeutralSpecial:       {"Neutral B", "neutral-b"},
+	SideSpecial:          {"Side B", "side-b"},
+	UpSpecial:            {"Up B", "up-b"},
+	DownSpecial:          {"Down B", "down-b"},
+	GetupAttackSlow:      {"Getup Attack (Slow)", "getup-slow"},
+	GetupAttack:          {"Getup Attack", "getup"},
+	Pummel:               {"Pummel", "pummel"},
+	ForwardThrow:         {"Forward Throw", "fthrow"},
+	BackThrow:            {"Back Throw", "bthrow"},
+	UpThrow:              {"Up Throw", "uthrow"},
+	DownThrow:            {"Down Throw", "dthrow"},
+	CargoForwardThrow:    {"Cargo Forward Throw", "cargo-fthrow"},
+	CargoBackThrow:       {"Cargo Back Throw", "cargo-bthrow"},
+	CargoUpThrow:         {"Cargo Up Throw", "cargo-uthrow"},
+	CargoDownThrow:       {"Cargo Down Throw", "cargo-dthrow"},
+	EdgeAttackSlow:       {"Edge Attack (Slow)", "edge-slow"},
+	EdgeAttack:           {"Edge Attack", "edge"},
+	BeamSwordJab:         {"Beam Sword Jab", "beam-sword-jab"},
+	BeamSwordTiltSwing:   {"Beam Sword Tilt Swing", "beam-sword-tilt"},
+	BeamSwordSmashSwing:  {"Beam Sword Smash Swing", "beam-sword-smash"},
+	BeamSwordDashSwing:   {"Beam Sword Dash Swing", "beam-sword-dash"},
+	HomeRunBatJab:        {"Home Run Bat Jab", "bat-jab"},
+	HomeRunBatTiltSwing:  {"Home Run Bat Tilt Swing", "bat-tilt"},
+	HomeRunBatSmashSwing: {"Home Run Bat Smash Swing", "bat-smash"},
+	HomeRunBatDashSwing:  {"Home Run Bat Dash Swing", "bat-dash"},
+	ParasolJab:           {"Parasol Jab", "parasol-jab"},
+	ParasolTiltSwing:     {"Parasol Tilt Swing", "parasol-tilt"},
+	ParasolSmashSwing:    {"Parasol Smash Swing", "parasol-smash"},
+	ParasolDashSwing:     {"Parasol Dash Swing", "parasol-dash"},
+	FanJab:               {"Fan Jab", "fan-jab"},
+	FanTiltSwing:         {"Fan Tilt Swing", "fan-tilt"},
+	FanSmashSwing:        {"Fan Smash Swing", "fan-smash"},
+	FanDashSwing:         {"Fan Dash Swing", "fan-dash"},
+	StarRodJab:           {"Star Rod Jab", "star-rod-jab"},
+	StarRodTiltSwing:     {"Star Rod Tilt Swing", "star-rod-tilt"},
+	StarRodSmashSwing:    {"Star Rod Smash Swing", "star-rod-smash"},
+	StarRodDashSwing:     {"Star Rod Dash Swing", "star-rod-dash"},
+	LipsStickJab:         {"Lip's Stick Jab", "lips-stick-jab"},
+	LipsStickTiltSwing:   {"Lip's Stick Tilt Swing", "lips-stick-tilt"},
+	LipsStickSmashSwing:  {"Lip's Stick Smash Swing", "lips-stick-smash"},
+	LipsStickDashSwing:   {"Lip's Stick Dash Swing", "lips-stick-dash"},
+	OpenParasol:          {"Open Parasol", "parasol-open"},
+	RayGunShoot:          {"Ray Gun Shoot", "ray-gun"},
+	FireFlowerShoot:      {"Fire Flower Shoot", "fire-flower"},
+	ScrewAttack:          {"Screw Attack", "screw-attack"},
+	SuperScopeRapid:      {"Super Scope (Rapid)", "super-scope-rapid"},
+	SuperScopeCharged:    {"Super Scope (Charged)", "super-scope-charged"},
+	HammerSwing:          {"Hammer", "hammer"},
+}
+
+// String returns the human-readable name of the attack.
+func (a AttackID) String() string {
+	if info, ok := attacks[a]; ok {
+		return info.name
+	}
+
+	if a == NoAttack {
+		return "None"
+	}
+
+	return fmt.Sprintf("Unknown Attack (%d)", uint8(a))
+}
+
+// ShortName returns the short, lowercase name of the attack (e.g. "fsmash").
+func (a AttackID) ShortName() string {
+	if info, ok := attacks[a]; ok {
+		return info.shortName
+	}
+
+	return "unknown"
+}
+
+// AttackOfActionState returns the attack of a normal attack's action state,
+// or NoAttack for other action states, including those of specials, which are
+// specific to each character.
+func AttackOfActionState(actionStateID uint16) AttackID {
+	switch {
+	case actionStateID == StateJab1:
+		return Jab1
+	case actionStateID == StateJab2:
+		return Jab2
+	case actionStateID == StateJab3:
+		return Jab3
+	case actionStateID >= StateRapidJabStart && actionStateID < StateDashAttack:
+		return RapidJabs
+	case actionStateID == StateDashAttack:
+		return DashAttack
+	case actionStateID >= StateForwardTiltStart && actionStateID <= StateForwardTiltEnd:
+		return ForwardTilt
+	case actionStateID == StateUpTilt:
+		return UpTilt
+	case actionStateID == StateDownTilt:
+		return DownTilt
+	case actionStateID >= StateForwardSmashStart && actionStateID <= StateForwardSmashEnd:
+		return ForwardSmash
+	case actionStateID == StateUpSmash:
+		return UpSmash
+	case actionStateID == StateDownSmash:
+		return DownSmash
+	case actionStateID == StateNair:
+		return NeutralAir
+	case actionStateID == StateFair:
+		return ForwardAir
+	case actionStateID == StateBair:
+		return BackAir
+	case actionStateID == StateUair:
+		return UpAir
+	case actionStateID == StateDair:
+		return DownAir
+	}
+
+	return NoAttack
+}
+
+// IsAerial returns whether the attack is an aerial.
+func (a AttackID) IsAerial() bool {
+	return a >= NeutralAir && a <= DownAir
+}
+
+// IsSmash returns whether the attack is a smash attack.
+func (a AttackID) IsSmash() bool {
+	return a >= ForwardSmash && a <= DownSmash
+}
+
+// IsSpecial returns whether the attack is a special move.
+func (a AttackID) IsSpecial() bool {
+	return a >= NeutralSpecial && a <= DownSpecial
+}
+
+// IsThrow returns whether the attack is a throw, including cargo throws.
+func (a AttackID) IsThrow() bool {
+	return (a >= ForwardThrow && a <= DownThrow) || (a >= CargoForwardThrow && a <= CargoDownThrow)
+}
+
+// IsItemAttack returns whether the attack was performed with an item.
+func (a AttackID) IsItemAttack() bool {
+	return a >= BeamSwordJab && a <= HammerSwing
+}
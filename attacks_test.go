@@ -0,0 +1,31 @@
+package slippi
+
+import "testing"
+
+func TestAttackIDs(t *testing.T) {
+	// attack IDs are numbered contiguously from the getup attacks through to
+	// the item attacks
+	expected := map[AttackID]uint8{
+		DownSpecial:       21,
+		GetupAttackSlow:   50,
+		DownThrow:         56,
+		CargoForwardThrow: 57,
+		CargoDownThrow:    60,
+		EdgeAttackSlow:    61,
+		EdgeAttack:        62,
+		BeamSwordJab:      63,
+		HammerSwing:       93,
+	}
+	for attack, id := range expected {
+		if uint8(attack) != id {
+			t.Errorf("expected %s to be %d, got %d", attack, id, uint8(attack))
+		}
+	}
+
+	if name := AttackID(61).String(); name != "Edge Attack (Slow)" {
+		t.Errorf("expected attack 61 to be Edge Attack (Slow), got %s", name)
+	}
+	if name := AttackID(94).String(); name != "Unknown Attack (94)" {
+		t.Errorf("expected attack 94 to be unknown, got %s", name)
+	}
+}
@@ -0,0 +1,116 @@
+package slippi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// SyntheticReplayOpts contains options that determine the replay generated
+// by SyntheticReplay.
+type SyntheticReplayOpts struct {
+	// Frames is the number of frames in the game, including the 123 frames
+	// before players can act.
+	Frames int
+	// RollbackEvery is the number of frames between rollbacks, or 0 for no
+	// rollbacks.
+	RollbackEvery int
+	// RollbackLength is the number of frames simulated again by each
+	// rollback, at most MaxRollbackFrames.
+	RollbackLength int
+}
+
+// SyntheticReplay generates an online Fox ditto on Final Destination with the
+// given length and rollback density, for benchmarking without real replays.
+// The players stand still for the whole game, which ends by timeout.
+func SyntheticReplay(opts SyntheticReplayOpts) ([]byte, error) {
+	if opts.Frames < 1 {
+		return nil, errors.New("synthetic replay must have at least 1 frame")
+	} else if opts.RollbackEvery > 0 && (opts.RollbackLength < 1 || opts.RollbackLength > MaxRollbackFrames) {
+		return nil, errors.New("synthetic replay rollback length must be between 1 and MaxRollbackFrames")
+	}
+
+	var buf bytes.Buffer
+	w := NewSlpWriter(&buf)
+
+	commands := []Command{GameStart, PreFrameUpdate, PostFrameUpdate, GameEnd, FrameStart, FrameBookend}
+	payloads := []byte{byte(1 + 3*len(commands))}
+	for _, command := range commands {
+		payloads = append(payloads, byte(command))
+		payloads = binary.BigEndian.AppendUint16(payloads, uint16(fullPayloadSizes[command]))
+	}
+	if err := w.WriteEvent(EventPayloads, payloads); err != nil {
+		return nil, err
+	}
+
+	gameStart := make([]byte, fullPayloadSizes[GameStart])
+	copy(gameStart, []byte{3, 12, 0})
+	binary.BigEndian.PutUint16(gameStart[0x12:0x14], uint16(FinalDestination))
+	for i := 0; i < 4; i++ {
+		gameStart[0x64+0x24*i] = byte(Fox)
+		gameStart[0x65+0x24*i] = byte(Empty)
+		gameStart[0x66+0x24*i] = 4
+	}
+	gameStart[0x65] = byte(Human)
+	gameStart[0x65+0x24] = byte(Human)
+	gameStart[0x1A3] = byte(SceneOnline)
+	if err := w.WriteEvent(GameStart, gameStart); err != nil {
+		return nil, err
+	}
+
+	writeFrame := func(frameNumber int32) error {
+		frameStart := make([]byte, fullPayloadSizes[FrameStart])
+		binary.BigEndian.PutUint32(frameStart, uint32(frameNumber))
+		if err := w.WriteEvent(FrameStart, frameStart); err != nil {
+			return err
+		}
+
+		for _, command := range []Command{PreFrameUpdate, PostFrameUpdate} {
+			for index := byte(0); index < 2; index++ {
+				update := make([]byte, fullPayloadSizes[command])
+				binary.BigEndian.PutUint32(update, uint32(frameNumber))
+				update[0x4] = index
+				if command == PostFrameUpdate {
+					binary.BigEndian.PutUint16(update[0x7:0x9], StateGroundedControlStart)
+					binary.BigEndian.PutUint32(update[0x9:0xD], math.Float32bits(40*float32(index)-20))
+					update[0x20] = 4
+				}
+				if err := w.WriteEvent(command, update); err != nil {
+					return err
+				}
+			}
+		}
+
+		bookend := make([]byte, fullPayloadSizes[FrameBookend])
+		binary.BigEndian.PutUint32(bookend, uint32(frameNumber))
+		binary.BigEndian.PutUint32(bookend[0x4:], uint32(max(frameNumber-MaxRollbackFrames, FirstFrame)))
+		return w.WriteEvent(FrameBookend, bookend)
+	}
+
+	for i := 0; i < opts.Frames; i++ {
+		frameNumber := FirstFrame + int32(i)
+		if err := writeFrame(frameNumber); err != nil {
+			return nil, err
+		}
+
+		if opts.RollbackEvery > 0 && i >= opts.RollbackLength && i%opts.RollbackEvery == 0 {
+			for j := opts.RollbackLength - 1; j >= 0; j-- {
+				if err := writeFrame(frameNumber - int32(j)); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	gameEnd := []byte{byte(Time), 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}
+	if err := w.WriteEvent(GameEnd, gameEnd); err != nil {
+		return nil, err
+	}
+
+	if err := w.Finish(nil); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,49 @@
+package slippi
+
+import "testing"
+
+func TestSyntheticReplay(t *testing.T) {
+	replay, err := SyntheticReplay(SyntheticReplayOpts{Frames: 1000, RollbackEvery: 10, RollbackLength: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game, err := NewSlpGameFromBytes(replay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer game.Close()
+
+	gameInfo, err := game.GetGameInfo()
+	if err != nil {
+		t.Fatal(err)
+	} else if len(gameInfo.Players) != 2 || gameInfo.Stage != FinalDestination {
+		t.Fatalf("expected 2 players on Final Destination, got %d on %s", len(gameInfo.Players), gameInfo.Stage)
+	}
+
+	frames, err := game.GetFrameStore()
+	if err != nil {
+		t.Fatal(err)
+	} else if frames.FrameCount() != 1000 {
+		t.Errorf("expected 1000 frames, got %d", frames.FrameCount())
+	}
+
+	rollbacks, err := game.GetRollbackFrames()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replaced := 0
+	for _, versions := range rollbacks {
+		replaced += len(versions)
+	}
+
+	// every 10th frame from frame 10 onwards re-simulates 3 frames
+	if len(rollbacks) != 99*3 || replaced != 99*3 {
+		t.Errorf("expected %d rolled back frames, got %d with %d replaced versions", 99*3, len(rollbacks), replaced)
+	}
+
+	if _, err := SyntheticReplay(SyntheticReplayOpts{Frames: 10, RollbackEvery: 5, RollbackLength: MaxRollbackFrames + 1}); err == nil {
+		t.Error("expected error for rollback longer than MaxRollbackFrames")
+	}
+}
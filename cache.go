@@ -0,0 +1,190 @@
+package slippi
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// CacheExtension is the file extension of replay cache sidecars.
+const CacheExtension = ".slpcache"
+
+// cacheVersion is bumped whenever the layout of gameCache changes, which
+// invalidates all existing caches.
+const cacheVersion = 5
+
+var cacheMagic = []byte("SLPCACHE")
+
+// gameCache is the parsed state of a replay stored in a cache sidecar.
+type gameCache struct {
+	GameInfo         *GameInfo
+	GameEnd          *GameEndPayload
+	Frames           map[int32]FrameEntry
+	RollbackFrames   map[int32][]FrameEntry
+	RollbackCount    int
+	RollbackLengths  []int
+	Warnings         []ParseWarning
+	LatestFrameIndex int32
+}
+
+// CachePath returns the path of the cache sidecar for the replay at path.
+func CachePath(path string) string {
+	return strings.TrimSuffix(path, ".slp") + CacheExtension
+}
+
+// OpenSlpGame creates a new SlpGame from the replay at path. If a cache
+// sidecar written for the same replay exists, the game's frames are loaded
+// from it instead of being parsed. Otherwise, the replay is parsed and the
+// sidecar written, if it can be. Calculators still receive their events by
+// parsing the replay, since the cache only holds the parsed frames.
+func OpenSlpGame(path string, calculators []SlpCalculator) (*SlpGame, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	game, err := NewSlpGameFromBytes(b, calculators)
+	if err != nil {
+		return nil, err
+	}
+	game.opened = true
+	game.fingerprint = sha256.Sum256(b)
+
+	cachePath := CachePath(path)
+	if err := game.loadCache(cachePath); err == nil {
+		return game, nil
+	}
+
+	if err := game.process(false); err != nil {
+		return nil, err
+	}
+
+	// the cache is only an optimization, so failing to write it is fine
+	if f, err := os.Create(cachePath); err == nil {
+		err = game.WriteCache(f)
+		f.Close()
+		if err != nil {
+			os.Remove(cachePath)
+		}
+	}
+
+	return game, nil
+}
+
+// WriteCache writes the SlpGame's parsed frames to w in the cache sidecar
+// format. The game must have been opened with OpenSlpGame, so that the cache
+// can be matched to its replay.
+func (g *SlpGame) WriteCache(w io.Writer) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.opened {
+		return withCode(CodeUncacheableGame, errors.New("game was not opened from a file"))
+	} else if g.parser.Options.DiscardFrames || g.parser.Options.MaxRetainedFrames > 0 {
+		return withCode(CodeUncacheableGame, errors.New("game's frames are discarded"))
+	} else if g.parser.Options.RollbackRetention != RetainRollbackFrames {
+		return withCode(CodeUncacheableGame, errors.New("game's rolled back frames are discarded"))
+	}
+
+	if !g.cached {
+		err := g.processLocked(false)
+		if err != nil {
+			return err
+		}
+	}
+
+	gameInfo, _ := g.parser.GetGameInfo()
+	cache := gameCache{
+		GameInfo:         gameInfo,
+		GameEnd:          g.parser.GameEnd,
+		Frames:           g.parser.Frames.Map(),
+		RollbackFrames:   g.parser.Rollbacks.Frames,
+		RollbackCount:    g.parser.Rollbacks.Count,
+		RollbackLengths:  g.parser.Rollbacks.Lengths,
+		Warnings:         g.parser.Warnings,
+		LatestFrameIndex: g.parser.latestFrameIndex,
+	}
+
+	header := append(append([]byte{}, cacheMagic...), cacheVersion)
+	header = append(header, g.fingerprint[:]...)
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+
+	zw := gzip.NewWriter(w)
+	if err := gob.NewEncoder(zw).Encode(&cache); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+// loadCache restores the game's parsed state from the cache sidecar at path,
+// if it was written for the same replay.
+func (g *SlpGame) loadCache(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	header := make([]byte, len(cacheMagic)+1+sha256.Size)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return err
+	}
+
+	if !bytes.Equal(header[:len(cacheMagic)], cacheMagic) {
+		return withCode(CodeInvalidCache, errors.New("not a replay cache"))
+	} else if version := header[len(cacheMagic)]; version != cacheVersion {
+		return withCode(CodeInvalidCache, errors.New(fmt.Sprintf("unsupported cache version %d", version)))
+	} else if !bytes.Equal(header[len(cacheMagic)+1:], g.fingerprint[:]) {
+		return withCode(CodeInvalidCache, errors.New("cache does not match replay"))
+	}
+
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+
+	var cache gameCache
+	if err := gob.NewDecoder(zr).Decode(&cache); err != nil {
+		return err
+	}
+
+	g.parser.restore(&cache)
+	g.gameInfo = cache.GameInfo
+	g.cached = true
+
+	return nil
+}
+
+// restore replaces the SlpParser's state with the state stored in a cache.
+func (p *SlpParser) restore(cache *gameCache) {
+	p.Reset()
+
+	for frameNumber, frame := range cache.Frames {
+		p.Frames.Set(frameNumber, frame)
+	}
+	if cache.RollbackFrames != nil {
+		p.Rollbacks.Frames = cache.RollbackFrames
+	}
+	if cache.RollbackLengths != nil {
+		p.Rollbacks.Lengths = cache.RollbackLengths
+	}
+	p.Rollbacks.Count = cache.RollbackCount
+	if cache.Warnings != nil {
+		p.Warnings = cache.Warnings
+	}
+	p.setGameInfo(cache.GameInfo, cache.GameInfo != nil)
+	p.GameEnd = cache.GameEnd
+	p.latestFrameIndex = cache.LatestFrameIndex
+	p.lastFinalizedFrame = cache.LatestFrameIndex
+}
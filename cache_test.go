@@ -0,0 +1,73 @@
+package slippi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenSlpGameCache(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "game.slp")
+	if err := os.WriteFile(path, b, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	game, err := OpenSlpGame(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if game.cached {
+		t.Error("expected first open to parse the replay")
+	}
+	if _, err := os.Stat(CachePath(path)); err != nil {
+		t.Fatalf("expected cache to be written: %v", err)
+	}
+
+	parsedFrames, _ := game.GetFrames()
+	parsedEnd, _ := game.GetGameEnd()
+
+	cachedGame, err := OpenSlpGame(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cachedGame.cached {
+		t.Fatal("expected second open to load the cache")
+	}
+
+	cachedFrames, err := cachedGame.GetFrames()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cachedFrames) != len(parsedFrames) {
+		t.Errorf("expected %d cached frames, got %d", len(parsedFrames), len(cachedFrames))
+	}
+
+	latest, _ := cachedGame.GetLatestFrame()
+	if post := latest.Players[0].Post; post == nil || *post != *parsedFrames[latest.FrameNumber].Players[0].Post {
+		t.Errorf("cached latest frame %d does not match parsed frame", latest.FrameNumber)
+	}
+
+	cachedEnd, err := cachedGame.GetGameEnd()
+	if err != nil || *cachedEnd != *parsedEnd {
+		t.Errorf("expected game end %+v, got %+v", parsedEnd, cachedEnd)
+	}
+
+	gameInfo, err := cachedGame.GetGameInfo()
+	if err != nil || gameInfo.Stage != YoshisStory {
+		t.Errorf("expected cached game info on %s, got %+v", YoshisStory, gameInfo)
+	}
+
+	// a changed replay invalidates its cache
+	b[len(b)-2] ^= 0xFF
+	if err := os.WriteFile(path, b, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if game, err := OpenSlpGame(path, nil); err == nil && game.cached {
+		t.Error("expected cache of modified replay to be rejected")
+	}
+}
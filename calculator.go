@@ -0,0 +1,118 @@
+package slippi
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// A CalculatorHandler handles a ParserEvent delivered to a Calculator, along
+// with its payload.
+type CalculatorHandler func(event ParserEvent, payload interface{})
+
+// A Calculator is a SlpCalculator that runs a handler for the parser events it
+// subscribes to on its own goroutine. If the handler panics, the panic is
+// recovered and recorded as the calculator's error, and the calculator ignores
+// all later events, so the parse and other calculators carry on unaffected.
+type Calculator struct {
+	Name     string
+	handler  CalculatorHandler
+	channels map[ParserEvent][]chan interface{}
+	done     chan struct{}
+	mu       sync.Mutex
+	err      error
+}
+
+// NewCalculator creates a new Calculator with the given name, which runs
+// handler for each of the given events.
+func NewCalculator(name string, handler CalculatorHandler, events ...ParserEvent) *Calculator {
+	c := &Calculator{
+		Name:     name,
+		handler:  handler,
+		channels: make(map[ParserEvent][]chan interface{}),
+		done:     make(chan struct{}),
+	}
+
+	cases := make([]reflect.SelectCase, 0, len(events)+1)
+	cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(c.done)})
+	for _, event := range events {
+		channel := make(chan interface{})
+		c.channels[event] = append(c.channels[event], channel)
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(channel)})
+	}
+
+	go func() {
+		for {
+			chosen, payload, _ := reflect.Select(cases)
+			if chosen == 0 {
+				return
+			}
+
+			// events keep being received after a panic, so that the parser's
+			// deliveries to the calculator don't block forever
+			c.handle(events[chosen-1], payload.Interface())
+		}
+	}()
+
+	return c
+}
+
+func (c *Calculator) getChannels() map[ParserEvent][]chan interface{} {
+	return c.channels
+}
+
+func (c *Calculator) handle(event ParserEvent, payload interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err != nil {
+		return
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			c.err = errors.New(fmt.Sprintf("calculator %s panicked: %v", c.Name, r))
+		}
+	}()
+
+	c.handler(event, payload)
+}
+
+// Err returns the error recorded when the calculator's handler panicked, or
+// nil if it hasn't.
+func (c *Calculator) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.err
+}
+
+// Close stops the calculator's goroutine. It must first be removed from any
+// game it was added to, after which nothing is sent to it.
+func (c *Calculator) Close() {
+	close(c.done)
+}
+
+// A CalculatorResult reports the outcome of a Calculator added to a SlpGame.
+type CalculatorResult struct {
+	Name string
+	Err  error
+}
+
+// Results returns the outcome of each Calculator added to the SlpGame, in the
+// order they were added. Events are delivered to calculators asynchronously,
+// so a result only reflects the events the calculator has handled so far.
+func (g *SlpGame) Results() []CalculatorResult {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	results := make([]CalculatorResult, 0, len(g.calculators))
+	for _, calculator := range g.calculators {
+		if c, ok := calculator.(*Calculator); ok {
+			results = append(results, CalculatorResult{Name: c.Name, Err: c.Err()})
+		}
+	}
+
+	return results
+}
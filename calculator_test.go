@@ -0,0 +1,140 @@
+package slippi
+
+import (
+	"os"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestCalculatorPanicRecovery(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ended := make(chan struct{}, 1)
+	well := NewCalculator("well", func(event ParserEvent, payload interface{}) {
+		ended <- struct{}{}
+	}, Ended)
+	panicking := NewCalculator("panicking", func(event ParserEvent, payload interface{}) {
+		panic("bad frame")
+	}, Frame)
+	defer well.Close()
+	defer panicking.Close()
+
+	game, err := NewSlpGameFromBytes(b, []SlpCalculator{panicking, well})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer game.Close()
+
+	if _, err := game.GetFrames(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-ended:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the other calculator to receive the end of the game")
+	}
+
+	// the frame events are delivered concurrently with the end of the game
+	deadline := time.Now().Add(5 * time.Second)
+	for panicking.Err() == nil && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	results := game.Results()
+	if len(results) != 2 || results[0].Name != "panicking" || results[0].Err == nil {
+		t.Errorf("expected the panicking calculator to report an error, got %+v", results)
+	}
+	if len(results) == 2 && results[1].Err != nil {
+		t.Errorf("expected no error from the other calculator, got %v", results[1].Err)
+	}
+}
+
+func TestRemoveCalculator(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game, err := NewSlpGameFromBytes(b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer game.Close()
+
+	handlers := len(game.parser.handlers[Frame])
+	first := NewCalculator("first", func(ParserEvent, interface{}) {}, Frame)
+	second := NewCalculator("second", func(ParserEvent, interface{}) {}, Frame)
+	defer first.Close()
+	defer second.Close()
+
+	game.AddCalculator(first)
+	game.AddCalculator(second)
+	game.RemoveCalculator(first)
+	if n := len(game.parser.handlers[Frame]); n != handlers+1 {
+		t.Errorf("expected removing a calculator to remove its handler, got %d handlers", n)
+	}
+
+	game.RemoveAllCalculators()
+	if n := len(game.parser.handlers[Frame]); n != handlers {
+		t.Errorf("expected removing all calculators to remove their handlers, got %d handlers", n)
+	}
+}
+
+func TestCloseRemovedCalculator(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, mode := range []DispatchMode{DispatchConcurrent, DispatchOrdered, DispatchSynchronous} {
+		before := runtime.NumGoroutine()
+
+		game, err := NewSlpGameFromBytes(b, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		game.SetDispatchMode(mode)
+
+		calculator := NewCalculator("closed", func(ParserEvent, interface{}) {}, Frame, Ended)
+		game.AddCalculator(calculator)
+		if _, err := game.GetFrames(); err != nil {
+			t.Fatal(err)
+		}
+		game.RemoveCalculator(calculator)
+		calculator.Close()
+
+		// adding another calculator processes the game again, which mustn't
+		// wait on the closed one
+		other := NewCalculator("other", func(ParserEvent, interface{}) {}, Frame)
+		game.AddCalculator(other)
+		finished := make(chan error, 1)
+		go func() {
+			_, err := game.GetFrames()
+			finished <- err
+		}()
+		select {
+		case err := <-finished:
+			if err != nil {
+				t.Fatal(err)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatalf("mode %d: expected the game to be processed without the closed calculator", mode)
+		}
+		game.RemoveAllCalculators()
+		other.Close()
+		game.Close()
+
+		deadline := time.Now().Add(5 * time.Second)
+		for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+		if after := runtime.NumGoroutine(); after > before {
+			t.Errorf("mode %d: expected closed calculators to leave no goroutines, went from %d to %d", mode, before, after)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package slippi
+
+import "fmt"
+
+// CharacterID enumerates the external character IDs in Melee, as stored in
+// the GameStart event.
+type CharacterID uint8
+
+// CharacterIDs
+const (
+	CaptainFalcon CharacterID = iota
+	DonkeyKong
+	Fox
+	MrGameAndWatch
+	Kirby
+	Bowser
+	Link
+	Luigi
+	Mario
+	Marth
+	Mewtwo
+	Ness
+	Peach
+	Pikachu
+	IceClimbers
+	Jigglypuff
+	Samus
+	Yoshi
+	Zelda
+	Sheik
+	Falco
+	YoungLink
+	DrMario
+	Roy
+	Pichu
+	Ganondorf
+	MasterHand
+	WireframeMale
+	WireframeFemale
+	GigaBowser
+	CrazyHand
+	Sandbag
+	Popo
+	NoCharacter
+)
+
+type characterInfo struct {
+	name      string
+	shortName string
+	costumes  []string
+}
+
+var characters = map[CharacterID]characterInfo{
+	CaptainFalcon:   {"Captain Falcon", "Falcon", []string{"Default", "Black", "Red", "White", "Green", "Blue"}},
+	DonkeyKong:      {"Donkey Kong", "DK", []string{"Default", "Black", "Red", "Blue", "Green"}},
+	Fox:             {"Fox", "Fox", []string{"Default", "Red", "Blue", "Green"}},
+	MrGameAndWatch:  {"Mr. Game & Watch", "G&W", []string{"Default", "Red", "Blue", "Green"}},
+	Kirby:           {"Kirby", "Kirby", []string{"Default", "Yellow", "Blue", "Red", "Green", "White"}},
+	Bowser:          {"Bowser", "Bowser", []string{"Default", "Red", "Blue", "Black"}},
+	Link:            {"Link", "Link", []string{"Default", "Red", "Blue", "Black", "White"}},
+	Luigi:           {"Luigi", "Luigi", []string{"Default", "White", "Blue", "Red"}},
+	Mario:           {"Mario", "Mario", []string{"Default", "Yellow", "Black", "Blue", "Green"}},
+	Marth:           {"Marth", "Marth", []string{"Default", "Red", "Green", "Black", "White"}},
+	Mewtwo:          {"Mewtwo", "Mewtwo", []string{"Default", "Red", "Blue", "Green"}},
+	Ness:            {"Ness", "Ness", []string{"Default", "Yellow", "Blue", "Green"}},
+	Peach:           {"Peach", "Peach", []string{"Default", "Daisy", "White", "Blue", "Green"}},
+	Pikachu:         {"Pikachu", "Pikachu", []string{"Default", "Red", "Party Hat", "Cowboy Hat"}},
+	IceClimbers:     {"Ice Climbers", "ICs", []string{"Default", "Green", "Orange", "Red"}},
+	Jigglypuff:      {"Jigglypuff", "Puff", []string{"Default", "Red", "Blue", "Headband", "Crown"}},
+	Samus:           {"Samus", "Samus", []string{"Default", "Pink", "Black", "Green", "Purple"}},
+	Yoshi:           {"Yoshi", "Yoshi", []string{"Default", "Red", "Blue", "Yellow", "Pink", "Cyan"}},
+	Zelda:           {"Zelda", "Zelda", []string{"Default", "Red", "Blue", "Green", "White"}},
+	Sheik:           {"Sheik", "Sheik", []string{"Default", "Red", "Blue", "Green", "White"}},
+	Falco:           {"Falco", "Falco", []string{"Default", "Red", "Blue", "Green"}},
+	YoungLink:       {"Young Link", "YL", []string{"Default", "Red", "Blue", "White", "Black"}},
+	DrMario:         {"Dr. Mario", "Doc", []string{"Default", "Red", "Blue", "Green", "Black"}},
+	Roy:             {"Roy", "Roy", []string{"Default", "Red", "Blue", "Green", "Yellow"}},
+	Pichu:           {"Pichu", "Pichu", []string{"Default", "Red", "Blue", "Green"}},
+	Ganondorf:       {"Ganondorf", "Ganon", []string{"Default", "Red", "Blue", "Green", "Purple"}},
+	MasterHand:      {"Master Hand", "Master Hand", []string{"Default"}},
+	WireframeMale:   {"Wireframe Male", "Male Wireframe", []string{"Default"}},
+	WireframeFemale: {"Wireframe Female", "Female Wireframe", []string{"Default"}},
+	GigaBowser:      {"Giga Bowser", "Giga Bowser", []string{"Default"}},
+	CrazyHand:       {"Crazy Hand", "Crazy Hand", []string{"Default"}},
+	Sandbag:         {"Sandbag", "Sandbag", []string{"Default"}},
+	Popo:            {"Popo", "Popo", []string{"Default"}},
+}
+
+// String returns the full name of the character.
+func (c CharacterID) String() string {
+	if info, ok := characters[c]; ok {
+		return info.name
+	}
+
+	if c == NoCharacter {
+		return "None"
+	}
+
+	return fmt.Sprintf("Unknown Character (%d)", uint8(c))
+}
+
+// ShortName returns the commonly used abbreviation of the character's name.
+func (c CharacterID) ShortName() string {
+	if info, ok := characters[c]; ok {
+		return info.shortName
+	}
+
+	return c.String()
+}
+
+// Costumes returns the names of the character's costumes, ordered by costume
+// index.
+func (c CharacterID) Costumes() []string {
+	info, ok := characters[c]
+	if !ok {
+		return nil
+	}
+
+	return append(make([]string, 0, len(info.costumes)), info.costumes...)
+}
+
+// CostumeName returns the color name of the character's costume with the given
+// index, or an empty string if the index is unknown for the character.
+func (c CharacterID) CostumeName(costumeIndex uint8) string {
+	info, ok := characters[c]
+	if !ok || int(costumeIndex) >= len(info.costumes) {
+		return ""
+	}
+
+	return info.costumes[costumeIndex]
+}
+
+// CostumeName returns the color name of the player's costume.
+func (p PlayerInfo) CostumeName() string {
+	return p.CharacterID.CostumeName(p.CostumeIndex)
+}
@@ -0,0 +1,55 @@
+package slippi
+
+import (
+	"os"
+	"testing"
+)
+
+func TestCharacterIDs(t *testing.T) {
+	expected := map[CharacterID]uint8{
+		CaptainFalcon: 0,
+		Fox:           2,
+		Falco:         20,
+		Ganondorf:     25,
+		Sandbag:       31,
+		Popo:          32,
+		NoCharacter:   33,
+	}
+	for character, id := range expected {
+		if uint8(character) != id {
+			t.Errorf("expected %s to be %d, got %d", character, id, uint8(character))
+		}
+	}
+
+	if name := Sheik.CostumeName(4); name != "White" {
+		t.Errorf("expected Sheik's fifth costume to be White, got %s", name)
+	}
+}
+
+func TestCharactersOfGame(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game, err := NewSlpGameFromBytes(b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer game.Close()
+
+	gameInfo, err := game.GetGameInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []CharacterID{Fox, Falco}
+	if len(gameInfo.Players) != len(expected) {
+		t.Fatalf("expected %d players, got %d", len(expected), len(gameInfo.Players))
+	}
+	for i, player := range gameInfo.Players {
+		if player.CharacterID != expected[i] {
+			t.Errorf("expected player %d to be %s, got %s", i, expected[i], player.CharacterID)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package slippi
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+)
+
+// checkpointVersion is bumped whenever the layout of parserCheckpoint
+// changes, which invalidates all existing checkpoints.
+const checkpointVersion = 1
+
+var checkpointMagic = []byte("SLPCKPT")
+
+// parserCheckpoint is the state of a SlpParser stored in a checkpoint.
+type parserCheckpoint struct {
+	GameInfo              *GameInfo
+	GameInfoComplete      bool
+	GameEnd               *GameEndPayload
+	Frames                map[int32]FrameEntry
+	RollbackFrames        map[int32][]FrameEntry
+	RollbackCount         int
+	RollbackLengths       []int
+	LastFrameWasRollback  bool
+	CurrentRollbackLength int
+	Warnings              []ParseWarning
+	LatestFrameIndex      int32
+	LastFinalizedFrame    int32
+	VersionFrame          int32
+	Eliminated            map[uint8]bool
+	LastPosts             map[uint8]PostFrameUpdatePayload
+	LastFrame             FrameEntry
+	Items                 []ItemLifecycle
+	// ActiveItems are the indices in Items of the items present on the last
+	// finalized frame.
+	ActiveItems []int
+}
+
+// Checkpoint returns the state of the game parsed so far, which
+// RestoreCheckpoint restores, so that services mirroring games live can resume
+// a game in progress after restarting, such as from the SlpReader offset the
+// checkpoint was taken at. Options and handlers aren't part of the state, and
+// events still queued for handlers aren't delivered after restoring.
+func (p *SlpParser) Checkpoint() ([]byte, error) {
+	active := make([]int, 0, len(p.items.active))
+	for i, lifecycle := range p.items.items {
+		if p.items.active[lifecycle.SpawnID] == lifecycle {
+			active = append(active, i)
+		}
+	}
+
+	checkpoint := parserCheckpoint{
+		GameInfo:              p.gameInfo,
+		GameInfoComplete:      p.gameInfoComplete,
+		GameEnd:               p.GameEnd,
+		Frames:                p.Frames.Map(),
+		RollbackFrames:        p.Rollbacks.Frames,
+		RollbackCount:         p.Rollbacks.Count,
+		RollbackLengths:       p.Rollbacks.Lengths,
+		LastFrameWasRollback:  p.Rollbacks.lastFrameWasRollback,
+		CurrentRollbackLength: p.Rollbacks.currentRollbackLength,
+		Warnings:              p.Warnings,
+		LatestFrameIndex:      p.latestFrameIndex,
+		LastFinalizedFrame:    p.lastFinalizedFrame,
+		VersionFrame:          p.versionFrame,
+		Eliminated:            p.eliminated,
+		LastPosts:             p.lastPosts,
+		LastFrame:             p.lastFrame,
+		Items:                 p.items.lifecycles(),
+		ActiveItems:           active,
+	}
+
+	var b bytes.Buffer
+	b.Write(checkpointMagic)
+	b.WriteByte(checkpointVersion)
+	if err := gob.NewEncoder(&b).Encode(&checkpoint); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// RestoreCheckpoint replaces the SlpParser's state with the state of a
+// checkpoint returned by Checkpoint, after which the parser continues parsing
+// the game from the events following the checkpoint. No events are triggered
+// for the state restored.
+func (p *SlpParser) RestoreCheckpoint(b []byte) error {
+	if !bytes.HasPrefix(b, checkpointMagic) || len(b) == len(checkpointMagic) {
+		return withCode(CodeInvalidCheckpoint, errors.New("not a parser checkpoint"))
+	} else if version := b[len(checkpointMagic)]; version != checkpointVersion {
+		return withCode(CodeInvalidCheckpoint, errors.New(fmt.Sprintf("unsupported checkpoint version %d", version)))
+	}
+
+	var checkpoint parserCheckpoint
+	if err := gob.NewDecoder(bytes.NewReader(b[len(checkpointMagic)+1:])).Decode(&checkpoint); err != nil {
+		return withCode(CodeInvalidCheckpoint, errors.New(fmt.Sprintf("failed to decode checkpoint: %s", err)))
+	}
+
+	for _, i := range checkpoint.ActiveItems {
+		if i < 0 || i >= len(checkpoint.Items) {
+			return withCode(CodeInvalidCheckpoint, errors.New(fmt.Sprintf("active item %d out of range", i)))
+		}
+	}
+
+	p.Reset()
+
+	for frameNumber, frame := range checkpoint.Frames {
+		// gob decodes empty slices as nil
+		if frame.Items == nil {
+			frame.Items = make([]ItemUpdatePayload, 0)
+		}
+		p.Frames.Set(frameNumber, frame)
+	}
+	if checkpoint.RollbackFrames != nil {
+		p.Rollbacks.Frames = checkpoint.RollbackFrames
+	}
+	if checkpoint.RollbackLengths != nil {
+		p.Rollbacks.Lengths = checkpoint.RollbackLengths
+	}
+	p.Rollbacks.Count = checkpoint.RollbackCount
+	p.Rollbacks.lastFrameWasRollback = checkpoint.LastFrameWasRollback
+	p.Rollbacks.currentRollbackLength = checkpoint.CurrentRollbackLength
+	if checkpoint.Warnings != nil {
+		p.Warnings = checkpoint.Warnings
+	}
+	p.setGameInfo(checkpoint.GameInfo, checkpoint.GameInfoComplete)
+	p.GameEnd = checkpoint.GameEnd
+	p.latestFrameIndex = checkpoint.LatestFrameIndex
+	p.lastFinalizedFrame = checkpoint.LastFinalizedFrame
+	p.versionFrame = checkpoint.VersionFrame
+	if checkpoint.Eliminated != nil {
+		p.eliminated = checkpoint.Eliminated
+	}
+	if checkpoint.LastPosts != nil {
+		p.lastPosts = checkpoint.LastPosts
+	}
+	p.lastFrame = checkpoint.LastFrame
+
+	for i := range checkpoint.Items {
+		p.items.items = append(p.items.items, &checkpoint.Items[i])
+	}
+	for _, i := range checkpoint.ActiveItems {
+		p.items.active[p.items.items[i].SpawnID] = p.items.items[i]
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package slippi
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestCheckpoint(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reader, err := NewSlpReader(*NewSlpSourceBytes(bytes.NewReader(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	full := NewSlpParser(SlpParserOpts{})
+	events, err := reader.YieldEvents(func(*SlpEvent) bool { return false })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := full.ParseReplay(events); err != nil {
+		t.Fatal(err)
+	}
+
+	// parse the first half of the game, then checkpoint it and resume the
+	// game with another parser, as after a restart
+	first := NewSlpParser(SlpParserOpts{})
+	events, err = reader.YieldEvents(func(event *SlpEvent) bool {
+		bookend, ok := event.Payload.(FrameBookendPayload)
+		return ok && bookend.FrameNumber == 6000
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := first.ParseMore(events); err != nil {
+		t.Fatal(err)
+	}
+
+	checkpoint, err := first.Checkpoint()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resumed := NewSlpParser(SlpParserOpts{})
+	if err := resumed.RestoreCheckpoint(checkpoint); err != nil {
+		t.Fatal(err)
+	}
+	if resumed.latestFrameIndex != 6000 {
+		t.Errorf("expected the checkpoint to end on frame 6000, got %d", resumed.latestFrameIndex)
+	}
+
+	events, err = reader.YieldEventsFrom(reader.Offset(), func(*SlpEvent) bool { return false })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := resumed.ParseReplay(events); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(resumed.Frames.Map(), full.Frames.Map()) {
+		t.Error("expected the resumed game to have the frames of the full game")
+	}
+	if !reflect.DeepEqual(resumed.Items(), full.Items()) {
+		t.Error("expected the resumed game to have the items of the full game")
+	}
+	if !reflect.DeepEqual(resumed.ComputeResult(), full.ComputeResult()) {
+		t.Errorf("expected the result %+v, got %+v", full.ComputeResult(), resumed.ComputeResult())
+	}
+	if resumed.Rollbacks.Count != full.Rollbacks.Count || resumed.lastFinalizedFrame != full.lastFinalizedFrame {
+		t.Errorf("expected the rollbacks and finalized frames of the full game, got %d and %d", resumed.Rollbacks.Count, resumed.lastFinalizedFrame)
+	}
+
+	err = resumed.RestoreCheckpoint(checkpoint[:len(checkpoint)/2])
+	if ErrorCode(err) != CodeInvalidCheckpoint {
+		t.Errorf("expected a truncated checkpoint to be invalid, got %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package slippi
+
+import (
+	"errors"
+	"sync"
+)
+
+// FrameChunkHandler is called with each chunk of consecutive finalized frames
+// by ProcessChunks. A non-nil error stops processing the game, and is returned
+// by ProcessChunks.
+type FrameChunkHandler func(frames []FrameEntry) error
+
+// ProcessChunks processes the game in chunks of size finalized frames,
+// calling handler with each chunk in order, the last of which may be shorter.
+// Frames are discarded once their chunk has been handled, so that replays of
+// any length are processed in constant memory. The game is processed from the
+// start again the next time its frames are read. handler is called while the
+// game is being processed, so it must not call the game's methods.
+func (g *SlpGame) ProcessChunks(size int, handler FrameChunkHandler) error {
+	if size < 1 {
+		return errors.New("chunk size must be at least 1")
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	options := g.parser.Options
+	defer func() {
+		g.parser.Options = options
+	}()
+
+	// the handler's error is read by the parser and the reader, which stop
+	// once it is set
+	var errMu sync.Mutex
+	var handlerErr error
+	failed := func() error {
+		errMu.Lock()
+		defer errMu.Unlock()
+
+		return handlerErr
+	}
+	handle := func(chunk []FrameEntry) {
+		if err := handler(chunk); err != nil {
+			errMu.Lock()
+			handlerErr = err
+			errMu.Unlock()
+		}
+	}
+
+	// frames are delivered synchronously, so that parsing waits for each
+	// chunk to be handled before discarding more frames
+	g.parser.Options.DiscardFrames = true
+	g.parser.Options.Dispatch = DispatchSynchronous
+	g.parser.Options.Abort = failed
+	g.invalidate()
+
+	finalized := make(chan interface{})
+	g.parser.AddHandler(FinalizedFrame, finalized)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		chunk := make([]FrameEntry, 0, size)
+		for payload := range finalized {
+			if failed() != nil {
+				continue
+			}
+
+			chunk = append(chunk, payload.(FrameEntry))
+			if len(chunk) == size {
+				handle(chunk)
+				chunk = make([]FrameEntry, 0, size)
+			}
+		}
+
+		if failed() == nil && len(chunk) > 0 {
+			handle(chunk)
+		}
+	}()
+
+	err := g.processLocked(false)
+
+	// nothing more is sent once processing returns, since delivery is
+	// synchronous
+	g.parser.RemoveHandler(FinalizedFrame, finalized)
+	close(finalized)
+	<-done
+
+	// the frames that weren't discarded leave the game's state incomplete
+	g.invalidate()
+
+	if err != nil {
+		return err
+	}
+
+	return failed()
+}
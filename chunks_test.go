@@ -0,0 +1,90 @@
+package slippi
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestProcessChunks(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game, err := NewSlpGameFromBytes(b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer game.Close()
+
+	sizes := make([]int, 0)
+	next := FirstFrame
+	err = game.ProcessChunks(1000, func(frames []FrameEntry) error {
+		sizes = append(sizes, len(frames))
+		for _, frame := range frames {
+			if frame.FrameNumber != next {
+				t.Fatalf("expected frame %d, got %d", next, frame.FrameNumber)
+			}
+			next++
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sizes) != 13 {
+		t.Fatalf("expected 13 chunks, got %d", len(sizes))
+	}
+	for i, size := range sizes[:12] {
+		if size != 1000 {
+			t.Errorf("expected chunk %d to have 1000 frames, got %d", i, size)
+		}
+	}
+	if sizes[12] != 343 {
+		t.Errorf("expected last chunk to have 343 frames, got %d", sizes[12])
+	}
+
+	// the game is processed again as usual afterwards
+	frames, err := game.GetFrames()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(frames) != 12343 {
+		t.Errorf("expected 12343 frames after chunked processing, got %d", len(frames))
+	}
+}
+
+func TestProcessChunksStops(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game, err := NewSlpGameFromBytes(b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer game.Close()
+
+	stop := errors.New("stop")
+	calls := 0
+	err = game.ProcessChunks(1000, func(frames []FrameEntry) error {
+		calls++
+		return stop
+	})
+	if err != stop {
+		t.Errorf("expected handler error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected handler to be called once, got %d", calls)
+	}
+	if latest := game.parser.latestFrameIndex; latest > 2000 {
+		t.Errorf("expected parsing to stop after the first chunk, got to frame %d", latest)
+	}
+
+	if err := game.ProcessChunks(0, nil); err == nil {
+		t.Error("expected error for chunk size 0")
+	}
+}
@@ -0,0 +1,103 @@
+package slippi
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// A Clock is a source of time, which components that measure or wait on time
+// take so that time can be simulated in tests.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// SystemClock is the Clock backed by the system's time.
+type SystemClock struct{}
+
+// Now implements the Clock interface.
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// After implements the Clock interface.
+func (SystemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+type fakeTimer struct {
+	at      time.Time
+	channel chan time.Time
+}
+
+// A FakeClock is a Clock whose time only moves when it is advanced, for
+// deterministic tests of components that depend on time.
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []fakeTimer
+}
+
+// NewFakeClock returns a new FakeClock set to the given time.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{
+		now:    now,
+		timers: make([]fakeTimer, 0),
+	}
+}
+
+// Now implements the Clock interface.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// After implements the Clock interface. The returned channel receives the
+// clock's time once it has been advanced by at least d.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	channel := make(chan time.Time, 1)
+	if d <= 0 {
+		channel <- c.now
+		return channel
+	}
+
+	c.timers = append(c.timers, fakeTimer{at: c.now.Add(d), channel: channel})
+	return channel
+}
+
+// Advance moves the clock forward by d, firing the timers that expire in
+// order of their expiry.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+
+	sort.SliceStable(c.timers, func(i, j int) bool {
+		return c.timers[i].at.Before(c.timers[j].at)
+	})
+
+	pending := c.timers[:0]
+	for _, timer := range c.timers {
+		if timer.at.After(c.now) {
+			pending = append(pending, timer)
+		} else {
+			timer.channel <- c.now
+		}
+	}
+	c.timers = pending
+}
+
+// Pending returns the number of timers that haven't fired yet.
+func (c *FakeClock) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.timers)
+}
@@ -0,0 +1,41 @@
+package slippi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	late := clock.After(2 * time.Second)
+	early := clock.After(time.Second)
+
+	clock.Advance(500 * time.Millisecond)
+	select {
+	case <-early:
+		t.Fatal("expected timer not to fire before it expires")
+	default:
+	}
+
+	clock.Advance(500 * time.Millisecond)
+	select {
+	case now := <-early:
+		if !now.Equal(start.Add(time.Second)) {
+			t.Errorf("expected timer to fire at %s, got %s", start.Add(time.Second), now)
+		}
+	default:
+		t.Fatal("expected timer to fire once it expires")
+	}
+
+	if clock.Pending() != 1 {
+		t.Errorf("expected 1 pending timer, got %d", clock.Pending())
+	}
+
+	clock.Advance(time.Hour)
+	<-late
+	if !clock.Now().Equal(start.Add(time.Hour + time.Second)) {
+		t.Errorf("unexpected time %s", clock.Now())
+	}
+}
@@ -0,0 +1,119 @@
+package slippi
+
+import (
+	"archive/zip"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SlpGameHandler is called with each replay found while processing a
+// collection of replays. Returning a non-nil error stops processing.
+type SlpGameHandler func(path string, game *SlpGame) error
+
+// ProcessDirectory calls handler with a SlpGame for every replay in the
+// directory dir. Zip archives in the directory are opened and their replay
+// entries are processed as well, with paths of the form
+// "archive.zip/entry.slp". If recursive is true, subdirectories are also
+// processed.
+func ProcessDirectory(dir string, recursive bool, handler SlpGameHandler) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if path != dir && !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		switch {
+		case isSlpName(path):
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			game, err := NewSlpGameFromFile(f, nil)
+			if err != nil {
+				return err
+			}
+			defer game.Close()
+
+			return handler(path, game)
+		case strings.EqualFold(filepath.Ext(path), ".zip"):
+			z, err := zip.OpenReader(path)
+			if err != nil {
+				return err
+			}
+			defer z.Close()
+
+			return processZip(path, &z.Reader, handler)
+		}
+
+		return nil
+	})
+}
+
+// ProcessZip calls handler with a SlpGame for every replay entry in the zip
+// archive r. Entries are decompressed into memory one at a time; nothing is
+// written to disk.
+func ProcessZip(r *zip.Reader, handler SlpGameHandler) error {
+	return processZip("", r, handler)
+}
+
+func processZip(prefix string, r *zip.Reader, handler SlpGameHandler) error {
+	for _, f := range ZipSlpEntries(r) {
+		game, err := NewSlpGameFromZipFile(f, nil)
+		if err != nil {
+			return err
+		}
+
+		err = handler(filepath.Join(prefix, f.Name), game)
+		game.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ZipSlpEntries returns the replay entries in the zip archive r, in archive
+// order.
+func ZipSlpEntries(r *zip.Reader) []*zip.File {
+	entries := make([]*zip.File, 0)
+	for _, f := range r.File {
+		if !f.FileInfo().IsDir() && isSlpName(f.Name) {
+			entries = append(entries, f)
+		}
+	}
+
+	return entries
+}
+
+// NewSlpGameFromZipFile creates a new SlpGame from the provided zip archive
+// entry.
+func NewSlpGameFromZipFile(f *zip.File, calculators []SlpCalculator) (*SlpGame, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	b, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSlpGameFromBytes(b, calculators)
+}
+
+func isSlpName(name string) bool {
+	return strings.EqualFold(filepath.Ext(name), ".slp")
+}
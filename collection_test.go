@@ -0,0 +1,116 @@
+package slippi
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestZip(t *testing.T, w *bytes.Buffer) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	z := zip.NewWriter(w)
+	for _, name := range []string{"set/Game_1.slp", "readme.txt", "set/Game_2.slp"} {
+		f, err := z.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if name == "readme.txt" {
+			_, err = f.Write([]byte("not a replay"))
+		} else {
+			_, err = f.Write(b)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = z.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProcessZip(t *testing.T) {
+	var buf bytes.Buffer
+	writeTestZip(t, &buf)
+
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if entries := ZipSlpEntries(r); len(entries) != 2 {
+		t.Fatalf("expected 2 replay entries, got %d", len(entries))
+	}
+
+	names := make([]string, 0)
+	err = ProcessZip(r, func(path string, game *SlpGame) error {
+		gameInfo, err := game.GetGameInfo()
+		if err != nil {
+			return err
+		}
+
+		if len(gameInfo.Players) != 2 {
+			t.Errorf("%s: expected 2 players, got %d", path, len(gameInfo.Players))
+		}
+		names = append(names, path)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(names) != 2 || names[0] != "set/Game_1.slp" || names[1] != "set/Game_2.slp" {
+		t.Errorf("unexpected entries processed: %v", names)
+	}
+}
+
+func TestProcessDirectory(t *testing.T) {
+	dir := t.TempDir()
+
+	var buf bytes.Buffer
+	writeTestZip(t, &buf)
+	err := os.WriteFile(filepath.Join(dir, "replays.zip"), buf.Bytes(), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Mkdir(filepath.Join(dir, "nested"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(dir, "nested", "Game_3.slp"), b, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, recursive := range []bool{false, true} {
+		count := 0
+		err = ProcessDirectory(dir, recursive, func(path string, game *SlpGame) error {
+			count++
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := 2
+		if recursive {
+			expected = 3
+		}
+		if count != expected {
+			t.Errorf("recursive=%t: expected %d replays, got %d", recursive, expected, count)
+		}
+	}
+}
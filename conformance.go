@@ -0,0 +1,319 @@
+package slippi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// TestVectorOpts contains options that determine how test vectors are
+// generated.
+type TestVectorOpts struct {
+	// FrameStride is the interval between frames included in full in the
+	// expected result. The first and last frames are always included. A stride
+	// of 0 includes no intermediate frames.
+	FrameStride int32
+	// Tolerance is the tolerance float fields are verified with. The zero
+	// Tolerance requires them to match exactly.
+	Tolerance Tolerance
+}
+
+// A TestVector pairs replay bytes with the result expected from parsing them.
+// Its JSON form uses language-neutral camelCase keys so that other Slippi
+// implementations can be validated against the same vectors.
+type TestVector struct {
+	Name     string           `json:"name"`
+	SHA256   string           `json:"sha256"`
+	Replay   []byte           `json:"-"`
+	Expected TestVectorResult `json:"expected"`
+}
+
+// TestVectorResult is the canonical parse result of a replay.
+type TestVectorResult struct {
+	SlpVersion string              `json:"slpVersion"`
+	IsTeams    bool                `json:"isTeams"`
+	IsPAL      bool                `json:"isPAL"`
+	StageID    StageID             `json:"stageId"`
+	Players    []TestVectorPlayer  `json:"players"`
+	Metadata   *TestVectorMetadata `json:"metadata"`
+	GameEnd    *TestVectorGameEnd  `json:"gameEnd"`
+	FirstFrame int32               `json:"firstFrame"`
+	LastFrame  int32               `json:"lastFrame"`
+	FrameCount int                 `json:"frameCount"`
+	Frames     []TestVectorFrame   `json:"frames"`
+}
+
+// TestVectorPlayer is the canonical form of a player's GameStart info.
+type TestVectorPlayer struct {
+	PlayerIndex    uint8       `json:"playerIndex"`
+	Port           uint8       `json:"port"`
+	CharacterID    CharacterID `json:"characterId"`
+	CharacterColor uint8       `json:"characterColor"`
+	StartStocks    uint8       `json:"startStocks"`
+	Type           PlayerType  `json:"type"`
+	TeamID         TeamID      `json:"teamId"`
+	Nametag        string      `json:"nametag"`
+	DisplayName    string      `json:"displayName"`
+	ConnectCode    string      `json:"connectCode"`
+	UserID         string      `json:"userId"`
+}
+
+// TestVectorMetadata is the canonical form of a replay's metadata.
+type TestVectorMetadata struct {
+	StartAt   string `json:"startAt"`
+	LastFrame int32  `json:"lastFrame"`
+	PlayedOn  string `json:"playedOn"`
+}
+
+// TestVectorGameEnd is the canonical form of a GameEnd event.
+type TestVectorGameEnd struct {
+	GameEndMethod      GameEndMethod `json:"gameEndMethod"`
+	LRASInitiatorIndex int8          `json:"lrasInitiatorIndex"`
+}
+
+// TestVectorFrame is the canonical form of a single frame.
+type TestVectorFrame struct {
+	Frame   int32                   `json:"frame"`
+	Players []TestVectorFrameUpdate `json:"players"`
+	Items   int                     `json:"itemCount"`
+}
+
+// TestVectorFrameUpdate is the canonical form of a player's pre- and
+// post-frame updates.
+type TestVectorFrameUpdate struct {
+	PlayerIndex     uint8   `json:"playerIndex"`
+	IsFollower      bool    `json:"isFollower"`
+	ActionStateID   uint16  `json:"actionStateId"`
+	PositionX       float32 `json:"positionX"`
+	PositionY       float32 `json:"positionY"`
+	FacingDirection float32 `json:"facingDirection"`
+	Percent         float32 `json:"percent"`
+	ShieldSize      float32 `json:"shieldSize"`
+	StocksRemaining uint8   `json:"stocksRemaining"`
+	JoystickX       float32 `json:"joystickX"`
+	JoystickY       float32 `json:"joystickY"`
+	Buttons         uint16  `json:"physicalButtons"`
+}
+
+// GenerateTestVector parses replay and returns a TestVector whose expected
+// result is the canonical parse result of the replay.
+func GenerateTestVector(name string, replay []byte, opts TestVectorOpts) (*TestVector, error) {
+	result, err := canonicalResult(replay, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(replay)
+
+	return &TestVector{
+		Name:     name,
+		SHA256:   hex.EncodeToString(sum[:]),
+		Replay:   replay,
+		Expected: *result,
+	}, nil
+}
+
+// VerifyTestVector parses the vector's replay and returns an error if the parse
+// result differs from the expected result beyond the tolerance, naming the
+// first mismatched frame and field where possible.
+func VerifyTestVector(v *TestVector, opts TestVectorOpts) error {
+	sum := sha256.Sum256(v.Replay)
+	if hex.EncodeToString(sum[:]) != v.SHA256 {
+		return errors.New(fmt.Sprintf("test vector %s: replay checksum mismatch", v.Name))
+	}
+
+	actual, err := canonicalResult(v.Replay, opts)
+	if err != nil {
+		return err
+	}
+
+	if len(actual.Frames) == len(v.Expected.Frames) {
+		for i := range actual.Frames {
+			if diffs := opts.Tolerance.Diff(v.Expected.Frames[i], actual.Frames[i]); len(diffs) > 0 {
+				return errors.New(fmt.Sprintf("test vector %s: frame %d does not match expected result at %s", v.Name, v.Expected.Frames[i].Frame, diffs[0]))
+			}
+		}
+	}
+
+	if diffs := opts.Tolerance.Diff(v.Expected, *actual); len(diffs) > 0 {
+		return errors.New(fmt.Sprintf("test vector %s: parse result does not match expected result at %s", v.Name, diffs[0]))
+	}
+
+	return nil
+}
+
+// WriteTestVector writes the vector to dir as <name>.slp and <name>.json.
+func WriteTestVector(dir string, v *TestVector) error {
+	err := os.WriteFile(filepath.Join(dir, v.Name+".slp"), v.Replay, 0o644)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, v.Name+".json"), append(b, '\n'), 0o644)
+}
+
+// ReadTestVector reads the vector with the given name from dir, as written by
+// WriteTestVector.
+func ReadTestVector(dir string, name string) (*TestVector, error) {
+	b, err := os.ReadFile(filepath.Join(dir, name+".json"))
+	if err != nil {
+		return nil, err
+	}
+
+	v := &TestVector{}
+	err = json.Unmarshal(b, v)
+	if err != nil {
+		return nil, err
+	}
+
+	v.Replay, err = os.ReadFile(filepath.Join(dir, name+".slp"))
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func canonicalResult(replay []byte, opts TestVectorOpts) (*TestVectorResult, error) {
+	game, err := NewSlpGameFromBytes(replay, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer game.Close()
+
+	gameInfo, err := game.GetGameInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TestVectorResult{
+		SlpVersion: gameInfo.Version.String(),
+		IsTeams:    gameInfo.Teams,
+		IsPAL:      gameInfo.PAL,
+		StageID:    gameInfo.Stage,
+		Players:    make([]TestVectorPlayer, 0, len(gameInfo.Players)),
+		Frames:     make([]TestVectorFrame, 0),
+	}
+
+	for _, player := range gameInfo.Players {
+		result.Players = append(result.Players, TestVectorPlayer{
+			PlayerIndex:    player.Index,
+			Port:           player.Port,
+			CharacterID:    player.CharacterID,
+			CharacterColor: player.CostumeIndex,
+			StartStocks:    player.StockStartCount,
+			Type:           player.PlayerType,
+			TeamID:         player.TeamID,
+			Nametag:        player.Nametag,
+			DisplayName:    player.DisplayName,
+			ConnectCode:    player.ConnectCode,
+			UserID:         player.SlippiUID,
+		})
+	}
+
+	metadata, err := game.GetMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	if metadata != nil {
+		result.Metadata = &TestVectorMetadata{
+			StartAt:   metadata.StartAt,
+			LastFrame: metadata.LastFrame,
+			PlayedOn:  metadata.PlayedOn,
+		}
+	}
+
+	frames, err := game.GetFrames()
+	if err != nil {
+		return nil, err
+	}
+
+	if gameEnd := game.parser.GameEnd; gameEnd != nil {
+		result.GameEnd = &TestVectorGameEnd{
+			GameEndMethod:      gameEnd.GameEndMethod,
+			LRASInitiatorIndex: gameEnd.LRASInitiator,
+		}
+	}
+
+	frameNumbers := sortedFrameNumbers(frames)
+
+	result.FrameCount = len(frameNumbers)
+	if len(frameNumbers) == 0 {
+		return result, nil
+	}
+
+	result.FirstFrame = frameNumbers[0]
+	result.LastFrame = frameNumbers[len(frameNumbers)-1]
+
+	for i, frameNumber := range frameNumbers {
+		isEndpoint := i == 0 || i == len(frameNumbers)-1
+		isStride := opts.FrameStride > 0 && (frameNumber-result.FirstFrame)%opts.FrameStride == 0
+		if isEndpoint || isStride {
+			result.Frames = append(result.Frames, canonicalFrame(frameNumber, frames[frameNumber]))
+		}
+	}
+
+	return result, nil
+}
+
+func canonicalFrame(frameNumber int32, frame FrameEntry) TestVectorFrame {
+	updates := make([]TestVectorFrameUpdate, 0, len(frame.Players)+len(frame.Followers))
+	for _, followers := range []bool{false, true} {
+		entries := frame.Players
+		if followers {
+			entries = frame.Followers
+		}
+
+		indices := make([]int, 0, len(entries))
+		for index := range entries {
+			indices = append(indices, int(index))
+		}
+		sort.Ints(indices)
+
+		for _, index := range indices {
+			updates = append(updates, canonicalFrameUpdate(entries[uint8(index)]))
+		}
+	}
+
+	return TestVectorFrame{
+		Frame:   frameNumber,
+		Players: updates,
+		Items:   len(frame.Items),
+	}
+}
+
+func canonicalFrameUpdate(updates FrameUpdates) TestVectorFrameUpdate {
+	var update TestVectorFrameUpdate
+	if updates.Pre != nil {
+		update.PlayerIndex = updates.Pre.PlayerIndex
+		update.IsFollower = updates.Pre.IsFollower
+		update.JoystickX = updates.Pre.JoystickX
+		update.JoystickY = updates.Pre.JoystickY
+		update.Buttons = updates.Pre.PhysicalButtons
+	}
+
+	if updates.Post != nil {
+		update.PlayerIndex = updates.Post.PlayerIndex
+		update.IsFollower = updates.Post.IsFollower
+		update.ActionStateID = updates.Post.ActionStateID
+		update.PositionX = updates.Post.XPosition
+		update.PositionY = updates.Post.YPosition
+		update.FacingDirection = updates.Post.FacingDirection
+		update.Percent = updates.Post.Percent
+		update.ShieldSize = updates.Post.ShieldSize
+		update.StocksRemaining = updates.Post.StocksRemaining
+	}
+
+	return update
+}
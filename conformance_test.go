@@ -0,0 +1,67 @@
+package slippi
+
+import (
+	"os"
+	"testing"
+)
+
+func TestTestVectorRoundTrip(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := TestVectorOpts{FrameStride: 600}
+	v, err := GenerateTestVector("game", b, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v.Expected.FrameCount == 0 || len(v.Expected.Frames) < 2 {
+		t.Fatalf("expected frames in test vector, got %d sampled of %d", len(v.Expected.Frames), v.Expected.FrameCount)
+	}
+
+	dir := t.TempDir()
+	err = WriteTestVector(dir, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	readBack, err := ReadTestVector(dir, "game")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = VerifyTestVector(readBack, opts)
+	if err != nil {
+		t.Error(err)
+	}
+
+	readBack.Expected.Frames[1].Players[0].Percent += 1
+	if VerifyTestVector(readBack, opts) == nil {
+		t.Error("expected verification to fail for a modified vector")
+	}
+}
+
+func TestVerifyTestVectorTolerance(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := GenerateTestVector("game", b, TestVectorOpts{FrameStride: 600})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// as if the position had been formatted with fewer digits
+	v.Expected.Frames[1].Players[0].PositionX += 1e-4
+
+	if err := VerifyTestVector(v, TestVectorOpts{FrameStride: 600}); err == nil {
+		t.Error("expected exact verification to fail")
+	}
+
+	if err := VerifyTestVector(v, TestVectorOpts{FrameStride: 600, Tolerance: DefaultTolerance}); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,177 @@
+package slippi
+
+import (
+	"math"
+	"sort"
+)
+
+// ControllerType enumerates the kinds of controllers a player can use.
+type ControllerType uint8
+
+// ControllerTypes
+const (
+	// UnknownController is used when too little of the game was played to
+	// tell which kind of controller was used.
+	UnknownController ControllerType = iota
+	// AnalogController is an analog controller, such as a GameCube
+	// controller.
+	AnalogController
+	// DigitalController is a digital controller, such as a box, whose stick
+	// coordinates come from buttons.
+	DigitalController
+)
+
+var controllerTypeNames = map[ControllerType]string{
+	UnknownController: "Unknown",
+	AnalogController:  "Analog",
+	DigitalController: "Digital",
+}
+
+// String returns the human-readable name of the controller type.
+func (c ControllerType) String() string {
+	return controllerTypeNames[c]
+}
+
+// controllerNotches is the number of most used joystick coordinates that a
+// digital controller's coordinates are expected to fall within.
+const controllerNotches = 16
+
+// controllerRim is the smallest joystick deflection considered fully tilted.
+const controllerRim = 0.9
+
+// digitalScoreThreshold is the DigitalScore at or above which a controller is
+// classified as digital.
+const digitalScoreThreshold = 0.75
+
+// minControllerMovements is the number of joystick movements needed to be
+// fully confident in a classification.
+const minControllerMovements = 100
+
+// A ControllerEstimate is a heuristic classification of the controller a
+// player used in a game, from the coordinates of their joystick.
+type ControllerEstimate struct {
+	PlayerIndex uint8          `json:"playerIndex"`
+	Type        ControllerType `json:"type"`
+	// Confidence is how confident the classification is, from 0 to 1.
+	Confidence float64 `json:"confidence"`
+	// DistinctCoordinates is the number of distinct joystick coordinates the
+	// player used outside the deadzone, and NotchShare the fraction of the
+	// frames outside it spent on the controllerNotches most used ones.
+	// Digital controllers can only reach a few coordinates.
+	DistinctCoordinates int     `json:"distinctCoordinates"`
+	NotchShare          float64 `json:"notchShare"`
+	// Movements is the number of times the player tilted the joystick from
+	// inside the deadzone to the rim, and InstantShare the fraction of those
+	// that took no frames in between. Digital controllers have no travel
+	// time.
+	Movements    int     `json:"movements"`
+	InstantShare float64 `json:"instantShare"`
+	// DigitalScore combines the evidence for a digital controller, from 0 to
+	// 1.
+	DigitalScore float64 `json:"digitalScore"`
+}
+
+// EstimateControllerTypes returns an estimate of the controller each player in
+// frames used, keyed by player index.
+func EstimateControllerTypes(frames map[int32]FrameEntry) map[uint8]ControllerEstimate {
+	coordinates := make(map[uint8]map[[2]int]int)
+	tilted := make(map[uint8]int)
+	movements := make(map[uint8]int)
+	instant := make(map[uint8]int)
+	// travel is the number of frames each player's joystick has spent between
+	// the deadzone and the rim since it left the deadzone, or -1 if it hasn't
+	// since reaching the rim
+	travel := make(map[uint8]int)
+
+	for _, frameNumber := range sortedFrameNumbers(frames) {
+		for index, updates := range frames[frameNumber].Players {
+			pre := updates.Pre
+			if pre == nil {
+				continue
+			}
+
+			if _, ok := coordinates[index]; !ok {
+				coordinates[index] = make(map[[2]int]int)
+				travel[index] = -1
+			}
+
+			// coordinates are stored in steps of 1/80
+			x, y := float64(pre.JoystickX), float64(pre.JoystickY)
+			deflection := math.Hypot(x, y)
+			switch {
+			case deflection <= idleJoystickDeadzone:
+				travel[index] = 0
+				continue
+			case deflection < controllerRim:
+				if travel[index] >= 0 {
+					travel[index]++
+				}
+			default:
+				if travel[index] >= 0 {
+					movements[index]++
+					if travel[index] == 0 {
+						instant[index]++
+					}
+				}
+				travel[index] = -1
+			}
+
+			coordinates[index][[2]int{int(math.Round(x * 80)), int(math.Round(y * 80))}]++
+			tilted[index]++
+		}
+	}
+
+	estimates := make(map[uint8]ControllerEstimate)
+	for index, counts := range coordinates {
+		estimate := ControllerEstimate{
+			PlayerIndex:         index,
+			DistinctCoordinates: len(counts),
+			Movements:           movements[index],
+		}
+
+		uses := make([]int, 0, len(counts))
+		for _, count := range counts {
+			uses = append(uses, count)
+		}
+		sort.Sort(sort.Reverse(sort.IntSlice(uses)))
+
+		notched := 0
+		for _, count := range uses[:min(controllerNotches, len(uses))] {
+			notched += count
+		}
+		if tilted[index] > 0 {
+			estimate.NotchShare = float64(notched) / float64(tilted[index])
+		}
+		if estimate.Movements > 0 {
+			estimate.InstantShare = float64(instant[index]) / float64(estimate.Movements)
+		}
+
+		if estimate.Movements > 0 {
+			estimate.DigitalScore = (estimate.NotchShare + estimate.InstantShare) / 2
+			estimate.Type = AnalogController
+			if estimate.DigitalScore >= digitalScoreThreshold {
+				estimate.Type = DigitalController
+			}
+
+			// confidence grows with the distance from the threshold and the
+			// number of movements seen
+			distance := math.Abs(estimate.DigitalScore-digitalScoreThreshold) / (1 - digitalScoreThreshold)
+			estimate.Confidence = math.Min(distance, 1) * math.Min(float64(estimate.Movements)/minControllerMovements, 1)
+		}
+
+		estimates[index] = estimate
+	}
+
+	return estimates
+}
+
+// ControllerTypes returns an estimate of the controller each player in the
+// game used, keyed by player index.
+func (g *SlpGame) ControllerTypes() (map[uint8]ControllerEstimate, error) {
+	frames, err := g.GetFrames()
+	if err != nil {
+		return nil, err
+	}
+
+	return EstimateControllerTypes(frames), nil
+}
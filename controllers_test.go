@@ -0,0 +1,72 @@
+package slippi
+
+import (
+	"encoding/binary"
+	"math"
+	"os"
+	"testing"
+)
+
+func TestEstimateControllerTypes(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game, err := NewSlpGameFromBytes(b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer game.Close()
+
+	estimates, err := game.ControllerTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, index := range []uint8{0, 1} {
+		estimate := estimates[index]
+		if estimate.Type != AnalogController || estimate.Confidence < 0.5 {
+			t.Errorf("expected player %d to use an analog controller, got %s with confidence %f", index, estimate.Type, estimate.Confidence)
+		}
+	}
+
+	// snapping the joystick of player 0 to the 8 directions a box can reach
+	// makes their controller look digital
+	replay := rewriteEvents(t, b, func(event []byte) []byte {
+		if Command(event[0]) != PreFrameUpdate || event[1+0x4] != 0 || event[1+0x5] != 0 {
+			return event
+		}
+
+		x := float64(math.Float32frombits(binary.BigEndian.Uint32(event[1+0x18:])))
+		y := float64(math.Float32frombits(binary.BigEndian.Uint32(event[1+0x1C:])))
+		if math.Hypot(x, y) <= idleJoystickDeadzone {
+			x, y = 0, 0
+		} else {
+			angle := math.Round(math.Atan2(y, x)/(math.Pi/4)) * math.Pi / 4
+			x, y = math.Cos(angle), math.Sin(angle)
+		}
+		binary.BigEndian.PutUint32(event[1+0x18:], math.Float32bits(float32(x)))
+		binary.BigEndian.PutUint32(event[1+0x1C:], math.Float32bits(float32(y)))
+
+		return event
+	})
+
+	boxGame, err := NewSlpGameFromBytes(replay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer boxGame.Close()
+
+	estimates, err = boxGame.ControllerTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if estimate := estimates[0]; estimate.Type != DigitalController || estimate.Confidence < 0.5 || estimate.DistinctCoordinates > 8 {
+		t.Errorf("expected player 0 to use a digital controller, got %+v", estimate)
+	}
+	if estimate := estimates[1]; estimate.Type != AnalogController {
+		t.Errorf("expected player 1 to use an analog controller, got %s", estimate.Type)
+	}
+}
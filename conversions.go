@@ -0,0 +1,336 @@
+package slippi
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// PunishResetFrames is the number of frames a defender must be in control for
+// before a conversion against them ends.
+const PunishResetFrames = 45
+
+// A ConversionMove is an attack landed during a conversion.
+type ConversionMove struct {
+	PlayerIndex uint8    `json:"playerIndex"`
+	Frame       int32    `json:"frame"`
+	MoveID      AttackID `json:"moveId"`
+	HitCount    int      `json:"hitCount"`
+	Damage      float32  `json:"damage"`
+	// ByFollower is whether the move was landed by the attacker's follower,
+	// such as Nana for Ice Climbers. It and the item fields aren't encoded
+	// in JSON, which matches the moves of slippi-js.
+	ByFollower bool `json:"-"`
+	// Item is the type of the projectile that landed the move, if ByItem
+	// is true.
+	Item   ItemType `json:"-"`
+	ByItem bool     `json:"-"`
+}
+
+// A Conversion is a sequence of hits by one player on another, beginning with
+// an opening and ending when the defender loses a stock or regains control for
+// PunishResetFrames frames. Its JSON encoding is that of slippi-js.
+type Conversion struct {
+	AttackerIndex  uint8
+	DefenderIndex  uint8
+	StartFrame     int32
+	EndFrame       int32
+	StartPercent   float32
+	CurrentPercent float32
+	EndPercent     float32
+	Moves          []ConversionMove
+	DidKill        bool
+	IsComplete     bool
+	// OpeningType is how the conversion began, which only a
+	// ConversionComputer determines.
+	OpeningType OpeningType
+}
+
+// MarshalJSON encodes the conversion as slippi-js does, by the index of the
+// defender and the index of the attacker as the player who last hit them, and
+// without an end frame or end percent while it is in progress.
+func (c Conversion) MarshalJSON() ([]byte, error) {
+	var endFrame *int32
+	var endPercent *float32
+	if c.IsComplete {
+		endFrame = &c.EndFrame
+		endPercent = &c.EndPercent
+	}
+
+	return json.Marshal(struct {
+		PlayerIndex    uint8            `json:"playerIndex"`
+		LastHitBy      uint8            `json:"lastHitBy"`
+		StartFrame     int32            `json:"startFrame"`
+		EndFrame       *int32           `json:"endFrame"`
+		StartPercent   float32          `json:"startPercent"`
+		CurrentPercent float32          `json:"currentPercent"`
+		EndPercent     *float32         `json:"endPercent"`
+		Moves          []ConversionMove `json:"moves"`
+		DidKill        bool             `json:"didKill"`
+		OpeningType    OpeningType      `json:"openingType"`
+	}{
+		PlayerIndex:    c.DefenderIndex,
+		LastHitBy:      c.AttackerIndex,
+		StartFrame:     c.StartFrame,
+		EndFrame:       endFrame,
+		StartPercent:   c.StartPercent,
+		CurrentPercent: c.CurrentPercent,
+		EndPercent:     endPercent,
+		Moves:          c.Moves,
+		DidKill:        c.DidKill,
+		OpeningType:    c.OpeningType,
+	})
+}
+
+type conversionState struct {
+	conversion       *Conversion
+	move             int
+	resetCounter     int
+	lastHitAnimation int32
+}
+
+// ConversionTracker detects conversions between every ordered pair of players
+// from frames processed in order.
+type ConversionTracker struct {
+	states     map[[2]uint8]*conversionState
+	prev       map[uint8]*PostFrameUpdatePayload
+	prevFrame  FrameEntry
+	onComplete func(Conversion)
+	// OnStart, if set, is called with each conversion as it begins, which
+	// the tracker keeps updating until it completes.
+	OnStart func(*Conversion)
+}
+
+// NewConversionTracker returns a ConversionTracker that hasn't processed any
+// frames, which calls onComplete with each conversion once it completes.
+func NewConversionTracker(onComplete func(Conversion)) *ConversionTracker {
+	return &ConversionTracker{
+		states:     make(map[[2]uint8]*conversionState),
+		prev:       make(map[uint8]*PostFrameUpdatePayload),
+		onComplete: onComplete,
+	}
+}
+
+// ProcessFrame processes the next frame of the game.
+func (t *ConversionTracker) ProcessFrame(frame FrameEntry) {
+	indices := make([]int, 0, len(frame.Players))
+	for index, updates := range frame.Players {
+		if updates.Post != nil {
+			indices = append(indices, int(index))
+		}
+	}
+	sort.Ints(indices)
+
+	for _, attacker := range indices {
+		for _, defender := range indices {
+			if attacker != defender {
+				t.processPair(frame, uint8(attacker), uint8(defender))
+			}
+		}
+	}
+
+	for _, index := range indices {
+		t.prev[uint8(index)] = frame.Players[uint8(index)].Post
+	}
+	t.prevFrame = frame
+}
+
+func (t *ConversionTracker) processPair(frame FrameEntry, attacker uint8, defender uint8) {
+	key := [2]uint8{attacker, defender}
+	state, ok := t.states[key]
+	if !ok {
+		state = &conversionState{lastHitAnimation: -1}
+		t.states[key] = state
+	}
+
+	playerFrame := frame.Players[attacker].Post
+	opponentFrame := frame.Players[defender].Post
+	prevPlayerFrame := t.prev[attacker]
+	prevOpponentFrame := t.prev[defender]
+
+	opponentActionState := opponentFrame.ActionStateID
+	opponentIsPunished := IsDamaged(opponentActionState) || IsGrabbed(opponentActionState) || IsCommandGrabbed(opponentActionState)
+
+	var damageTaken float32
+	if prevOpponentFrame != nil {
+		damageTaken = opponentFrame.Percent - prevOpponentFrame.Percent
+	}
+
+	// track whether the attacker's animation changed since the last hit, so
+	// repeated uses of the same move are counted separately
+	actionChangedSinceHit := int32(playerFrame.ActionStateID) != state.lastHitAnimation
+	actionCounterReset := prevPlayerFrame != nil && playerFrame.ActionStateFrameCounter < prevPlayerFrame.ActionStateFrameCounter
+	if actionChangedSinceHit || actionCounterReset {
+		state.lastHitAnimation = -1
+	}
+
+	if opponentIsPunished {
+		if state.conversion == nil {
+			startPercent := float32(0)
+			if prevOpponentFrame != nil {
+				startPercent = prevOpponentFrame.Percent
+			}
+
+			state.conversion = &Conversion{
+				AttackerIndex:  attacker,
+				DefenderIndex:  defender,
+				StartFrame:     frame.FrameNumber,
+				StartPercent:   startPercent,
+				CurrentPercent: opponentFrame.Percent,
+				Moves:          make([]ConversionMove, 0),
+			}
+			state.move = -1
+			if t.OnStart != nil {
+				t.OnStart(state.conversion)
+			}
+		}
+
+		// damage dealt by other players doesn't count towards the conversion
+		var hit Hit
+		attributed := false
+		if damageTaken > 0 {
+			hit, attributed = AttributeHit(frame, t.prevFrame, defender)
+		}
+
+		if damageTaken > 0 && (!attributed || hit.AttackerIndex == attacker) {
+			if state.lastHitAnimation == -1 {
+				hitter, byFollower := hittingClimber(frame, attacker)
+				move := ConversionMove{
+					PlayerIndex: attacker,
+					Frame:       frame.FrameNumber,
+					MoveID:      hitter.LastHittingAttackID,
+					ByFollower:  byFollower,
+				}
+				if attributed {
+					move.ByFollower = hit.ByFollower
+					if hit.Item != nil {
+						move.Item = hit.Item.TypeID
+						move.ByItem = true
+					}
+				}
+				state.conversion.Moves = append(state.conversion.Moves, move)
+				state.move = len(state.conversion.Moves) - 1
+			}
+
+			if state.move >= 0 {
+				state.conversion.Moves[state.move].HitCount++
+				state.conversion.Moves[state.move].Damage += damageTaken
+			}
+
+			// the previous frame's animation is the one that connected, which
+			// matters in the case of a trade
+			if prevPlayerFrame != nil {
+				state.lastHitAnimation = int32(prevPlayerFrame.ActionStateID)
+			}
+		}
+	}
+
+	if state.conversion == nil {
+		return
+	}
+
+	opponentDidLoseStock := prevOpponentFrame != nil && prevOpponentFrame.StocksRemaining > opponentFrame.StocksRemaining
+	if !opponentDidLoseStock {
+		state.conversion.CurrentPercent = opponentFrame.Percent
+	}
+
+	if opponentIsPunished {
+		state.resetCounter = 0
+	}
+
+	// count frames once the defender is back in control
+	if (state.resetCounter == 0 && IsInControl(opponentActionState)) || state.resetCounter > 0 {
+		state.resetCounter++
+	}
+
+	if opponentDidLoseStock {
+		state.conversion.DidKill = true
+	}
+
+	if opponentDidLoseStock || state.resetCounter > PunishResetFrames {
+		state.conversion.EndFrame = frame.FrameNumber
+		if prevOpponentFrame != nil {
+			state.conversion.EndPercent = prevOpponentFrame.Percent
+		}
+		state.conversion.IsComplete = true
+
+		t.onComplete(*state.conversion)
+		state.conversion = nil
+		state.move = -1
+		state.resetCounter = 0
+	}
+}
+
+// Flush emits all conversions that are still in progress, with the given
+// frame as their end frame.
+func (t *ConversionTracker) Flush(lastFrame int32) {
+	keys := make([][2]uint8, 0, len(t.states))
+	for key, state := range t.states {
+		if state.conversion != nil {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return t.states[keys[i]].conversion.StartFrame < t.states[keys[j]].conversion.StartFrame
+	})
+
+	for _, key := range keys {
+		state := t.states[key]
+		state.conversion.EndFrame = lastFrame
+		state.conversion.EndPercent = state.conversion.CurrentPercent
+
+		t.onComplete(*state.conversion)
+		state.conversion = nil
+		state.move = -1
+		state.resetCounter = 0
+	}
+}
+
+// openStartFrame returns the earliest start frame of any conversion in
+// progress, and whether there is one.
+func (t *ConversionTracker) openStartFrame() (int32, bool) {
+	var start int32
+	found := false
+	for _, state := range t.states {
+		if state.conversion != nil && (!found || state.conversion.StartFrame < start) {
+			start = state.conversion.StartFrame
+			found = true
+		}
+	}
+
+	return start, found
+}
+
+// OpeningType enumerates the ways a conversion can begin.
+type OpeningType uint8
+
+// OpeningTypes
+const (
+	// UnknownOpening is the opening type of conversions that haven't been
+	// classified.
+	UnknownOpening OpeningType = iota
+	// NeutralWin is the opening type of conversions begun from neutral.
+	NeutralWin
+	// CounterAttack is the opening type of conversions begun while the
+	// attacker was being punished by the defender.
+	CounterAttack
+	// Trade is the opening type of conversions begun on the same frame as a
+	// conversion by the defender.
+	Trade
+)
+
+var openingTypeNames = map[OpeningType]string{
+	UnknownOpening: "unknown",
+	NeutralWin:     "neutral-win",
+	CounterAttack:  "counter-attack",
+	Trade:          "trade",
+}
+
+// String returns the name slippi-js gives the opening type.
+func (o OpeningType) String() string {
+	return openingTypeNames[o]
+}
+
+// MarshalText encodes the opening type as its name.
+func (o OpeningType) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
@@ -0,0 +1,198 @@
+package slippi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// A CrewRuleset contains the rules of a crew battle.
+type CrewRuleset struct {
+	// Stocks is the number of stocks each player of a crew starts with.
+	Stocks int `json:"stocks"`
+}
+
+// A Crew is a team of players who play a crew battle in order.
+type Crew struct {
+	Name string `json:"name"`
+	// Members identify the players of the crew, in the order they play, by
+	// their connect code, display name or nametag.
+	Members []string `json:"members"`
+}
+
+// A CrewGame is a single game of a crew battle, between the current players
+// of each crew.
+type CrewGame struct {
+	// Members are the indices within their crews of the players of the
+	// game, and PlayerIndices their indices within the game.
+	Members       [2]int   `json:"members"`
+	PlayerIndices [2]uint8 `json:"playerIndices"`
+	// StartStocks are the stocks each player carried into the game, and
+	// EndStocks those they had left when one of them was eliminated.
+	StartStocks [2]int `json:"startStocks"`
+	EndStocks   [2]int `json:"endStocks"`
+	// Eliminated is the crew whose player was eliminated, or -1 if neither
+	// was, such as when the game was quit out of with no winner.
+	Eliminated int8 `json:"eliminated"`
+}
+
+// A CrewTracker carries the stocks of the players of two crews across the
+// consecutive games of a crew battle. The player who wins a game carries the
+// stocks they have left into their next game, against the next player of the
+// losing crew, who starts with the ruleset's stocks.
+type CrewTracker struct {
+	Ruleset CrewRuleset `json:"ruleset"`
+	Crews   [2]Crew     `json:"crews"`
+	Games   []CrewGame  `json:"games"`
+	// QuitOuts determines who is eliminated from games that were quit out
+	// of, where no player ran out of stocks.
+	QuitOuts QuitOutPolicy `json:"-"`
+	current  [2]int
+	carried  [2]int
+}
+
+// NewCrewTracker returns a CrewTracker for a crew battle between the given
+// crews under ruleset.
+func NewCrewTracker(ruleset CrewRuleset, a Crew, b Crew) *CrewTracker {
+	return &CrewTracker{
+		Ruleset:  ruleset,
+		Crews:    [2]Crew{a, b},
+		Games:    make([]CrewGame, 0),
+		QuitOuts: QuitOutLoss,
+		current:  [2]int{0, 0},
+		carried:  [2]int{ruleset.Stocks, ruleset.Stocks},
+	}
+}
+
+// Stocks returns the total number of stocks the crew with the given index has
+// left, across its current player and those yet to play.
+func (t *CrewTracker) Stocks(crew int) int {
+	if t.current[crew] >= len(t.Crews[crew].Members) {
+		return 0
+	}
+
+	waiting := len(t.Crews[crew].Members) - t.current[crew] - 1
+	return t.carried[crew] + waiting*t.Ruleset.Stocks
+}
+
+// Current returns the index within the crew of its current player, and the
+// stocks they have left, or -1 if all of its players have been eliminated.
+func (t *CrewTracker) Current(crew int) (int, int) {
+	if t.current[crew] >= len(t.Crews[crew].Members) {
+		return -1, 0
+	}
+
+	return t.current[crew], t.carried[crew]
+}
+
+// Winner returns the index of the crew that won the crew battle, and whether
+// it is over.
+func (t *CrewTracker) Winner() (int, bool) {
+	for crew := range t.Crews {
+		if t.Stocks(crew) == 0 {
+			return 1 - crew, true
+		}
+	}
+
+	return -1, false
+}
+
+// AddGame adds the next game of the crew battle, which must be between the
+// current players of each crew. The first player to lose as many stocks as
+// they carried into the game is eliminated.
+func (t *CrewTracker) AddGame(game *SlpGame) error {
+	if _, over := t.Winner(); over {
+		return errors.New("crew battle is over")
+	}
+
+	gameInfo, err := game.GetGameInfo()
+	if err != nil {
+		return err
+	} else if len(gameInfo.Players) != 2 {
+		return errors.New(fmt.Sprintf("crew battle games must have 2 players, not %d", len(gameInfo.Players)))
+	}
+
+	crewGame := CrewGame{StartStocks: t.carried, Eliminated: -1}
+	for crew := range t.Crews {
+		member := t.Crews[crew].Members[t.current[crew]]
+		found := false
+		for _, player := range gameInfo.Players {
+			if player.ConnectCode == member || player.DisplayName == member || player.Nametag == member {
+				crewGame.Members[crew] = t.current[crew]
+				crewGame.PlayerIndices[crew] = player.Index
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return errors.New(fmt.Sprintf("player %s of crew %s is not in the game", member, t.Crews[crew].Name))
+		}
+	}
+
+	if crewGame.PlayerIndices[0] == crewGame.PlayerIndices[1] {
+		return errors.New("crews' current players are the same player")
+	}
+
+	deaths, err := game.DeathStats()
+	if err != nil {
+		return err
+	}
+
+	// each player is eliminated on the death of the last stock they carried
+	eliminatedAt := [2]int32{}
+	eliminated := [2]bool{}
+	for crew := range t.Crews {
+		playerDeaths := deaths[crewGame.PlayerIndices[crew]].Deaths
+		if len(playerDeaths) >= t.carried[crew] && t.carried[crew] > 0 {
+			eliminatedAt[crew] = playerDeaths[t.carried[crew]-1].Frame
+			eliminated[crew] = true
+		}
+	}
+
+	loser := -1
+	switch {
+	case eliminated[0] && (!eliminated[1] || eliminatedAt[0] <= eliminatedAt[1]):
+		loser = 0
+	case eliminated[1]:
+		loser = 1
+	default:
+		result, err := game.Result(t.QuitOuts)
+		if err != nil {
+			return err
+		}
+
+		for crew := range t.Crews {
+			if result.WinnerIndex >= 0 && uint8(result.WinnerIndex) == crewGame.PlayerIndices[1-crew] {
+				loser = crew
+			}
+		}
+	}
+
+	// stocks lost after the loser was eliminated don't count
+	for crew := range t.Crews {
+		lost := 0
+		for _, death := range deaths[crewGame.PlayerIndices[crew]].Deaths {
+			if loser < 0 || !eliminated[loser] || death.Frame <= eliminatedAt[loser] {
+				lost++
+			}
+		}
+		crewGame.EndStocks[crew] = max(t.carried[crew]-lost, 0)
+	}
+	if loser >= 0 {
+		crewGame.EndStocks[loser] = 0
+		crewGame.Eliminated = int8(loser)
+	}
+
+	t.carried = crewGame.EndStocks
+	if loser >= 0 {
+		t.current[loser]++
+		t.carried[loser] = t.Ruleset.Stocks
+		if t.current[loser] >= len(t.Crews[loser].Members) {
+			t.carried[loser] = 0
+		}
+	}
+
+	t.Games = append(t.Games, crewGame)
+
+	return nil
+}
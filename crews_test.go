@@ -0,0 +1,63 @@
+package slippi
+
+import (
+	"os"
+	"testing"
+)
+
+func TestCrewTracker(t *testing.T) {
+	replay, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the fixture is played twice, with Falco's connect code standing in for
+	// both of crew B's players
+	tracker := NewCrewTracker(CrewRuleset{Stocks: 4},
+		Crew{Name: "A", Members: []string{"JUGG＃230", "Player 2"}},
+		Crew{Name: "B", Members: []string{"CRAP＃761", "CRAP＃761"}})
+
+	addGame := func() {
+		game, err := NewSlpGameFromBytes(replay, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer game.Close()
+
+		if err := tracker.AddGame(game); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Fox takes all of Falco's stocks with 1 left
+	addGame()
+	if game := tracker.Games[0]; game.Eliminated != 1 || game.EndStocks != [2]int{1, 0} {
+		t.Errorf("expected Falco to be eliminated with Fox on 1 stock, got %+v", game)
+	}
+	if tracker.Stocks(0) != 5 || tracker.Stocks(1) != 4 {
+		t.Errorf("expected crews on 5 and 4 stocks, got %d and %d", tracker.Stocks(0), tracker.Stocks(1))
+	}
+
+	// Fox is eliminated by their first death, after Falco has lost 2 stocks
+	addGame()
+	if game := tracker.Games[1]; game.Eliminated != 0 || game.StartStocks != [2]int{1, 4} || game.EndStocks != [2]int{0, 2} || tracker.Stocks(1) != 2 {
+		t.Errorf("expected Fox to be eliminated on their carried stock, got %+v", game)
+	}
+	if member, stocks := tracker.Current(0); member != 1 || stocks != 4 {
+		t.Errorf("expected crew A's second player on 4 stocks, got %d on %d", member, stocks)
+	}
+
+	if _, over := tracker.Winner(); over {
+		t.Error("expected crew battle to continue")
+	}
+
+	game, err := NewSlpGameFromBytes(replay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer game.Close()
+
+	if err := tracker.AddGame(game); err == nil {
+		t.Error("expected error for a game without crew A's current player")
+	}
+}
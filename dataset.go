@@ -0,0 +1,238 @@
+package slippi
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// DatasetFormat enumerates the formats ExportDataset can write.
+type DatasetFormat uint8
+
+// DatasetFormats
+const (
+	// DatasetJSONL writes a JSON object per game, one per line.
+	DatasetJSONL DatasetFormat = iota
+	// DatasetCSV writes a header row followed by a row per game, with the
+	// columns of each player grouped by port.
+	DatasetCSV
+)
+
+// DatasetOpts are the options of ExportDataset.
+type DatasetOpts struct {
+	Format DatasetFormat
+	// QuitOuts determines the result of games that were quit out of, and
+	// whether they are exported at all.
+	QuitOuts QuitOutPolicy
+	// SkipErrors skips the games that fail to parse, instead of stopping
+	// the export.
+	SkipErrors bool
+}
+
+// A PlayerSummary summarizes how a single player did in a game.
+type PlayerSummary struct {
+	Index         uint8       `json:"index"`
+	Port          uint8       `json:"port"`
+	Character     CharacterID `json:"character"`
+	CharacterName string      `json:"characterName"`
+	DisplayName   string      `json:"displayName"`
+	ConnectCode   string      `json:"connectCode"`
+	// Stocks are the stocks the player had left at the end of the game.
+	Stocks uint8 `json:"stocks"`
+	// DamageTaken is the damage the player took from their opponents.
+	DamageTaken   float32 `json:"damageTaken"`
+	Deaths        int     `json:"deaths"`
+	SelfDestructs int     `json:"selfDestructs"`
+}
+
+// A GameSummary summarizes a game, as a single entry of a dataset.
+type GameSummary struct {
+	Path      string  `json:"path"`
+	Stage     StageID `json:"stage"`
+	StageName string  `json:"stageName"`
+	// Frames is the number of playable frames in the game, and Duration its
+	// length in seconds.
+	Frames      int32           `json:"frames"`
+	Duration    float64         `json:"duration"`
+	EndMethod   GameEndMethod   `json:"endMethod"`
+	WinnerIndex int8            `json:"winnerIndex"`
+	QuitOut     bool            `json:"quitOut"`
+	Players     []PlayerSummary `json:"players"`
+}
+
+// SummarizeGame returns the summary of the game read from path, with its
+// result determined by policy.
+func SummarizeGame(path string, game *SlpGame, policy QuitOutPolicy) (*GameSummary, error) {
+	gameInfo, err := game.GetGameInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := game.Result(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	damage, err := game.DamageBreakdowns()
+	if err != nil {
+		return nil, err
+	}
+
+	frames := game.parser.GetPlayableFrameCount()
+	summary := &GameSummary{
+		Path:        path,
+		Stage:       gameInfo.Stage,
+		StageName:   gameInfo.Stage.String(),
+		Frames:      frames,
+		Duration:    float64(frames) / 60,
+		EndMethod:   result.EndMethod,
+		WinnerIndex: result.WinnerIndex,
+		QuitOut:     result.QuitOut != nil,
+		Players:     make([]PlayerSummary, 0, len(gameInfo.Players)),
+	}
+
+	for _, player := range gameInfo.Players {
+		playerSummary := PlayerSummary{
+			Index:         player.Index,
+			Port:          player.Port,
+			Character:     player.CharacterID,
+			CharacterName: player.CharacterID.String(),
+			DisplayName:   player.DisplayName,
+			ConnectCode:   player.ConnectCode,
+		}
+
+		if breakdown, ok := damage[player.Index]; ok {
+			playerSummary.DamageTaken = breakdown.FromOpponents
+			playerSummary.Deaths = breakdown.Deaths
+			playerSummary.SelfDestructs = breakdown.SelfDestructs
+		}
+
+		// the last frame parsed is before the final death of a game that
+		// ended by stocks, so stocks are counted from the deaths instead
+		playerSummary.Stocks = uint8(max(int(player.StockStartCount)-playerSummary.Deaths, 0))
+
+		summary.Players = append(summary.Players, playerSummary)
+	}
+
+	return summary, nil
+}
+
+// datasetPlayerColumns are the CSV columns of each player, which are prefixed
+// with their port.
+var datasetPlayerColumns = []string{"character", "display_name", "connect_code", "stocks", "damage_taken", "deaths", "self_destructs"}
+
+// ExportDataset writes the summary of each of the replays at paths to w, in
+// the format given by opts, so that a collection of replays can be analyzed as
+// a single dataset.
+func ExportDataset(paths []string, w io.Writer, opts DatasetOpts) error {
+	var rows *csv.Writer
+	encoder := json.NewEncoder(w)
+	switch opts.Format {
+	case DatasetJSONL:
+	case DatasetCSV:
+		rows = csv.NewWriter(w)
+		header := []string{"path", "stage", "frames", "duration", "end_method", "winner_index", "quit_out"}
+		for port := 1; port <= 4; port++ {
+			for _, column := range datasetPlayerColumns {
+				header = append(header, fmt.Sprintf("p%d_%s", port, column))
+			}
+		}
+		if err := rows.Write(header); err != nil {
+			return err
+		}
+	default:
+		return errors.New(fmt.Sprintf("unknown dataset format %d", opts.Format))
+	}
+
+	for _, path := range paths {
+		summary, err := summarizeFile(path, opts.QuitOuts)
+		if err != nil && opts.SkipErrors {
+			continue
+		} else if err != nil {
+			return errors.New(fmt.Sprintf("failed to summarize %s: %s", path, err))
+		} else if summary == nil {
+			continue
+		}
+
+		if rows == nil {
+			if err := encoder.Encode(summary); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := rows.Write(summary.csvRow()); err != nil {
+			return err
+		}
+	}
+
+	if rows != nil {
+		rows.Flush()
+		return rows.Error()
+	}
+
+	return nil
+}
+
+// summarizeFile returns the summary of the replay at path, or nil if it is
+// excluded by policy.
+func summarizeFile(path string, policy QuitOutPolicy) (*GameSummary, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	game, err := NewSlpGameFromFile(f, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer game.Close()
+
+	result, err := game.Result(policy)
+	if err != nil {
+		return nil, err
+	} else if result.Excluded {
+		return nil, nil
+	}
+
+	return SummarizeGame(path, game, policy)
+}
+
+func (s *GameSummary) csvRow() []string {
+	row := []string{
+		s.Path,
+		s.StageName,
+		strconv.Itoa(int(s.Frames)),
+		strconv.FormatFloat(s.Duration, 'f', -1, 64),
+		strconv.Itoa(int(s.EndMethod)),
+		strconv.Itoa(int(s.WinnerIndex)),
+		strconv.FormatBool(s.QuitOut),
+	}
+
+	for port := uint8(1); port <= 4; port++ {
+		columns := make([]string, len(datasetPlayerColumns))
+		for _, player := range s.Players {
+			if player.Port != port {
+				continue
+			}
+
+			columns = []string{
+				player.CharacterName,
+				player.DisplayName,
+				player.ConnectCode,
+				strconv.Itoa(int(player.Stocks)),
+				strconv.FormatFloat(float64(player.DamageTaken), 'f', -1, 32),
+				strconv.Itoa(player.Deaths),
+				strconv.Itoa(player.SelfDestructs),
+			}
+		}
+		row = append(row, columns...)
+	}
+
+	return row
+}
@@ -0,0 +1,73 @@
+package slippi
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+)
+
+func TestExportDataset(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing.slp")
+	paths := []string{"game.slp", missing, "game.slp"}
+
+	if err := ExportDataset(paths, &bytes.Buffer{}, DatasetOpts{}); err == nil {
+		t.Error("expected error for missing replay")
+	}
+
+	var out bytes.Buffer
+	if err := ExportDataset(paths, &out, DatasetOpts{Format: DatasetJSONL, SkipErrors: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	summaries := make([]GameSummary, 0)
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		var summary GameSummary
+		if err := json.Unmarshal(scanner.Bytes(), &summary); err != nil {
+			t.Fatal(err)
+		}
+		summaries = append(summaries, summary)
+	}
+
+	if len(summaries) != 2 {
+		t.Fatalf("expected 2 summaries, got %d", len(summaries))
+	}
+
+	summary := summaries[0]
+	if summary.Stage != YoshisStory || summary.WinnerIndex != 0 || summary.EndMethod != Game || summary.QuitOut {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+	if len(summary.Players) != 2 {
+		t.Fatalf("expected 2 players, got %d", len(summary.Players))
+	}
+	if fox := summary.Players[0]; fox.Character != Fox || fox.Deaths != 3 || fox.SelfDestructs != 1 || fox.Stocks != 1 {
+		t.Errorf("unexpected summary of Fox %+v", fox)
+	}
+	if falco := summary.Players[1]; falco.Character != Falco || falco.Deaths != 4 || falco.Stocks != 0 || falco.DamageTaken == 0 {
+		t.Errorf("unexpected summary of Falco %+v", falco)
+	}
+
+	out.Reset()
+	if err := ExportDataset([]string{"game.slp"}, &out, DatasetOpts{Format: DatasetCSV}); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected a header and a row, got %d records", len(records))
+	}
+
+	row := make(map[string]string)
+	for i, column := range records[0] {
+		row[column] = records[1][i]
+	}
+	if row["stage"] != "Yoshi's Story" || row["p1_connect_code"] != "JUGG＃230" || row["p2_deaths"] != "4" || row["p3_character"] != "" {
+		t.Errorf("unexpected row %v", row)
+	}
+}
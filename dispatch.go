@@ -0,0 +1,101 @@
+package slippi
+
+// DispatchMode enumerates the ways a SlpParser can deliver events to its
+// handler channels.
+type DispatchMode uint8
+
+// DispatchModes
+const (
+	// DispatchConcurrent sends each event to each handler on a new
+	// goroutine, so parsing never waits on handlers, but a handler may
+	// receive events out of order.
+	DispatchConcurrent DispatchMode = iota
+	// DispatchOrdered queues the events for each handler channel, which
+	// receives them in the order they were triggered. Parsing never waits on
+	// handlers, and the queues are drained after parsing finishes.
+	DispatchOrdered
+	// DispatchSynchronous sends each event to each handler before parsing
+	// continues, so handlers receive events in order, but parsing blocks
+	// until every handler has received each event.
+	DispatchSynchronous
+)
+
+// A handlerQueue delivers the events triggered for a handler channel in
+// order.
+type handlerQueue struct {
+	in   chan<- *interface{}
+	done chan struct{}
+}
+
+// newHandlerQueue starts a queue delivering to handler, which waits for the
+// previous queue delivering to it, if any, to finish first. Once stop is
+// closed, the rest of the queue is discarded.
+func newHandlerQueue(handler chan interface{}, previous *handlerQueue, stop <-chan struct{}) *handlerQueue {
+	in, out := MakeUnboundedChannel[interface{}]()
+	q := &handlerQueue{in: in, done: make(chan struct{})}
+
+	go func() {
+		defer close(q.done)
+		if previous != nil {
+			<-previous.done
+		}
+
+		for payload := range out {
+			select {
+			case handler <- *payload:
+			case <-stop:
+			}
+		}
+	}()
+
+	return q
+}
+
+// dispatch delivers payload to handler according to the parser's dispatch
+// mode, unless stop, which is nil for handlers that aren't subscriptions, is
+// closed first.
+func (p *SlpParser) dispatch(handler chan interface{}, payload interface{}, stop <-chan struct{}) {
+	switch p.Options.Dispatch {
+	case DispatchOrdered:
+		p.handlersMu.Lock()
+		defer p.handlersMu.Unlock()
+
+		select {
+		case <-stop:
+			return
+		default:
+		}
+
+		q, ok := p.queues[handler]
+		if !ok {
+			q = newHandlerQueue(handler, p.drainingQueues[handler], stop)
+			p.queues[handler] = q
+		}
+		q.in <- &payload
+	case DispatchSynchronous:
+		select {
+		case handler <- payload:
+		case <-stop:
+		}
+	default:
+		go func() {
+			select {
+			case handler <- payload:
+			case <-stop:
+			}
+		}()
+	}
+}
+
+// closeQueues closes the parser's handler queues, which finish delivering
+// their queued events in the background.
+func (p *SlpParser) closeQueues() {
+	p.handlersMu.Lock()
+	defer p.handlersMu.Unlock()
+
+	for handler, q := range p.queues {
+		close(q.in)
+		p.drainingQueues[handler] = q
+	}
+	p.queues = make(map[chan interface{}]*handlerQueue)
+}
@@ -0,0 +1,62 @@
+package slippi
+
+import (
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDispatchOrder(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, mode := range []DispatchMode{DispatchOrdered, DispatchSynchronous} {
+		var mu sync.Mutex
+		received := make([]int32, 0)
+		recorder := NewCalculator("recorder", func(event ParserEvent, payload interface{}) {
+			mu.Lock()
+			received = append(received, payload.(FrameEntry).FrameNumber)
+			mu.Unlock()
+		}, FinalizedFrame)
+
+		game, err := NewSlpGameFromBytes(b, []SlpCalculator{recorder})
+		if err != nil {
+			t.Fatal(err)
+		}
+		game.SetDispatchMode(mode)
+
+		store, err := game.GetFrameStore()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			mu.Lock()
+			done := len(received) == store.FrameCount()
+			mu.Unlock()
+			if done {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		mu.Lock()
+		if len(received) != store.FrameCount() {
+			t.Errorf("mode %d: expected %d finalized frames, got %d", mode, store.FrameCount(), len(received))
+		}
+		for i := 1; i < len(received); i++ {
+			if received[i] != received[i-1]+1 {
+				t.Errorf("mode %d: expected frame %d after %d, got %d", mode, received[i-1]+1, received[i-1], received[i])
+				break
+			}
+		}
+		mu.Unlock()
+
+		game.Close()
+		recorder.Close()
+	}
+}
@@ -1,3 +1,9 @@
+//go:build cgo && !slippi_pure
+
+// The Dolphin connection uses enet through cgo, so it is left out of builds
+// without cgo, such as for WASM, and of builds with the slippi_pure tag, for
+// users who only parse replays.
+
 package slippi
 
 import (
@@ -0,0 +1,124 @@
+package slippi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SlpErrorCode enumerates stable codes identifying the kinds of failures of
+// reading and parsing replays, so that services can categorize failures
+// without matching error messages, which may change between versions. Codes
+// are never reused for a different kind of failure.
+type SlpErrorCode string
+
+// SlpErrorCodes
+const (
+	// NoErrorCode is the code of errors without one.
+	NoErrorCode SlpErrorCode = ""
+	// CodeInvalidPreamble is the code of files that don't start with the
+	// preamble of a replay.
+	CodeInvalidPreamble SlpErrorCode = "SLP001"
+	// CodeMissingEventPayloads is the code of replays and event streams that
+	// don't start with an event payloads event.
+	CodeMissingEventPayloads SlpErrorCode = "SLP002"
+	// CodeUnknownCommand is the code of events whose command has no known
+	// payload size.
+	CodeUnknownCommand SlpErrorCode = "SLP003"
+	// CodeUnreadableSource is the code of failures to read, seek or get the
+	// length of the source of a replay.
+	CodeUnreadableSource SlpErrorCode = "SLP004"
+	// CodeMissingGameInfo is the code of replays without a game start event.
+	CodeMissingGameInfo SlpErrorCode = "SLP005"
+	// CodeInvalidCache is the code of replay caches that are corrupt, of
+	// another version, or of another replay.
+	CodeInvalidCache SlpErrorCode = "SLP010"
+	// CodeUncacheableGame is the code of games whose state can't be cached,
+	// since it wasn't opened from a file or isn't retained.
+	CodeUncacheableGame SlpErrorCode = "SLP011"
+	// CodeBookendWindow is the code of frames whose bookend finalizes a frame
+	// outside of the rollback window.
+	CodeBookendWindow SlpErrorCode = "SLP013"
+	// CodeTruncatedFrame is the code of frames missing the updates of a
+	// player.
+	CodeTruncatedFrame SlpErrorCode = "SLP014"
+	// CodeTruncatedEvent is the code of events cut off by the end of the
+	// replay.
+	CodeTruncatedEvent SlpErrorCode = "SLP015"
+	// CodeInvalidCheckpoint is the code of parser checkpoints that are
+	// corrupt or of another version.
+	CodeInvalidCheckpoint SlpErrorCode = "SLP016"
+)
+
+// codedError attaches a SlpErrorCode to an error.
+type codedError struct {
+	code SlpErrorCode
+	err  error
+}
+
+// Error implements the error interface.
+func (e codedError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the error the code is attached to.
+func (e codedError) Unwrap() error {
+	return e.err
+}
+
+// withCode returns err with code attached.
+func withCode(code SlpErrorCode, err error) error {
+	return codedError{code: code, err: err}
+}
+
+// A ParseError is a failure to read or parse an event of a replay, with the
+// position of the event, so that corrupt replays can be inspected where they
+// fail.
+type ParseError struct {
+	// Command is the command byte of the event, or 0 if it couldn't be read.
+	Command Command
+	// Frame is the number of the frame the event belongs to, or of the
+	// latest frame before it for events that don't belong to one, or
+	// FirstFrame-1 if there is none.
+	Frame int32
+	// Offset is the offset of the event within the raw element of the
+	// replay.
+	Offset int64
+	Err    error
+}
+
+// Error implements the error interface.
+func (e ParseError) Error() string {
+	return fmt.Sprintf("%s (command 0x%X, frame %d, offset %d)", e.Err, byte(e.Command), e.Frame, e.Offset)
+}
+
+// Unwrap returns the error that occurred at the event.
+func (e ParseError) Unwrap() error {
+	return e.Err
+}
+
+// parseError returns err with the position of the event it occurred at,
+// unless it already has one.
+func parseError(err error, command Command, frame int32, offset int64) error {
+	var parseErr ParseError
+	if errors.As(err, &parseErr) {
+		return err
+	}
+
+	return ParseError{Command: command, Frame: frame, Offset: offset, Err: err}
+}
+
+// ErrorCode returns the code of err, or of the first error it wraps that has
+// one, or NoErrorCode if none of them do.
+func ErrorCode(err error) SlpErrorCode {
+	var coded codedError
+	if errors.As(err, &coded) {
+		return coded.code
+	}
+
+	var warning ParseWarning
+	if errors.As(err, &warning) {
+		return warning.Code
+	}
+
+	return NoErrorCode
+}
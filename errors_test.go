@@ -0,0 +1,121 @@
+package slippi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestErrorCode(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if code := ErrorCode(nil); code != NoErrorCode {
+		t.Errorf("expected no code for nil, got %s", code)
+	}
+
+	_, err = NewSlpReader(*NewSlpSourceBytes(bytes.NewReader(append([]byte("not a replay"), b...))))
+	if code := ErrorCode(fmt.Errorf("opening replay: %w", err)); code != CodeInvalidPreamble {
+		t.Errorf("expected %s for invalid preamble, got %s (%v)", CodeInvalidPreamble, code, err)
+	}
+
+	_, err = NewSlpReaderRaw(*NewSlpSourceBytes(bytes.NewReader(b)))
+	if code := ErrorCode(err); code != CodeMissingEventPayloads {
+		t.Errorf("expected %s for missing event payloads, got %s (%v)", CodeMissingEventPayloads, code, err)
+	}
+
+	truncated, err := NewSlpGameFromBytes(b[:len(b)/2], nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer truncated.Close()
+
+	_, err = truncated.GetFrames()
+	if code := ErrorCode(err); code != CodeTruncatedEvent {
+		t.Errorf("expected %s for truncated replay, got %s (%v)", CodeTruncatedEvent, code, err)
+	}
+
+	// a frame missing the post-frame update of a player
+	replay := rewriteEvents(t, b, func(event []byte) []byte {
+		if Command(event[0]) == PostFrameUpdate && int32(binary.BigEndian.Uint32(event[1:5])) == 100 && event[5] == 1 {
+			return nil
+		}
+		return event
+	})
+
+	game, err := NewSlpGameFromBytes(replay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer game.Close()
+
+	warnings, err := game.GetWarnings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(warnings) != 1 || ErrorCode(warnings[0]) != CodeTruncatedFrame {
+		t.Errorf("expected a warning with code %s, got %+v", CodeTruncatedFrame, warnings)
+	}
+
+	game.SetStrictChecks(StrictOpts{RequireCompleteFrames: true})
+	_, err = game.GetFrames()
+	if code := ErrorCode(err); code != CodeTruncatedFrame {
+		t.Errorf("expected %s for incomplete frame, got %s (%v)", CodeTruncatedFrame, code, err)
+	}
+}
+
+func TestParseError(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a frame missing the post-frame update of a player, which fails to
+	// finalize on the bookend of a later frame
+	replay := rewriteEvents(t, b, func(event []byte) []byte {
+		if Command(event[0]) == PostFrameUpdate && int32(binary.BigEndian.Uint32(event[1:5])) == 100 && event[5] == 1 {
+			return nil
+		}
+		return event
+	})
+	raw := replay[15 : 15+int(binary.BigEndian.Uint32(replay[11:15]))]
+
+	game, err := NewSlpGameFromBytes(replay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer game.Close()
+	game.SetStrictChecks(StrictOpts{RequireCompleteFrames: true})
+
+	_, err = game.GetFrames()
+	var parseErr ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected a parse error, got %v", err)
+	}
+	if parseErr.Command != FrameBookend || parseErr.Frame < 100 || ErrorCode(err) != CodeTruncatedFrame {
+		t.Errorf("expected the bookend of a frame after 100 to fail, got %+v", parseErr)
+	}
+	if Command(raw[parseErr.Offset]) != FrameBookend || int32(binary.BigEndian.Uint32(raw[parseErr.Offset+1:])) != parseErr.Frame {
+		t.Errorf("expected offset %d to be the bookend of frame %d", parseErr.Offset, parseErr.Frame)
+	}
+
+	// a replay cut off partway through an event
+	truncated, err := NewSlpGameFromBytes(b[:len(b)/2], nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer truncated.Close()
+
+	_, err = truncated.GetFrames()
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected a parse error, got %v", err)
+	}
+	if parseErr.Frame <= FirstFrame || int(parseErr.Offset) >= len(b)/2-15 || ErrorCode(err) != CodeTruncatedEvent {
+		t.Errorf("expected an event of a later frame to be cut off, got %+v", parseErr)
+	}
+}
@@ -75,7 +75,7 @@ const (
 type PlayerInfo struct {
 	Index           uint8
 	Port            uint8
-	CharacterID     uint8
+	CharacterID     CharacterID
 	PlayerType      PlayerType
 	StockStartCount uint8
 	CostumeIndex    uint8
@@ -122,7 +122,7 @@ type GameInfoBlock struct {
 	IsTeams                bool
 	ItemSpawnBehavior      ItemSpawnBehavior
 	SelfDestructScoreValue int8
-	Stage                  uint16
+	Stage                  StageID
 	GameTimer              uint32
 	ItemSpawnBitfield1     uint8
 	ItemSpawnBitfield2     uint8
@@ -149,8 +149,8 @@ type GameStartPayload struct {
 	RandomSeed     uint32
 	PAL            bool
 	FrozenPS       bool
-	MajorScene     uint8
-	MinorScene     uint8
+	MajorScene     MajorScene
+	MinorScene     MinorScene
 	LanguageOption Language
 }
 
@@ -219,7 +219,7 @@ type PostFrameUpdatePayload struct {
 	FrameUpdate
 	InternalCharacterID     uint8
 	ShieldSize              float32
-	LastHittingAttackID     uint8
+	LastHittingAttackID     AttackID
 	CurrentComboCount       uint8
 	LastHitBy               uint8
 	StocksRemaining         uint8
@@ -265,6 +265,10 @@ const (
 type GameEndPayload struct {
 	GameEndMethod GameEndMethod
 	LRASInitiator int8
+	// Placements are the placements of the players by port, starting at 0
+	// for first place, or -1 for ports without a player or replays that
+	// don't record placements.
+	Placements [4]int8
 }
 
 // FrameStartPayload represents the FrameStart Slippi event.
@@ -277,7 +281,7 @@ type FrameStartPayload struct {
 // ItemUpdatePayload represents the ItemUpdate Slippi event.
 type ItemUpdatePayload struct {
 	FrameNumber      int32
-	TypeID           uint16
+	TypeID           ItemType
 	State            uint8
 	FacingDirection  float32
 	XVelocity        float32
@@ -291,7 +295,10 @@ type ItemUpdatePayload struct {
 	PeachTurnipFace  uint8
 	IsLaunched       uint8
 	ChargedPower     uint8
-	Owner            int8
+	// Owner is the index of the player who owns the item, or -1 if no one
+	// does. Replays before 3.6.0 don't record owners, so the parser infers
+	// the owners of character projectiles from who spawned them.
+	Owner int8
 }
 
 // FrameBookendPayload represents the FrameBookend Slippi event.
@@ -326,4 +333,7 @@ const (
 type SlpEvent struct {
 	Command Command
 	Payload interface{}
+	// Offset is the offset of the event within the raw element of the
+	// replay.
+	Offset int64
 }
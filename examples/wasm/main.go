@@ -0,0 +1,113 @@
+//go:build js && wasm
+
+// Command wasm exposes the replay parser to JavaScript through WebAssembly.
+//
+// Build it with:
+//
+//	GOOS=js GOARCH=wasm go build -o slippi.wasm ./examples/wasm
+//
+// and load it with the wasm_exec.js shipped with Go, found under
+// $(go env GOROOT)/lib/wasm. It defines a global parseReplay function, which
+// takes the bytes of a replay as a Uint8Array and returns a summary of the
+// game as a JSON string, or throws an error:
+//
+//	const go = new Go();
+//	const { instance } = await WebAssembly.instantiateStreaming(fetch("slippi.wasm"), go.importObject);
+//	go.run(instance);
+//	const replay = new Uint8Array(await file.arrayBuffer());
+//	const summary = JSON.parse(parseReplay(replay));
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"syscall/js"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+type playerSummary struct {
+	Port      uint8                    `json:"port"`
+	Character string                   `json:"character"`
+	Damage    *slippi.DamageBreakdown  `json:"damage"`
+	Deaths    *slippi.PlayerDeathStats `json:"deaths"`
+}
+
+type gameSummary struct {
+	Stage   string             `json:"stage"`
+	Result  *slippi.GameResult `json:"result"`
+	Players []playerSummary    `json:"players"`
+}
+
+func summarize(replay []byte) (*gameSummary, error) {
+	// the replay is read from memory, since there is no file system
+	game, err := slippi.NewSlpGameFromReader(bytes.NewReader(replay), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer game.Close()
+
+	gameInfo, err := game.GetGameInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := game.Result(slippi.QuitOutPartial)
+	if err != nil {
+		return nil, err
+	}
+
+	damage, err := game.DamageBreakdowns()
+	if err != nil {
+		return nil, err
+	}
+
+	deaths, err := game.DeathStats()
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &gameSummary{
+		Stage:   gameInfo.Stage.String(),
+		Result:  result,
+		Players: make([]playerSummary, 0, len(gameInfo.Players)),
+	}
+	for _, player := range gameInfo.Players {
+		summary.Players = append(summary.Players, playerSummary{
+			Port:      player.Port,
+			Character: player.CharacterID.String(),
+			Damage:    damage[player.Index],
+			Deaths:    deaths[player.Index],
+		})
+	}
+
+	return summary, nil
+}
+
+func parseReplay(this js.Value, args []js.Value) any {
+	if len(args) != 1 || args[0].Type() != js.TypeObject {
+		panic(js.Global().Get("Error").New("parseReplay expects a Uint8Array"))
+	}
+
+	replay := make([]byte, args[0].Get("length").Int())
+	js.CopyBytesToGo(replay, args[0])
+
+	summary, err := summarize(replay)
+	if err != nil {
+		panic(js.Global().Get("Error").New(err.Error()))
+	}
+
+	b, err := json.Marshal(summary)
+	if err != nil {
+		panic(js.Global().Get("Error").New(err.Error()))
+	}
+
+	return string(b)
+}
+
+func main() {
+	js.Global().Set("parseReplay", js.FuncOf(parseReplay))
+
+	// keep the functions available to JavaScript
+	select {}
+}
@@ -0,0 +1,78 @@
+package slippi
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// replayTimeLayout is the layout of the timestamp in the names Slippi gives
+// replays, which is in the local time of the recording machine.
+const replayTimeLayout = "20060102T150405"
+
+// replayFolderLayout is the layout of the monthly folders Slippi can sort
+// replays into.
+const replayFolderLayout = "2006-01"
+
+// ParseReplayFilename returns the time a replay was recorded from its name,
+// which has the Slippi form "Game_YYYYMMDDTHHMMSS.slp". Paths are accepted,
+// and only their final element is parsed. Since Slippi names replays by the
+// local time of the recording machine, the time is returned in loc.
+func ParseReplayFilename(name string, loc *time.Location) (time.Time, error) {
+	base := path.Base(strings.ReplaceAll(name, "\\", "/"))
+	if !strings.HasPrefix(base, "Game_") || !strings.HasSuffix(base, ".slp") {
+		return time.Time{}, errors.New(fmt.Sprintf("%s is not a Slippi replay name", base))
+	}
+
+	timestamp := strings.TrimSuffix(strings.TrimPrefix(base, "Game_"), ".slp")
+	t, err := time.ParseInLocation(replayTimeLayout, timestamp, loc)
+	if err != nil {
+		return time.Time{}, errors.New(fmt.Sprintf("%s has an invalid timestamp: %v", base, err))
+	}
+
+	return t, nil
+}
+
+// FormatReplayFilename returns the Slippi name of a replay recorded at t.
+func FormatReplayFilename(t time.Time) string {
+	return "Game_" + t.Format(replayTimeLayout) + ".slp"
+}
+
+// FormatReplayPath returns the slash-separated path of a replay recorded at t
+// relative to the replay directory. If monthlyFolders is true, the replay is
+// placed in a "YYYY-MM" folder for its month, as Slippi does when sorting
+// replays into folders.
+func FormatReplayPath(t time.Time, monthlyFolders bool) string {
+	if !monthlyFolders {
+		return FormatReplayFilename(t)
+	}
+
+	return t.Format(replayFolderLayout) + "/" + FormatReplayFilename(t)
+}
+
+// SortReplayPaths sorts paths to replays by the times in their names, without
+// opening them. Paths without a Slippi replay name are sorted after the rest,
+// in lexical order.
+func SortReplayPaths(paths []string) {
+	times := make(map[string]time.Time, len(paths))
+	for _, p := range paths {
+		if t, err := ParseReplayFilename(p, time.UTC); err == nil {
+			times[p] = t
+		}
+	}
+
+	sort.SliceStable(paths, func(i, j int) bool {
+		a, okA := times[paths[i]]
+		b, okB := times[paths[j]]
+		if okA != okB {
+			return okA
+		} else if !okA || a.Equal(b) {
+			return paths[i] < paths[j]
+		}
+
+		return a.Before(b)
+	})
+}
@@ -0,0 +1,40 @@
+package slippi
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReplayFilenames(t *testing.T) {
+	recorded := time.Date(2023, 4, 12, 19, 23, 14, 0, time.UTC)
+
+	name := FormatReplayFilename(recorded)
+	if name != "Game_20230412T192314.slp" {
+		t.Errorf("unexpected replay name %s", name)
+	}
+
+	if path := FormatReplayPath(recorded, true); path != "2023-04/Game_20230412T192314.slp" {
+		t.Errorf("unexpected replay path %s", path)
+	}
+
+	for _, path := range []string{name, "Slippi/2023-04/" + name, `C:\Slippi\` + name} {
+		parsed, err := ParseReplayFilename(path, time.UTC)
+		if err != nil || !parsed.Equal(recorded) {
+			t.Errorf("%s: expected %s, got %s (%v)", path, recorded, parsed, err)
+		}
+	}
+
+	for _, path := range []string{"game.slp", "Game_2023.slp", "Game_20230412T192314.zip"} {
+		if _, err := ParseReplayFilename(path, time.UTC); err == nil {
+			t.Errorf("%s: expected an error", path)
+		}
+	}
+
+	paths := []string{"notes.slp", "2023-05/Game_20230501T000000.slp", "Game_20230412T192314.slp", "a.slp", "Game_20221231T235959.slp"}
+	SortReplayPaths(paths)
+	expected := []string{"Game_20221231T235959.slp", "Game_20230412T192314.slp", "2023-05/Game_20230501T000000.slp", "a.slp", "notes.slp"}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("expected %v, got %v", expected, paths)
+	}
+}
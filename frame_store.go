@@ -0,0 +1,157 @@
+package slippi
+
+import "iter"
+
+// FirstFrame is the number of the first frame of every game, 123 frames
+// before players can act.
+const FirstFrame int32 = -123
+
+// FirstPlayableFrame is the number of the first frame of every game on which
+// players can act.
+const FirstPlayableFrame int32 = -39
+
+// frameChunkSize is the number of frames in each chunk of a FrameStore.
+const frameChunkSize = 1024
+
+type frameChunk struct {
+	frames  [frameChunkSize]FrameEntry
+	present [frameChunkSize]bool
+	count   int
+}
+
+// A FrameStore holds the frames of a game indexed by frame number, offset by
+// FirstFrame. Frames are stored in fixed size chunks, so that growing the
+// store never copies the frames already stored, and chunks whose frames have
+// all been deleted are released.
+type FrameStore struct {
+	chunks []*frameChunk
+	count  int
+}
+
+// NewFrameStore returns a new, empty FrameStore.
+func NewFrameStore() *FrameStore {
+	return &FrameStore{
+		chunks: make([]*frameChunk, 0),
+		count:  0,
+	}
+}
+
+// Get returns the frame with the given number, and whether it is stored.
+func (s *FrameStore) Get(frameNumber int32) (FrameEntry, bool) {
+	if frame := s.FrameAt(frameNumber); frame != nil {
+		return *frame, true
+	}
+
+	return FrameEntry{}, false
+}
+
+// FrameAt returns a pointer to the stored frame with the given number, or nil
+// if it isn't stored.
+func (s *FrameStore) FrameAt(frameNumber int32) *FrameEntry {
+	i := int(frameNumber - FirstFrame)
+	if i < 0 || i/frameChunkSize >= len(s.chunks) {
+		return nil
+	}
+
+	chunk := s.chunks[i/frameChunkSize]
+	if chunk == nil || !chunk.present[i%frameChunkSize] {
+		return nil
+	}
+
+	return &chunk.frames[i%frameChunkSize]
+}
+
+// Set stores the frame with the given number, growing the store as needed.
+// Frames numbered before FirstFrame can't be stored and are ignored.
+func (s *FrameStore) Set(frameNumber int32, frame FrameEntry) {
+	i := int(frameNumber - FirstFrame)
+	if i < 0 {
+		return
+	}
+
+	for len(s.chunks) <= i/frameChunkSize {
+		s.chunks = append(s.chunks, &frameChunk{})
+	}
+
+	chunk := s.chunks[i/frameChunkSize]
+	if chunk == nil {
+		chunk = &frameChunk{}
+		s.chunks[i/frameChunkSize] = chunk
+	}
+	if !chunk.present[i%frameChunkSize] {
+		chunk.present[i%frameChunkSize] = true
+		chunk.count++
+		s.count++
+	}
+	chunk.frames[i%frameChunkSize] = frame
+}
+
+// Delete removes the frame with the given number from the store, if it is
+// stored.
+func (s *FrameStore) Delete(frameNumber int32) {
+	i := int(frameNumber - FirstFrame)
+	if s.FrameAt(frameNumber) == nil {
+		return
+	}
+
+	chunk := s.chunks[i/frameChunkSize]
+	chunk.present[i%frameChunkSize] = false
+	chunk.frames[i%frameChunkSize] = FrameEntry{}
+	chunk.count--
+	s.count--
+
+	if chunk.count == 0 {
+		s.chunks[i/frameChunkSize] = nil
+	}
+}
+
+// FrameCount returns the number of frames stored.
+func (s *FrameStore) FrameCount() int {
+	return s.count
+}
+
+// All returns an iterator over the stored frames in increasing frame order.
+func (s *FrameStore) All() iter.Seq2[int32, FrameEntry] {
+	return func(yield func(int32, FrameEntry) bool) {
+		for c, chunk := range s.chunks {
+			if chunk == nil {
+				continue
+			}
+
+			for i := range chunk.frames {
+				frameNumber := int32(c*frameChunkSize+i) + FirstFrame
+				if chunk.present[i] && !yield(frameNumber, chunk.frames[i]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Clone returns a copy of the store, which storing and deleting frames in the
+// store doesn't change. The frames' updates and items are shared with the
+// store.
+func (s *FrameStore) Clone() *FrameStore {
+	clone := &FrameStore{
+		chunks: make([]*frameChunk, len(s.chunks)),
+		count:  s.count,
+	}
+	for i, chunk := range s.chunks {
+		if chunk != nil {
+			copied := *chunk
+			clone.chunks[i] = &copied
+		}
+	}
+
+	return clone
+}
+
+// Map returns the stored frames in a map keyed by frame number.
+func (s *FrameStore) Map() map[int32]FrameEntry {
+	frames := make(map[int32]FrameEntry, s.count)
+	for frameNumber, frame := range s.All() {
+		frames[frameNumber] = frame
+	}
+
+	return frames
+}
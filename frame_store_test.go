@@ -0,0 +1,110 @@
+package slippi
+
+import (
+	"os"
+	"testing"
+)
+
+func TestFrameStore(t *testing.T) {
+	store := NewFrameStore()
+	store.Set(FirstFrame-1, FrameEntry{})
+	store.Set(5, FrameEntry{FrameNumber: 5})
+	store.Set(FirstFrame, FrameEntry{FrameNumber: FirstFrame})
+	store.Set(5, FrameEntry{FrameNumber: 5, IsTransferComplete: true})
+
+	if store.FrameCount() != 2 {
+		t.Errorf("expected 2 frames, got %d", store.FrameCount())
+	}
+
+	if frame, ok := store.Get(5); !ok || !frame.IsTransferComplete {
+		t.Errorf("expected frame 5 to be replaced, got %+v", frame)
+	}
+	if _, ok := store.Get(4); ok {
+		t.Error("expected frame 4 not to be stored")
+	}
+	if store.FrameAt(FirstFrame-1) != nil || store.FrameAt(1000) != nil {
+		t.Error("expected frames outside the store not to be stored")
+	}
+
+	order := make([]int32, 0)
+	for frameNumber, frame := range store.All() {
+		if frame.FrameNumber != frameNumber {
+			t.Errorf("expected frame %d, got %d", frameNumber, frame.FrameNumber)
+		}
+		order = append(order, frameNumber)
+	}
+	if len(order) != 2 || order[0] != FirstFrame || order[1] != 5 {
+		t.Errorf("expected frames in order, got %v", order)
+	}
+
+	store.Delete(5)
+	store.Delete(4)
+	if _, ok := store.Get(5); ok || store.FrameCount() != 1 {
+		t.Errorf("expected frame 5 to be deleted, got %d frames", store.FrameCount())
+	}
+	if store.chunks[0] == nil {
+		t.Error("expected the chunk holding the first frame to be kept")
+	}
+
+	store.Delete(FirstFrame)
+	if store.chunks[0] != nil || store.FrameCount() != 0 {
+		t.Error("expected the empty chunk to be released")
+	}
+	store.Set(5, FrameEntry{FrameNumber: 5})
+	if frame, ok := store.Get(5); !ok || frame.FrameNumber != 5 {
+		t.Errorf("expected frame 5 to be stored again, got %+v", frame)
+	}
+}
+
+func loadFrames(b *testing.B) map[int32]FrameEntry {
+	f, err := os.Open("game.slp")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer f.Close()
+
+	game, err := NewSlpGameFromFile(f, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	frames, err := game.GetFrames()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	return frames
+}
+
+func BenchmarkFrameStore(b *testing.B) {
+	frames := loadFrames(b)
+	numbers := sortedFrameNumbers(frames)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		store := NewFrameStore()
+		for _, frameNumber := range numbers {
+			store.Set(frameNumber, frames[frameNumber])
+		}
+		for range store.All() {
+		}
+	}
+}
+
+func BenchmarkFrameMap(b *testing.B) {
+	frames := loadFrames(b)
+	numbers := sortedFrameNumbers(frames)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		store := make(map[int32]FrameEntry)
+		for _, frameNumber := range numbers {
+			store[frameNumber] = frames[frameNumber]
+		}
+		for _, frameNumber := range sortedFrameNumbers(store) {
+			_ = store[frameNumber]
+		}
+	}
+}
@@ -2,7 +2,11 @@ package slippi
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"errors"
+	"io"
 	"os"
+	"sync"
 )
 
 // SlpCalculator is the interface to represent calculators
@@ -10,14 +14,38 @@ type SlpCalculator interface {
 	getChannels() map[ParserEvent][]chan interface{}
 }
 
-// A SlpGame contains information about a Slippi game.
+// A SlpGame contains information about a Slippi game. Its methods are safe for
+// concurrent use: the game is processed by one of them at a time, and the
+// state it leaves is read before it is processed again. Handlers receiving the
+// game's events while it is processed must not call its methods.
 type SlpGame struct {
+	// mu serializes processing the game and reading the state it leaves, and
+	// infoMu guards gameInfo, which the Started handler sets while the game
+	// is processed
+	mu           sync.Mutex
+	infoMu       sync.Mutex
 	reader       *SlpReader
 	parser       *SlpParser
 	metadata     *Metadata
 	gameInfo     *GameInfo
 	gameInfoChan chan interface{}
+	done         chan struct{}
 	calculators  []SlpCalculator
+
+	localIdentities []string
+
+	// opened is whether the game was opened with OpenSlpGame, fingerprint
+	// is the hash of the replay of opened games, and cached is whether the
+	// parsed state was loaded from a cache sidecar rather than parsed
+	opened      bool
+	fingerprint [sha256.Size]byte
+	cached      bool
+
+	// resume is the offset in the replay processing continues from, or -1
+	// if the replay must be processed from the start, and processed is
+	// whether all of the replay has been processed
+	resume    int64
+	processed bool
 }
 
 // NewSlpGameFromBytes creates a new SlpGame from the provided bytes.
@@ -34,6 +62,13 @@ func NewSlpGameFromFile(f *os.File, calculators []SlpCalculator) (*SlpGame, erro
 	return newSlpGame(src, calculators)
 }
 
+// NewSlpGameFromReader creates a new SlpGame from the provided io.ReadSeeker.
+func NewSlpGameFromReader(r io.ReadSeeker, calculators []SlpCalculator) (*SlpGame, error) {
+	src := NewSlpSourceReader(r)
+
+	return newSlpGame(src, calculators)
+}
+
 func newSlpGame(src *SlpSource, calculators []SlpCalculator) (*SlpGame, error) {
 	reader, err := NewSlpReader(*src)
 	if err != nil {
@@ -44,11 +79,12 @@ func newSlpGame(src *SlpSource, calculators []SlpCalculator) (*SlpGame, error) {
 	parser := NewSlpParser(SlpParserOpts{Strict: false})
 	parser.AddHandler(Started, gameInfoChan)
 
-	// attach calculators
+	// attach calculators, whose deliveries are abandoned when they're
+	// removed so that they can be closed
 	for _, calculator := range calculators {
 		for event, channels := range calculator.getChannels() {
 			for _, channel := range channels {
-				parser.AddHandler(event, channel)
+				parser.subscribe(event, channel, nil)
 			}
 		}
 	}
@@ -59,36 +95,58 @@ func newSlpGame(src *SlpSource, calculators []SlpCalculator) (*SlpGame, error) {
 		metadata:     nil,
 		gameInfo:     nil,
 		gameInfoChan: gameInfoChan,
+		done:         make(chan struct{}),
 		calculators:  calculators,
+		resume:       -1,
 	}
 
 	go func() {
-		for val := range gameInfoChan {
-			gameInfo := val.(*GameInfo)
-			game.gameInfo = gameInfo
+		for {
+			select {
+			case val := <-gameInfoChan:
+				game.infoMu.Lock()
+				game.gameInfo = val.(*GameInfo)
+				game.infoMu.Unlock()
+			case <-game.done:
+				return
+			}
 		}
 	}()
 
 	return game, nil
 }
 
-// Close the SlpGame's underlying channel.
+// Close stops the SlpGame's game info handler. The handler channel itself is
+// left open, since Trigger may still be delivering to it.
 func (g *SlpGame) Close() {
-	close(g.gameInfoChan)
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.parser.RemoveHandler(Started, g.gameInfoChan)
+	close(g.done)
 }
 
-// AddCalculator adds a calculator to the SlpGame.
+// AddCalculator adds a calculator to the SlpGame, which receives the events of
+// the whole replay the next time the game is processed.
 func (g *SlpGame) AddCalculator(c SlpCalculator) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	g.calculators = append(g.calculators, c)
 	for event, handlers := range c.getChannels() {
 		for _, handler := range handlers {
-			g.parser.AddHandler(event, handler)
+			g.parser.subscribe(event, handler, nil)
 		}
 	}
+	g.invalidate()
 }
 
-// RemoveCalculator removes a calculator from the SlpGame.
+// RemoveCalculator removes a calculator from the SlpGame, abandoning the events
+// still being delivered to it.
 func (g *SlpGame) RemoveCalculator(c SlpCalculator) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	for i, calculator := range g.calculators {
 		if calculator == c {
 			g.calculators = append(g.calculators[:i], g.calculators[i+1:]...)
@@ -96,17 +154,20 @@ func (g *SlpGame) RemoveCalculator(c SlpCalculator) {
 	}
 	for event, handlers := range c.getChannels() {
 		for _, handler := range handlers {
-			g.parser.AddHandler(event, handler)
+			g.parser.unsubscribe(event, handler)
 		}
 	}
 }
 
 // RemoveAllCalculators removes all calculators from the SlpGame.
 func (g *SlpGame) RemoveAllCalculators() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	for _, calculator := range g.calculators {
 		for event, handlers := range calculator.getChannels() {
 			for _, handler := range handlers {
-				g.parser.AddHandler(event, handler)
+				g.parser.unsubscribe(event, handler)
 			}
 		}
 	}
@@ -114,63 +175,242 @@ func (g *SlpGame) RemoveAllCalculators() {
 	g.calculators = make([]SlpCalculator, 0)
 }
 
+// TrackPlayers restricts the frame data stored and sent to calculators to the
+// players with the given indices, which takes effect the next time the game is
+// processed. Calling it with no indices tracks all players.
+func (g *SlpGame) TrackPlayers(indices ...uint8) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.parser.Options.TrackedPlayers = append(make([]uint8, 0, len(indices)), indices...)
+	g.invalidate()
+}
+
+// SetDispatchMode sets how parser events are delivered to calculators, which
+// takes effect the next time the game is processed. Calculators that depend
+// on receiving frames in order should use DispatchOrdered.
+func (g *SlpGame) SetDispatchMode(mode DispatchMode) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.parser.Options.Dispatch = mode
+	g.invalidate()
+}
+
+// SetDiscardFrames sets whether the game's frames are discarded once they have
+// been sent to calculators, which takes effect the next time the game is
+// processed. While frames are discarded, the game's frame getters return no
+// frames.
+func (g *SlpGame) SetDiscardFrames(discard bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.parser.Options.DiscardFrames = discard
+	g.invalidate()
+}
+
+// SetMaxRetainedFrames caps the number of finalized frames the game retains
+// to max, evicting the oldest ones, which takes effect the next time the game
+// is processed. A max of 0 retains every frame.
+func (g *SlpGame) SetMaxRetainedFrames(max int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.parser.Options.MaxRetainedFrames = max
+	g.invalidate()
+}
+
+// SetRollbackRetention sets what is kept about rolled back frames, which takes
+// effect the next time the game is processed. Unless every version is
+// retained, GetRollbackFrames returns no frames and GetFrameVersions only
+// returns final versions.
+func (g *SlpGame) SetRollbackRetention(retention RollbackRetention) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.parser.Options.RollbackRetention = retention
+	g.invalidate()
+}
+
+// SetStrictChecks sets the validations that fail processing the game, which
+// takes effect the next time the game is processed.
+func (g *SlpGame) SetStrictChecks(checks StrictOpts) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.parser.Options.StrictChecks = checks
+	g.invalidate()
+}
+
+// SetTrace sets the handler that receives a TraceEntry for every event read
+// while processing the game. Passing nil disables tracing.
+func (g *SlpGame) SetTrace(handler TraceHandler) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.reader.SetTrace(handler)
+	g.invalidate()
+}
+
 // GetGameInfo gets the game info of the SlpGame.
 func (g *SlpGame) GetGameInfo() (*GameInfo, error) {
-	if g.gameInfo != nil {
-		return &*g.gameInfo, nil
-	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.gameInfoLocked()
+}
+
+// gameInfoLocked gets the game info of the SlpGame, processing as much of the
+// game as it takes. The caller must hold g.mu.
+func (g *SlpGame) gameInfoLocked() (*GameInfo, error) {
+	g.infoMu.Lock()
+	gameInfo := g.gameInfo
+	g.infoMu.Unlock()
+
+	if gameInfo == nil {
+		var complete bool
+		gameInfo, complete = g.parser.GetGameInfo()
+		if !complete {
+			err := g.processLocked(true)
+			if err != nil {
+				return nil, err
+			}
 
-	gameInfo, complete := g.parser.GetGameInfo()
-	if complete {
+			// the Started handler sets g.gameInfo asynchronously, so read
+			// the result from the parser directly
+			gameInfo, _ = g.parser.GetGameInfo()
+			if gameInfo == nil {
+				return nil, withCode(CodeMissingGameInfo, errors.New("replay does not contain game info"))
+			}
+		}
+
+		g.infoMu.Lock()
 		g.gameInfo = gameInfo
-		return &*g.gameInfo, nil
+		g.infoMu.Unlock()
 	}
 
-	err := g.process(true)
-	if err != nil {
-		return nil, err
-	}
+	// the game info is shared with the parser and the other callers, so
+	// the local player is set on a copy
+	result := *gameInfo
+	result.LocalPlayerIndex = g.inferLocalPlayerIndex(gameInfo)
 
-	return &*g.gameInfo, nil
+	return &result, nil
 }
 
 // GetLatestFrame gets the latest frame in the SlpGame.
 func (g *SlpGame) GetLatestFrame() (*FrameEntry, error) {
-	err := g.process(false)
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	err := g.processLocked(false)
 	if err != nil {
 		return nil, err
 	}
 
-	return g.parser.GetLatestFrame(), nil
+	frame := cloneFrame(*g.parser.GetLatestFrame())
+	return &frame, nil
 }
 
-// GetGameEnd gets the game end event from the SlpGame.
+// GetGameEnd gets the game end event from the SlpGame. Games that crashed or
+// were force quit have no game end event, in which case an unresolved end
+// without an LRAS initiator is returned, whose last frame is given by
+// GetLastFrameNumber.
 func (g *SlpGame) GetGameEnd() (*GameEndPayload, error) {
-	err := g.process(false)
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	err := g.processLocked(false)
 	if err != nil {
 		return nil, err
 	}
 
-	return &*g.parser.GameEnd, nil
+	if g.parser.GameEnd == nil {
+		return &GameEndPayload{GameEndMethod: Unresolved, LRASInitiator: -1, Placements: [4]int8{-1, -1, -1, -1}}, nil
+	}
+
+	gameEnd := *g.parser.GameEnd
+	return &gameEnd, nil
 }
 
-// GetFrames gets the frames from the SlpGame.
+// IsComplete returns whether the game has a game end event, which games that
+// crashed, were force quit, or are still being played don't.
+func (g *SlpGame) IsComplete() (bool, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	err := g.processLocked(false)
+	if err != nil {
+		return false, err
+	}
+
+	return g.parser.GameEnd != nil, nil
+}
+
+// GetLastFrameNumber gets the number of the last frame of the SlpGame, whether
+// or not it has a game end event.
+func (g *SlpGame) GetLastFrameNumber() (int32, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	err := g.processLocked(false)
+	if err != nil {
+		return 0, err
+	}
+
+	return max(g.parser.latestFrameIndex, FirstFrame), nil
+}
+
+// GetWarnings gets the violations of validations found while processing the
+// game that didn't fail it, since they weren't selected as strict checks.
+func (g *SlpGame) GetWarnings() ([]ParseWarning, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	err := g.processLocked(false)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(make([]ParseWarning, 0), g.parser.Warnings...), nil
+}
+
+// GetFrames gets the frames from the SlpGame, keyed by frame number.
 func (g *SlpGame) GetFrames() (map[int32]FrameEntry, error) {
-	err := g.process(false)
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	err := g.processLocked(false)
 	if err != nil {
 		return nil, err
 	}
 
-	frames := make(map[int32]FrameEntry)
-	for key, frame := range g.parser.Frames {
-		frames[key] = frame
+	return g.parser.snapshotFrames().Map(), nil
+}
+
+// GetFrameStore gets a snapshot of the store of the frames from the SlpGame,
+// which is cheaper than GetFrames for large replays and can be iterated in
+// frame order. Processing the rest of a live game doesn't change the snapshot,
+// but the frames' updates are shared with the game, so it must not be
+// modified.
+func (g *SlpGame) GetFrameStore() (*FrameStore, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	err := g.processLocked(false)
+	if err != nil {
+		return nil, err
 	}
-	return frames, nil
+
+	return g.parser.snapshotFrames(), nil
 }
 
-// GetRollbackFrames gets the rollback frames from the SlpGame.
+// GetRollbackFrames gets the versions of each rolled back frame that were
+// replaced, keyed by frame number.
 func (g *SlpGame) GetRollbackFrames() (map[int32][]FrameEntry, error) {
-	err := g.process(false)
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	err := g.processLocked(false)
 	if err != nil {
 		return nil, err
 	}
@@ -182,8 +422,37 @@ func (g *SlpGame) GetRollbackFrames() (map[int32][]FrameEntry, error) {
 	return rollbackFrames, nil
 }
 
+// GetFrameVersions gets every version of the frame with the given number
+// that was received, in order, ending with the final version. Frames that
+// weren't rolled back have a single version.
+func (g *SlpGame) GetFrameVersions(frameNumber int32) ([]FrameEntry, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	err := g.processLocked(false)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := append(make([]FrameEntry, 0), g.parser.Rollbacks.Frames[frameNumber]...)
+	if frame, ok := g.parser.Frames.Get(frameNumber); ok {
+		versions = append(versions, cloneFrame(frame))
+	}
+
+	return versions, nil
+}
+
 // GetMetadata gets the SlpGame's metadata.
 func (g *SlpGame) GetMetadata() (*Metadata, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.metadataLocked()
+}
+
+// metadataLocked gets the SlpGame's metadata, reading it the first time. The
+// caller must hold g.mu.
+func (g *SlpGame) metadataLocked() (*Metadata, error) {
 	if g.metadata != nil {
 		return &*g.metadata, nil
 	}
@@ -200,22 +469,71 @@ func (g *SlpGame) GetMetadata() (*Metadata, error) {
 	return &*metadata, nil
 }
 
+// process processes the game while holding its lock.
 func (g *SlpGame) process(onlyGameInfo bool) error {
-	g.parser.Reset()
+	g.mu.Lock()
+	defer g.mu.Unlock()
 
+	return g.processLocked(onlyGameInfo)
+}
+
+// processLocked processes the game if it hasn't been processed already, or
+// the rest of a live replay. The caller must hold g.mu.
+func (g *SlpGame) processLocked(onlyGameInfo bool) error {
+	// state loaded from a cache is complete, unless calculators need events
+	// or only some of the state should be retained
+	options := g.parser.Options
+	if g.cached && len(g.calculators) == 0 && len(options.TrackedPlayers) == 0 && !options.DiscardFrames && options.MaxRetainedFrames == 0 && options.RollbackRetention == RetainRollbackFrames {
+		return nil
+	} else if g.cached {
+		g.cached = false
+		g.invalidate()
+	}
+
+	// processing resumes from the state left by the last time, so that data
+	// appended to live replays is parsed without parsing the rest again
+	if g.processed {
+		return nil
+	} else if _, complete := g.parser.GetGameInfo(); onlyGameInfo && complete {
+		return nil
+	}
+
+	abort := g.parser.Options.Abort
 	stopYielding := func(*SlpEvent) bool {
+		if abort != nil && abort() != nil {
+			return true
+		}
+
 		_, complete := g.parser.GetGameInfo()
 		return onlyGameInfo && complete
 	}
 
-	events, err := g.reader.YieldEvents(stopYielding)
+	var events <-chan *SlpEventResult
+	var err error
+	if g.resume < 0 {
+		g.parser.Reset()
+		events, err = g.reader.YieldEvents(stopYielding)
+	} else {
+		events, err = g.reader.YieldEventsFrom(g.resume, stopYielding)
+	}
 	if err != nil {
 		return err
 	}
 
-	err = g.parser.ParseReplay(events)
+	err = g.parser.ParseMore(events)
 	if err != nil {
+		g.invalidate()
 		return err
 	}
+
+	g.resume = g.reader.Offset()
+	g.processed = !onlyGameInfo && !g.reader.IsLive()
 	return nil
 }
+
+// invalidate discards the state left by processing the game, so that the next
+// time it is processed, the replay is processed from the start.
+func (g *SlpGame) invalidate() {
+	g.resume = -1
+	g.processed = false
+}
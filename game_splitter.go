@@ -0,0 +1,117 @@
+package slippi
+
+// A StreamGame is a single game within a continuous stream of Slippi data,
+// such as the data received from a Connection over a whole session.
+type StreamGame struct {
+	// Number is the number of the game in the stream, starting from 1.
+	Number int
+	// Parser is the parser fed the game's events.
+	Parser *SlpParser
+	// Ended is whether the game's GameEnd event was received. It is false
+	// for a game that was abandoned because the stream moved on to the next
+	// game, or was closed, without one. It must only be read after the
+	// channel returned by Done is closed.
+	Ended bool
+	done  chan struct{}
+}
+
+// Done returns a channel that is closed once the game ends or is abandoned.
+func (g *StreamGame) Done() <-chan struct{} {
+	return g.done
+}
+
+// A GameHandler is called with each new game found by a GameSplitter, before
+// any of the game's events are fed to its parser, so that handlers can be
+// attached to the parser.
+type GameHandler func(game *StreamGame)
+
+// A GameSplitter splits a continuous stream of Slippi data into games, giving
+// each game its own SlpParser. A new game begins at each EventPayloads
+// command. If the previous game hasn't ended by then, for example because the
+// players returned to the menu or the connection dropped mid-game, it is
+// abandoned. Like a SlpStream, the stream must begin with an EventPayloads
+// command, since commands can't be split until their sizes are known. A
+// GameSplitter is not safe for concurrent writes.
+type GameSplitter struct {
+	stream  *SlpStream
+	options SlpParserOpts
+	handler GameHandler
+	current *StreamGame
+	count   int
+}
+
+// NewGameSplitter returns a new GameSplitter, which creates the parser of
+// each game with the given options and calls handler with each game.
+func NewGameSplitter(options SlpParserOpts, handler GameHandler) *GameSplitter {
+	s := &GameSplitter{
+		stream:  NewSlpStream(),
+		options: options,
+		handler: handler,
+		current: nil,
+		count:   0,
+	}
+	s.stream.handleCommand = s.handleCommand
+
+	return s
+}
+
+// Write processes the next chunk of raw data from the stream. Commands may be
+// split across chunks. Write returns the first error returned by the parser
+// of the current game, and the rest of the chunk isn't processed. Write
+// implements io.Writer.
+func (s *GameSplitter) Write(data []byte) (int, error) {
+	return s.stream.Write(data)
+}
+
+// Current returns the game in progress, or nil if there is none.
+func (s *GameSplitter) Current() *StreamGame {
+	return s.current
+}
+
+// Close abandons the game in progress, if any.
+func (s *GameSplitter) Close() {
+	s.finish(false)
+	s.stream.Close()
+}
+
+func (s *GameSplitter) handleCommand(command Command, payload []byte) error {
+	if command == EventPayloads {
+		s.finish(false)
+
+		s.count++
+		s.current = &StreamGame{
+			Number: s.count,
+			Parser: NewSlpParser(s.options),
+			done:   make(chan struct{}),
+		}
+		s.stream.SetParser(s.current.Parser)
+		if s.handler != nil {
+			s.handler(s.current)
+		}
+	}
+
+	// commands between games belong to no game
+	if s.current == nil {
+		return nil
+	}
+
+	err := s.stream.feed(command, payload)
+	if command == GameEnd {
+		s.finish(true)
+	}
+
+	return err
+}
+
+// finish ends the game in progress, if any.
+func (s *GameSplitter) finish(ended bool) {
+	if s.current == nil {
+		return
+	}
+
+	s.current.Parser.closeQueues()
+	s.current.Ended = ended
+	close(s.current.done)
+	s.current = nil
+	s.stream.SetParser(nil)
+}
@@ -0,0 +1,68 @@
+package slippi
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestGameSplitter(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reader, err := NewSlpReader(*NewSlpSourceBytes(bytes.NewReader(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw := b[reader.RawStart : reader.RawStart+reader.RawLength]
+
+	games := make([]*StreamGame, 0)
+	splitter := NewGameSplitter(SlpParserOpts{}, func(game *StreamGame) {
+		games = append(games, game)
+	})
+
+	// find the end of the command halfway through the game
+	cut := 0
+	for cut < len(raw)/2 {
+		cut += 1 + int(reader.PayloadSizes[raw[cut]])
+	}
+
+	// a complete game, a game cut off partway through as if the players
+	// returned to the menu, and another complete game
+	data := append([]byte{}, raw...)
+	data = append(data, raw[:cut]...)
+	data = append(data, raw...)
+	for len(data) > 0 {
+		n := min(4096, len(data))
+		if _, err := splitter.Write(data[:n]); err != nil {
+			t.Fatal(err)
+		}
+		data = data[n:]
+	}
+	splitter.Close()
+
+	if len(games) != 3 {
+		t.Fatalf("expected 3 games, got %d", len(games))
+	}
+
+	for i, game := range games {
+		<-game.Done()
+
+		ended := i != 1
+		if game.Number != i+1 || game.Ended != ended {
+			t.Errorf("expected game %d to have ended %v, got number %d ended %v", i+1, ended, game.Number, game.Ended)
+		}
+		if ended && (game.Parser.GameEnd == nil || game.Parser.Frames.FrameCount() != 12343) {
+			t.Errorf("expected game %d to be parsed in full, got %d frames", i+1, game.Parser.Frames.FrameCount())
+		}
+		if !ended && game.Parser.GameEnd != nil {
+			t.Errorf("expected game %d not to end", i+1)
+		}
+	}
+
+	if splitter.Current() != nil {
+		t.Error("expected no game in progress after closing")
+	}
+}
@@ -1,8 +1,12 @@
 package slippi
 
 import (
+	"bytes"
+	"encoding/binary"
 	"fmt"
+	"io"
 	"os"
+	"sync"
 	"testing"
 )
 
@@ -10,6 +14,37 @@ func TestNewSlpGameFromBytes(t *testing.T) {
 
 }
 
+func TestNewSlpGameFromReader(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a section reader is neither a file nor a bytes.Reader
+	src := NewSlpSourceReader(io.NewSectionReader(bytes.NewReader(b), 0, int64(len(b))))
+	src.Seek(100, io.SeekStart)
+	if length, err := src.GetLength(false); err != nil || length != int64(len(b)) {
+		t.Errorf("expected length %d, got %d, %v", len(b), length, err)
+	}
+	if position, _ := src.Seek(0, io.SeekCurrent); position != 100 {
+		t.Errorf("expected getting the length to keep the position, got %d", position)
+	}
+
+	game, err := NewSlpGameFromReader(io.NewSectionReader(bytes.NewReader(b), 0, int64(len(b))), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer game.Close()
+
+	store, err := game.GetFrameStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if store.FrameCount() != 12343 {
+		t.Errorf("expected 12343 frames, got %d", store.FrameCount())
+	}
+}
+
 func TestNewSlpGameFromFile(t *testing.T) {
 	f, err := os.Open("game.slp")
 	if err != nil {
@@ -25,4 +60,378 @@ func TestNewSlpGameFromFile(t *testing.T) {
 	}
 
 	fmt.Println(gameInfo.Stage)
+
+	if gameInfo.Stage != YoshisStory || !gameInfo.Stage.IsLegalStage() {
+		t.Errorf("expected legal stage %s, got %s", YoshisStory, gameInfo.Stage)
+	}
+
+	if mode := gameInfo.GameMode(); mode != OnlineMode {
+		t.Errorf("expected %s, got %s", OnlineMode, mode)
+	}
+
+	if name := gameInfo.Players[0].CostumeName(); gameInfo.Players[0].CharacterID != Fox || name != "Blue" {
+		t.Errorf("expected Blue Fox, got %s %s", name, gameInfo.Players[0].CharacterID)
+	}
+
+	players := gameInfo.PlayersByPort()
+	if fox, falco := players[1], players[2]; len(players) != 2 || fox.CharacterID != Fox || fox.Index != 0 || falco.CharacterID != Falco || falco.Index != 1 {
+		t.Errorf("expected Fox on port 1 and Falco on port 2, got %+v", players)
+	}
+}
+
+func TestLiveReplayResumes(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a live replay has no raw element length or metadata yet, and may end
+	// partway through an event
+	rawLength := int(binary.BigEndian.Uint32(b[11:15]))
+	raw := b[15 : 15+rawLength]
+	split := len(raw)/2 + 3
+	preamble := append(append([]byte{}, b[:11]...), 0, 0, 0, 0)
+
+	path := t.TempDir() + "/live.slp"
+	if err := os.WriteFile(path, append(preamble, raw[:split]...), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	game, err := NewSlpGameFromFile(f, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer game.Close()
+
+	frames, err := game.GetFrames()
+	if err != nil {
+		t.Fatal(err)
+	}
+	partial := len(frames)
+	if partial == 0 || partial >= 12343 || game.parser.GameEnd != nil {
+		t.Fatalf("expected part of the game, got %d frames", partial)
+	}
+
+	store, err := game.GetFrameStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	latest := *store.FrameAt(game.parser.latestFrameIndex)
+	updates := len(latest.Players)
+
+	out, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := out.Write(raw[split:]); err != nil {
+		t.Fatal(err)
+	}
+	out.Close()
+
+	// the frames parsed before are kept, rather than parsed again
+	game.parser.Frames.Delete(0)
+	frames, err = game.GetFrames()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := frames[0]; ok || len(frames) != 12343-1 || game.parser.GameEnd == nil {
+		t.Errorf("expected the rest of the game to be parsed from where it left off, got %d frames", len(frames))
+	}
+
+	// snapshots of the store aren't changed by parsing the rest of the game
+	if store.FrameCount() != partial || len(latest.Players) != updates {
+		t.Errorf("expected the store's snapshot to keep %d frames, got %d", partial, store.FrameCount())
+	}
+}
+
+func TestItemTypes(t *testing.T) {
+	f, err := os.Open("game.slp")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	game, err := NewSlpGameFromFile(f, nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer game.Close()
+
+	frames, err := game.GetFrames()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	for _, frame := range frames {
+		for _, item := range frame.Items {
+			if item.TypeID.Category() == ItemCategoryUnknown {
+				t.Errorf("unknown item type %d on frame %d", uint16(item.TypeID), item.FrameNumber)
+				return
+			}
+		}
+	}
+
+	if !ItemFoxLaser.IsCharacterProjectile() || !ItemFlyGuy.IsStageItem() || !ItemCapsule.IsContainer() {
+		t.Error("unexpected item categories")
+	}
+}
+
+func TestLocalPlayerIndex(t *testing.T) {
+	f, err := os.Open("game.slp")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	game, err := NewSlpGameFromFile(f, nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer game.Close()
+
+	gameInfo, err := game.GetGameInfo()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if gameInfo.LocalPlayerIndex != -1 {
+		t.Errorf("expected unknown local player, got %d", gameInfo.LocalPlayerIndex)
+	}
+
+	game.SetLocalIdentities("crap#761")
+	gameInfo, err = game.GetGameInfo()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if gameInfo.LocalPlayerIndex != 1 {
+		t.Errorf("expected local player 1, got %d", gameInfo.LocalPlayerIndex)
+	}
+
+	// when both players match, the first of them is the local player every
+	// time
+	for i := 0; i < 20; i++ {
+		game.SetLocalIdentities("crap#761", "jugg#230")
+		gameInfo, err = game.GetGameInfo()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if gameInfo.LocalPlayerIndex != 0 {
+			t.Fatalf("expected local player 0, got %d", gameInfo.LocalPlayerIndex)
+		}
+	}
+}
+
+func TestStateFlags(t *testing.T) {
+	f, err := os.Open("game.slp")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	game, err := NewSlpGameFromFile(f, nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer game.Close()
+
+	frames, err := game.GetFrames()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	for frameNumber, frame := range frames {
+		for index, updates := range frame.Players {
+			post := updates.Post
+			flags := post.StateFlags()
+
+			if flags.InHitlag != (post.HitlagFramesRemaining > 0) {
+				t.Errorf("frame %d, player %d: hitlag flag does not match hitlag frames remaining", frameNumber, index)
+				return
+			}
+
+			if flags.InHitstun && !IsDamaged(post.ActionStateID) {
+				t.Errorf("frame %d, player %d: in hitstun outside of a damaged state", frameNumber, index)
+				return
+			}
+
+			if flags.Shielding && !IsShielding(post.ActionStateID) {
+				t.Errorf("frame %d, player %d: shielding outside of a shield state", frameNumber, index)
+				return
+			}
+		}
+	}
+
+	// Falco powershields a hit on frame 9268, while the hit on frame 2025
+	// lands after his powershield on frame 2017 has ended
+	for frameNumber, powershielded := range map[int32]bool{9268: true, 2025: false} {
+		post := frames[frameNumber].Players[1].Post
+		if post.ActionStateID != StateGuardSetOff || post.HitlagFramesRemaining == 0 {
+			t.Errorf("frame %d: expected Falco to be hit on his shield", frameNumber)
+		}
+		if post.StateFlags().PowershieldBubble != powershielded {
+			t.Errorf("frame %d: expected the powershield bubble flag to be %t", frameNumber, powershielded)
+		}
+	}
+}
+
+func TestFrames(t *testing.T) {
+	f, err := os.Open("game.slp")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	game, err := NewSlpGameFromFile(f, nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer game.Close()
+
+	previous := int32(-124)
+	for frameNumber, frame := range game.Frames() {
+		if frameNumber <= previous || frame.FrameNumber != frameNumber {
+			t.Errorf("expected frames in order, got %d after %d", frameNumber, previous)
+			return
+		}
+		previous = frameNumber
+	}
+
+	if previous < 0 {
+		t.Error("expected frames")
+	}
+}
+
+func TestMissingGameEnd(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game, err := NewSlpGameFromBytes(b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer game.Close()
+
+	if complete, err := game.IsComplete(); err != nil || !complete {
+		t.Errorf("expected game to be complete, got %t, %v", complete, err)
+	}
+
+	// a crashed game has no game end event
+	crashed, err := NewSlpGameFromBytes(rewriteEvents(t, b, func(event []byte) []byte {
+		if Command(event[0]) == GameEnd {
+			return nil
+		}
+		return event
+	}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer crashed.Close()
+
+	if complete, err := crashed.IsComplete(); err != nil || complete {
+		t.Errorf("expected crashed game to be incomplete, got %t, %v", complete, err)
+	}
+
+	gameEnd, err := crashed.GetGameEnd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gameEnd.GameEndMethod != Unresolved || gameEnd.LRASInitiator != -1 {
+		t.Errorf("expected unresolved game end, got %+v", gameEnd)
+	}
+
+	lastFrame, err := crashed.GetLastFrameNumber()
+	if err != nil || lastFrame != 12219 {
+		t.Errorf("expected last frame 12219, got %d, %v", lastFrame, err)
+	}
+}
+
+func TestConcurrentGetters(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game, err := NewSlpGameFromBytes(b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer game.Close()
+
+	// the first getter to run processes the game while the others wait
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			if _, err := game.GetGameInfo(); err != nil {
+				t.Error(err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if frames, err := game.GetFrames(); err != nil || len(frames) != 12343 {
+				t.Errorf("expected 12343 frames, got %d, %v", len(frames), err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if frame, err := game.GetLatestFrame(); err != nil || frame.FrameNumber != 12218 {
+				t.Errorf("expected the latest frame to be 12218, got %v, %v", frame, err)
+			}
+		}()
+	}
+
+	// methods that read the replay or change how the game is processed wait
+	// for processing too
+	wg.Add(4)
+	go func() {
+		defer wg.Done()
+		if _, err := game.Fingerprint(); err != nil {
+			t.Error(err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if err := game.ProcessChunks(1000, func([]FrameEntry) error { return nil }); err != nil {
+			t.Error(err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		game.SetLocalIdentities("crap#761")
+		if err := game.TrackLocalPlayer(); err != nil {
+			t.Error(err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		count := 0
+		for range game.Frames() {
+			count++
+		}
+		if count == 0 {
+			t.Error("expected frames")
+		}
+	}()
+	wg.Wait()
 }
@@ -0,0 +1,145 @@
+package slippi
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// GeckoCodeType enumerates the Gecko codetypes found in Slippi's code list.
+type GeckoCodeType uint8
+
+// GeckoCodeTypes
+const (
+	GeckoWrite8       GeckoCodeType = 0x00
+	GeckoWrite16      GeckoCodeType = 0x02
+	GeckoWrite32      GeckoCodeType = 0x04
+	GeckoStringWrite  GeckoCodeType = 0x06
+	GeckoSerialWrite  GeckoCodeType = 0x08
+	GeckoIfEqual32    GeckoCodeType = 0x20
+	GeckoIfNotEqual32 GeckoCodeType = 0x22
+	GeckoIfGreater32  GeckoCodeType = 0x24
+	GeckoIfLess32     GeckoCodeType = 0x26
+	GeckoIfEqual16    GeckoCodeType = 0x28
+	GeckoIfNotEqual16 GeckoCodeType = 0x2A
+	GeckoIfGreater16  GeckoCodeType = 0x2C
+	GeckoIfLess16     GeckoCodeType = 0x2E
+	GeckoExecuteASM   GeckoCodeType = 0xC0
+	GeckoInsertASM    GeckoCodeType = 0xC2
+	GeckoBranch       GeckoCodeType = 0xC6
+	GeckoTerminator   GeckoCodeType = 0xE0
+	GeckoEndIf        GeckoCodeType = 0xE2
+	GeckoEndOfCodes   GeckoCodeType = 0xF0
+)
+
+// IsKnown returns whether the codetype is one of the GeckoCodeTypes, whose
+// layout is known.
+func (t GeckoCodeType) IsKnown() bool {
+	switch t {
+	case GeckoWrite8, GeckoWrite16, GeckoWrite32, GeckoStringWrite, GeckoSerialWrite,
+		GeckoIfEqual32, GeckoIfNotEqual32, GeckoIfGreater32, GeckoIfLess32,
+		GeckoIfEqual16, GeckoIfNotEqual16, GeckoIfGreater16, GeckoIfLess16,
+		GeckoExecuteASM, GeckoInsertASM, GeckoBranch, GeckoTerminator, GeckoEndIf, GeckoEndOfCodes:
+		return true
+	default:
+		return false
+	}
+}
+
+// A GeckoCode is a single code from a Gecko code list.
+type GeckoCode struct {
+	// Type is the codetype of the code. Codes of codetypes that aren't known
+	// are decoded as a single line of 8 bytes, without an address.
+	Type GeckoCodeType
+	// Address is the address of memory the code targets, or 0 for codetypes
+	// without one.
+	Address uint32
+	// Length is the total length of the code in bytes, including its header.
+	Length int
+	// Data is the code's raw bytes, including its header.
+	Data []byte
+}
+
+// A KnownGeckoCode identifies a Gecko code by its type and address. No list
+// of known codes is bundled, since the addresses of Slippi's and UCF's codes
+// change between their releases; build one from the code lists of the
+// releases to verify against.
+type KnownGeckoCode struct {
+	Name    string
+	Type    GeckoCodeType
+	Address uint32
+}
+
+// GeckoCodeList decodes the payload's raw code list into individual codes.
+func (g GeckoListPayload) GeckoCodeList() ([]GeckoCode, error) {
+	return DecodeGeckoCodes(g.GeckoCodes)
+}
+
+// DecodeGeckoCodes decodes a raw Gecko code list into individual codes,
+// stopping at the end of the list or an end-of-codes code. Codes whose
+// codetype isn't known are kept, so that the codes after them still decode.
+func DecodeGeckoCodes(b []byte) ([]GeckoCode, error) {
+	codes := make([]GeckoCode, 0)
+	for position := 0; position+8 <= len(b); {
+		header := binary.BigEndian.Uint32(b[position : position+4])
+		codeType := GeckoCodeType(b[position] & 0xFE)
+
+		length := geckoCodeLength(codeType, b[position:])
+		if position+length > len(b) {
+			return nil, errors.New(fmt.Sprintf("gecko code at offset 0x%X: code of length %d exceeds code list", position, length))
+		}
+
+		code := GeckoCode{
+			Type:   codeType,
+			Length: length,
+			Data:   b[position : position+length],
+		}
+		if codeType.IsKnown() && codeType < GeckoTerminator && codeType != GeckoExecuteASM {
+			// the lowest bit of the codetype byte is the high bit of the
+			// address, selecting between the 0x80 and 0x81 memory regions
+			code.Address = (header & 0x01FFFFFF) | 0x80000000
+		}
+
+		codes = append(codes, code)
+		position += length
+
+		if codeType == GeckoEndOfCodes {
+			break
+		}
+	}
+
+	return codes, nil
+}
+
+func geckoCodeLength(codeType GeckoCodeType, b []byte) int {
+	switch codeType {
+	case GeckoSerialWrite:
+		return 16
+	case GeckoStringWrite:
+		byteCount := int(binary.BigEndian.Uint32(b[4:8]))
+		return 8 + (byteCount+7)/8*8
+	case GeckoExecuteASM, GeckoInsertASM:
+		lineCount := int(binary.BigEndian.Uint32(b[4:8]))
+		return 8 + lineCount*8
+	default:
+		return 8
+	}
+}
+
+// IdentifyGeckoCodes returns the known codes that are present in codes,
+// matched by codetype and address.
+func IdentifyGeckoCodes(codes []GeckoCode, known []KnownGeckoCode) []KnownGeckoCode {
+	present := make(map[KnownGeckoCode]bool)
+	for _, code := range codes {
+		present[KnownGeckoCode{Type: code.Type, Address: code.Address}] = true
+	}
+
+	matches := make([]KnownGeckoCode, 0)
+	for _, k := range known {
+		if present[KnownGeckoCode{Type: k.Type, Address: k.Address}] {
+			matches = append(matches, k)
+		}
+	}
+
+	return matches
+}
@@ -0,0 +1,67 @@
+package slippi
+
+import "testing"
+
+func TestDecodeGeckoCodes(t *testing.T) {
+	list := []byte{
+		// 32-bit write to 0x8045BF28
+		0x04, 0x45, 0xBF, 0x28, 0x00, 0x00, 0x00, 0x01,
+		// asm insert at 0x8016E74C with two lines
+		0xC2, 0x16, 0xE7, 0x4C, 0x00, 0x00, 0x00, 0x02,
+		0x38, 0x60, 0x00, 0x01, 0x60, 0x00, 0x00, 0x00,
+		0x60, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		// string write of 5 bytes to 0x81000000
+		0x07, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x05,
+		0x01, 0x02, 0x03, 0x04, 0x05, 0x00, 0x00, 0x00,
+		// asm execute with one line, which targets no address
+		0xC0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+		0x4E, 0x80, 0x00, 0x20, 0x00, 0x00, 0x00, 0x00,
+		// set base address, a codetype without a known layout
+		0x42, 0x00, 0x00, 0x00, 0x80, 0x00, 0x00, 0x00,
+		// end of codes
+		0xF0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	}
+
+	codes, err := DecodeGeckoCodes(list)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	expected := []GeckoCode{
+		{Type: GeckoWrite32, Address: 0x8045BF28, Length: 8},
+		{Type: GeckoInsertASM, Address: 0x8016E74C, Length: 24},
+		{Type: GeckoStringWrite, Address: 0x81000000, Length: 16},
+		{Type: GeckoExecuteASM, Address: 0, Length: 16},
+		{Type: GeckoCodeType(0x42), Address: 0, Length: 8},
+		{Type: GeckoEndOfCodes, Address: 0, Length: 8},
+	}
+
+	if len(codes) != len(expected) {
+		t.Errorf("expected %d codes, got %d", len(expected), len(codes))
+		return
+	}
+
+	for i, code := range codes {
+		if code.Type != expected[i].Type || code.Address != expected[i].Address || code.Length != expected[i].Length || len(code.Data) != code.Length {
+			t.Errorf("code %d: expected %+v, got %+v", i, expected[i], code)
+		}
+	}
+
+	if codes[4].Type.IsKnown() {
+		t.Errorf("expected codetype 0x%X to be unknown", uint8(codes[4].Type))
+	}
+
+	matches := IdentifyGeckoCodes(codes, []KnownGeckoCode{
+		{Name: "present", Type: GeckoInsertASM, Address: 0x8016E74C},
+		{Name: "absent", Type: GeckoInsertASM, Address: 0x80000000},
+	})
+	if len(matches) != 1 || matches[0].Name != "present" {
+		t.Errorf("expected only the present code to match, got %+v", matches)
+	}
+
+	_, err = DecodeGeckoCodes(list[:20])
+	if err == nil {
+		t.Error("expected error for truncated code list")
+	}
+}
@@ -1,6 +1,6 @@
 module github.com/ZadenRB/go-slippi
 
-go 1.19
+go 1.23
 
 require (
 	github.com/blang/semver/v4 v4.0.0
@@ -0,0 +1,106 @@
+package slippi
+
+import "math"
+
+// GroundSurface enumerates the named surfaces of stages that players can
+// stand on.
+type GroundSurface uint8
+
+// GroundSurfaces
+const (
+	UnknownSurface GroundSurface = iota
+	MainStage
+	LeftEdge
+	RightEdge
+	LeftPlatform
+	RightPlatform
+	TopPlatform
+	Randall
+)
+
+var groundSurfaceNames = map[GroundSurface]string{
+	UnknownSurface: "Unknown",
+	MainStage:      "Main Stage",
+	LeftEdge:       "Left Edge",
+	RightEdge:      "Right Edge",
+	LeftPlatform:   "Left Platform",
+	RightPlatform:  "Right Platform",
+	TopPlatform:    "Top Platform",
+	Randall:        "Randall",
+}
+
+// String returns the human-readable name of the surface.
+func (s GroundSurface) String() string {
+	return groundSurfaceNames[s]
+}
+
+// IsPlatform returns whether the surface is a platform above the main stage.
+func (s GroundSurface) IsPlatform() bool {
+	return s == LeftPlatform || s == RightPlatform || s == TopPlatform
+}
+
+// groundSurfaces maps the ground IDs of stages, as stored in the LastGroundID
+// field of post-frame updates, to their surfaces. Only IDs confirmed against
+// replays are listed.
+var groundSurfaces = map[StageID]map[uint16]GroundSurface{
+	YoshisStory: {
+		0: Randall,
+		1: LeftPlatform,
+		2: LeftEdge,
+		3: MainStage,
+		4: TopPlatform,
+		5: RightPlatform,
+		6: RightEdge,
+	},
+}
+
+// groundTolerance is how far from a surface a grounded character's position
+// can be while still being considered on it.
+const groundTolerance = 1
+
+// GroundSurface returns the surface of the stage with the given ground ID, or
+// UnknownSurface if the ID isn't known for the stage.
+func (s StageID) GroundSurface(groundID uint16) GroundSurface {
+	return groundSurfaces[s][groundID]
+}
+
+// GroundSurface returns the surface of the stage the update's character is
+// standing on, or last stood on if they are airborne. If the stage's ground
+// IDs aren't known, the surface of a grounded character is found from their
+// position on the stage's platforms and main stage instead.
+func (u PostFrameUpdatePayload) GroundSurface(stage StageID) GroundSurface {
+	if surface := stage.GroundSurface(u.LastGroundID); surface != UnknownSurface {
+		return surface
+	}
+
+	if u.Airborne {
+		return UnknownSurface
+	}
+
+	return surfaceAt(stage, u.XPosition, u.YPosition)
+}
+
+// surfaceAt returns the surface of the stage at the given position, from the
+// stage's geometry.
+func surfaceAt(stage StageID, x float32, y float32) GroundSurface {
+	geometry, ok := stage.Geometry()
+	if !ok {
+		return UnknownSurface
+	}
+
+	near := func(a float32, b float32) bool {
+		return math.Abs(float64(a-b)) <= groundTolerance
+	}
+
+	for _, platform := range geometry.Platforms {
+		if near(y, platform.Height) && x >= platform.Left-groundTolerance && x <= platform.Right+groundTolerance {
+			return platform.Surface
+		}
+	}
+
+	if near(y, 0) && math.Abs(float64(x)) <= float64(geometry.LedgeX)+groundTolerance {
+		return MainStage
+	}
+
+	return UnknownSurface
+}
@@ -0,0 +1,75 @@
+package slippi
+
+import (
+	"os"
+	"testing"
+)
+
+func TestGroundSurface(t *testing.T) {
+	f, err := os.Open("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	game, err := NewSlpGameFromFile(f, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	frames, err := game.GetFrames()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the surfaces found from positions agree with the ground IDs
+	checked := 0
+	for _, frame := range frames {
+		for _, updates := range frame.Players {
+			post := updates.Post
+			if post == nil || post.Airborne {
+				continue
+			}
+
+			expected := map[uint16]GroundSurface{1: LeftPlatform, 3: MainStage, 4: TopPlatform, 5: RightPlatform}[post.LastGroundID]
+			if expected == UnknownSurface || (expected == MainStage && post.YPosition != 0) {
+				continue
+			}
+
+			if surface := post.GroundSurface(YoshisStory); surface != expected {
+				t.Fatalf("frame %d: expected %s for ground %d, got %s", frame.FrameNumber, expected, post.LastGroundID, surface)
+			}
+			if surface := surfaceAt(YoshisStory, post.XPosition, post.YPosition); surface != expected {
+				t.Fatalf("frame %d: expected %s for ground %d at (%f, %f), got %s", frame.FrameNumber, expected, post.LastGroundID, post.XPosition, post.YPosition, surface)
+			}
+			checked++
+		}
+	}
+
+	if checked == 0 {
+		t.Error("expected grounded frames to check")
+	}
+}
+
+func TestSurfaceAt(t *testing.T) {
+	cases := []struct {
+		stage    StageID
+		x        float32
+		y        float32
+		expected GroundSurface
+	}{
+		{Battlefield, 0, 54.4, TopPlatform},
+		{Battlefield, -40, 27.2, LeftPlatform},
+		{Battlefield, 40, 0, MainStage},
+		{PokemonStadium, 40, 25, RightPlatform},
+		{FinalDestination, -80, 0, MainStage},
+		{FinalDestination, 0, 30, UnknownSurface},
+		{Brinstar, 0, 0, UnknownSurface},
+	}
+
+	for _, c := range cases {
+		if surface := surfaceAt(c.stage, c.x, c.y); surface != c.expected {
+			t.Errorf("%s (%f, %f): expected %s, got %s", c.stage, c.x, c.y, c.expected, surface)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package slippi
+
+import "math"
+
+// projectileHitRadius is the furthest a character projectile can be from the
+// character it hits, on the frame of the hit or the one before.
+const projectileHitRadius = 20
+
+// A Hit attributes the damage taken by a player on a frame to the player who
+// dealt it.
+type Hit struct {
+	AttackerIndex uint8
+	DefenderIndex uint8
+	// ByFollower is whether the hit was landed by the attacker's follower,
+	// such as Nana for Ice Climbers.
+	ByFollower bool
+	// Item is the projectile that landed the hit, or nil if the attacker hit
+	// the defender directly.
+	Item *ItemUpdatePayload
+}
+
+// IsSelfDamage returns whether the defender dealt the damage to themselves,
+// such as with their own bomb or by charging Roy's neutral-B.
+func (h Hit) IsSelfDamage() bool {
+	return h.AttackerIndex == h.DefenderIndex
+}
+
+// A PercentChange is an increase in the percent of a player from a hit they
+// took, sent by a SlpParser with the PercentChanged event once the frame it
+// happened on is finalized.
+type PercentChange struct {
+	PlayerIndex uint8 `json:"playerIndex"`
+	Frame       int32 `json:"frame"`
+	// PreviousPercent is the percent of the player on the frame before, and
+	// Percent their percent as of the frame.
+	PreviousPercent float32 `json:"previousPercent"`
+	Percent         float32 `json:"percent"`
+	// Hit attributes the damage to the player who dealt it, if Attributed is
+	// set, and AttackID is the attack they last hit with.
+	Hit        Hit      `json:"hit"`
+	Attributed bool     `json:"attributed"`
+	AttackID   AttackID `json:"attackId"`
+}
+
+// Damage returns the damage the player took.
+func (c PercentChange) Damage() float32 {
+	return c.Percent - c.PreviousPercent
+}
+
+// percentChange returns the increase in the percent of the player with the
+// given index, whose post-frame update on the last finalized frame was last,
+// on the finalized frame.
+func (p *SlpParser) percentChange(frame FrameEntry, index uint8, last PostFrameUpdatePayload, post PostFrameUpdatePayload) PercentChange {
+	change := PercentChange{
+		PlayerIndex:     index,
+		Frame:           frame.FrameNumber,
+		PreviousPercent: last.Percent,
+		Percent:         post.Percent,
+	}
+
+	change.Hit, change.Attributed = AttributeHit(frame, p.lastFrame, index)
+	if attacker := frame.Players[change.Hit.AttackerIndex].Post; change.Attributed && attacker != nil {
+		change.AttackID = attacker.LastHittingAttackID
+	}
+
+	return change
+}
+
+// AttributeHit returns who dealt the damage the player with the given index
+// took on frame, given the frame before it, and whether it could be
+// attributed. A character that lands a hit enters hitlag along with the
+// defender, but one whose projectile lands a hit doesn't, so hits are
+// attributed to an attacker in hitlag, then to the owner of the closest
+// character projectile. Damage which none of the opponents dealt is self
+// damage if the defender's own projectile is nearby, or if they took it
+// during one of their character's own moves without being hit or grabbed.
+// Only then is it attributed to the defender's LastHitBy, which lags behind
+// projectile hits.
+func AttributeHit(frame FrameEntry, prev FrameEntry, defender uint8) (Hit, bool) {
+	defenderFrame := frame.Players[defender].Post
+	if defenderFrame == nil {
+		return Hit{}, false
+	}
+
+	distance := func(x float32, y float32) float64 {
+		return math.Hypot(float64(x-defenderFrame.XPosition), float64(y-defenderFrame.YPosition))
+	}
+
+	// attackers in hitlag, closest first
+	var hit *Hit
+	closest := math.MaxFloat64
+	for index := range frame.Players {
+		if index == defender {
+			continue
+		}
+
+		hitter, byFollower := hittingClimber(frame, index)
+		if hitter == nil || !hitter.StateFlags().InHitlag {
+			continue
+		}
+
+		if d := distance(hitter.XPosition, hitter.YPosition); d < closest {
+			hit = &Hit{AttackerIndex: index, DefenderIndex: defender, ByFollower: byFollower}
+			closest = d
+		}
+	}
+	if hit != nil {
+		return *hit, true
+	}
+
+	// projectiles near the defender, which disappear as soon as they hit
+	closestProjectile := func(own bool) *Hit {
+		var hit *Hit
+		closest := float64(projectileHitRadius)
+		for _, items := range [][]ItemUpdatePayload{frame.Items, prev.Items} {
+			for i := range items {
+				item := &items[i]
+				if !item.TypeID.IsCharacterProjectile() || item.Owner < 0 || (uint8(item.Owner) == defender) != own {
+					continue
+				}
+				if _, ok := frame.Players[uint8(item.Owner)]; !ok {
+					continue
+				}
+
+				if d := distance(item.XPosition, item.YPosition); d <= closest {
+					hit = &Hit{AttackerIndex: uint8(item.Owner), DefenderIndex: defender, Item: item}
+					closest = d
+				}
+			}
+		}
+
+		return hit
+	}
+	if hit := closestProjectile(false); hit != nil {
+		return *hit, true
+	}
+
+	// self damage
+	if hit := closestProjectile(true); hit != nil {
+		return *hit, true
+	}
+	state := defenderFrame.ActionStateID
+	if IsCharacterSpecific(state) && !IsCommandGrabbed(state) && !defenderFrame.StateFlags().InHitlag {
+		return Hit{AttackerIndex: defender, DefenderIndex: defender}, true
+	}
+
+	if _, ok := frame.Players[defenderFrame.LastHitBy]; ok && defenderFrame.LastHitBy != defender {
+		return Hit{AttackerIndex: defenderFrame.LastHitBy, DefenderIndex: defender}, true
+	}
+
+	return Hit{}, false
+}
@@ -0,0 +1,110 @@
+package slippi
+
+import (
+	"os"
+	"testing"
+)
+
+func TestAttributeHit(t *testing.T) {
+	f, err := os.Open("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	game, err := NewSlpGameFromFile(f, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	frames, err := game.GetFrames()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	projectileHits := make(map[ItemType]int)
+	frameNumbers := sortedFrameNumbers(frames)
+	for i := 1; i < len(frameNumbers); i++ {
+		frame, prev := frames[frameNumbers[i]], frames[frameNumbers[i-1]]
+		for defender, updates := range frame.Players {
+			prevUpdates, ok := prev.Players[defender]
+			if updates.Post == nil || !ok || prevUpdates.Post == nil || updates.Post.Percent <= prevUpdates.Post.Percent {
+				continue
+			}
+
+			hit, ok := AttributeHit(frame, prev, defender)
+			if !ok {
+				continue
+			}
+			if hit.AttackerIndex != 1-defender {
+				t.Errorf("frame %d: expected damage to player %d to be dealt by their opponent, got %+v", frame.FrameNumber, defender, hit)
+			}
+			if hit.Item != nil {
+				projectileHits[hit.Item.TypeID]++
+			}
+		}
+	}
+
+	if projectileHits[ItemFoxLaser] == 0 || projectileHits[ItemFalcoLaser] == 0 {
+		t.Errorf("expected laser hits from both players, got %v", projectileHits)
+	}
+
+	// LastHitBy hasn't caught up with this laser hit yet
+	frame := frames[1455]
+	if post := frame.Players[1].Post; post.LastHitBy == 0 {
+		t.Fatalf("expected LastHitBy to lag, got %d", post.LastHitBy)
+	}
+	if hit, ok := AttributeHit(frame, frames[1454], 1); !ok || hit.AttackerIndex != 0 || hit.Item == nil || hit.Item.TypeID != ItemFoxLaser {
+		t.Errorf("expected Fox's laser to have hit, got %+v", hit)
+	}
+}
+
+func TestPercentChanges(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game, err := NewSlpGameFromBytes(b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer game.Close()
+
+	frames, err := game.GetFrames()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type damage struct {
+		frame    int32
+		player   uint8
+		amount   float32
+		attacker uint8
+	}
+	expected := make([]damage, 0)
+	walkDamage(frames, func(frame FrameEntry, index uint8, post *PostFrameUpdatePayload, amount float32, hit Hit, attributed bool) {
+		expected = append(expected, damage{frame.FrameNumber, index, amount, hit.AttackerIndex})
+	}, func(FrameEntry, FrameEntry, uint8, *PostFrameUpdatePayload, bool) {})
+
+	changes := collectEvents(t, b, PercentChanged)
+	if len(changes) != len(expected) || len(changes) == 0 {
+		t.Fatalf("expected %d percent changes, got %d", len(expected), len(changes))
+	}
+
+	// walkDamage visits the players of each frame in any order
+	received := make(map[damage]bool)
+	for _, payload := range changes {
+		change := payload.(PercentChange)
+		received[damage{change.Frame, change.PlayerIndex, change.Damage(), change.Hit.AttackerIndex}] = true
+
+		if change.Attributed && !change.Hit.IsSelfDamage() && change.AttackID == 0 {
+			t.Errorf("expected attack ID for hit on frame %d", change.Frame)
+		}
+	}
+	for _, d := range expected {
+		if !received[d] {
+			t.Errorf("expected percent change %+v", d)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package slippi
+
+import "errors"
+
+// A ClimberFrame pairs the updates of an Ice Climbers player's leader, Popo,
+// with those of their follower, Nana, on a single frame.
+type ClimberFrame struct {
+	FrameNumber int32
+	PlayerIndex uint8
+	Popo        FrameUpdates
+	Nana        FrameUpdates
+}
+
+// ClimberFrame returns the paired Popo and Nana updates of the player with
+// the given index, and whether the player has a follower on the frame. Nana
+// has no updates once she has died, so a player without a follower may still
+// be Ice Climbers.
+func (f FrameEntry) ClimberFrame(playerIndex uint8) (ClimberFrame, bool) {
+	nana, ok := f.Followers[playerIndex]
+	if !ok {
+		return ClimberFrame{}, false
+	}
+
+	return ClimberFrame{
+		FrameNumber: f.FrameNumber,
+		PlayerIndex: playerIndex,
+		Popo:        f.Players[playerIndex],
+		Nana:        nana,
+	}, true
+}
+
+// NanaAlive returns whether Nana is alive on the frame.
+func (c ClimberFrame) NanaAlive() bool {
+	nana := c.Nana.Post
+	return nana != nil && !IsDead(nana.ActionStateID) && !nana.StateFlags().Dead
+}
+
+// IsDesynced returns whether Nana is alive and in a different action state
+// than Popo on the frame. Nana copies Popo's inputs a frame late, so the
+// climbers briefly differ when Popo changes action state, but longer
+// differences mean they have desynced.
+func (c ClimberFrame) IsDesynced() bool {
+	if !c.NanaAlive() || c.Popo.Post == nil {
+		return false
+	}
+
+	return c.Popo.Post.ActionStateID != c.Nana.Post.ActionStateID
+}
+
+// A ClimberDesync is a range of frames during which an Ice Climbers player's
+// climbers were desynced.
+type ClimberDesync struct {
+	PlayerIndex uint8
+	StartFrame  int32
+	EndFrame    int32
+}
+
+// Length returns the number of frames the desync lasted.
+func (d ClimberDesync) Length() int32 {
+	return d.EndFrame - d.StartFrame + 1
+}
+
+// FindClimberDesyncs returns the ranges of frames, in order, during which the
+// climbers of the player with the given index were desynced for at least
+// minFrames consecutive frames.
+func FindClimberDesyncs(frames map[int32]FrameEntry, playerIndex uint8, minFrames int32) []ClimberDesync {
+	desyncs := make([]ClimberDesync, 0)
+
+	var current *ClimberDesync
+	end := func() {
+		if current != nil && current.Length() >= minFrames {
+			desyncs = append(desyncs, *current)
+		}
+		current = nil
+	}
+
+	for _, frameNumber := range sortedFrameNumbers(frames) {
+		climbers, ok := frames[frameNumber].ClimberFrame(playerIndex)
+		if !ok || !climbers.IsDesynced() {
+			end()
+			continue
+		}
+
+		if current == nil {
+			current = &ClimberDesync{PlayerIndex: playerIndex, StartFrame: frameNumber}
+		}
+		current.EndFrame = frameNumber
+	}
+	end()
+
+	return desyncs
+}
+
+// ClimberDesyncs returns the desyncs of the Ice Climbers player with the
+// given index that lasted at least minFrames frames.
+func (g *SlpGame) ClimberDesyncs(playerIndex uint8, minFrames int32) ([]ClimberDesync, error) {
+	gameInfo, err := g.GetGameInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	isClimbers := false
+	for _, player := range gameInfo.Players {
+		if player.Index == playerIndex && player.CharacterID == IceClimbers {
+			isClimbers = true
+		}
+	}
+	if !isClimbers {
+		return nil, errors.New("player is not playing Ice Climbers")
+	}
+
+	frames, err := g.GetFrames()
+	if err != nil {
+		return nil, err
+	}
+
+	return FindClimberDesyncs(frames, playerIndex, minFrames), nil
+}
+
+// hittingClimber returns the update of the climber of the player with the
+// given index who landed a hit on the frame. Only the climber who connected
+// enters hitlag, so Nana landed the hit if she is in hitlag and Popo isn't.
+// Players other than Ice Climbers always return their own update.
+func hittingClimber(frame FrameEntry, playerIndex uint8) (*PostFrameUpdatePayload, bool) {
+	popo := frame.Players[playerIndex].Post
+	nana := frame.Followers[playerIndex].Post
+	if nana == nil || popo == nil || popo.StateFlags().InHitlag || !nana.StateFlags().InHitlag {
+		return popo, false
+	}
+
+	return nana, true
+}
@@ -0,0 +1,83 @@
+package slippi
+
+import "testing"
+
+func climberPost(actionStateID uint16, attack AttackID, inHitlag bool) *PostFrameUpdatePayload {
+	post := &PostFrameUpdatePayload{LastHittingAttackID: attack, StocksRemaining: 4}
+	post.ActionStateID = actionStateID
+	if inHitlag {
+		post.StateBitFlags2 = 0x20
+	}
+	return post
+}
+
+func TestFindClimberDesyncs(t *testing.T) {
+	frames := make(map[int32]FrameEntry)
+	for i := int32(0); i < 20; i++ {
+		nanaState := uint16(StateWait)
+		// a one frame delay, then a desync lasting frames 10 to 14
+		if i == 3 || (i >= 10 && i < 15) {
+			nanaState = StateRun
+		}
+
+		frames[i] = FrameEntry{
+			FrameNumber: i,
+			Players:     map[uint8]FrameUpdates{0: {Post: climberPost(StateWait, NoAttack, false)}},
+			Followers:   map[uint8]FrameUpdates{0: {Post: climberPost(nanaState, NoAttack, false)}},
+		}
+	}
+
+	desyncs := FindClimberDesyncs(frames, 0, 2)
+	if len(desyncs) != 1 || desyncs[0].StartFrame != 10 || desyncs[0].Length() != 5 {
+		t.Errorf("expected a single desync on frames 10 to 14, got %+v", desyncs)
+	}
+
+	// Nana can't be desynced once she's dead
+	frames[12].Followers[0].Post.ActionStateID = StateDyingStart
+	if desyncs := FindClimberDesyncs(frames, 0, 1); len(desyncs) != 3 {
+		t.Errorf("expected dead Nana to split the desync, got %+v", desyncs)
+	}
+}
+
+func TestConversionMoveByFollower(t *testing.T) {
+	conversions := make([]Conversion, 0)
+	tracker := NewConversionTracker(func(c Conversion) {
+		conversions = append(conversions, c)
+	})
+
+	hits := []struct {
+		popoHitlag bool
+		nanaHitlag bool
+	}{{false, false}, {false, true}, {true, false}}
+	for i, hit := range hits {
+		defender := climberPost(StateWait, NoAttack, false)
+		if i > 0 {
+			defender.ActionStateID = StateDamageStart
+			defender.Percent = float32(10 * i)
+		}
+
+		tracker.ProcessFrame(FrameEntry{
+			FrameNumber: int32(i),
+			Players: map[uint8]FrameUpdates{
+				0: {Post: climberPost(StateGroundAttackStart+uint16(i), ForwardSmash, hit.popoHitlag)},
+				1: {Post: defender},
+			},
+			Followers: map[uint8]FrameUpdates{
+				0: {Post: climberPost(StateGroundAttackStart+uint16(i), UpSmash, hit.nanaHitlag)},
+			},
+		})
+	}
+	tracker.Flush(int32(len(hits)))
+
+	if len(conversions) != 1 || len(conversions[0].Moves) != 2 {
+		t.Fatalf("expected one conversion with two moves, got %+v", conversions)
+	}
+
+	nanaMove, popoMove := conversions[0].Moves[0], conversions[0].Moves[1]
+	if !nanaMove.ByFollower || nanaMove.MoveID != UpSmash {
+		t.Errorf("expected Nana's up smash, got %+v", nanaMove)
+	}
+	if popoMove.ByFollower || popoMove.MoveID != ForwardSmash {
+		t.Errorf("expected Popo's forward smash, got %+v", popoMove)
+	}
+}
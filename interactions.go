@@ -0,0 +1,140 @@
+package slippi
+
+// An Interaction is a completed conversion together with the frames
+// surrounding it, from the tracker's window before the conversion started to
+// the window after it ended.
+type Interaction struct {
+	Conversion
+	Frames []FrameEntry
+}
+
+// InteractionHandler is called with each completed interaction.
+type InteractionHandler func(Interaction)
+
+// An InteractionTracker detects interactions between players as frames are
+// processed and delivers each one to its handler once the frames after it are
+// available. It can be fed frames live, as they are finalized, or post-game.
+type InteractionTracker struct {
+	window     int32
+	handler    InteractionHandler
+	tracker    *ConversionTracker
+	history    []FrameEntry
+	pending    []Conversion
+	lastFrame  int32
+	seenFrames bool
+}
+
+// NewInteractionTracker returns a tracker that delivers interactions to
+// handler with window frames of context on either side.
+func NewInteractionTracker(window int32, handler InteractionHandler) *InteractionTracker {
+	if window < 0 {
+		window = 0
+	}
+
+	t := &InteractionTracker{
+		window:  window,
+		handler: handler,
+		history: make([]FrameEntry, 0),
+		pending: make([]Conversion, 0),
+	}
+	t.tracker = NewConversionTracker(func(c Conversion) {
+		t.pending = append(t.pending, c)
+	})
+
+	return t
+}
+
+// ProcessFrame processes a finalized frame. Frames must be processed in
+// increasing frame order.
+func (t *InteractionTracker) ProcessFrame(frame FrameEntry) {
+	t.history = append(t.history, frame)
+	t.lastFrame = frame.FrameNumber
+	t.seenFrames = true
+
+	t.tracker.ProcessFrame(frame)
+	t.deliver(false)
+	t.prune()
+}
+
+// Flush delivers all remaining interactions, including conversions still in
+// progress, using whatever frames after them are available. It should be
+// called once the game ends.
+func (t *InteractionTracker) Flush() {
+	if !t.seenFrames {
+		return
+	}
+
+	t.tracker.Flush(t.lastFrame)
+	t.deliver(true)
+	t.history = t.history[:0]
+}
+
+// deliver sends pending interactions whose post-conversion window is
+// complete, or all of them if force is set.
+func (t *InteractionTracker) deliver(force bool) {
+	remaining := t.pending[:0]
+	for _, c := range t.pending {
+		if !force && t.lastFrame < c.EndFrame+t.window {
+			remaining = append(remaining, c)
+			continue
+		}
+
+		t.handler(Interaction{
+			Conversion: c,
+			Frames:     t.framesBetween(c.StartFrame-t.window, c.EndFrame+t.window),
+		})
+	}
+	t.pending = remaining
+}
+
+// framesBetween returns a copy of the frames in history within [start, end].
+func (t *InteractionTracker) framesBetween(start int32, end int32) []FrameEntry {
+	frames := make([]FrameEntry, 0)
+	for _, frame := range t.history {
+		if frame.FrameNumber >= start && frame.FrameNumber <= end {
+			frames = append(frames, frame)
+		}
+	}
+
+	return frames
+}
+
+// prune drops frames that can no longer fall within the window of any
+// pending or in-progress interaction.
+func (t *InteractionTracker) prune() {
+	keepFrom := t.lastFrame - t.window
+	if start, ok := t.tracker.openStartFrame(); ok && start-t.window < keepFrom {
+		keepFrom = start - t.window
+	}
+	for _, c := range t.pending {
+		if c.StartFrame-t.window < keepFrom {
+			keepFrom = c.StartFrame - t.window
+		}
+	}
+
+	drop := 0
+	for drop < len(t.history) && t.history[drop].FrameNumber < keepFrom {
+		drop++
+	}
+	if drop > 0 {
+		t.history = append(t.history[:0], t.history[drop:]...)
+	}
+}
+
+// ForEachInteraction processes the game and calls handler with each
+// interaction, in the order they complete, with window frames of context on
+// either side.
+func (g *SlpGame) ForEachInteraction(window int32, handler InteractionHandler) error {
+	store, err := g.GetFrameStore()
+	if err != nil {
+		return err
+	}
+
+	tracker := NewInteractionTracker(window, handler)
+	for _, frame := range store.All() {
+		tracker.ProcessFrame(frame)
+	}
+	tracker.Flush()
+
+	return nil
+}
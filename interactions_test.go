@@ -0,0 +1,66 @@
+package slippi
+
+import (
+	"os"
+	"testing"
+)
+
+func TestForEachInteraction(t *testing.T) {
+	f, err := os.Open("game.slp")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	game, err := NewSlpGameFromFile(f, nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer game.Close()
+
+	const window = 30
+	interactions := make([]Interaction, 0)
+	err = game.ForEachInteraction(window, func(i Interaction) {
+		interactions = append(interactions, i)
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(interactions) == 0 {
+		t.Error("expected interactions, got none")
+		return
+	}
+
+	kills := 0
+	for _, i := range interactions {
+		if i.DidKill {
+			kills++
+		}
+
+		if i.AttackerIndex == i.DefenderIndex || i.EndFrame < i.StartFrame {
+			t.Errorf("invalid conversion %+v", i.Conversion)
+		}
+
+		if len(i.Frames) == 0 {
+			t.Errorf("expected frames for conversion starting at %d", i.StartFrame)
+			continue
+		}
+
+		for j, frame := range i.Frames {
+			if frame.FrameNumber < i.StartFrame-window || frame.FrameNumber > i.EndFrame+window {
+				t.Errorf("frame %d outside window of conversion %d-%d", frame.FrameNumber, i.StartFrame, i.EndFrame)
+			}
+
+			if j > 0 && frame.FrameNumber <= i.Frames[j-1].FrameNumber {
+				t.Errorf("frames out of order for conversion starting at %d", i.StartFrame)
+			}
+		}
+	}
+
+	if kills == 0 {
+		t.Error("expected at least one killing conversion")
+	}
+}
@@ -0,0 +1,186 @@
+package slippi
+
+import (
+	"math"
+	"slices"
+)
+
+// An ItemPosition is the position of an item on a frame.
+type ItemPosition struct {
+	Frame int32   `json:"frame"`
+	X     float32 `json:"x"`
+	Y     float32 `json:"y"`
+}
+
+// An ItemOwnerChange is a change in the player who owns an item, such as when
+// an item is caught or a turnip is picked up.
+type ItemOwnerChange struct {
+	Frame int32 `json:"frame"`
+	// Owner is the index of the player who owns the item as of the frame, or
+	// -1 if no one does.
+	Owner int8 `json:"owner"`
+}
+
+// An ItemLifecycle is the lifetime of a single item, from the frame it spawned
+// to the frame it despawned, as identified by its spawn ID.
+type ItemLifecycle struct {
+	SpawnID uint32   `json:"spawnId"`
+	TypeID  ItemType `json:"typeId"`
+	// SpawnFrame is the first frame the item was present on, and
+	// DespawnFrame the first frame after it that it was absent on, if
+	// Despawned is set. Items that are still present on the last frame
+	// haven't despawned.
+	SpawnFrame   int32 `json:"spawnFrame"`
+	DespawnFrame int32 `json:"despawnFrame"`
+	Despawned    bool  `json:"despawned"`
+	// Trajectory is the position of the item on each frame it was present
+	// on, in order.
+	Trajectory []ItemPosition `json:"trajectory"`
+	// Owners are the owners of the item, starting with the owner it spawned
+	// with, followed by each change.
+	Owners []ItemOwnerChange `json:"owners"`
+}
+
+// itemTracker groups the item updates of consecutive frames into the
+// lifecycles of each item.
+type itemTracker struct {
+	items  []*ItemLifecycle
+	active map[uint32]*ItemLifecycle
+}
+
+func newItemTracker() *itemTracker {
+	return &itemTracker{
+		items:  make([]*ItemLifecycle, 0),
+		active: make(map[uint32]*ItemLifecycle),
+	}
+}
+
+// processFrame adds the item updates of the frame following the last one
+// processed.
+func (t *itemTracker) processFrame(frame FrameEntry) {
+	present := make(map[uint32]bool, len(frame.Items))
+	for _, item := range frame.Items {
+		present[item.SpawnID] = true
+
+		lifecycle, ok := t.active[item.SpawnID]
+		if !ok {
+			lifecycle = &ItemLifecycle{
+				SpawnID:    item.SpawnID,
+				TypeID:     item.TypeID,
+				SpawnFrame: frame.FrameNumber,
+				Trajectory: make([]ItemPosition, 0),
+				Owners:     []ItemOwnerChange{{Frame: frame.FrameNumber, Owner: item.Owner}},
+			}
+			t.items = append(t.items, lifecycle)
+			t.active[item.SpawnID] = lifecycle
+		}
+
+		lifecycle.Trajectory = append(lifecycle.Trajectory, ItemPosition{
+			Frame: frame.FrameNumber,
+			X:     item.XPosition,
+			Y:     item.YPosition,
+		})
+		if owner := lifecycle.Owners[len(lifecycle.Owners)-1].Owner; owner != item.Owner {
+			lifecycle.Owners = append(lifecycle.Owners, ItemOwnerChange{Frame: frame.FrameNumber, Owner: item.Owner})
+		}
+	}
+
+	for spawnID, lifecycle := range t.active {
+		if !present[spawnID] {
+			lifecycle.DespawnFrame = frame.FrameNumber
+			lifecycle.Despawned = true
+			delete(t.active, spawnID)
+		}
+	}
+}
+
+// inferOwners fills in the owners of the character projectiles of the frame
+// following the last one processed, for replays that predate owners. Each
+// projectile is taken to be owned by the closest of the players whose
+// character creates it, or of all players if none of them do, as of the
+// frame it spawned on, and to keep that owner until it despawns. The frame
+// shares its item updates with the stored frame, which is updated as well.
+func (t *itemTracker) inferOwners(gameInfo *GameInfo, frame FrameEntry) {
+	for i := range frame.Items {
+		item := &frame.Items[i]
+		if item.Owner >= 0 || !item.TypeID.IsCharacterProjectile() {
+			continue
+		}
+
+		if lifecycle, ok := t.active[item.SpawnID]; ok {
+			item.Owner = lifecycle.Owners[len(lifecycle.Owners)-1].Owner
+		} else {
+			item.Owner = closestCreator(gameInfo, frame, *item)
+		}
+	}
+}
+
+// closestCreator returns the index of the player closest to item on frame of
+// those whose character creates it, or of all players if none of them do, or
+// -1 if there are no players on the frame.
+func closestCreator(gameInfo *GameInfo, frame FrameEntry, item ItemUpdatePayload) int8 {
+	creators := make(map[uint8]bool)
+	for _, player := range gameInfo.Players {
+		if slices.Contains(projectileCharacters[item.TypeID], player.CharacterID) {
+			creators[player.Index] = true
+		}
+	}
+
+	var owner int8 = -1
+	closest := math.MaxFloat64
+	for _, candidates := range []map[uint8]bool{creators, nil} {
+		for index, updates := range frame.Players {
+			if updates.Post == nil || candidates != nil && !candidates[index] {
+				continue
+			}
+
+			if d := math.Hypot(float64(item.XPosition-updates.Post.XPosition), float64(item.YPosition-updates.Post.YPosition)); d < closest {
+				owner = int8(index)
+				closest = d
+			}
+		}
+
+		if owner >= 0 {
+			break
+		}
+	}
+
+	return owner
+}
+
+// lifecycles returns the lifecycles of the items processed, in the order they
+// spawned.
+func (t *itemTracker) lifecycles() []ItemLifecycle {
+	lifecycles := make([]ItemLifecycle, 0, len(t.items))
+	for _, lifecycle := range t.items {
+		lifecycles = append(lifecycles, *lifecycle)
+	}
+
+	return lifecycles
+}
+
+// Items returns the lifecycles of the items in the frames finalized so far, in
+// the order they spawned.
+func (p *SlpParser) Items() []ItemLifecycle {
+	return p.items.lifecycles()
+}
+
+// Items returns the lifecycles of the items in the game, in the order they
+// spawned.
+func (g *SlpGame) Items() ([]ItemLifecycle, error) {
+	store, err := g.GetFrameStore()
+	if err != nil {
+		return nil, err
+	}
+
+	// state loaded from a cache has the frames, but wasn't parsed
+	if g.cached {
+		tracker := newItemTracker()
+		for _, frame := range store.All() {
+			tracker.processFrame(frame)
+		}
+		return tracker.lifecycles(), nil
+	}
+
+	return g.parser.Items(), nil
+}
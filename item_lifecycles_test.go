@@ -0,0 +1,142 @@
+package slippi
+
+import (
+	"encoding/binary"
+	"os"
+	"testing"
+)
+
+func TestItemLifecycles(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game, err := NewSlpGameFromBytes(b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer game.Close()
+
+	frames, err := game.GetFrames()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updates := 0
+	for _, frame := range frames {
+		updates += len(frame.Items)
+	}
+
+	items, err := game.Items()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) == 0 {
+		t.Fatal("expected items")
+	}
+
+	positions := 0
+	for i, item := range items {
+		positions += len(item.Trajectory)
+
+		if i > 0 && item.SpawnFrame < items[i-1].SpawnFrame {
+			t.Errorf("expected items in spawn order, got %d after %d", item.SpawnFrame, items[i-1].SpawnFrame)
+		}
+		if item.Trajectory[0].Frame != item.SpawnFrame {
+			t.Errorf("expected item %d to start its trajectory on frame %d, got %d", item.SpawnID, item.SpawnFrame, item.Trajectory[0].Frame)
+		}
+
+		last := item.Trajectory[len(item.Trajectory)-1].Frame
+		if item.Despawned && item.DespawnFrame != last+1 {
+			t.Errorf("expected item %d to despawn on frame %d, got %d", item.SpawnID, last+1, item.DespawnFrame)
+		}
+
+		for j, position := range item.Trajectory {
+			frameItem, ok := findItem(frames[position.Frame], item.SpawnID)
+			if !ok || frameItem.XPosition != position.X || frameItem.YPosition != position.Y || position.Frame != item.SpawnFrame+int32(j) {
+				t.Fatalf("expected item %d to be at %+v on frame %d", item.SpawnID, position, position.Frame)
+			}
+		}
+
+		if owner := item.Owners[0].Owner; item.TypeID.IsCharacterProjectile() && owner != 0 && owner != 1 {
+			t.Errorf("expected item %d to be owned by a player, got %d", item.SpawnID, owner)
+		}
+	}
+
+	if positions != updates {
+		t.Errorf("expected %d item positions, got %d", updates, positions)
+	}
+}
+
+func TestInferredItemOwners(t *testing.T) {
+	replay, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// rewrite the game as a 3.5.0 replay, whose item updates end before the
+	// owner
+	old := rewriteEvents(t, replay, func(event []byte) []byte {
+		switch Command(event[0]) {
+		case EventPayloads:
+			for position := 2; position < len(event); position += 3 {
+				if Command(event[position]) == ItemUpdate {
+					binary.BigEndian.PutUint16(event[position+1:position+3], 0x29)
+				}
+			}
+		case GameStart:
+			event[1], event[2], event[3] = 3, 5, 0
+		case ItemUpdate:
+			event = event[:0x2A]
+		}
+		return event
+	})
+
+	items := make([][]ItemLifecycle, 0, 2)
+	breakdowns := make([]map[uint8]*DamageBreakdown, 0, 2)
+	for _, b := range [][]byte{replay, old} {
+		game, err := NewSlpGameFromBytes(b, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		lifecycles, err := game.Items()
+		if err != nil {
+			t.Fatal(err)
+		}
+		breakdown, err := game.DamageBreakdowns()
+		if err != nil {
+			t.Fatal(err)
+		}
+		game.Close()
+
+		items = append(items, lifecycles)
+		breakdowns = append(breakdowns, breakdown)
+	}
+
+	if len(items[1]) != len(items[0]) {
+		t.Fatalf("expected %d items, got %d", len(items[0]), len(items[1]))
+	}
+	for i, item := range items[1] {
+		if owner := items[0][i].Owners[0].Owner; item.Owners[0].Owner != owner {
+			t.Errorf("expected item %d (%s) to be owned by %d, got %d", item.SpawnID, item.TypeID, owner, item.Owners[0].Owner)
+		}
+	}
+
+	for index, b := range breakdowns[0] {
+		if inferred := breakdowns[1][index]; inferred.FromProjectiles != b.FromProjectiles || inferred.Unattributed != b.Unattributed {
+			t.Errorf("expected player %d to take the damage %+v with inferred owners, got %+v", index, b, inferred)
+		}
+	}
+}
+
+func findItem(frame FrameEntry, spawnID uint32) (ItemUpdatePayload, bool) {
+	for _, item := range frame.Items {
+		if item.SpawnID == spawnID {
+			return item, true
+		}
+	}
+
+	return ItemUpdatePayload{}, false
+}
@@ -0,0 +1,355 @@
+package slippi
+
+import "fmt"
+
+// ItemType enumerates the item types in Melee, as stored in the TypeID field
+// of the ItemUpdate event. Character projectiles, such as lasers and turnips,
+// are items as far as the game is concerned.
+type ItemType uint16
+
+// ItemTypes
+const (
+	ItemCapsule ItemType = iota
+	ItemBox
+	ItemBarrel
+	ItemEgg
+	ItemPartyBall
+	ItemBarrelCannon
+	ItemBobOmb
+	ItemMrSaturn
+	ItemHeartContainer
+	ItemMaximTomato
+	ItemStarman
+	ItemHomeRunBat
+	ItemBeamSword
+	ItemParasol
+	ItemGreenShell
+	ItemRedShell
+	ItemRayGun
+	ItemFreezie
+	ItemFood
+	ItemMotionSensorBomb
+	ItemFlipper
+	ItemSuperScope
+	ItemStarRod
+	ItemLipsStick
+	ItemFan
+	ItemFireFlower
+	ItemSuperMushroom
+	ItemPoisonMushroom
+	ItemHammer
+	ItemWarpStar
+	ItemScrewAttack
+	ItemBunnyHood
+	ItemMetalBox
+	ItemCloakingDevice
+	ItemPokeBall
+	ItemRayGunRecoil
+	ItemStarRodStar
+	ItemLipsStickDust
+	ItemSuperScopeBeam
+	ItemRayGunBeam
+	ItemHammerHead
+	ItemFlower
+	ItemYoshisEggItem
+	ItemGoomba
+	ItemRedead
+	ItemOctarok
+	ItemOttosea
+	ItemOctarokStone
+	ItemMarioFireball
+	ItemDrMarioMegavitamin
+	ItemKirbyCutterBeam
+	ItemKirbyHammer
+	ItemFoxLaser ItemType = iota + 2
+	ItemFalcoLaser
+	ItemFoxShadow
+	ItemFalcoShadow
+	ItemLinkBomb
+	ItemYoungLinkBomb
+	ItemLinkBoomerang
+	ItemYoungLinkBoomerang
+	ItemLinkHookshot
+	ItemYoungLinkHookshot
+	ItemLinkArrow
+	ItemYoungLinkFireArrow
+	ItemNessPKFire
+	ItemNessPKFlash
+	ItemNessPKFlashExplosion
+	ItemNessPKThunder
+	ItemNessPKThunder2
+	ItemNessPKThunder3
+	ItemNessPKThunder4
+	ItemNessPKThunder5
+	ItemFoxBlaster
+	ItemFalcoBlaster
+	ItemLinkBow
+	ItemYoungLinkBow
+	ItemNessBat
+	ItemNessYoyo
+	ItemPeachParasol
+	ItemPeachToad
+	ItemLuigiFireball
+	ItemIceClimbersIce
+	ItemIceClimbersBlizzard
+	ItemZeldaDinsFire
+	ItemZeldaDinsFireExplosion
+	ItemYoshiEgg
+	ItemYoshiEggShell
+	ItemYoshiStar
+	ItemPikachuThunder
+	ItemPikachuThunder2
+	ItemPichuThunder
+	ItemPichuThunder2
+	ItemSamusBomb
+	ItemSamusChargeShot
+	ItemSamusMissile
+	ItemSamusGrappleBeam
+	ItemSheikChain
+	ItemPeachTurnip
+	ItemBowserFlame
+	ItemNessBatSwing
+	ItemMewtwoShadowBall
+	ItemFlyGuy ItemType = 0xD2
+)
+
+// ItemCategory groups item types by where they come from.
+type ItemCategory uint8
+
+// ItemCategories
+const (
+	ItemCategoryUnknown ItemCategory = iota
+	ItemCategoryContainer
+	ItemCategoryItem
+	ItemCategoryItemProjectile
+	ItemCategoryStage
+	ItemCategoryCharacterProjectile
+)
+
+var itemCategoryNames = map[ItemCategory]string{
+	ItemCategoryUnknown:             "Unknown",
+	ItemCategoryContainer:           "Container",
+	ItemCategoryItem:                "Item",
+	ItemCategoryItemProjectile:      "Item Projectile",
+	ItemCategoryStage:               "Stage",
+	ItemCategoryCharacterProjectile: "Character Projectile",
+}
+
+type itemInfo struct {
+	name     string
+	category ItemCategory
+}
+
+var items = map[ItemType]itemInfo{
+	ItemCapsule:                {"Capsule", ItemCategoryContainer},
+	ItemBox:                    {"Box", ItemCategoryContainer},
+	ItemBarrel:                 {"Barrel", ItemCategoryContainer},
+	ItemEgg:                    {"Egg", ItemCategoryContainer},
+	ItemPartyBall:              {"Party Ball", ItemCategoryContainer},
+	ItemBarrelCannon:           {"Barrel Cannon", ItemCategoryItem},
+	ItemBobOmb:                 {"Bob-omb", ItemCategoryItem},
+	ItemMrSaturn:               {"Mr. Saturn", ItemCategoryItem},
+	ItemHeartContainer:         {"Heart Container", ItemCategoryItem},
+	ItemMaximTomato:            {"Maxim Tomato", ItemCategoryItem},
+	ItemStarman:                {"Starman", ItemCategoryItem},
+	ItemHomeRunBat:             {"Home-Run Bat", ItemCategoryItem},
+	ItemBeamSword:              {"Beam Sword", ItemCategoryItem},
+	ItemParasol:                {"Parasol", ItemCategoryItem},
+	ItemGreenShell:             {"Green Shell", ItemCategoryItem},
+	ItemRedShell:               {"Red Shell", ItemCategoryItem},
+	ItemRayGun:                 {"Ray Gun", ItemCategoryItem},
+	ItemFreezie:                {"Freezie", ItemCategoryItem},
+	ItemFood:                   {"Food", ItemCategoryItem},
+	ItemMotionSensorBomb:       {"Motion-Sensor Bomb", ItemCategoryItem},
+	ItemFlipper:                {"Flipper", ItemCategoryItem},
+	ItemSuperScope:             {"Super Scope", ItemCategoryItem},
+	ItemStarRod:                {"Star Rod", ItemCategoryItem},
+	ItemLipsStick:              {"Lip's Stick", ItemCategoryItem},
+	ItemFan:                    {"Fan", ItemCategoryItem},
+	ItemFireFlower:             {"Fire Flower", ItemCategoryItem},
+	ItemSuperMushroom:          {"Super Mushroom", ItemCategoryItem},
+	ItemPoisonMushroom:         {"Poison Mushroom", ItemCategoryItem},
+	ItemHammer:                 {"Hammer", ItemCategoryItem},
+	ItemWarpStar:               {"Warp Star", ItemCategoryItem},
+	ItemScrewAttack:            {"Screw Attack", ItemCategoryItem},
+	ItemBunnyHood:              {"Bunny Hood", ItemCategoryItem},
+	ItemMetalBox:               {"Metal Box", ItemCategoryItem},
+	ItemCloakingDevice:         {"Cloaking Device", ItemCategoryItem},
+	ItemPokeBall:               {"Poké Ball", ItemCategoryItem},
+	ItemRayGunRecoil:           {"Ray Gun Recoil", ItemCategoryItemProjectile},
+	ItemStarRodStar:            {"Star Rod Star", ItemCategoryItemProjectile},
+	ItemLipsStickDust:          {"Lip's Stick Dust", ItemCategoryItemProjectile},
+	ItemSuperScopeBeam:         {"Super Scope Beam", ItemCategoryItemProjectile},
+	ItemRayGunBeam:             {"Ray Gun Beam", ItemCategoryItemProjectile},
+	ItemHammerHead:             {"Hammer Head", ItemCategoryItemProjectile},
+	ItemFlower:                 {"Flower", ItemCategoryItemProjectile},
+	ItemYoshisEggItem:          {"Yoshi's Egg (Item)", ItemCategoryItemProjectile},
+	ItemGoomba:                 {"Goomba", ItemCategoryStage},
+	ItemRedead:                 {"Redead", ItemCategoryStage},
+	ItemOctarok:                {"Octarok", ItemCategoryStage},
+	ItemOttosea:                {"Ottosea", ItemCategoryStage},
+	ItemOctarokStone:           {"Octarok Stone", ItemCategoryStage},
+	ItemMarioFireball:          {"Mario's Fireball", ItemCategoryCharacterProjectile},
+	ItemDrMarioMegavitamin:     {"Dr. Mario's Megavitamin", ItemCategoryCharacterProjectile},
+	ItemKirbyCutterBeam:        {"Kirby's Cutter Beam", ItemCategoryCharacterProjectile},
+	ItemKirbyHammer:            {"Kirby's Hammer", ItemCategoryCharacterProjectile},
+	ItemFoxLaser:               {"Fox's Laser", ItemCategoryCharacterProjectile},
+	ItemFalcoLaser:             {"Falco's Laser", ItemCategoryCharacterProjectile},
+	ItemFoxShadow:              {"Fox's Shadow", ItemCategoryCharacterProjectile},
+	ItemFalcoShadow:            {"Falco's Shadow", ItemCategoryCharacterProjectile},
+	ItemLinkBomb:               {"Link's Bomb", ItemCategoryCharacterProjectile},
+	ItemYoungLinkBomb:          {"Young Link's Bomb", ItemCategoryCharacterProjectile},
+	ItemLinkBoomerang:          {"Link's Boomerang", ItemCategoryCharacterProjectile},
+	ItemYoungLinkBoomerang:     {"Young Link's Boomerang", ItemCategoryCharacterProjectile},
+	ItemLinkHookshot:           {"Link's Hookshot", ItemCategoryCharacterProjectile},
+	ItemYoungLinkHookshot:      {"Young Link's Hookshot", ItemCategoryCharacterProjectile},
+	ItemLinkArrow:              {"Link's Arrow", ItemCategoryCharacterProjectile},
+	ItemYoungLinkFireArrow:     {"Young Link's Fire Arrow", ItemCategoryCharacterProjectile},
+	ItemNessPKFire:             {"Ness's PK Fire", ItemCategoryCharacterProjectile},
+	ItemNessPKFlash:            {"Ness's PK Flash", ItemCategoryCharacterProjectile},
+	ItemNessPKFlashExplosion:   {"Ness's PK Flash Explosion", ItemCategoryCharacterProjectile},
+	ItemNessPKThunder:          {"Ness's PK Thunder", ItemCategoryCharacterProjectile},
+	ItemNessPKThunder2:         {"Ness's PK Thunder", ItemCategoryCharacterProjectile},
+	ItemNessPKThunder3:         {"Ness's PK Thunder", ItemCategoryCharacterProjectile},
+	ItemNessPKThunder4:         {"Ness's PK Thunder", ItemCategoryCharacterProjectile},
+	ItemNessPKThunder5:         {"Ness's PK Thunder", ItemCategoryCharacterProjectile},
+	ItemFoxBlaster:             {"Fox's Blaster", ItemCategoryCharacterProjectile},
+	ItemFalcoBlaster:           {"Falco's Blaster", ItemCategoryCharacterProjectile},
+	ItemLinkBow:                {"Link's Bow", ItemCategoryCharacterProjectile},
+	ItemYoungLinkBow:           {"Young Link's Bow", ItemCategoryCharacterProjectile},
+	ItemNessBat:                {"Ness's Bat", ItemCategoryCharacterProjectile},
+	ItemNessYoyo:               {"Ness's Yo-yo", ItemCategoryCharacterProjectile},
+	ItemPeachParasol:           {"Peach's Parasol", ItemCategoryCharacterProjectile},
+	ItemPeachToad:              {"Peach's Toad", ItemCategoryCharacterProjectile},
+	ItemLuigiFireball:          {"Luigi's Fireball", ItemCategoryCharacterProjectile},
+	ItemIceClimbersIce:         {"Ice Climbers' Ice", ItemCategoryCharacterProjectile},
+	ItemIceClimbersBlizzard:    {"Ice Climbers' Blizzard", ItemCategoryCharacterProjectile},
+	ItemZeldaDinsFire:          {"Zelda's Din's Fire", ItemCategoryCharacterProjectile},
+	ItemZeldaDinsFireExplosion: {"Zelda's Din's Fire Explosion", ItemCategoryCharacterProjectile},
+	ItemYoshiEgg:               {"Yoshi's Egg", ItemCategoryCharacterProjectile},
+	ItemYoshiEggShell:          {"Yoshi's Egg Shell", ItemCategoryCharacterProjectile},
+	ItemYoshiStar:              {"Yoshi's Star", ItemCategoryCharacterProjectile},
+	ItemPikachuThunder:         {"Pikachu's Thunder", ItemCategoryCharacterProjectile},
+	ItemPikachuThunder2:        {"Pikachu's Thunder", ItemCategoryCharacterProjectile},
+	ItemPichuThunder:           {"Pichu's Thunder", ItemCategoryCharacterProjectile},
+	ItemPichuThunder2:          {"Pichu's Thunder", ItemCategoryCharacterProjectile},
+	ItemSamusBomb:              {"Samus's Bomb", ItemCategoryCharacterProjectile},
+	ItemSamusChargeShot:        {"Samus's Charge Shot", ItemCategoryCharacterProjectile},
+	ItemSamusMissile:           {"Samus's Missile", ItemCategoryCharacterProjectile},
+	ItemSamusGrappleBeam:       {"Samus's Grapple Beam", ItemCategoryCharacterProjectile},
+	ItemSheikChain:             {"Sheik's Chain", ItemCategoryCharacterProjectile},
+	ItemPeachTurnip:            {"Peach's Turnip", ItemCategoryCharacterProjectile},
+	ItemBowserFlame:            {"Bowser's Flame", ItemCategoryCharacterProjectile},
+	ItemNessBatSwing:           {"Ness's Bat Swing", ItemCategoryCharacterProjectile},
+	ItemMewtwoShadowBall:       {"Mewtwo's Shadow Ball", ItemCategoryCharacterProjectile},
+	ItemFlyGuy:                 {"Fly Guy", ItemCategoryStage},
+}
+
+// projectileCharacters are the characters whose moves create each character
+// projectile. Kirby creates the projectiles of the neutral specials he copies,
+// and Zelda and Sheik those of each other, since they transform mid-game.
+var projectileCharacters = map[ItemType][]CharacterID{
+	ItemMarioFireball:          {Mario, Kirby},
+	ItemDrMarioMegavitamin:     {DrMario, Kirby},
+	ItemKirbyCutterBeam:        {Kirby},
+	ItemKirbyHammer:            {Kirby},
+	ItemFoxLaser:               {Fox, Kirby},
+	ItemFalcoLaser:             {Falco, Kirby},
+	ItemFoxShadow:              {Fox},
+	ItemFalcoShadow:            {Falco},
+	ItemLinkBomb:               {Link},
+	ItemYoungLinkBomb:          {YoungLink},
+	ItemLinkBoomerang:          {Link},
+	ItemYoungLinkBoomerang:     {YoungLink},
+	ItemLinkHookshot:           {Link},
+	ItemYoungLinkHookshot:      {YoungLink},
+	ItemLinkArrow:              {Link, Kirby},
+	ItemYoungLinkFireArrow:     {YoungLink, Kirby},
+	ItemNessPKFire:             {Ness},
+	ItemNessPKFlash:            {Ness, Kirby},
+	ItemNessPKFlashExplosion:   {Ness, Kirby},
+	ItemNessPKThunder:          {Ness},
+	ItemNessPKThunder2:         {Ness},
+	ItemNessPKThunder3:         {Ness},
+	ItemNessPKThunder4:         {Ness},
+	ItemNessPKThunder5:         {Ness},
+	ItemFoxBlaster:             {Fox, Kirby},
+	ItemFalcoBlaster:           {Falco, Kirby},
+	ItemLinkBow:                {Link, Kirby},
+	ItemYoungLinkBow:           {YoungLink, Kirby},
+	ItemNessBat:                {Ness},
+	ItemNessYoyo:               {Ness},
+	ItemPeachParasol:           {Peach},
+	ItemPeachToad:              {Peach, Kirby},
+	ItemLuigiFireball:          {Luigi, Kirby},
+	ItemIceClimbersIce:         {IceClimbers, Kirby},
+	ItemIceClimbersBlizzard:    {IceClimbers},
+	ItemZeldaDinsFire:          {Zelda, Sheik, Kirby},
+	ItemZeldaDinsFireExplosion: {Zelda, Sheik, Kirby},
+	ItemYoshiEgg:               {Yoshi},
+	ItemYoshiEggShell:          {Yoshi},
+	ItemYoshiStar:              {Yoshi},
+	ItemPikachuThunder:         {Pikachu},
+	ItemPikachuThunder2:        {Pikachu},
+	ItemPichuThunder:           {Pichu},
+	ItemPichuThunder2:          {Pichu},
+	ItemSamusBomb:              {Samus},
+	ItemSamusChargeShot:        {Samus, Kirby},
+	ItemSamusMissile:           {Samus},
+	ItemSamusGrappleBeam:       {Samus},
+	ItemSheikChain:             {Sheik, Zelda},
+	ItemPeachTurnip:            {Peach},
+	ItemBowserFlame:            {Bowser, Kirby},
+	ItemNessBatSwing:           {Ness},
+	ItemMewtwoShadowBall:       {Mewtwo, Kirby},
+}
+
+// String returns the human-readable name of the item type.
+func (i ItemType) String() string {
+	if info, ok := items[i]; ok {
+		return info.name
+	}
+
+	return fmt.Sprintf("Unknown Item (%d)", uint16(i))
+}
+
+// Category returns the category of the item type.
+func (i ItemType) Category() ItemCategory {
+	if info, ok := items[i]; ok {
+		return info.category
+	}
+
+	return ItemCategoryUnknown
+}
+
+// IsCharacterProjectile returns whether the item is created by a character's
+// moves rather than spawned as an item.
+func (i ItemType) IsCharacterProjectile() bool {
+	return i.Category() == ItemCategoryCharacterProjectile
+}
+
+// Characters returns the characters whose moves create the item, which are
+// none for items that aren't character projectiles.
+func (i ItemType) Characters() []CharacterID {
+	return append([]CharacterID{}, projectileCharacters[i]...)
+}
+
+// IsStageItem returns whether the item belongs to a stage, such as Goombas on
+// Mushroom Kingdom.
+func (i ItemType) IsStageItem() bool {
+	return i.Category() == ItemCategoryStage
+}
+
+// IsContainer returns whether the item is a container that holds other items.
+func (i ItemType) IsContainer() bool {
+	return i.Category() == ItemCategoryContainer
+}
+
+// String returns the human-readable name of the item category.
+func (c ItemCategory) String() string {
+	if name, ok := itemCategoryNames[c]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("Unknown Item Category (%d)", uint8(c))
+}
@@ -0,0 +1,25 @@
+package slippi
+
+import "testing"
+
+func TestItemTypeIDs(t *testing.T) {
+	// the first and last item of each block of IDs
+	expected := map[ItemType]uint16{
+		ItemCapsule:          0,
+		ItemPokeBall:         34,
+		ItemKirbyHammer:      51,
+		ItemFoxLaser:         54,
+		ItemPeachTurnip:      99,
+		ItemMewtwoShadowBall: 102,
+		ItemFlyGuy:           210,
+	}
+	for item, id := range expected {
+		if uint16(item) != id {
+			t.Errorf("expected %s to be %d, got %d", item, id, uint16(item))
+		}
+	}
+
+	if name := ItemType(52).String(); name != "Unknown Item (52)" {
+		t.Errorf("expected item 52 to be unknown, got %s", name)
+	}
+}
@@ -0,0 +1,60 @@
+package slippi
+
+import (
+	"iter"
+	"sync/atomic"
+)
+
+// Frames returns an iterator over the game's frames in increasing frame
+// order. The game is processed when iteration begins; if processing fails, the
+// iterator yields no frames, and the error can be retrieved from GetFrameStore.
+func (g *SlpGame) Frames() iter.Seq2[int32, FrameEntry] {
+	return func(yield func(int32, FrameEntry) bool) {
+		frames, err := g.GetFrameStore()
+		if err != nil {
+			return
+		}
+
+		for frameNumber, frame := range frames.All() {
+			if !yield(frameNumber, frame) {
+				return
+			}
+		}
+	}
+}
+
+// Events returns an iterator over the events read from the replay. Reading
+// stops when the loop breaks or after the first error, which is yielded with
+// an empty event.
+func (r *SlpReader) Events() iter.Seq2[SlpEvent, error] {
+	return func(yield func(SlpEvent, error) bool) {
+		var stopped atomic.Bool
+		events, err := r.YieldEvents(func(*SlpEvent) bool {
+			return stopped.Load()
+		})
+		if err != nil {
+			yield(SlpEvent{}, err)
+			return
+		}
+
+		// let the reader finish in the background if the loop breaks early
+		defer func() {
+			stopped.Store(true)
+			go func() {
+				for range events {
+				}
+			}()
+		}()
+
+		for result := range events {
+			if result.Error != nil {
+				yield(SlpEvent{}, result.Error)
+				return
+			}
+
+			if !yield(*result.Event, nil) {
+				return
+			}
+		}
+	}
+}
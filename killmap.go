@@ -0,0 +1,140 @@
+package slippi
+
+import "sort"
+
+// A KillMapEntry is the location of a single death.
+type KillMapEntry struct {
+	Character CharacterID `json:"character"`
+	// X and Y are the last position of the player before they died.
+	X float32 `json:"x"`
+	Y float32 `json:"y"`
+	// HitX and HitY are the position of the player when they were last hit
+	// before dying, and are equal to X and Y if they weren't hit that stock.
+	HitX    float32 `json:"hitX"`
+	HitY    float32 `json:"hitY"`
+	Percent float32 `json:"percent"`
+	Frame   int32   `json:"frame"`
+	// KillerCharacter and KillMove are the character and move of the
+	// opponent who last hit the player, and are unset for self destructs.
+	KillerCharacter CharacterID `json:"killerCharacter"`
+	KillMove        AttackID    `json:"killMove"`
+	// SelfDestruct is whether the player died without being hit by an
+	// opponent since they last had control on the ground.
+	SelfDestruct bool `json:"selfDestruct"`
+}
+
+// A StageKillMap contains the deaths that occurred on a single stage.
+type StageKillMap struct {
+	Stage     StageID        `json:"stage"`
+	StageName string         `json:"stageName"`
+	Games     int            `json:"games"`
+	Deaths    []KillMapEntry `json:"deaths"`
+}
+
+// A KillMap aggregates death locations across games, grouped by stage. Its
+// JSON form can be used directly as scatter or heatmap data.
+type KillMap struct {
+	Stages []*StageKillMap `json:"stages"`
+	// QuitOuts determines whether games that were quit out of are added.
+	QuitOuts QuitOutPolicy `json:"-"`
+}
+
+// NewKillMap returns an empty KillMap.
+func NewKillMap() *KillMap {
+	return &KillMap{Stages: make([]*StageKillMap, 0)}
+}
+
+// Stage returns the kill map of the given stage, or nil if no games on the
+// stage have been added.
+func (k *KillMap) Stage(stage StageID) *StageKillMap {
+	for _, s := range k.Stages {
+		if s.Stage == stage {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// AddGame adds the deaths in game to the kill map, unless it is a quit out
+// excluded by the kill map's QuitOuts policy.
+func (k *KillMap) AddGame(game *SlpGame) error {
+	gameInfo, err := game.GetGameInfo()
+	if err != nil {
+		return err
+	}
+
+	result, err := game.Result(k.QuitOuts)
+	if err != nil {
+		return err
+	} else if result.Excluded {
+		return nil
+	}
+
+	frames, err := game.GetFrames()
+	if err != nil {
+		return err
+	}
+
+	stage := k.Stage(gameInfo.Stage)
+	if stage == nil {
+		stage = &StageKillMap{
+			Stage:     gameInfo.Stage,
+			StageName: gameInfo.Stage.String(),
+			Deaths:    make([]KillMapEntry, 0),
+		}
+		k.Stages = append(k.Stages, stage)
+		sort.Slice(k.Stages, func(i, j int) bool {
+			return k.Stages[i].Stage < k.Stages[j].Stage
+		})
+	}
+	stage.Games++
+
+	characters := make(map[uint8]CharacterID)
+	for _, player := range gameInfo.Players {
+		characters[player.Index] = player.CharacterID
+	}
+
+	character := func(index uint8) CharacterID {
+		if c, ok := characters[index]; ok {
+			return c
+		}
+
+		return NoCharacter
+	}
+
+	lastHit := make(map[uint8]*PostFrameUpdatePayload)
+	walkDamage(frames, func(frame FrameEntry, index uint8, post *PostFrameUpdatePayload, damage float32, hit Hit, attributed bool) {
+		lastHit[index] = post
+	}, func(frame FrameEntry, prev FrameEntry, index uint8, alive *PostFrameUpdatePayload, selfDestruct bool) {
+		entry := KillMapEntry{
+			Character:       character(index),
+			X:               alive.XPosition,
+			Y:               alive.YPosition,
+			HitX:            alive.XPosition,
+			HitY:            alive.YPosition,
+			Percent:         alive.Percent,
+			Frame:           frame.FrameNumber,
+			KillerCharacter: NoCharacter,
+			SelfDestruct:    selfDestruct,
+		}
+
+		if hit, ok := lastHit[index]; ok {
+			entry.HitX = hit.XPosition
+			entry.HitY = hit.YPosition
+		}
+
+		killer := alive.LastHitBy
+		if !selfDestruct && killer != index {
+			if killerUpdates, ok := prev.Players[killer]; ok && killerUpdates.Post != nil {
+				entry.KillerCharacter = character(killer)
+				entry.KillMove = killerUpdates.Post.LastHittingAttackID
+			}
+		}
+
+		stage.Deaths = append(stage.Deaths, entry)
+		delete(lastHit, index)
+	})
+
+	return nil
+}
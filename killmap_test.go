@@ -0,0 +1,66 @@
+package slippi
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func TestKillMap(t *testing.T) {
+	replay, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	killMap := NewKillMap()
+	for i := 0; i < 2; i++ {
+		game, err := NewSlpGameFromBytes(replay, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		err = killMap.AddGame(game)
+		game.Close()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	if len(killMap.Stages) != 1 {
+		t.Errorf("expected 1 stage, got %d", len(killMap.Stages))
+		return
+	}
+
+	stage := killMap.Stage(YoshisStory)
+	if stage == nil || stage.Games != 2 || len(stage.Deaths) != 14 {
+		t.Errorf("expected 14 deaths over 2 games on %s, got %+v", YoshisStory, stage)
+		return
+	}
+
+	selfDestructs := 0
+	for _, death := range stage.Deaths {
+		if death.SelfDestruct {
+			selfDestructs++
+			if death.KillerCharacter != NoCharacter {
+				t.Errorf("self destruct on frame %d has a killer, %s", death.Frame, death.KillerCharacter)
+			}
+		}
+
+		nearBlastZone := death.X < -170 || death.X > 170 || death.Y < -80 || death.Y > 160
+		if !nearBlastZone {
+			t.Errorf("death at (%f, %f) on frame %d is not near a blast zone", death.X, death.Y, death.Frame)
+		}
+	}
+
+	if selfDestructs != 2 {
+		t.Errorf("expected 2 self destructs, got %d", selfDestructs)
+	}
+
+	_, err = json.Marshal(killMap)
+	if err != nil {
+		t.Error(err)
+	}
+}
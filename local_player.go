@@ -0,0 +1,112 @@
+package slippi
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SetLocalIdentities sets the connect codes or netplay names that identify
+// the account that recorded the game's replays, which are used to determine
+// GameInfo.LocalPlayerIndex.
+func (g *SlpGame) SetLocalIdentities(identities ...string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.localIdentities = append(make([]string, 0, len(identities)), identities...)
+}
+
+// inferLocalPlayerIndex returns the index of the player who recorded the
+// game, or -1 if it can't be determined. Players are matched against the
+// local identities by connect code and name, first from the metadata and then
+// from the game info. Without a match, a lone human player in a game against
+// CPUs is assumed to be local. The caller must hold g.mu.
+func (g *SlpGame) inferLocalPlayerIndex(gameInfo *GameInfo) int8 {
+	identities := make(map[string]bool)
+	for _, identity := range g.localIdentities {
+		identities[normalizeIdentity(identity)] = true
+	}
+
+	matches := func(names ...string) bool {
+		for _, name := range names {
+			if name != "" && identities[normalizeIdentity(name)] {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if len(identities) > 0 {
+		metadata, err := g.metadataLocked()
+		if err == nil && metadata != nil {
+			// players are matched in order of their index, so that the
+			// same player is found each time if several match
+			indices := make(map[string]int, len(metadata.Players))
+			keys := make([]string, 0, len(metadata.Players))
+			for key := range metadata.Players {
+				if index, err := strconv.Atoi(key); err == nil {
+					indices[key] = index
+					keys = append(keys, key)
+				}
+			}
+			sort.Slice(keys, func(i, j int) bool { return indices[keys[i]] < indices[keys[j]] })
+
+			for _, key := range keys {
+				player := metadata.Players[key]
+				if matches(player.Names.Code, player.Names.Netplay) {
+					return int8(indices[key])
+				}
+			}
+		}
+
+		for _, player := range gameInfo.Players {
+			if matches(player.ConnectCode, player.DisplayName) {
+				return int8(player.Index)
+			}
+		}
+	}
+
+	humans := make([]PlayerInfo, 0)
+	for _, player := range gameInfo.Players {
+		if player.PlayerType == Human {
+			humans = append(humans, player)
+		}
+	}
+
+	if len(humans) == 1 && len(gameInfo.Players) > 1 {
+		return int8(humans[0].Index)
+	}
+
+	return -1
+}
+
+// normalizeIdentity normalizes a connect code or name for comparison. Connect
+// codes in GameStart events use a full-width number sign, while those in
+// metadata do not.
+func normalizeIdentity(identity string) string {
+	return strings.ToUpper(strings.TrimSpace(strings.ReplaceAll(identity, "＃", "#")))
+}
+
+// TrackLocalPlayer restricts the tracked players to the local player, as
+// determined by GameInfo.LocalPlayerIndex. It returns an error if the local
+// player can't be determined.
+func (g *SlpGame) TrackLocalPlayer() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	gameInfo, err := g.gameInfoLocked()
+	if err != nil {
+		return err
+	}
+
+	if gameInfo.LocalPlayerIndex < 0 {
+		return errors.New("could not determine the local player")
+	}
+
+	g.parser.Options.TrackedPlayers = []uint8{uint8(gameInfo.LocalPlayerIndex)}
+	g.invalidate()
+
+	return nil
+}
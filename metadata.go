@@ -0,0 +1,300 @@
+package slippi
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/jmank88/ubjson"
+)
+
+// Metadata keys, as written by Slippi.
+const (
+	startAtKey     = "startAt"
+	lastFrameKey   = "lastFrame"
+	playersKey     = "players"
+	playedOnKey    = "playedOn"
+	consoleNickKey = "consoleNick"
+	extensionsKey  = "extensions"
+)
+
+// A Metadata contains metadata about a Slippi game.
+//
+// Extensions holds custom keys (e.g. tournament name, set ID) written under the
+// "extensions" object, and Unknown holds any other top-level keys that are not
+// part of the official layout. Both are preserved when metadata is decoded and
+// re-encoded.
+type Metadata struct {
+	StartAt     string                    `ubjson:"startAt"`
+	LastFrame   int32                     `ubjson:"lastFrame"`
+	Players     map[string]PlayerMetadata `ubjson:"players"`
+	PlayedOn    string                    `ubjson:"playedOn"`
+	ConsoleNick string                    `ubjson:"consoleNick"`
+	Extensions  map[string]interface{}    `ubjson:"extensions"`
+	Unknown     map[string]interface{}
+}
+
+// A PlayerMetadata contains metadata about a player.
+type PlayerMetadata struct {
+	Characters map[string]int32 `ubjson:"characters"`
+	Names      Names            `ubjson:"names"`
+}
+
+// A Names contains the names of a player.
+type Names struct {
+	Netplay string `ubjson:"netplay"`
+	Code    string `ubjson:"code"`
+}
+
+// SetExtension sets a custom key under the metadata's extensions object.
+func (m *Metadata) SetExtension(key string, value interface{}) {
+	if m.Extensions == nil {
+		m.Extensions = make(map[string]interface{})
+	}
+
+	m.Extensions[key] = value
+}
+
+// UBJSONType implements the ubjson.Value interface.
+func (m *Metadata) UBJSONType() ubjson.Marker {
+	return ubjson.ObjectStartMarker
+}
+
+// MarshalUBJSON implements the ubjson.Value interface. Official keys are
+// written first in the order Slippi writes them, with player ports and
+// character IDs in ascending order and each player's names before their
+// characters, followed by unknown keys and extensions, in sorted order.
+func (m *Metadata) MarshalUBJSON(e *ubjson.Encoder) error {
+	o, err := e.Object()
+	if err != nil {
+		return err
+	}
+
+	err = encodeEntry(o, startAtKey, m.StartAt)
+	if err != nil {
+		return err
+	}
+
+	err = encodeEntry(o, lastFrameKey, m.LastFrame)
+	if err != nil {
+		return err
+	}
+
+	err = o.EncodeKey(playersKey)
+	if err != nil {
+		return err
+	}
+
+	err = encodePlayers(o, m.Players)
+	if err != nil {
+		return err
+	}
+
+	err = encodeEntry(o, playedOnKey, m.PlayedOn)
+	if err != nil {
+		return err
+	}
+
+	if m.ConsoleNick != "" {
+		err = encodeEntry(o, consoleNickKey, m.ConsoleNick)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, key := range sortedKeys(m.Unknown) {
+		err = encodeEntry(o, key, m.Unknown[key])
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(m.Extensions) > 0 {
+		err = o.EncodeKey(extensionsKey)
+		if err != nil {
+			return err
+		}
+
+		err = o.EncodeObject(func(e *ubjson.Encoder) error {
+			extensionsObject, err := e.Object()
+			if err != nil {
+				return err
+			}
+
+			for _, key := range sortedKeys(m.Extensions) {
+				err = encodeEntry(extensionsObject, key, m.Extensions[key])
+				if err != nil {
+					return err
+				}
+			}
+
+			return extensionsObject.End()
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return o.End()
+}
+
+// UnmarshalUBJSON implements the ubjson.Value interface.
+func (m *Metadata) UnmarshalUBJSON(d *ubjson.Decoder) error {
+	o, err := d.Object()
+	if err != nil {
+		return err
+	}
+
+	for o.NextEntry() {
+		key, err := o.DecodeKey()
+		if err != nil {
+			return err
+		}
+
+		switch key {
+		case startAtKey:
+			err = o.Decode(&m.StartAt)
+		case lastFrameKey:
+			err = o.Decode(&m.LastFrame)
+		case playersKey:
+			err = o.Decode(&m.Players)
+		case playedOnKey:
+			err = o.Decode(&m.PlayedOn)
+		case consoleNickKey:
+			err = o.Decode(&m.ConsoleNick)
+		case extensionsKey:
+			var value interface{}
+			err = o.Decode(&value)
+			if extensions, ok := value.(map[string]interface{}); ok {
+				m.Extensions = extensions
+			} else if err == nil {
+				m.setUnknown(key, value)
+			}
+		default:
+			var value interface{}
+			err = o.Decode(&value)
+			m.setUnknown(key, value)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return o.End()
+}
+
+func (m *Metadata) setUnknown(key string, value interface{}) {
+	if m.Unknown == nil {
+		m.Unknown = make(map[string]interface{})
+	}
+
+	m.Unknown[key] = value
+}
+
+func encodeEntry(o *ubjson.ObjectEncoder, key string, value interface{}) error {
+	err := o.EncodeKey(key)
+	if err != nil {
+		return err
+	}
+
+	return o.Encode(value)
+}
+
+func encodePlayers(o *ubjson.ObjectEncoder, players map[string]PlayerMetadata) error {
+	return o.EncodeObject(func(e *ubjson.Encoder) error {
+		playersObject, err := e.Object()
+		if err != nil {
+			return err
+		}
+
+		ports := make([]string, 0, len(players))
+		for port := range players {
+			ports = append(ports, port)
+		}
+		sortNumericKeys(ports)
+
+		for _, port := range ports {
+			err = playersObject.EncodeKey(port)
+			if err != nil {
+				return err
+			}
+
+			err = encodePlayer(playersObject, players[port])
+			if err != nil {
+				return err
+			}
+		}
+
+		return playersObject.End()
+	})
+}
+
+func encodePlayer(o *ubjson.ObjectEncoder, player PlayerMetadata) error {
+	return o.EncodeObject(func(e *ubjson.Encoder) error {
+		playerObject, err := e.Object()
+		if err != nil {
+			return err
+		}
+
+		err = encodeEntry(playerObject, "names", player.Names)
+		if err != nil {
+			return err
+		}
+
+		err = playerObject.EncodeKey("characters")
+		if err != nil {
+			return err
+		}
+
+		err = playerObject.EncodeObject(func(e *ubjson.Encoder) error {
+			charactersObject, err := e.Object()
+			if err != nil {
+				return err
+			}
+
+			characters := make([]string, 0, len(player.Characters))
+			for character := range player.Characters {
+				characters = append(characters, character)
+			}
+			sortNumericKeys(characters)
+
+			for _, character := range characters {
+				err = encodeEntry(charactersObject, character, player.Characters[character])
+				if err != nil {
+					return err
+				}
+			}
+
+			return charactersObject.End()
+		})
+		if err != nil {
+			return err
+		}
+
+		return playerObject.End()
+	})
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// sortNumericKeys sorts keys that are decimal numbers numerically, falling back
+// to lexical order for keys that are not.
+func sortNumericKeys(keys []string) {
+	sort.Slice(keys, func(i, j int) bool {
+		a, errA := strconv.Atoi(keys[i])
+		b, errB := strconv.Atoi(keys[j])
+		if errA != nil || errB != nil {
+			return keys[i] < keys[j]
+		}
+
+		return a < b
+	})
+}
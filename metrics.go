@@ -0,0 +1,198 @@
+package slippi
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var connectionStatusNames = map[ConnectionStatus]string{
+	Disconnected:  "disconnected",
+	Connecting:    "connecting",
+	Connected:     "connected",
+	ReconnectWait: "reconnect_wait",
+}
+
+// connectionMetrics are the metrics of a single connection.
+type connectionMetrics struct {
+	status         ConnectionStatus
+	games          uint64
+	parseErrors    uint64
+	frames         uint64
+	rollbackFrames uint64
+	latestFrame    int32
+	finalizedFrame int32
+}
+
+// Metrics collects the metrics of the connections of a live ingestion daemon,
+// and serves them in the Prometheus text format, so that the daemon can be
+// monitored by Prometheus by serving Metrics on its metrics endpoint. Each
+// metric is labeled by the name of its connection. Metrics is safe for
+// concurrent use.
+//
+// The metrics are:
+//   - slippi_connection_status, 1 for the connection's current status and 0
+//     for the others, labeled by status
+//   - slippi_games_recorded_total, the number of games that ended
+//   - slippi_parse_errors_total, the number of parse errors reported
+//   - slippi_frames_total, the number of frames finalized
+//   - slippi_rollback_frames_total, the number of frames replaced by
+//     rollbacks, from which rollback rates are derived with rate()
+//   - slippi_frame_lag, the number of frames received that haven't been
+//     finalized yet
+type Metrics struct {
+	mu          sync.Mutex
+	connections map[string]*connectionMetrics
+}
+
+// NewMetrics returns a Metrics without any connections.
+func NewMetrics() *Metrics {
+	return &Metrics{connections: make(map[string]*connectionMetrics)}
+}
+
+// connection returns the metrics of the connection with the given name. The
+// caller must hold m.mu.
+func (m *Metrics) connection(name string) *connectionMetrics {
+	c, ok := m.connections[name]
+	if !ok {
+		c = &connectionMetrics{status: Disconnected, latestFrame: FirstFrame - 1, finalizedFrame: FirstFrame - 1}
+		m.connections[name] = c
+	}
+
+	return c
+}
+
+func (m *Metrics) update(name string, update func(c *connectionMetrics)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	update(m.connection(name))
+}
+
+// SetConnectionStatus sets the status of the connection with the given name.
+func (m *Metrics) SetConnectionStatus(name string, status ConnectionStatus) {
+	m.update(name, func(c *connectionMetrics) {
+		c.status = status
+	})
+}
+
+// ParseError counts a parse error of the connection with the given name.
+func (m *Metrics) ParseError(name string) {
+	m.update(name, func(c *connectionMetrics) {
+		c.parseErrors++
+	})
+}
+
+// ObserveParser counts the games, frames and rollbacks parsed by parser as
+// those of the connection with the given name, until the returned function is
+// called. The frame lag is only accurate if the parser delivers events in
+// order.
+func (m *Metrics) ObserveParser(name string, parser *SlpParser) func() {
+	m.update(name, func(*connectionMetrics) {})
+
+	unsubscribes := []func(){
+		parser.OnFrame(func(frame FrameEntry) {
+			m.update(name, func(c *connectionMetrics) {
+				c.latestFrame = frame.FrameNumber
+			})
+		}),
+		parser.OnFinalizedFrame(func(frame FrameEntry) {
+			m.update(name, func(c *connectionMetrics) {
+				c.frames++
+				c.finalizedFrame = frame.FrameNumber
+			})
+		}),
+		parser.OnRollbackFrame(func(FrameEntry) {
+			m.update(name, func(c *connectionMetrics) {
+				c.rollbackFrames++
+			})
+		}),
+		parser.OnEnded(func(GameEndPayload) {
+			m.update(name, func(c *connectionMetrics) {
+				c.games++
+			})
+		}),
+	}
+
+	return func() {
+		for _, unsubscribe := range unsubscribes {
+			unsubscribe()
+		}
+	}
+}
+
+// ServeHTTP serves the metrics in the Prometheus text format. It implements
+// http.Handler.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WriteTo(w)
+}
+
+// WriteTo writes the metrics to w in the Prometheus text format. It implements
+// io.WriterTo.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	m.mu.Lock()
+	names := make([]string, 0, len(m.connections))
+	snapshot := make(map[string]connectionMetrics, len(m.connections))
+	for name, c := range m.connections {
+		names = append(names, name)
+		snapshot[name] = *c
+	}
+	m.mu.Unlock()
+	sort.Strings(names)
+
+	var b strings.Builder
+	sample := func(name string, connection string, value interface{}) string {
+		return fmt.Sprintf("%s{connection=\"%s\"} %v\n", name, escapeLabel(connection), value)
+	}
+
+	statuses := make([]ConnectionStatus, 0, len(connectionStatusNames))
+	for status := range connectionStatusNames {
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i] < statuses[j] })
+
+	fmt.Fprintf(&b, "# HELP slippi_connection_status Whether the connection has the status.\n# TYPE slippi_connection_status gauge\n")
+	for _, connection := range names {
+		for _, status := range statuses {
+			value := 0
+			if snapshot[connection].status == status {
+				value = 1
+			}
+			fmt.Fprintf(&b, "slippi_connection_status{connection=\"%s\",status=\"%s\"} %d\n", escapeLabel(connection), connectionStatusNames[status], value)
+		}
+	}
+
+	for _, metric := range []struct {
+		name  string
+		help  string
+		count func(c connectionMetrics) uint64
+	}{
+		{"slippi_games_recorded_total", "Games that ended.", func(c connectionMetrics) uint64 { return c.games }},
+		{"slippi_parse_errors_total", "Parse errors.", func(c connectionMetrics) uint64 { return c.parseErrors }},
+		{"slippi_frames_total", "Frames finalized.", func(c connectionMetrics) uint64 { return c.frames }},
+		{"slippi_rollback_frames_total", "Frames replaced by rollbacks.", func(c connectionMetrics) uint64 { return c.rollbackFrames }},
+	} {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s counter\n", metric.name, metric.help, metric.name)
+		for _, connection := range names {
+			b.WriteString(sample(metric.name, connection, metric.count(snapshot[connection])))
+		}
+	}
+
+	fmt.Fprintf(&b, "# HELP slippi_frame_lag Frames received that haven't been finalized.\n# TYPE slippi_frame_lag gauge\n")
+	for _, connection := range names {
+		c := snapshot[connection]
+		b.WriteString(sample("slippi_frame_lag", connection, max(c.latestFrame-c.finalizedFrame, 0)))
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// escapeLabel escapes a Prometheus label value.
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
@@ -0,0 +1,83 @@
+package slippi
+
+import (
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetrics(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game, err := NewSlpGameFromBytes(b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer game.Close()
+
+	metrics := NewMetrics()
+	metrics.SetConnectionStatus("setup \"1\"", Connected)
+	metrics.ParseError("setup \"1\"")
+	game.SetDispatchMode(DispatchOrdered)
+	detach := metrics.ObserveParser("setup \"1\"", game.parser)
+	defer detach()
+
+	if _, err := game.GetFrames(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		`slippi_connection_status{connection="setup \"1\"",status="connected"} 1`,
+		`slippi_connection_status{connection="setup \"1\"",status="disconnected"} 0`,
+		`slippi_games_recorded_total{connection="setup \"1\""} 1`,
+		`slippi_parse_errors_total{connection="setup \"1\""} 1`,
+		`slippi_frames_total{connection="setup \"1\""} 12343`,
+		`slippi_frame_lag{connection="setup \"1\""} 0`,
+		"# TYPE slippi_rollback_frames_total counter",
+	}
+
+	var body string
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		recorder := httptest.NewRecorder()
+		metrics.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+		body = recorder.Body.String()
+
+		missing := false
+		for _, line := range expected {
+			missing = missing || !strings.Contains(body, line+"\n")
+		}
+		if !missing {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Errorf("expected metrics to contain %q, got:\n%s", expected, body)
+}
+
+func TestMetricsDetach(t *testing.T) {
+	metrics := NewMetrics()
+	before := runtime.NumGoroutine()
+
+	for i := 0; i < 20; i++ {
+		parser := NewSlpParser(SlpParserOpts{})
+		detach := metrics.ObserveParser("setup", parser)
+		detach()
+		detach()
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("expected detaching parsers to stop their goroutines, went from %d to %d", before, after)
+	}
+}
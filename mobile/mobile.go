@@ -0,0 +1,206 @@
+// Package mobile is a facade over the replay parser that can be bound with
+// gomobile for use in Android and iOS apps. Its exported API only uses types
+// gomobile supports: no channels, generics, maps or slices other than []byte,
+// with lists exposed through a count and an accessor, and callbacks through
+// interfaces the app implements.
+//
+// Bind it with:
+//
+//	gomobile bind -target android github.com/ZadenRB/go-slippi/mobile
+package mobile
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+// A PlayerSummary summarizes a single player's game.
+type PlayerSummary struct {
+	Port        int
+	Character   string
+	DisplayName string
+	ConnectCode string
+	// Deaths and SelfDestructs count the stocks the player lost, and those
+	// lost without being hit by an opponent.
+	Deaths        int
+	SelfDestructs int
+	// DamageTaken is the total damage the player took, and
+	// AverageDeathPercent the average percent at which they lost their
+	// stocks.
+	DamageTaken         float64
+	AverageDeathPercent float64
+}
+
+// A Summary summarizes a game.
+type Summary struct {
+	Stage string
+	// Frames is the number of frames the game lasted.
+	Frames int
+	// WinnerPort is the port of the winner of the game, or 0 if there is
+	// none.
+	WinnerPort int
+	QuitOut    bool
+	players    []*PlayerSummary
+}
+
+// PlayerCount returns the number of players in the game.
+func (s *Summary) PlayerCount() int {
+	return len(s.players)
+}
+
+// Player returns the summary of the i-th player of the game, in port order,
+// or nil if there is no such player.
+func (s *Summary) Player(i int) *PlayerSummary {
+	if i < 0 || i >= len(s.players) {
+		return nil
+	}
+
+	return s.players[i]
+}
+
+// JSON returns the summary encoded as JSON, including its players.
+func (s *Summary) JSON() (string, error) {
+	b, err := json.Marshal(struct {
+		Stage      string           `json:"stage"`
+		Frames     int              `json:"frames"`
+		WinnerPort int              `json:"winnerPort"`
+		QuitOut    bool             `json:"quitOut"`
+		Players    []*PlayerSummary `json:"players"`
+	}{s.Stage, s.Frames, s.WinnerPort, s.QuitOut, s.players})
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// A Replay is an opened replay.
+type Replay struct {
+	game *slippi.SlpGame
+}
+
+// OpenReplay opens the replay at path.
+func OpenReplay(path string) (*Replay, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return OpenReplayBytes(b)
+}
+
+// OpenReplayBytes opens the replay with the given contents.
+func OpenReplayBytes(b []byte) (*Replay, error) {
+	game, err := slippi.NewSlpGameFromBytes(b, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Replay{game: game}, nil
+}
+
+// Close releases the replay.
+func (r *Replay) Close() {
+	r.game.Close()
+}
+
+// Summary parses the replay and returns a summary of the game.
+func (r *Replay) Summary() (*Summary, error) {
+	gameInfo, err := r.game.GetGameInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	frames, err := r.game.GetFrameStore()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.game.Result(slippi.QuitOutPartial)
+	if err != nil {
+		return nil, err
+	}
+
+	damage, err := r.game.DamageBreakdowns()
+	if err != nil {
+		return nil, err
+	}
+
+	deaths, err := r.game.DeathStats()
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &Summary{
+		Stage:   gameInfo.Stage.String(),
+		Frames:  frames.FrameCount(),
+		QuitOut: result.QuitOut != nil,
+		players: make([]*PlayerSummary, 0, len(gameInfo.Players)),
+	}
+
+	for _, player := range gameInfo.Players {
+		if int8(player.Index) == result.WinnerIndex {
+			summary.WinnerPort = int(player.Port)
+		}
+
+		playerSummary := &PlayerSummary{
+			Port:        int(player.Port),
+			Character:   player.CharacterID.String(),
+			DisplayName: player.DisplayName,
+			ConnectCode: player.ConnectCode,
+		}
+		if d, ok := damage[player.Index]; ok {
+			playerSummary.Deaths = d.Deaths
+			playerSummary.SelfDestructs = d.SelfDestructs
+			playerSummary.DamageTaken = float64(d.Total())
+		}
+		if d, ok := deaths[player.Index]; ok {
+			playerSummary.AverageDeathPercent = float64(d.All.Average())
+		}
+
+		summary.players = append(summary.players, playerSummary)
+	}
+
+	return summary, nil
+}
+
+// A ReplayHandler receives the summaries of the replays found by
+// ScanDirectory. Returning false from a method stops the scan.
+type ReplayHandler interface {
+	OnReplay(path string, summary *Summary) bool
+	// OnError is called with replays that couldn't be summarized.
+	OnError(path string, err error) bool
+}
+
+// ScanDirectory summarizes every replay in the directory dir, including zip
+// archives of replays, and passes each summary to handler. If recursive is
+// true, subdirectories are also scanned.
+func ScanDirectory(dir string, recursive bool, handler ReplayHandler) error {
+	err := slippi.ProcessDirectory(dir, recursive, func(path string, game *slippi.SlpGame) error {
+		summary, err := (&Replay{game: game}).Summary()
+
+		keepGoing := false
+		if err != nil {
+			keepGoing = handler.OnError(path, err)
+		} else {
+			keepGoing = handler.OnReplay(path, summary)
+		}
+
+		if !keepGoing {
+			return errStopped
+		}
+
+		return nil
+	})
+	if err == errStopped {
+		return nil
+	}
+
+	return err
+}
+
+// errStopped stops ScanDirectory when its handler asks it to.
+var errStopped = errors.New("scan stopped")
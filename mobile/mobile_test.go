@@ -0,0 +1,86 @@
+package mobile
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSummary(t *testing.T) {
+	replay, err := OpenReplay("../game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer replay.Close()
+
+	summary, err := replay.Summary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if summary.Stage != "Yoshi's Story" || summary.WinnerPort != 1 || summary.QuitOut || summary.Frames != 12343 {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+	if summary.PlayerCount() != 2 || summary.Player(2) != nil {
+		t.Fatalf("expected 2 players, got %d", summary.PlayerCount())
+	}
+
+	fox, falco := summary.Player(0), summary.Player(1)
+	if fox.Port != 1 || fox.Character != "Fox" || fox.Deaths != 3 || fox.SelfDestructs != 1 {
+		t.Errorf("unexpected summary of Fox, %+v", fox)
+	}
+	if falco.Port != 2 || falco.Character != "Falco" || falco.Deaths != 4 || falco.AverageDeathPercent <= 0 {
+		t.Errorf("unexpected summary of Falco, %+v", falco)
+	}
+
+	s, err := summary.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded struct {
+		Players []PlayerSummary `json:"players"`
+	}
+	if err := json.Unmarshal([]byte(s), &decoded); err != nil || len(decoded.Players) != 2 {
+		t.Errorf("expected the JSON summary to include both players, got %s", s)
+	}
+}
+
+type countingHandler struct {
+	replays int
+	errors  int
+}
+
+func (h *countingHandler) OnReplay(path string, summary *Summary) bool {
+	h.replays++
+	return h.replays < 2
+}
+
+func (h *countingHandler) OnError(path string, err error) bool {
+	h.errors++
+	return true
+}
+
+func TestScanDirectory(t *testing.T) {
+	b, err := os.ReadFile("../game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	for _, name := range []string{"Game_1.slp", "Game_2.slp", "Game_3.slp"} {
+		if err := os.WriteFile(filepath.Join(dir, name), b, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	handler := &countingHandler{}
+	if err := ScanDirectory(dir, false, handler); err != nil {
+		t.Fatal(err)
+	}
+
+	if handler.replays != 2 || handler.errors != 0 {
+		t.Errorf("expected the scan to stop after 2 replays, got %+v", handler)
+	}
+}
@@ -4,14 +4,85 @@ import (
 	"errors"
 	"fmt"
 	"github.com/blang/semver/v4"
+	"maps"
 	"math"
+	"slices"
+	"sync"
 )
 
 const MaxRollbackFrames = 7
 
 // SlpParserOpts contains options that determine how a SlpParser behaves.
 type SlpParserOpts struct {
+	// Strict enables every check in StrictChecks.
 	Strict bool
+	// StrictChecks selects the validations that fail parsing. Violations of
+	// the other validations are collected as warnings instead.
+	StrictChecks StrictOpts
+	// TrackedPlayers restricts the players whose frame updates are stored
+	// and emitted to those with the given indices. All players are tracked
+	// if it is empty. Item updates are always kept, since items owned by
+	// untracked players may still interact with tracked ones.
+	TrackedPlayers []uint8
+	// DiscardFrames discards each frame once it has been sent to the
+	// FinalizedFrame handlers, instead of retaining every frame of the game,
+	// for callers that only need the frames as they are parsed. The latest
+	// frames are still kept until they are finalized, so that rollbacks can
+	// be applied to them.
+	DiscardFrames bool
+	// MaxRetainedFrames caps the number of finalized frames retained, if it
+	// is positive, by evicting the oldest finalized frame each time another
+	// is finalized, so that long-running live sessions use bounded memory.
+	// Frames that aren't finalized yet are always kept.
+	MaxRetainedFrames int
+	// Dispatch determines how events are delivered to handler channels. With
+	// the default, DispatchConcurrent, a handler may receive events out of
+	// order.
+	Dispatch DispatchMode
+	// RollbackRetention determines what is kept about rolled back frames.
+	// With the default, RetainRollbackFrames, every replaced version of each
+	// frame is kept, which can double the memory used by laggy online games.
+	RollbackRetention RollbackRetention
+	// Abort, if set, is called after each event is handled, and parsing
+	// stops with the error it returns if it isn't nil, such as once a
+	// handler has failed. It may be called from the reader's goroutine too,
+	// so it must be safe for concurrent use.
+	Abort func() error
+}
+
+// StrictOpts selects validations of a replay that fail parsing when violated.
+type StrictOpts struct {
+	// RequireBookendWindow requires the latest finalized frame of online
+	// games to be within MaxRollbackFrames of each frame bookend.
+	RequireBookendWindow bool
+	// RequireCompleteFrames requires each finalized frame to have pre- and
+	// post-frame updates for every tracked player, except where players may
+	// be absent.
+	RequireCompleteFrames bool
+}
+
+// strictChecks returns the validations that fail parsing.
+func (o SlpParserOpts) strictChecks() StrictOpts {
+	if o.Strict {
+		return StrictOpts{
+			RequireBookendWindow:  true,
+			RequireCompleteFrames: true,
+		}
+	}
+
+	return o.StrictChecks
+}
+
+// A ParseWarning is a violation of a validation that doesn't fail parsing.
+type ParseWarning struct {
+	Frame   int32
+	Code    SlpErrorCode
+	Message string
+}
+
+// Error implements the error interface.
+func (w ParseWarning) Error() string {
+	return w.Message
 }
 
 // FrameUpdateType enumerates the types of frame updates.
@@ -31,6 +102,7 @@ type FrameUpdates struct {
 
 // A FrameEntry contains all relevant updates from a given frame.
 type FrameEntry struct {
+	FrameNumber        int32
 	Players            map[uint8]FrameUpdates
 	Followers          map[uint8]FrameUpdates
 	Items              []ItemUpdatePayload
@@ -42,10 +114,26 @@ type GameInfo struct {
 	Version    semver.Version
 	Teams      bool
 	PAL        bool
-	Stage      uint16
+	Stage      StageID
+	FrozenPS   bool
 	Players    []PlayerInfo
-	MajorScene uint8
-	MinorScene uint8
+	MajorScene MajorScene
+	MinorScene MinorScene
+	// LocalPlayerIndex is the index of the player who recorded the game, or
+	// -1 if unknown. It is only set on game info returned by a SlpGame.
+	LocalPlayerIndex int8
+}
+
+// PlayersByPort returns the game's players keyed by the port they played on,
+// from 1 to 4. A player's port is always one more than the PlayerIndex of
+// their frame updates.
+func (g GameInfo) PlayersByPort() map[uint8]PlayerInfo {
+	players := make(map[uint8]PlayerInfo, len(g.Players))
+	for _, player := range g.Players {
+		players[player.Port] = player
+	}
+
+	return players
 }
 
 // ParserEvent enumerates events sent by a SlpParser
@@ -58,103 +146,219 @@ const (
 	FinalizedFrame
 	RollbackFrame
 	Ended
+	StockChanged
+	ActionStateChanged
+	PercentChanged
+)
+
+// RollbackRetention enumerates what a SlpParser keeps about rollbacks.
+type RollbackRetention uint8
+
+// RollbackRetentions
+const (
+	// RetainRollbackFrames keeps every replaced version of each frame, along
+	// with the rollback counts and lengths.
+	RetainRollbackFrames RollbackRetention = iota
+	// RetainRollbackCounts only keeps the rollback counts and lengths.
+	RetainRollbackCounts
+	// DiscardRollbacks keeps nothing about rollbacks. Replaced frames are
+	// still sent to the RollbackFrame handlers.
+	DiscardRollbacks
 )
 
-// Rollbacks tracks the rollbacks within a replay.
+// Rollbacks tracks the rollbacks within a replay. A frame is rolled back when
+// the game simulates it again, which replaces the version of the frame
+// received before.
 type Rollbacks struct {
-	Frames                map[int32][]FrameEntry
-	Count                 int
+	// Frames are the replaced versions of each rolled back frame, in the
+	// order they were received. The final version of each frame is kept
+	// with the rest of the frames.
+	Frames map[int32][]FrameEntry
+	// Count is the number of frame versions that were replaced.
+	Count int
+	// Lengths are the number of frames simulated again by each rollback.
 	Lengths               []int
-	playerIndex           int8
 	lastFrameWasRollback  bool
 	currentRollbackLength int
 }
 
-func (r *Rollbacks) checkIfRollbackFrame(frameIndex int32, frame *FrameEntry, playerIndex uint8) bool {
-	if r.playerIndex == -1 {
-		r.playerIndex = int8(playerIndex)
-	} else if r.playerIndex != int8(playerIndex) {
-		return false
+func newRollbacks() Rollbacks {
+	return Rollbacks{
+		Frames:                make(map[int32][]FrameEntry),
+		Count:                 0,
+		Lengths:               make([]int, 0),
+		lastFrameWasRollback:  false,
+		currentRollbackLength: 0,
 	}
+}
 
-	if frame != nil {
-		if frames, ok := r.Frames[frameIndex]; !ok {
-			r.Frames[frameIndex] = append(make([]FrameEntry, 0), *frame)
-		} else {
-			r.Frames[frameIndex] = append(frames, *frame)
-		}
-		r.Count++
-		r.currentRollbackLength++
-		r.lastFrameWasRollback = true
-	} else if r.lastFrameWasRollback {
+// VersionCount returns the number of versions of the frame with the given
+// number that were received before the final one.
+func (r *Rollbacks) VersionCount(frameNumber int32) int {
+	return len(r.Frames[frameNumber])
+}
+
+// replace records that frame, which was received before, is being simulated
+// again, keeping what retention allows.
+func (r *Rollbacks) replace(frame FrameEntry, retention RollbackRetention) {
+	if retention == DiscardRollbacks {
+		return
+	} else if retention == RetainRollbackFrames {
+		r.Frames[frame.FrameNumber] = append(r.Frames[frame.FrameNumber], frame)
+	}
+	r.Count++
+	r.currentRollbackLength++
+	r.lastFrameWasRollback = true
+}
+
+// advance records that a frame that wasn't received before is being
+// simulated, which ends the rollback in progress, if any.
+func (r *Rollbacks) advance() {
+	if r.lastFrameWasRollback {
 		r.Lengths = append(r.Lengths, r.currentRollbackLength)
 		r.currentRollbackLength = 0
 		r.lastFrameWasRollback = false
 	}
-
-	return r.lastFrameWasRollback
 }
 
 // A SlpParser parses a replay into frames.
 type SlpParser struct {
-	Options            SlpParserOpts
-	Frames             map[int32]FrameEntry
-	Rollbacks          Rollbacks
-	gameInfo           *GameInfo
-	GameEnd            *GameEndPayload
-	handlers           map[ParserEvent][]chan interface{}
+	Options   SlpParserOpts
+	Frames    *FrameStore
+	Rollbacks Rollbacks
+	// Warnings are the violations of validations that don't fail parsing,
+	// in the order they were found.
+	Warnings []ParseWarning
+	// infoMu guards gameInfo and gameInfoComplete, which GetGameInfo reads,
+	// such as from the reader while the parser is parsing
+	infoMu   sync.Mutex
+	gameInfo *GameInfo
+	GameEnd  *GameEndPayload
+	// handlersMu guards the handler state below, which subscriptions may
+	// change while the parser is parsing. handlers, filters and stops are
+	// replaced rather than changed in place, so that Trigger can deliver to
+	// them without holding the lock
+	handlersMu sync.Mutex
+	handlers   map[ParserEvent][]chan interface{}
+	// filters are the predicates payloads must match to be sent to the
+	// handlers that have one
+	filters map[ParserEvent]map[chan interface{}]func(interface{}) bool
+	// stops are closed when the subscription of the handler they belong to
+	// ends, which abandons the deliveries to it still in flight
+	stops              map[chan interface{}]chan struct{}
+	queues             map[chan interface{}]*handlerQueue
+	drainingQueues     map[chan interface{}]*handlerQueue
 	latestFrameIndex   int32
 	lastFinalizedFrame int32
 	gameInfoComplete   bool
+	// versionFrame is the number of the frame whose version is being
+	// received
+	versionFrame int32
+	// eliminated are the indices of the players who have lost all of their
+	// stocks as of the last finalized frame
+	eliminated map[uint8]bool
+	// lastPosts are the post-frame updates of each player on the last
+	// finalized frame they were in
+	lastPosts map[uint8]PostFrameUpdatePayload
+	// lastFrame is the last finalized frame
+	lastFrame FrameEntry
+	// items tracks the lifecycles of the items in the finalized frames
+	items *itemTracker
 }
 
 // NewSlpParser creates a new SlpParser with the given SlpParserOpts.
 func NewSlpParser(options SlpParserOpts) *SlpParser {
 	return &SlpParser{
 		Options:            options,
-		Frames:             make(map[int32]FrameEntry),
+		Frames:             NewFrameStore(),
 		gameInfo:           nil,
 		GameEnd:            nil,
 		handlers:           make(map[ParserEvent][]chan interface{}),
+		filters:            make(map[ParserEvent]map[chan interface{}]func(interface{}) bool),
+		stops:              make(map[chan interface{}]chan struct{}),
+		queues:             make(map[chan interface{}]*handlerQueue),
+		drainingQueues:     make(map[chan interface{}]*handlerQueue),
 		latestFrameIndex:   -124,
 		lastFinalizedFrame: -124,
 		gameInfoComplete:   false,
-		Rollbacks: Rollbacks{
-			Frames:                make(map[int32][]FrameEntry),
-			Count:                 0,
-			Lengths:               make([]int, 0),
-			playerIndex:           -1,
-			lastFrameWasRollback:  false,
-			currentRollbackLength: 0,
-		},
+		versionFrame:       -124,
+		eliminated:         make(map[uint8]bool),
+		lastPosts:          make(map[uint8]PostFrameUpdatePayload),
+		items:              newItemTracker(),
+		Rollbacks:          newRollbacks(),
+		Warnings:           make([]ParseWarning, 0),
 	}
 }
 
 // Reset resets the SlpParser's state. This does not reset parser options or
 // remove event handler channels.
 func (p *SlpParser) Reset() {
-	p.Frames = make(map[int32]FrameEntry)
-	p.gameInfo = nil
+	p.Frames = NewFrameStore()
+	p.setGameInfo(nil, false)
 	p.GameEnd = nil
 	p.latestFrameIndex = -124
 	p.lastFinalizedFrame = -124
-	p.gameInfoComplete = false
-	p.Rollbacks = Rollbacks{
-		Frames:                make(map[int32][]FrameEntry),
-		Count:                 0,
-		Lengths:               make([]int, 0),
-		playerIndex:           -1,
-		lastFrameWasRollback:  false,
-		currentRollbackLength: 0,
+	p.versionFrame = -124
+	p.eliminated = make(map[uint8]bool)
+	p.lastPosts = make(map[uint8]PostFrameUpdatePayload)
+	p.lastFrame = FrameEntry{}
+	p.items = newItemTracker()
+	p.Rollbacks = newRollbacks()
+	p.Warnings = make([]ParseWarning, 0)
+}
+
+// triggerChanges triggers the events for the changes in the state of each
+// player between the last finalized frame and the newly finalized frame.
+func (p *SlpParser) triggerChanges(frame FrameEntry) {
+	for _, player := range p.gameInfo.Players {
+		updates, ok := frame.Players[player.Index]
+		if !ok || updates.Post == nil {
+			continue
+		}
+
+		post := *updates.Post
+		last, ok := p.lastPosts[player.Index]
+		p.lastPosts[player.Index] = post
+		if !ok {
+			continue
+		}
+
+		if change, ok := p.stockChange(frame, player.Index, last, post); ok {
+			p.Trigger(StockChanged, change)
+		}
+		if post.ActionStateID != last.ActionStateID {
+			p.Trigger(ActionStateChanged, ActionStateChange{
+				PlayerIndex:   player.Index,
+				Frame:         frame.FrameNumber,
+				PreviousState: last.ActionStateID,
+				State:         post.ActionStateID,
+			})
+		}
+		if post.Percent > last.Percent && !IsDead(post.ActionStateID) {
+			p.Trigger(PercentChanged, p.percentChange(frame, player.Index, last, post))
+		}
 	}
+
+	p.lastFrame = frame
+}
+
+// violate reports that a validation failed for the frame with the given
+// number, which fails parsing if the check is fatal.
+func (p *SlpParser) violate(fatal bool, frameNumber int32, code SlpErrorCode, message string) error {
+	if fatal {
+		return withCode(code, errors.New(message))
+	}
+
+	p.Warnings = append(p.Warnings, ParseWarning{Frame: frameNumber, Code: code, Message: message})
+	return nil
 }
 
 // GetPlayableFrameCount returns the number of playable frames parsed so far.
 func (p *SlpParser) GetPlayableFrameCount() int32 {
-	if p.latestFrameIndex < -39 {
+	if p.latestFrameIndex < FirstPlayableFrame {
 		return 0
 	}
-	return p.latestFrameIndex + 39
+	return p.latestFrameIndex - FirstPlayableFrame
 }
 
 // GetLatestFrame gets the latest frame parsed by the SlpParser.
@@ -164,7 +368,7 @@ func (p *SlpParser) GetLatestFrame() *FrameEntry {
 		frameIndex -= 1
 	}
 
-	frame := p.Frames[frameIndex]
+	frame, _ := p.Frames.Get(frameIndex)
 
 	return &frame
 }
@@ -172,6 +376,9 @@ func (p *SlpParser) GetLatestFrame() *FrameEntry {
 // GetGameInfo gets the current parsed game info, as well as a boolean indicating
 // if the full game info has been parsed yet.
 func (p *SlpParser) GetGameInfo() (*GameInfo, bool) {
+	p.infoMu.Lock()
+	defer p.infoMu.Unlock()
+
 	if p.gameInfo == nil {
 		return nil, p.gameInfoComplete
 	} else {
@@ -181,46 +388,133 @@ func (p *SlpParser) GetGameInfo() (*GameInfo, bool) {
 
 // AddHandler attaches an event handler channel to a ParseEvent.
 func (p *SlpParser) AddHandler(event ParserEvent, handler chan interface{}) {
-	handlers, ok := p.handlers[event]
-	if !ok {
-		handlers = make([]chan interface{}, 0)
+	p.handlersMu.Lock()
+	defer p.handlersMu.Unlock()
+
+	p.addHandler(event, handler, nil)
+}
+
+// AddFilteredHandler adds an event handler channel to a ParserEvent, which is
+// only sent the payloads for which filter returns true.
+func (p *SlpParser) AddFilteredHandler(event ParserEvent, handler chan interface{}, filter func(interface{}) bool) {
+	p.handlersMu.Lock()
+	defer p.handlersMu.Unlock()
+
+	p.addHandler(event, handler, filter)
+}
+
+// addHandler attaches handler to event, with filter if it isn't nil. The
+// caller must hold p.handlersMu.
+func (p *SlpParser) addHandler(event ParserEvent, handler chan interface{}, filter func(interface{}) bool) {
+	if filter != nil {
+		filters := maps.Clone(p.filters[event])
+		if filters == nil {
+			filters = make(map[chan interface{}]func(interface{}) bool)
+		}
+		filters[handler] = filter
+		p.filters[event] = filters
 	}
 
-	p.handlers[event] = append(handlers, handler)
+	p.handlers[event] = append(slices.Clip(p.handlers[event]), handler)
 }
 
 // RemoveHandler removes an event handler channel from a ParseEvent.
 func (p *SlpParser) RemoveHandler(event ParserEvent, toRemove chan interface{}) {
+	p.handlersMu.Lock()
+	defer p.handlersMu.Unlock()
+
+	p.removeHandler(event, toRemove)
+}
+
+// removeHandler removes handler from event. The caller must hold
+// p.handlersMu.
+func (p *SlpParser) removeHandler(event ParserEvent, toRemove chan interface{}) {
 	if handlers, ok := p.handlers[event]; ok {
-		for i, handler := range handlers {
-			if handler == toRemove {
-				p.handlers[event] = append(p.handlers[event][:i], p.handlers[event][i+1:]...)
-			}
-		}
+		p.handlers[event] = slices.DeleteFunc(slices.Clone(handlers), func(handler chan interface{}) bool {
+			return handler == toRemove
+		})
+	}
+	if _, ok := p.filters[event][toRemove]; ok {
+		filters := maps.Clone(p.filters[event])
+		delete(filters, toRemove)
+		p.filters[event] = filters
 	}
 }
 
 // RemoveAllHandlers removes all event handler channels from a ParseEvent.
 func (p *SlpParser) RemoveAllHandlers(event ParserEvent) {
+	p.handlersMu.Lock()
+	defer p.handlersMu.Unlock()
+
 	p.handlers[event] = nil
+	delete(p.filters, event)
+}
+
+// subscribe attaches handler to event, with filter if it isn't nil, until
+// unsubscribe is called with it. Unlike handlers added with AddHandler, the
+// deliveries to it still in flight are then abandoned, so that nothing is
+// left waiting to send to it.
+func (p *SlpParser) subscribe(event ParserEvent, handler chan interface{}, filter func(interface{}) bool) <-chan struct{} {
+	p.handlersMu.Lock()
+	defer p.handlersMu.Unlock()
+
+	stop := make(chan struct{})
+	stops := maps.Clone(p.stops)
+	stops[handler] = stop
+	p.stops = stops
+	p.addHandler(event, handler, filter)
+
+	return stop
+}
+
+// unsubscribe removes handler, which was attached by subscribe, from event,
+// and abandons the deliveries to it still in flight.
+func (p *SlpParser) unsubscribe(event ParserEvent, handler chan interface{}) {
+	p.handlersMu.Lock()
+	defer p.handlersMu.Unlock()
+
+	p.removeHandler(event, handler)
+	if stop, ok := p.stops[handler]; ok {
+		close(stop)
+		stops := maps.Clone(p.stops)
+		delete(stops, handler)
+		p.stops = stops
+	}
+	if q, ok := p.queues[handler]; ok {
+		close(q.in)
+		delete(p.queues, handler)
+	}
 }
 
 // Trigger triggers the given ParserEvent with the given payload, sending it to
-// all attached handler channels.
+// all attached handler channels according to the parser's dispatch mode.
 func (p *SlpParser) Trigger(event ParserEvent, payload interface{}) {
-	if handlers, ok := p.handlers[event]; ok {
-		for _, handler := range handlers {
-			h := handler
-			go func() {
-				h <- payload
-			}()
+	p.handlersMu.Lock()
+	handlers, filters, stops := p.handlers[event], p.filters[event], p.stops
+	p.handlersMu.Unlock()
+
+	for _, handler := range handlers {
+		if filter, ok := filters[handler]; ok && !filter(payload) {
+			continue
 		}
+		p.dispatch(handler, payload, stops[handler])
 	}
 }
 
 // ParseReplay processes events from the given SlpEventResult channel and updates
 // the SlpParser's state accordingly.
 func (p *SlpParser) ParseReplay(eventResults <-chan *SlpEventResult) error {
+	defer p.closeQueues()
+
+	return p.ParseMore(eventResults)
+}
+
+// ParseMore processes events from the given SlpEventResult channel, continuing
+// from the SlpParser's existing state, so that the events of a replay can be
+// parsed in several batches as they become available, such as from a live
+// replay or a connection. Unlike ParseReplay, the handler queues are kept
+// open after the channel closes, until the game ends.
+func (p *SlpParser) ParseMore(eventResults <-chan *SlpEventResult) error {
 	for eventResult := range eventResults {
 		if eventResult.Error != nil {
 			flushChannel(eventResults)
@@ -232,30 +526,47 @@ func (p *SlpParser) ParseReplay(eventResults <-chan *SlpEventResult) error {
 		err := p.handleEvent(event)
 		if err != nil {
 			flushChannel(eventResults)
-			return err
+			return p.parseError(event, err)
+		}
+
+		if p.Options.Abort != nil {
+			if err := p.Options.Abort(); err != nil {
+				flushChannel(eventResults)
+				return err
+			}
 		}
 	}
 
+	if p.GameEnd != nil {
+		p.closeQueues()
+	}
+
 	return nil
 }
 
 func flushChannel(channel <-chan *SlpEventResult) {
-	for {
-		select {
-		case _, ok := <-channel:
-			if !ok {
-				return
-			}
-		default:
-		}
+	for range channel {
 	}
 }
 
+// parseError attaches the position of event to the error that occurred
+// handling it.
+func (p *SlpParser) parseError(event SlpEvent, err error) error {
+	frame, ok := eventFrame(event)
+	if !ok {
+		frame = p.latestFrameIndex
+	}
+
+	return parseError(err, event.Command, frame, event.Offset)
+}
+
 func (p *SlpParser) handleEvent(event SlpEvent) error {
 	var err error = nil
 	switch event.Command {
 	case GameStart:
 		p.handleGameStart(event.Payload.(GameStartPayload))
+	case FrameStart:
+		p.beginFrameVersion(event.Payload.(FrameStartPayload).FrameNumber, true)
 	case PreFrameUpdate:
 		err = p.handleFrameUpdate(Pre, event.Payload.(PreFrameUpdatePayload))
 	case PostFrameUpdate:
@@ -282,15 +593,17 @@ func (p *SlpParser) handleGameStart(payload GameStartPayload) {
 	}
 
 	// set game info
-	p.gameInfo = &GameInfo{
-		Version:    payload.Version,
-		Teams:      payload.GameInfoBlock.IsTeams,
-		PAL:        payload.PAL,
-		Stage:      payload.GameInfoBlock.Stage,
-		Players:    players,
-		MajorScene: payload.MajorScene,
-		MinorScene: payload.MinorScene,
-	}
+	p.setGameInfo(&GameInfo{
+		Version:          payload.Version,
+		Teams:            payload.GameInfoBlock.IsTeams,
+		PAL:              payload.PAL,
+		Stage:            payload.GameInfoBlock.Stage,
+		FrozenPS:         payload.FrozenPS,
+		Players:          players,
+		MajorScene:       payload.MajorScene,
+		MinorScene:       payload.MinorScene,
+		LocalPlayerIndex: -1,
+	}, false)
 
 	if payload.Version.GTE(semver.MustParse("1.6.0")) {
 		p.completeGameInfo()
@@ -303,15 +616,14 @@ func (p *SlpParser) handleFrameUpdate(updateType FrameUpdateType, payload FrameU
 	isFollower := frameUpdate.IsFollower
 	playerIndex := frameUpdate.PlayerIndex
 
+	if !p.isTracked(playerIndex) {
+		return nil
+	}
+
+	p.beginFrameVersion(frameNumber, false)
 	frame := p.getFrame(frameNumber)
 
 	p.latestFrameIndex = frameNumber
-	if updateType == Pre && !isFollower {
-		currentFrame := p.Frames[frameNumber]
-		if p.Rollbacks.checkIfRollbackFrame(frameNumber, &currentFrame, playerIndex) {
-			p.Trigger(RollbackFrame, currentFrame)
-		}
-	}
 
 	// add frame update to followers or players
 	if isFollower {
@@ -352,23 +664,46 @@ func (p *SlpParser) handleFrameUpdate(updateType FrameUpdateType, payload FrameU
 		frame.Players[playerIndex] = player
 	}
 
-	p.Frames[frameNumber] = frame
+	p.Frames.Set(frameNumber, frame)
 
 	// emit frame here if file is from before frame bookending existed
 	if p.gameInfo != nil && p.gameInfo.Version.LTE(semver.MustParse("2.2.0")) {
-		p.Trigger(Frame, p.Frames[frameNumber])
+		p.Trigger(Frame, frame)
 		err := p.finalizeFrames(frameNumber - 1)
 		if err != nil {
 			return err
 		}
 	} else {
 		frame.IsTransferComplete = false
-		p.Frames[frameNumber] = frame
+		p.Frames.Set(frameNumber, frame)
 	}
 
 	return nil
 }
 
+// beginFrameVersion is called with the number of the frame each event
+// received belongs to. Replays from 2.2.0 on mark the start of every version
+// of a frame with a FrameStart event, for which started is true, and older
+// replays start a new version with the first update for a frame other than
+// the one being received. If a version of the frame was received before, it is
+// replaced by the new version, which is built from scratch.
+func (p *SlpParser) beginFrameVersion(frameNumber int32, started bool) {
+	if !started && frameNumber == p.versionFrame {
+		return
+	}
+	p.versionFrame = frameNumber
+
+	previous, ok := p.Frames.Get(frameNumber)
+	if !ok {
+		p.Rollbacks.advance()
+		return
+	}
+
+	p.Rollbacks.replace(previous, p.Options.RollbackRetention)
+	p.Frames.Delete(frameNumber)
+	p.Trigger(RollbackFrame, previous)
+}
+
 func (p *SlpParser) handlePostFrameUpdate(payload PostFrameUpdatePayload) error {
 	err := p.handleFrameUpdate(Post, payload)
 	if err != nil {
@@ -384,9 +719,9 @@ func (p *SlpParser) handlePostFrameUpdate(payload PostFrameUpdatePayload) error
 			if player.Index == payload.PlayerIndex {
 				switch payload.InternalCharacterID {
 				case 0x7:
-					p.gameInfo.Players[i].CharacterID = 0x13
+					p.gameInfo.Players[i].CharacterID = Sheik
 				case 0x13:
-					p.gameInfo.Players[i].CharacterID = 0x12
+					p.gameInfo.Players[i].CharacterID = Zelda
 				}
 			}
 		}
@@ -413,10 +748,11 @@ func (p *SlpParser) handleGameEnd(payload GameEndPayload) error {
 }
 
 func (p *SlpParser) handleItemUpdate(payload ItemUpdatePayload) {
+	p.beginFrameVersion(payload.FrameNumber, false)
 	frame := p.getFrame(payload.FrameNumber)
 
 	frame.Items = append(frame.Items, payload)
-	p.Frames[payload.FrameNumber] = frame
+	p.Frames.Set(payload.FrameNumber, frame)
 }
 
 func (p *SlpParser) handleFrameBookend(payload FrameBookendPayload) error {
@@ -425,15 +761,18 @@ func (p *SlpParser) handleFrameBookend(payload FrameBookendPayload) error {
 	frame := p.getFrame(frameNumber)
 
 	frame.IsTransferComplete = true
-	p.Frames[frameNumber] = frame
+	p.Frames.Set(frameNumber, frame)
 
 	p.Trigger(Frame, frame)
 
-	validLatestFrame := p.gameInfo.MajorScene == 0x8
+	validLatestFrame := p.gameInfo.MajorScene == SceneOnline
 	var err error = nil
 	if validLatestFrame && latestFinalizedFrame >= -123 {
-		if p.Options.Strict && latestFinalizedFrame < frameNumber-MaxRollbackFrames {
-			return errors.New(fmt.Sprintf("latestFinalizedFrame should be within %d frames of %d", MaxRollbackFrames, frameNumber))
+		if latestFinalizedFrame < frameNumber-MaxRollbackFrames {
+			message := fmt.Sprintf("latestFinalizedFrame should be within %d frames of %d", MaxRollbackFrames, frameNumber)
+			if err := p.violate(p.Options.strictChecks().RequireBookendWindow, frameNumber, CodeBookendWindow, message); err != nil {
+				return err
+			}
 		}
 		err = p.finalizeFrames(latestFinalizedFrame)
 	} else {
@@ -446,36 +785,65 @@ func (p *SlpParser) handleFrameBookend(payload FrameBookendPayload) error {
 func (p *SlpParser) finalizeFrames(frameNumber int32) error {
 	for p.lastFinalizedFrame < frameNumber {
 		toFinalize := p.lastFinalizedFrame + 1
-		frame, ok := p.Frames[toFinalize]
+		frame, ok := p.Frames.Get(toFinalize)
 		if !ok {
 			return nil
 		}
 
-		if p.Options.Strict {
-			for _, player := range p.gameInfo.Players {
-				playerFrameInfo, ok := frame.Players[player.Index]
+		fatal := p.Options.strictChecks().RequireCompleteFrames
+		for _, player := range p.gameInfo.Players {
+			if !p.isTracked(player.Index) {
+				continue
+			}
+
+			playerFrameInfo, ok := frame.Players[player.Index]
 
-				if !ok {
-					if len(p.gameInfo.Players) > 2 {
-						continue
-					}
+			message := ""
+			if !ok {
+				// players are absent from frames once they have been
+				// eliminated, and in single player modes, where opponents
+				// such as Sandbag aren't always present
+				if p.eliminated[player.Index] || p.gameInfo.GameMode().IsSinglePlayer() {
+					continue
+				}
 
-					return errors.New(fmt.Sprintf("could not finalize frame %d of %d: missing pre-frame update for player %d", toFinalize, frameNumber, player.Index))
+				message = fmt.Sprintf("could not finalize frame %d of %d: missing pre-frame update for player %d", toFinalize, frameNumber, player.Index)
+			} else if playerFrameInfo.Pre == nil || playerFrameInfo.Post == nil {
+				missing := "pre"
+				if playerFrameInfo.Pre != nil {
+					missing = "post"
 				}
 
-				if playerFrameInfo.Pre == nil || playerFrameInfo.Post == nil {
-					missing := "pre"
-					if playerFrameInfo.Pre != nil {
-						missing = "post"
-					}
+				message = fmt.Sprintf("could not finalize frame %d of %d: missing %s-frame update for player %d", toFinalize, frameNumber, missing, player.Index)
+			}
 
-					return errors.New(fmt.Sprintf("could not finalize frame %d of %d: missing %s-frame update for player %d", toFinalize, frameNumber, missing, player.Index))
+			if message != "" {
+				if err := p.violate(fatal, toFinalize, CodeTruncatedFrame, message); err != nil {
+					return err
 				}
 			}
+
+			if ok && playerFrameInfo.Post != nil && playerFrameInfo.Post.StocksRemaining == 0 {
+				p.eliminated[player.Index] = true
+			}
 		}
 
+		if !p.gameInfo.Has(ItemUpdate, "Owner") {
+			p.items.inferOwners(p.gameInfo, frame)
+		}
 		p.Trigger(FinalizedFrame, frame)
+		p.triggerChanges(frame)
+		p.items.processFrame(frame)
 		p.lastFinalizedFrame = toFinalize
+
+		if p.Options.DiscardFrames {
+			p.Frames.Delete(toFinalize)
+			delete(p.Rollbacks.Frames, toFinalize)
+		} else if p.Options.MaxRetainedFrames > 0 {
+			evicted := toFinalize - int32(p.Options.MaxRetainedFrames)
+			p.Frames.Delete(evicted)
+			delete(p.Rollbacks.Frames, evicted)
+		}
 	}
 
 	return nil
@@ -486,14 +854,65 @@ func (p *SlpParser) completeGameInfo() {
 		return
 	}
 
-	p.gameInfoComplete = true
+	p.setGameInfo(p.gameInfo, true)
 	p.Trigger(Started, p.gameInfo)
 }
 
+// setGameInfo sets the game info, and whether all of it has been parsed.
+func (p *SlpParser) setGameInfo(gameInfo *GameInfo, complete bool) {
+	p.infoMu.Lock()
+	defer p.infoMu.Unlock()
+
+	p.gameInfo = gameInfo
+	p.gameInfoComplete = complete
+}
+
+// isTracked returns whether frame updates for the player with the given index
+// should be kept.
+func (p *SlpParser) isTracked(playerIndex uint8) bool {
+	if len(p.Options.TrackedPlayers) == 0 {
+		return true
+	}
+
+	for _, index := range p.Options.TrackedPlayers {
+		if index == playerIndex {
+			return true
+		}
+	}
+
+	return false
+}
+
+// snapshotFrames returns a copy of the parser's frames that parsing more
+// events doesn't change. Finalized frames are never changed again, but the
+// updates and items of the frames after them are still added to, so those are
+// copied too.
+func (p *SlpParser) snapshotFrames() *FrameStore {
+	frames := p.Frames.Clone()
+	for frameNumber := max(p.lastFinalizedFrame+1, FirstFrame); frameNumber <= p.latestFrameIndex; frameNumber++ {
+		if frame := frames.FrameAt(frameNumber); frame != nil {
+			*frame = cloneFrame(*frame)
+		}
+	}
+
+	return frames
+}
+
+// cloneFrame returns a copy of frame whose updates and items can be changed
+// without changing those of frame.
+func cloneFrame(frame FrameEntry) FrameEntry {
+	frame.Players = maps.Clone(frame.Players)
+	frame.Followers = maps.Clone(frame.Followers)
+	frame.Items = slices.Clone(frame.Items)
+
+	return frame
+}
+
 func (p *SlpParser) getFrame(frameNumber int32) FrameEntry {
-	frame, ok := p.Frames[frameNumber]
+	frame, ok := p.Frames.Get(frameNumber)
 	if !ok {
 		frame = FrameEntry{
+			FrameNumber:        frameNumber,
 			Players:            make(map[uint8]FrameUpdates, 0),
 			Followers:          make(map[uint8]FrameUpdates, 0),
 			Items:              make([]ItemUpdatePayload, 0),
@@ -1 +1,478 @@
 package slippi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTrackedPlayers(t *testing.T) {
+	f, err := os.Open("game.slp")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	game, err := NewSlpGameFromFile(f, nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer game.Close()
+
+	game.TrackPlayers(1)
+
+	frames, err := game.GetFrames()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	for frameNumber, frame := range frames {
+		if _, ok := frame.Players[1]; !ok || len(frame.Players) != 1 {
+			t.Errorf("expected only player 1 on frame %d, got %d players", frameNumber, len(frame.Players))
+			return
+		}
+	}
+
+	gameInfo, err := game.GetGameInfo()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(gameInfo.Players) != 2 {
+		t.Errorf("expected game info for 2 players, got %d", len(gameInfo.Players))
+	}
+}
+
+func TestDiscardFrames(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game, err := NewSlpGameFromBytes(b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer game.Close()
+
+	frames, err := game.GetFrames()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	finalized := 0
+	counter := NewCalculator("counter", func(event ParserEvent, payload interface{}) {
+		mu.Lock()
+		finalized++
+		mu.Unlock()
+	}, FinalizedFrame)
+	defer counter.Close()
+
+	game.AddCalculator(counter)
+	game.SetDiscardFrames(true)
+
+	store, err := game.GetFrameStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if store.FrameCount() != 0 || len(game.parser.Rollbacks.Frames) != 0 {
+		t.Errorf("expected no frames to be retained, got %d", store.FrameCount())
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		mu.Lock()
+		done := finalized == len(frames)
+		mu.Unlock()
+		if done {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if finalized != len(frames) {
+		t.Errorf("expected %d finalized frames, got %d", len(frames), finalized)
+	}
+}
+
+func TestMaxRetainedFrames(t *testing.T) {
+	replay, err := SyntheticReplay(SyntheticReplayOpts{Frames: 1000, RollbackEvery: 10, RollbackLength: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game, err := NewSlpGameFromBytes(replay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer game.Close()
+
+	game.SetMaxRetainedFrames(100)
+	frames, err := game.GetFrames()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the game end finalizes every frame, leaving the last 100
+	last := FirstFrame + 999
+	if len(frames) != 100 {
+		t.Errorf("expected 100 frames, got %d", len(frames))
+	}
+	if _, ok := frames[last-100]; ok {
+		t.Errorf("expected frame %d to be evicted", last-100)
+	}
+	if _, ok := frames[last-99]; !ok {
+		t.Errorf("expected frame %d to be retained", last-99)
+	}
+
+	for frameNumber := range game.parser.Rollbacks.Frames {
+		if frameNumber <= last-100 {
+			t.Errorf("expected versions of evicted frame %d to be evicted", frameNumber)
+		}
+	}
+}
+
+func TestRollbacks(t *testing.T) {
+	replay, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// simulate frames 100 and 101 again after frame 101, as a rollback
+	// would, optionally without the FrameStart events of newer replays
+	rollBack := func(withFrameStart bool) []byte {
+		resimulated := make([]byte, 0)
+		return rewriteEvents(t, replay, func(event []byte) []byte {
+			command := Command(event[0])
+			if command == FrameStart && !withFrameStart {
+				return []byte{}
+			}
+
+			switch command {
+			case FrameStart, PreFrameUpdate, PostFrameUpdate, ItemUpdate, FrameBookend:
+			default:
+				return event
+			}
+
+			frameNumber := int32(binary.BigEndian.Uint32(event[1:5]))
+			if frameNumber != 100 && frameNumber != 101 {
+				return event
+			}
+
+			resimulated = append(resimulated, event...)
+			if frameNumber == 101 && command == FrameBookend {
+				return append(append([]byte{}, event...), resimulated...)
+			}
+			return event
+		})
+	}
+
+	// replays with FrameStart events also show the frame the fixture
+	// simulates twice in a row
+	for _, withFrameStart := range []bool{true, false} {
+		game, err := NewSlpGameFromBytes(rollBack(withFrameStart), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		frames, err := game.GetFrames()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rollbacks := game.parser.Rollbacks
+		expectedCount, expectedLengths := 2, []int{2}
+		if withFrameStart {
+			expectedCount, expectedLengths = 3, []int{2, 1}
+		}
+		if rollbacks.Count != expectedCount || !slices.Equal(rollbacks.Lengths, expectedLengths) {
+			t.Errorf("with FrameStart %v: expected %d rolled back frames in rollbacks %v, got %d in %v", withFrameStart, expectedCount, expectedLengths, rollbacks.Count, rollbacks.Lengths)
+		}
+
+		versions, err := game.GetFrameVersions(100)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(versions) != 2 || rollbacks.VersionCount(100) != 1 || len(frames) != 12343 {
+			t.Errorf("expected 2 versions of frame 100 and 12343 frames, got %d and %d", len(versions), len(frames))
+		}
+
+		// versions are built from scratch, rather than on top of the
+		// version they replace
+		for _, version := range versions {
+			if len(version.Players) != 2 || len(version.Items) != len(versions[0].Items) {
+				t.Errorf("expected each version of frame 100 to be complete, got %+v", version)
+			}
+		}
+
+		game.Close()
+	}
+}
+
+func TestRollbackRetention(t *testing.T) {
+	replay, err := SyntheticReplay(SyntheticReplayOpts{Frames: 600, RollbackEvery: 20, RollbackLength: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, retention := range []RollbackRetention{RetainRollbackFrames, RetainRollbackCounts, DiscardRollbacks} {
+		game, err := NewSlpGameFromBytes(replay, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		game.SetRollbackRetention(retention)
+
+		frames, err := game.GetFrames()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rollbacks := game.parser.Rollbacks
+		expectedCount, expectedFrames := 29*4, 29*4
+		switch retention {
+		case RetainRollbackCounts:
+			expectedFrames = 0
+		case DiscardRollbacks:
+			expectedCount, expectedFrames = 0, 0
+		}
+
+		if len(frames) != 600 || rollbacks.Count != expectedCount || len(rollbacks.Lengths) != expectedCount/4 || len(rollbacks.Frames) != expectedFrames {
+			t.Errorf("retention %d: expected 600 frames and %d rolled back frames with %d versions retained, got %d, %d and %d", retention, expectedCount, expectedFrames, len(frames), rollbacks.Count, len(rollbacks.Frames))
+		}
+
+		game.Close()
+	}
+}
+
+// rewriteEvents returns a copy of the replay with each raw event passed
+// through rewrite, which returns the event's new bytes or nil to drop it.
+func rewriteEvents(t *testing.T, replay []byte, rewrite func(event []byte) []byte) []byte {
+	raw := replay[15 : 15+int(binary.BigEndian.Uint32(replay[11:15]))]
+
+	payloadSizes := map[byte]int{raw[0]: int(raw[1])}
+	for position := 2; position < int(raw[1]); position += 3 {
+		payloadSizes[raw[position]] = int(binary.BigEndian.Uint16(raw[position+1 : position+3]))
+	}
+
+	var b bytes.Buffer
+	writer := NewSlpWriter(&b)
+	for position := 0; position < len(raw); {
+		size, ok := payloadSizes[raw[position]]
+		if !ok {
+			t.Fatalf("unknown command 0x%X", raw[position])
+		}
+
+		event := append([]byte{}, raw[position:position+size+1]...)
+		if rewritten := rewrite(event); rewritten != nil {
+			writer.Write(rewritten)
+		}
+		position += size + 1
+	}
+
+	err := writer.Finish(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b.Bytes()
+}
+
+func TestStrictChecks(t *testing.T) {
+	replay, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// drop Falco's post-frame update on frame 50, and report a latest
+	// finalized frame too far behind frame 200
+	replay = rewriteEvents(t, replay, func(event []byte) []byte {
+		switch Command(event[0]) {
+		case PostFrameUpdate:
+			if binary.BigEndian.Uint32(event[1:5]) == 50 && event[5] == 1 {
+				return nil
+			}
+		case FrameBookend:
+			if binary.BigEndian.Uint32(event[1:5]) == 200 {
+				binary.BigEndian.PutUint32(event[5:9], 100)
+			}
+		}
+
+		return event
+	})
+
+	cases := []struct {
+		checks   StrictOpts
+		failed   bool
+		warnings int
+	}{
+		{StrictOpts{}, false, 2},
+		{StrictOpts{RequireBookendWindow: true}, true, 1},
+		{StrictOpts{RequireCompleteFrames: true}, true, 0},
+	}
+
+	for _, c := range cases {
+		game, err := NewSlpGameFromBytes(replay, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		game.SetStrictChecks(c.checks)
+
+		_, err = game.GetFrames()
+		if (err != nil) != c.failed {
+			t.Errorf("checks %+v: expected failure %t, got %v", c.checks, c.failed, err)
+		}
+
+		if len(game.parser.Warnings) != c.warnings {
+			t.Errorf("checks %+v: expected %d warnings, got %v", c.checks, c.warnings, game.parser.Warnings)
+		}
+
+		game.Close()
+	}
+}
+
+func TestStrictEliminatedPlayers(t *testing.T) {
+	replay, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// add a third player, a copy of Falco in port 3, who leaves the game after
+	// frame 500, optionally having lost all of their stocks
+	threePlayers := func(eliminated bool) []byte {
+		return rewriteEvents(t, replay, func(event []byte) []byte {
+			switch Command(event[0]) {
+			case GameStart:
+				copy(event[1+0x64+0x24*2:1+0x64+0x24*3], event[1+0x64+0x24:1+0x64+0x24*2])
+			case PreFrameUpdate, PostFrameUpdate:
+				frameNumber := int32(binary.BigEndian.Uint32(event[1:5]))
+				if event[5] != 1 || frameNumber > 500 {
+					return event
+				}
+
+				third := append([]byte{}, event...)
+				third[5] = 2
+				if Command(event[0]) == PostFrameUpdate && eliminated && frameNumber == 500 {
+					third[1+0x20] = 0
+				}
+				return append(append([]byte{}, event...), third...)
+			}
+
+			return event
+		})
+	}
+
+	for _, eliminated := range []bool{true, false} {
+		game, err := NewSlpGameFromBytes(threePlayers(eliminated), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		game.SetStrictChecks(StrictOpts{RequireCompleteFrames: true})
+
+		_, err = game.GetFrames()
+		if eliminated && err != nil {
+			t.Errorf("expected frames without the eliminated player to pass, got %v", err)
+		} else if !eliminated && err == nil {
+			t.Error("expected frames missing a player who wasn't eliminated to fail")
+		}
+
+		if gameInfo, _ := game.parser.GetGameInfo(); len(gameInfo.Players) != 3 {
+			t.Errorf("expected 3 players, got %d", len(gameInfo.Players))
+		}
+
+		game.Close()
+	}
+}
+
+func TestStrictSinglePlayerMode(t *testing.T) {
+	replay, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// turn the game into a Home-Run Contest in which the first player is
+	// missing from most frames, as Sandbag can be
+	replay = rewriteEvents(t, replay, func(event []byte) []byte {
+		switch Command(event[0]) {
+		case GameStart:
+			event[1+0x1A3] = byte(SceneHomeRunContest)
+		case PreFrameUpdate, PostFrameUpdate:
+			frameNumber := int32(binary.BigEndian.Uint32(event[1:5]))
+			if event[5] == 0 && frameNumber > 0 {
+				return nil
+			}
+		}
+
+		return event
+	})
+
+	reader, err := NewSlpReader(*NewSlpSourceBytes(bytes.NewReader(replay)))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	events, err := reader.YieldEvents(func(*SlpEvent) bool { return false })
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	parser := NewSlpParser(SlpParserOpts{Strict: true})
+	err = parser.ParseReplay(events)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	gameInfo, _ := parser.GetGameInfo()
+	if mode := gameInfo.GameMode(); mode != HomeRunContestMode || !mode.IsSinglePlayer() {
+		t.Errorf("expected single player %s, got %s", HomeRunContestMode, mode)
+	}
+}
+
+// collectEvents parses replay, returning the payloads of the given event in
+// the order they were triggered.
+func collectEvents(t *testing.T, replay []byte, event ParserEvent) []interface{} {
+	t.Helper()
+
+	reader, err := NewSlpReader(*NewSlpSourceBytes(bytes.NewReader(replay)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := reader.YieldEvents(func(*SlpEvent) bool { return false })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parser := NewSlpParser(SlpParserOpts{Dispatch: DispatchSynchronous})
+	handler := make(chan interface{})
+	parser.AddHandler(event, handler)
+
+	var parseErr error
+	go func() {
+		parseErr = parser.ParseReplay(events)
+		close(handler)
+	}()
+
+	payloads := make([]interface{}, 0)
+	for payload := range handler {
+		payloads = append(payloads, payload)
+	}
+	if parseErr != nil {
+		t.Fatal(parseErr)
+	}
+
+	return payloads
+}
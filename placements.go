@@ -0,0 +1,202 @@
+package slippi
+
+// A PlayerPlacement is the placement of a single player in a game.
+type PlayerPlacement struct {
+	PlayerIndex uint8 `json:"playerIndex"`
+	// Placement is the place the player finished in, starting at 0 for
+	// first place. Players who are tied share a placement.
+	Placement int8 `json:"placement"`
+}
+
+// standing is how far ahead a player or team is at the end of a game.
+type standing struct {
+	stocks  int
+	percent float32
+}
+
+// standingOf returns the standing of the player with the given post-frame
+// update. A dying player has already lost their stock but not their percent,
+// so their percent is taken to be the 0 they respawn at.
+func standingOf(post PostFrameUpdatePayload) standing {
+	percent := post.Percent
+	if IsDead(post.ActionStateID) {
+		percent = 0
+	}
+
+	return standing{stocks: int(post.StocksRemaining), percent: percent}
+}
+
+// ahead returns whether s has more stocks than other, or as many stocks at a
+// lower percent.
+func (s standing) ahead(other standing) bool {
+	return s.stocks > other.stocks || s.stocks == other.stocks && s.percent < other.percent
+}
+
+// ComputeResult returns the outcome of the game parsed so far, with the player
+// who quit out of a game with LRAS losing it. The placements are those of the
+// game end event of replays that record them, which are 3.13.0 and later, and
+// are otherwise determined by the stocks and percent of the players at the end
+// of the game. Games that haven't ended have no winner or placements.
+func (p *SlpParser) ComputeResult() *GameResult {
+	return p.computeResult(QuitOutLoss)
+}
+
+// computeResult returns the outcome of the game parsed so far, treating a
+// quit out according to policy.
+func (p *SlpParser) computeResult(policy QuitOutPolicy) *GameResult {
+	result := &GameResult{
+		EndMethod:   Unresolved,
+		WinnerIndex: -1,
+		Winners:     make([]uint8, 0),
+		Placements:  make([]PlayerPlacement, 0),
+	}
+
+	gameEnd := p.GameEnd
+	if gameEnd == nil || p.gameInfo == nil {
+		return result
+	}
+	result.EndMethod = gameEnd.GameEndMethod
+
+	posts := p.finalPosts()
+	leader := leadingPlayer(posts)
+	result.WinnerIndex = leader
+
+	var quitter int8 = -1
+	if gameEnd.GameEndMethod == NoContest {
+		quitter = gameEnd.LRASInitiator
+		result.QuitOut = &QuitOut{
+			PlayerIndex: quitter,
+			Frame:       max(p.latestFrameIndex, FirstFrame),
+			WasLosing:   quitter >= 0 && leader != quitter,
+		}
+
+		switch policy {
+		case QuitOutLoss:
+			if quitter >= 0 && leader == quitter {
+				result.WinnerIndex = leadingPlayer(posts, uint8(quitter))
+			}
+		case QuitOutExclude:
+			result.Excluded = true
+		}
+	}
+
+	if recordsPlacements(*gameEnd, p.gameInfo.Players) {
+		for _, player := range p.gameInfo.Players {
+			result.Placements = append(result.Placements, PlayerPlacement{
+				PlayerIndex: player.Index,
+				Placement:   gameEnd.Placements[player.Port-1],
+			})
+		}
+	} else {
+		// the player who quit out only loses the game if the policy says so
+		if policy != QuitOutLoss {
+			quitter = -1
+		}
+		result.Placements = rankPlayers(p.gameInfo, posts, quitter)
+	}
+
+	losers := make([]uint8, 0)
+	for _, placement := range result.Placements {
+		if placement.Placement == 0 {
+			result.Winners = append(result.Winners, placement.PlayerIndex)
+		} else {
+			losers = append(losers, placement.PlayerIndex)
+		}
+	}
+
+	// the winner is the player furthest ahead of those in first place, who
+	// are several in teams games
+	if len(result.Winners) > 0 {
+		result.WinnerIndex = leadingPlayer(posts, losers...)
+		if result.WinnerIndex == -1 && len(result.Winners) == 1 {
+			result.WinnerIndex = int8(result.Winners[0])
+		}
+	}
+
+	return result
+}
+
+// finalPosts returns the last post-frame update of each player, including
+// those who were eliminated before the last frame.
+func (p *SlpParser) finalPosts() map[uint8]PostFrameUpdatePayload {
+	posts := make(map[uint8]PostFrameUpdatePayload, len(p.lastPosts))
+	for index, post := range p.lastPosts {
+		posts[index] = post
+	}
+
+	// the last frames haven't been finalized if the game hasn't ended, and
+	// no frames have been finalized if the game was loaded from a cache
+	if frame, ok := p.Frames.Get(max(p.latestFrameIndex, FirstFrame)); ok {
+		for index, updates := range frame.Players {
+			if updates.Post != nil {
+				posts[index] = *updates.Post
+			}
+		}
+	}
+
+	return posts
+}
+
+// recordsPlacements returns whether gameEnd has the placements of players.
+func recordsPlacements(gameEnd GameEndPayload, players []PlayerInfo) bool {
+	for _, player := range players {
+		if gameEnd.Placements[player.Port-1] >= 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// rankPlayers returns the placements of the players of the game by their
+// standing in posts, placing the player with the index quitter, if any,
+// last. In teams games, each team is placed by the total stocks and percent of
+// its players. Players without a post-frame update are taken to have been
+// eliminated.
+func rankPlayers(gameInfo *GameInfo, posts map[uint8]PostFrameUpdatePayload, quitter int8) []PlayerPlacement {
+	// sides are the players, or the teams in teams games, who are placed
+	// together
+	sides := make(map[uint8]standing)
+	sideOf := func(player PlayerInfo) uint8 {
+		if gameInfo.Teams {
+			return uint8(player.TeamID)
+		}
+		return player.Index
+	}
+
+	quitterSide := -1
+	for _, player := range gameInfo.Players {
+		side := sideOf(player)
+		total := sides[side]
+		if post, ok := posts[player.Index]; ok {
+			current := standingOf(post)
+			total.stocks += current.stocks
+			total.percent += current.percent
+		}
+		sides[side] = total
+
+		if int8(player.Index) == quitter {
+			quitterSide = int(side)
+		}
+	}
+
+	placements := make([]PlayerPlacement, 0, len(gameInfo.Players))
+	for _, player := range gameInfo.Players {
+		side := sideOf(player)
+		placement := 0
+		for other, otherStanding := range sides {
+			switch {
+			case other == side:
+			case int(side) == quitterSide:
+				placement++
+			case int(other) == quitterSide:
+			case otherStanding.ahead(sides[side]):
+				placement++
+			}
+		}
+
+		placements = append(placements, PlayerPlacement{PlayerIndex: player.Index, Placement: int8(placement)})
+	}
+
+	return placements
+}
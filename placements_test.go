@@ -0,0 +1,81 @@
+package slippi
+
+import (
+	"encoding/binary"
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestComputeResult(t *testing.T) {
+	replay, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name    string
+		rewrite func(event []byte) []byte
+		winner  int8
+		// placements are indexed by player
+		placements []int8
+	}{
+		{"stocks", nil, 0, []int8{0, 1}},
+		// Fox quits out while ahead, and loses
+		{"quit out", func(event []byte) []byte {
+			if Command(event[0]) == GameEnd {
+				event[1] = byte(NoContest)
+				event[2] = 0
+			}
+			return event
+		}, 1, []int8{1, 0}},
+		// the game end event records Falco in first place, as replays since
+		// 3.13.0 do
+		{"placements", func(event []byte) []byte {
+			switch Command(event[0]) {
+			case EventPayloads:
+				for position := 2; position < len(event); position += 3 {
+					if Command(event[position]) == GameEnd {
+						binary.BigEndian.PutUint16(event[position+1:position+3], 6)
+					}
+				}
+			case GameEnd:
+				event = append(event, 1, 0, 0xFF, 0xFF)
+			}
+			return event
+		}, 1, []int8{1, 0}},
+	}
+
+	for _, c := range cases {
+		rewritten := replay
+		if c.rewrite != nil {
+			rewritten = rewriteEvents(t, replay, c.rewrite)
+		}
+
+		game, err := NewSlpGameFromBytes(rewritten, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := game.GetFrames(); err != nil {
+			t.Fatal(err)
+		}
+		result := game.parser.ComputeResult()
+		game.Close()
+
+		if result.WinnerIndex != c.winner || !slices.Equal(result.Winners, []uint8{uint8(c.winner)}) {
+			t.Errorf("%s: expected player %d to win, got %+v", c.name, c.winner, result)
+		}
+
+		placements := make([]int8, 0, len(result.Placements))
+		for i, placement := range result.Placements {
+			if placement.PlayerIndex != uint8(i) {
+				t.Errorf("%s: expected the placement of player %d, got %+v", c.name, i, placement)
+			}
+			placements = append(placements, placement.Placement)
+		}
+		if !slices.Equal(placements, c.placements) {
+			t.Errorf("%s: expected placements %v, got %v", c.name, c.placements, placements)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+// Package playback generates the playback files Slippi Dolphin reads with its
+// -i flag, so that clips of replays, such as the highlights selected by a
+// stats.ComboFilter, can be played back or recorded directly.
+//
+// Play a queue back with:
+//
+//	dolphin -i queue.json -e "Super Smash Bros. Melee (v1.02).iso"
+package playback
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	slippi "github.com/ZadenRB/go-slippi"
+	"github.com/ZadenRB/go-slippi/stats"
+)
+
+// DefaultLeadIn is the number of frames of a clip played before the combo it
+// shows begins, and DefaultLeadOut the number played after it ends.
+const (
+	DefaultLeadIn  = 120
+	DefaultLeadOut = 60
+)
+
+// ClipOpts are the options of the clips of combos.
+type ClipOpts struct {
+	// LeadIn is the number of frames played before a combo, and LeadOut the
+	// number played after it. They are DefaultLeadIn and DefaultLeadOut if
+	// 0.
+	LeadIn  int32
+	LeadOut int32
+}
+
+// A QueueEntry is a single replay of a queue, played from StartFrame to
+// EndFrame.
+type QueueEntry struct {
+	Path       string `json:"path"`
+	StartFrame int32  `json:"startFrame"`
+	EndFrame   int32  `json:"endFrame"`
+	// GameStartAt and GameStation are the time the game started and the
+	// nickname of the console it was played on, which Dolphin shows while
+	// playing it back.
+	GameStartAt string `json:"gameStartAt,omitempty"`
+	GameStation string `json:"gameStation,omitempty"`
+}
+
+// A Queue is a playback file in the queue mode, which plays its entries in
+// order. The zero value is an empty queue without a mode; use NewQueue.
+type Queue struct {
+	Mode string `json:"mode"`
+	// Replay is the replay to play back outside of the queue mode.
+	Replay string `json:"replay"`
+	// IsRealTimeMode plays back without buffering, and OutputOverlayFiles
+	// writes the current frame and players to files recorders can overlay.
+	IsRealTimeMode     bool `json:"isRealTimeMode"`
+	OutputOverlayFiles bool `json:"outputOverlayFiles"`
+	// CommandID identifies the file to Dolphin, which reloads it when it
+	// changes.
+	CommandID string       `json:"commandId,omitempty"`
+	Queue     []QueueEntry `json:"queue"`
+}
+
+// NewQueue returns an empty queue.
+func NewQueue() *Queue {
+	return &Queue{Mode: "queue", Queue: make([]QueueEntry, 0)}
+}
+
+// AddRange adds the replay at path to the queue, played from start to end.
+// Unless game, the game of the replay, is nil, the range is clamped to the
+// frames of the game, and the entry has the game's start time and console.
+func (q *Queue) AddRange(path string, game *slippi.SlpGame, start int32, end int32) error {
+	if start > end {
+		return errors.New(fmt.Sprintf("frame range %d to %d ends before it starts", start, end))
+	}
+
+	entry := QueueEntry{Path: path, StartFrame: start, EndFrame: end}
+	if game != nil {
+		last, err := game.GetLastFrameNumber()
+		if err != nil {
+			return err
+		}
+		entry.StartFrame = min(max(start, slippi.FirstFrame), last)
+		entry.EndFrame = min(max(end, slippi.FirstFrame), last)
+
+		metadata, err := game.GetMetadata()
+		if err != nil {
+			return err
+		} else if metadata != nil {
+			entry.GameStartAt = metadata.StartAt
+			entry.GameStation = metadata.ConsoleNick
+		}
+	}
+
+	q.Queue = append(q.Queue, entry)
+
+	return nil
+}
+
+// AddCombos adds a clip of each combo of game, the game of the replay at
+// path, to the queue, in order. Combos still in progress are played to the
+// end of the game.
+func (q *Queue) AddCombos(path string, game *slippi.SlpGame, combos []stats.Combo, opts ClipOpts) error {
+	if game == nil {
+		return errors.New(fmt.Sprintf("clips of %s need the game of the replay", path))
+	}
+	if opts.LeadIn == 0 {
+		opts.LeadIn = DefaultLeadIn
+	}
+	if opts.LeadOut == 0 {
+		opts.LeadOut = DefaultLeadOut
+	}
+
+	last, err := game.GetLastFrameNumber()
+	if err != nil {
+		return err
+	}
+
+	for _, combo := range combos {
+		end := last
+		if combo.EndFrame != nil {
+			end = *combo.EndFrame + opts.LeadOut
+		}
+
+		if err := q.AddRange(path, game, combo.StartFrame-opts.LeadIn, end); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Encode writes the queue to w as the JSON of a playback file.
+func (q *Queue) Encode(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(q)
+}
+
+// WriteFile writes the queue to the playback file at path, replacing it if it
+// exists.
+func (q *Queue) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	err = q.Encode(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
@@ -0,0 +1,102 @@
+package playback
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	slippi "github.com/ZadenRB/go-slippi"
+	"github.com/ZadenRB/go-slippi/stats"
+)
+
+func TestQueue(t *testing.T) {
+	b, err := os.ReadFile("../game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game, err := slippi.NewSlpGameFromBytes(b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer game.Close()
+
+	highlights, err := stats.ComputeHighlights(game, stats.ComboFilter{MustKill: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	queue := NewQueue()
+	if err := queue.AddCombos("game.slp", game, highlights, ClipOpts{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := queue.AddCombos("other.slp", nil, highlights, ClipOpts{}); err == nil {
+		t.Error("expected error for clips without the game of the replay")
+	}
+	if len(queue.Queue) != len(highlights) {
+		t.Fatalf("expected a clip of each of the %d highlights, got %+v", len(highlights), queue.Queue)
+	}
+
+	metadata, err := game.GetMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, entry := range queue.Queue {
+		combo := highlights[i]
+		if entry.Path != "game.slp" || entry.StartFrame != combo.StartFrame-DefaultLeadIn || entry.GameStartAt != metadata.StartAt {
+			t.Errorf("entry %d: expected a clip of the combo from frame %d, got %+v", i, combo.StartFrame, entry)
+		}
+	}
+
+	// the last kill is the end of the game, past which clips don't play
+	last, err := game.GetLastFrameNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if end := queue.Queue[len(queue.Queue)-1].EndFrame; end != last {
+		t.Errorf("expected the last clip to end on the last frame %d, got %d", last, end)
+	}
+
+	if err := queue.AddRange("game.slp", game, -500, 100); err != nil {
+		t.Fatal(err)
+	}
+	if entry := queue.Queue[len(queue.Queue)-1]; entry.StartFrame != slippi.FirstFrame || entry.EndFrame != 100 {
+		t.Errorf("expected the range to start on the first frame, got %+v", entry)
+	}
+	if err := queue.AddRange("other.slp", nil, 200, 100); err == nil {
+		t.Error("expected a range ending before it starts to fail")
+	}
+
+	path := filepath.Join(t.TempDir(), "queue.json")
+	if err := queue.WriteFile(path); err != nil {
+		t.Fatal(err)
+	}
+	written, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(written, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded["mode"] != "queue" || len(decoded["queue"].([]any)) != len(queue.Queue) {
+		t.Errorf("expected a queue playback file, got %s", written)
+	}
+	entry := decoded["queue"].([]any)[0].(map[string]any)
+	for _, key := range []string{"path", "startFrame", "endFrame", "gameStartAt"} {
+		if _, ok := entry[key]; !ok {
+			t.Errorf("expected the entries to have %q, got %v", key, entry)
+		}
+	}
+
+	var encoded bytes.Buffer
+	if err := queue.Encode(&encoded); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(encoded.Bytes(), written) {
+		t.Error("expected the file to have the encoded queue")
+	}
+}
@@ -0,0 +1,76 @@
+package slippi
+
+// PlayerStats holds a per-player result of a game, such as a
+// DamageBreakdown, indexed by player index, by in-game port, and by connect
+// code and Slippi UID for online games, so that results can be aggregated
+// across games where the players' ports change.
+type PlayerStats[T any] struct {
+	ByIndex map[uint8]T `json:"byIndex"`
+	ByPort  map[uint8]T `json:"byPort"`
+	// ByConnectCode and ByUID only contain the players with a connect code
+	// and Slippi UID, which offline players don't have.
+	ByConnectCode map[string]T `json:"byConnectCode"`
+	ByUID         map[string]T `json:"byUid"`
+}
+
+// NewPlayerStats indexes the results in byIndex, keyed by player index, by
+// the port, connect code and Slippi UID of each player in gameInfo. Players
+// without a result are left out.
+func NewPlayerStats[T any](gameInfo *GameInfo, byIndex map[uint8]T) *PlayerStats[T] {
+	stats := &PlayerStats[T]{
+		ByIndex:       make(map[uint8]T, len(byIndex)),
+		ByPort:        make(map[uint8]T, len(byIndex)),
+		ByConnectCode: make(map[string]T),
+		ByUID:         make(map[string]T),
+	}
+
+	for _, player := range gameInfo.Players {
+		result, ok := byIndex[player.Index]
+		if !ok {
+			continue
+		}
+
+		stats.ByIndex[player.Index] = result
+		stats.ByPort[player.Port] = result
+		if player.ConnectCode != "" {
+			stats.ByConnectCode[player.ConnectCode] = result
+		}
+		if player.SlippiUID != "" {
+			stats.ByUID[player.SlippiUID] = result
+		}
+	}
+
+	return stats
+}
+
+// DamageBreakdownsByPlayer returns the damage breakdown of each player in the
+// game, indexed by player index, port, connect code and Slippi UID.
+func (g *SlpGame) DamageBreakdownsByPlayer() (*PlayerStats[*DamageBreakdown], error) {
+	gameInfo, err := g.GetGameInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	breakdowns, err := g.DamageBreakdowns()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPlayerStats(gameInfo, breakdowns), nil
+}
+
+// DeathStatsByPlayer returns the death stats of each player in the game,
+// indexed by player index, port, connect code and Slippi UID.
+func (g *SlpGame) DeathStatsByPlayer() (*PlayerStats[*PlayerDeathStats], error) {
+	gameInfo, err := g.GetGameInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	deaths, err := g.DeathStats()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPlayerStats(gameInfo, deaths), nil
+}
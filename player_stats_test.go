@@ -0,0 +1,53 @@
+package slippi
+
+import (
+	"os"
+	"testing"
+)
+
+func TestPlayerStats(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game, err := NewSlpGameFromBytes(b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer game.Close()
+
+	gameInfo, err := game.GetGameInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	breakdowns, err := game.DamageBreakdownsByPlayer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, player := range gameInfo.Players {
+		byIndex := breakdowns.ByIndex[player.Index]
+		if byIndex == nil || breakdowns.ByPort[player.Port] != byIndex || breakdowns.ByConnectCode[player.ConnectCode] != byIndex || breakdowns.ByUID[player.SlippiUID] != byIndex {
+			t.Errorf("expected player %d's breakdown under their port, connect code and UID", player.Index)
+		}
+	}
+
+	deaths, err := game.DeathStatsByPlayer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Falco is in port 2
+	if falco := deaths.ByPort[2]; falco == nil || falco.Character != Falco || len(falco.Deaths) != 4 {
+		t.Errorf("expected Falco's 4 deaths under port 2, got %+v", falco)
+	}
+
+	offline := *gameInfo
+	offline.Players = []PlayerInfo{gameInfo.Players[0]}
+	offline.Players[0].ConnectCode, offline.Players[0].SlippiUID = "", ""
+	if stats := NewPlayerStats(&offline, breakdowns.ByIndex); len(stats.ByIndex) != 1 || len(stats.ByConnectCode) != 0 || len(stats.ByUID) != 0 {
+		t.Errorf("expected only offline player's index and port, got %+v", stats)
+	}
+}
@@ -0,0 +1,102 @@
+package slippi
+
+import "slices"
+
+// QuitOutPolicy enumerates the ways stats aggregated across games can treat
+// games that were quit out of with LRAS.
+type QuitOutPolicy uint8
+
+// QuitOutPolicies
+const (
+	// QuitOutPartial counts the stats of the frames that were played, and
+	// the player ahead when the game was quit out of as its winner.
+	QuitOutPartial QuitOutPolicy = iota
+	// QuitOutLoss counts the stats of the frames that were played, and the
+	// game as a loss for the player who quit out.
+	QuitOutLoss
+	// QuitOutExclude leaves games that were quit out of out of the stats.
+	QuitOutExclude
+)
+
+// A QuitOut describes the end of a game that was quit out of with LRAS.
+type QuitOut struct {
+	// PlayerIndex is the index of the player who quit out, or -1 if the
+	// replay doesn't record who did.
+	PlayerIndex int8 `json:"playerIndex"`
+	// Frame is the last frame of the game.
+	Frame int32 `json:"frame"`
+	// WasLosing is whether the player who quit out was behind when they did,
+	// with fewer stocks than the leader, or as many stocks at a higher
+	// percent.
+	WasLosing bool `json:"wasLosing"`
+}
+
+// A GameResult is the outcome of a game.
+type GameResult struct {
+	EndMethod GameEndMethod `json:"endMethod"`
+	// WinnerIndex is the index of the winner of the game, or -1 if there is
+	// none, such as when the game was tied or never ended. In teams games, it
+	// is the index of the player furthest ahead.
+	WinnerIndex int8 `json:"winnerIndex"`
+	// Winners are the indices of the players in first place, which are the
+	// players of the winning team in teams games, and the players who tied
+	// in tied games. There are none if the game never ended.
+	Winners []uint8 `json:"winners"`
+	// Placements are the placements of the players, in order of index.
+	Placements []PlayerPlacement `json:"placements"`
+	// QuitOut is set if the game was quit out of.
+	QuitOut *QuitOut `json:"quitOut"`
+	// Excluded is whether the game should be left out of aggregated stats,
+	// according to the QuitOutPolicy the result was computed with.
+	Excluded bool `json:"excluded"`
+}
+
+// IsQuitOut returns whether the game was quit out of with LRAS.
+func (g *SlpGame) IsQuitOut() (bool, error) {
+	if _, err := g.GetLatestFrame(); err != nil {
+		return false, err
+	}
+
+	gameEnd := g.parser.GameEnd
+	return gameEnd != nil && gameEnd.GameEndMethod == NoContest, nil
+}
+
+// Result returns the outcome of the game, treating a quit out according to
+// policy.
+func (g *SlpGame) Result(policy QuitOutPolicy) (*GameResult, error) {
+	err := g.process(false)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.parser.computeResult(policy), nil
+}
+
+// leadingPlayer returns the index of the player with the most stocks in
+// posts, and of those, the lowest percent, ignoring the players with the given
+// indices. It returns -1 if no single player is ahead.
+func leadingPlayer(posts map[uint8]PostFrameUpdatePayload, ignored ...uint8) int8 {
+	leader := int8(-1)
+	var best standing
+	tied := false
+
+	for index, post := range posts {
+		if slices.Contains(ignored, index) {
+			continue
+		}
+
+		current := standingOf(post)
+		switch {
+		case leader == -1 && !tied, current.ahead(best):
+			leader, best, tied = int8(index), current, false
+		case !best.ahead(current):
+			leader, tied = -1, true
+		}
+	}
+
+	if tied {
+		return -1
+	}
+
+	return leader
+}
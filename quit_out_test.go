@@ -0,0 +1,76 @@
+package slippi
+
+import (
+	"os"
+	"testing"
+)
+
+func TestGameResult(t *testing.T) {
+	replay, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game, err := NewSlpGameFromBytes(replay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer game.Close()
+
+	if quitOut, err := game.IsQuitOut(); err != nil || quitOut {
+		t.Errorf("expected the game not to be quit out of, got %v, %v", quitOut, err)
+	}
+
+	result, err := game.Result(QuitOutExclude)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.EndMethod != Game || result.WinnerIndex != 0 || result.QuitOut != nil || result.Excluded {
+		t.Errorf("expected Fox to win the game, got %+v", result)
+	}
+
+	// end the game with Fox, who is ahead, quitting out
+	quitOut := rewriteEvents(t, replay, func(event []byte) []byte {
+		if Command(event[0]) == GameEnd {
+			event[1] = byte(NoContest)
+			event[2] = 0
+		}
+		return event
+	})
+
+	expected := map[QuitOutPolicy]int8{QuitOutPartial: 0, QuitOutLoss: 1, QuitOutExclude: 0}
+	for policy, winner := range expected {
+		game, err := NewSlpGameFromBytes(quitOut, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := game.Result(policy)
+		game.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if result.QuitOut == nil || result.QuitOut.PlayerIndex != 0 || result.QuitOut.WasLosing {
+			t.Errorf("expected Fox to quit out while ahead, got %+v", result.QuitOut)
+		}
+		if result.WinnerIndex != winner || result.Excluded != (policy == QuitOutExclude) {
+			t.Errorf("policy %d: expected player %d to win, got %+v", policy, winner, result)
+		}
+	}
+
+	killMap := NewKillMap()
+	killMap.QuitOuts = QuitOutExclude
+	game, err = NewSlpGameFromBytes(quitOut, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer game.Close()
+
+	if err := killMap.AddGame(game); err != nil {
+		t.Fatal(err)
+	}
+	if len(killMap.Stages) != 0 {
+		t.Errorf("expected the quit out to be excluded, got %+v", killMap.Stages)
+	}
+}
@@ -21,6 +21,7 @@ type InputType int
 const (
 	SlpFile InputType = iota
 	SlpBytes
+	SlpReadSeeker
 )
 
 // A SlpSource wraps a reader and the type of the reader, used to determine its
@@ -49,6 +50,17 @@ func NewSlpSourceBytes(r *bytes.Reader) *SlpSource {
 	}
 }
 
+// NewSlpSourceReader returns a SlpSource wrapping the provided io.ReadSeeker
+// r, for sources that are neither files nor byte slices, such as data handed
+// over from JavaScript in a WASM build.
+func NewSlpSourceReader(r io.ReadSeeker) *SlpSource {
+	return &SlpSource{
+		ReadSeeker: r,
+		InputType:  SlpReadSeeker,
+		length:     -1,
+	}
+}
+
 // GetLength gets the length of the underlying data source of the SlpSource.
 // If recalculate is true, the length will be recalculated. Otherwise, the
 // length is only calculated on the first call to GetLength for a given
@@ -82,7 +94,27 @@ func (s *SlpSource) GetLength(recalculate bool) (int64, error) {
 			}
 
 			// get length
-			s.length = int64(b.Len())
+			s.length = b.Size()
+		case SlpReadSeeker:
+			// seek to the end to get the length, then back to where the
+			// reader was
+			current, err := s.ReadSeeker.Seek(0, io.SeekCurrent)
+			if err != nil {
+				s.length = -1
+				return s.length, err
+			}
+
+			end, err := s.ReadSeeker.Seek(0, io.SeekEnd)
+			if err != nil {
+				s.length = -1
+				return s.length, err
+			}
+
+			if _, err := s.ReadSeeker.Seek(current, io.SeekStart); err != nil {
+				s.length = -1
+				return s.length, err
+			}
+			s.length = end
 		default:
 			s.length = -1
 			return s.length, errors.New(fmt.Sprintf("unrecognized slp input type: %d", s.InputType))
@@ -101,6 +133,14 @@ type SlpReader struct {
 	MetadataStart  int64
 	MetadataLength int64
 	PayloadSizes   map[byte]uint16
+	trace          TraceHandler
+	clock          Clock
+	// offset is the offset just past the last event yielded, frame is the
+	// number of the latest frame yielded, and assembler splices message
+	// splitter fragments across calls to YieldEventsFrom
+	offset    int64
+	frame     int32
+	assembler *MessageSplitterAssembler
 }
 
 // NewSlpReader returns a SlpReader that reads from the provided SlpSource s.
@@ -108,7 +148,7 @@ func NewSlpReader(s SlpSource) (*SlpReader, error) {
 	// get length
 	length, err := s.GetLength(false)
 	if err != nil {
-		return nil, errors.New("failed to get length of replay data source")
+		return nil, withCode(CodeUnreadableSource, errors.New("failed to get length of replay data source"))
 	}
 
 	// read preamble
@@ -120,7 +160,7 @@ func NewSlpReader(s SlpSource) (*SlpReader, error) {
 
 	// verify preamble contents
 	if bytes.Compare(preamble[:11], []byte{0x7B, 0x55, 0x03, 0x72, 0x61, 0x77, 0x5B, 0x24, 0x55, 0x23, 0x6c}) != 0 {
-		return nil, errors.New(fmt.Sprintf("replay had an invalid preamble: %X\n", preamble[:11]))
+		return nil, withCode(CodeInvalidPreamble, errors.New(fmt.Sprintf("replay had an invalid preamble: %X\n", preamble[:11])))
 	}
 
 	// get raw data start and length
@@ -131,16 +171,36 @@ func NewSlpReader(s SlpSource) (*SlpReader, error) {
 	metadataStart := rawStart + rawLength + 10
 	metadataLength := length - metadataStart - 1
 
+	return newSlpReader(s, rawStart, rawLength, metadataStart, metadataLength)
+}
+
+// NewSlpReaderRaw returns a SlpReader that reads from the provided SlpSource
+// s, which holds a bare event stream, such as one captured from the network,
+// instead of a replay. The stream must start with an event payloads event, and
+// has no metadata.
+func NewSlpReaderRaw(s SlpSource) (*SlpReader, error) {
+	// get length
+	length, err := s.GetLength(false)
+	if err != nil {
+		return nil, withCode(CodeUnreadableSource, errors.New("failed to get length of event stream data source"))
+	}
+
+	return newSlpReader(s, 0, length, length, 0)
+}
+
+// newSlpReader returns a SlpReader that reads the raw data of the given start
+// and length from s, which is positioned at its start.
+func newSlpReader(s SlpSource, rawStart int64, rawLength int64, metadataStart int64, metadataLength int64) (*SlpReader, error) {
 	// read first 2 bytes of event payloads event
 	eventPayloads := make([]byte, 2)
-	_, err = s.Read(eventPayloads)
+	_, err := s.Read(eventPayloads)
 	if err != nil {
 		return nil, err
 	}
 
 	// verify that the first event is an event payloads event
 	if eventPayloads[0] != 0x35 {
-		return nil, errors.New(fmt.Sprintf("expected event payloads event, got: %X\n", eventPayloads[0]))
+		return nil, withCode(CodeMissingEventPayloads, errors.New(fmt.Sprintf("expected event payloads event, got: %X\n", eventPayloads[0])))
 	}
 
 	// populate event payload sizes
@@ -176,6 +236,10 @@ func NewSlpReader(s SlpSource) (*SlpReader, error) {
 		MetadataStart:  metadataStart,
 		MetadataLength: metadataLength,
 		PayloadSizes:   payloadSizes,
+		clock:          SystemClock{},
+		offset:         rawStart,
+		frame:          FirstFrame - 1,
+		assembler:      NewMessageSplitterAssembler(),
 	}, nil
 }
 
@@ -191,6 +255,12 @@ func (r *SlpReader) SetInclude(command byte, include bool) error {
 	return nil
 }
 
+// SetClock sets the Clock the SlpReader uses to time the decoding of events
+// for its trace.
+func (r *SlpReader) SetClock(clock Clock) {
+	r.clock = clock
+}
+
 type SlpEventResult struct {
 	Event *SlpEvent
 	Error error
@@ -199,10 +269,42 @@ type SlpEventResult struct {
 // YieldEvents returns a channel to which it sends the events from the
 // SlpSource.
 func (r *SlpReader) YieldEvents(stopYielding func(*SlpEvent) bool) (<-chan *SlpEventResult, error) {
-	// reset to start of raw data
-	_, err := r.Source.Seek(r.RawStart, io.SeekStart)
+	r.assembler.Reset()
+	r.frame = FirstFrame - 1
+	return r.YieldEventsFrom(r.RawStart, stopYielding)
+}
+
+// IsLive returns whether the replay is still being written, in which case its
+// raw element has no length yet and extends to the end of the SlpSource.
+func (r *SlpReader) IsLive() bool {
+	return r.RawLength == 0
+}
+
+// Offset returns the offset in the SlpSource just past the last event sent by
+// the most recent call to YieldEvents or YieldEventsFrom, once its channel has
+// been closed.
+func (r *SlpReader) Offset() int64 {
+	return r.offset
+}
+
+// YieldEventsFrom returns a channel to which it sends the events from the
+// SlpSource, starting from the event at offset, such as one returned by
+// Offset to resume after the events already yielded. For live replays, it
+// stops at the end of the SlpSource, leaving any event that hasn't been fully
+// written yet to be yielded by the next call.
+func (r *SlpReader) YieldEventsFrom(offset int64, stopYielding func(*SlpEvent) bool) (<-chan *SlpEventResult, error) {
+	_, err := r.Source.Seek(offset, io.SeekStart)
 	if err != nil {
-		return nil, errors.New("failed to seek to start of replay")
+		return nil, withCode(CodeUnreadableSource, errors.New("failed to seek to start of replay"))
+	}
+
+	end := r.RawStart + r.RawLength - 1
+	if r.IsLive() {
+		length, err := r.Source.GetLength(true)
+		if err != nil {
+			return nil, err
+		}
+		end = length
 	}
 
 	send, receive := MakeUnboundedChannel[SlpEventResult]()
@@ -213,17 +315,21 @@ func (r *SlpReader) YieldEvents(stopYielding func(*SlpEvent) bool) (<-chan *SlpE
 		payloadBuffers[event] = make([]byte, payloadSize)
 	}
 
+	assembler := r.assembler
+	r.offset = offset
+
 	go func() {
-		position := r.RawStart
-		end := r.RawStart + r.RawLength - 1
+		position := offset
 		commandBuf := make([]byte, 1)
 		for position < end {
+			offset := position
+
 			// read event byte
 			bytesRead, err := r.Source.Read(commandBuf)
 			if err != nil {
 				send <- &SlpEventResult{
 					Event: nil,
-					Error: err,
+					Error: r.parseError(readError(err), 0, offset),
 				}
 				close(send)
 				return
@@ -237,12 +343,18 @@ func (r *SlpReader) YieldEvents(stopYielding func(*SlpEvent) bool) (<-chan *SlpE
 			if !ok {
 				send <- &SlpEventResult{
 					Event: nil,
-					Error: err,
+					Error: r.parseError(withCode(CodeUnknownCommand, errors.New(fmt.Sprintf("unknown command 0x%X", command))), command, offset),
 				}
 				close(send)
 				return
 			}
 
+			// live replays may end partway through an event, which is
+			// yielded once it has been written
+			if r.IsLive() && position+int64(len(payload)) > end {
+				break
+			}
+
 			include, ok := r.include[command]
 
 			// skip events that are unknown or not included
@@ -251,20 +363,28 @@ func (r *SlpReader) YieldEvents(stopYielding func(*SlpEvent) bool) (<-chan *SlpE
 				if err != nil {
 					send <- &SlpEventResult{
 						Event: nil,
-						Error: err,
+						Error: r.parseError(withCode(CodeUnreadableSource, err), command, offset),
 					}
 					close(send)
 					return
 				}
+				position += int64(len(payload))
+				r.offset = position
+				r.traceEvent(TraceEntry{
+					Command: Command(command),
+					Offset:  offset,
+					Size:    len(payload),
+					Skipped: true,
+				})
 				continue
 			}
 
 			// read event payload
-			bytesRead, err = r.Source.Read(payload)
+			bytesRead, err = io.ReadFull(r.Source, payload)
 			if err != nil {
 				send <- &SlpEventResult{
 					Event: nil,
-					Error: err,
+					Error: r.parseError(readError(err), command, offset),
 				}
 				close(send)
 				return
@@ -272,25 +392,72 @@ func (r *SlpReader) YieldEvents(stopYielding func(*SlpEvent) bool) (<-chan *SlpE
 			position += int64(bytesRead)
 
 			cmd := Command(command)
+			decodeStart := r.clock.Now()
 			event, err := parsePayload(cmd, payload)
+			if r.trace != nil {
+				entry := TraceEntry{
+					Command:  cmd,
+					Offset:   offset,
+					Size:     len(payload),
+					Duration: r.clock.Now().Sub(decodeStart),
+				}
+				if err != nil {
+					entry.Error = err.Error()
+				}
+				r.traceEvent(entry)
+			}
 			if err != nil {
 				send <- &SlpEventResult{
 					Event: nil,
-					Error: err,
+					Error: r.parseError(err, command, offset),
 				}
 				close(send)
 				return
 			}
 
+			event.Offset = offset - r.RawStart
+			if frame, ok := eventFrame(*event); ok {
+				r.frame = frame
+			}
+
 			send <- &SlpEventResult{
 				Event: event,
 				Error: nil,
 			}
+			r.offset = position
 
 			if stopYielding(event) {
 				close(send)
 				return
 			}
+
+			// emit the spliced event once its last fragment has been read
+			if cmd == MessageSplitter {
+				spliced, err := assembler.Add(event.Payload.(MessageSplitterPayload))
+				if err != nil {
+					send <- &SlpEventResult{
+						Event: nil,
+						Error: r.parseError(err, command, offset),
+					}
+					close(send)
+					return
+				}
+
+				if spliced == nil || !r.include[byte(spliced.Command)] {
+					continue
+				}
+				spliced.Offset = event.Offset
+
+				send <- &SlpEventResult{
+					Event: spliced,
+					Error: nil,
+				}
+
+				if stopYielding(spliced) {
+					close(send)
+					return
+				}
+			}
 		}
 
 		close(send)
@@ -299,8 +466,63 @@ func (r *SlpReader) YieldEvents(stopYielding func(*SlpEvent) bool) (<-chan *SlpE
 	return receive, nil
 }
 
+// parseError attaches the position of the event with the given command at
+// offset in the SlpSource to err.
+func (r *SlpReader) parseError(err error, command byte, offset int64) error {
+	return parseError(err, Command(command), r.frame, offset-r.RawStart)
+}
+
+// eventFrame returns the number of the frame event belongs to, if it belongs
+// to one.
+func eventFrame(event SlpEvent) (int32, bool) {
+	switch payload := event.Payload.(type) {
+	case FrameUpdatePayload:
+		return payload.GetFrameUpdate().FrameNumber, true
+	case FrameStartPayload:
+		return payload.FrameNumber, true
+	case ItemUpdatePayload:
+		return payload.FrameNumber, true
+	case FrameBookendPayload:
+		return payload.FrameNumber, true
+	}
+
+	return 0, false
+}
+
+// fullPayloadSizes are the sizes of the payloads of the latest replay version
+// read by parsePayload.
+var fullPayloadSizes = map[Command]int{
+	MessageSplitter: 0x204,
+	GameStart:       0x2BD,
+	PreFrameUpdate:  0x3F,
+	PostFrameUpdate: 0x50,
+	GameEnd:         0x6,
+	FrameStart:      0xC,
+	ItemUpdate:      0x2A,
+	FrameBookend:    0x8,
+}
+
+// readError attaches the code of the failure to read an event to err.
+func readError(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return withCode(CodeTruncatedEvent, err)
+	}
+
+	return withCode(CodeUnreadableSource, err)
+}
+
 // See https://github.com/project-slippi/slippi-wiki/blob/master/SPEC.md
 func parsePayload(command Command, payloadBytes []byte) (*SlpEvent, error) {
+	payloadLength := len(payloadBytes)
+
+	// payloads of older replays lack the fields added since, which are read
+	// as zero values
+	if size, ok := fullPayloadSizes[command]; ok && len(payloadBytes) < size {
+		padded := make([]byte, size)
+		copy(padded, payloadBytes)
+		payloadBytes = padded
+	}
+
 	var payload interface{}
 	switch command {
 	case MessageSplitter:
@@ -346,9 +568,9 @@ func parsePayload(command Command, payloadBytes []byte) (*SlpEvent, error) {
 			fixOffset := 0x8 * playerIndex
 
 			return &PlayerInfo{
-				Index:           0,
-				Port:            1,
-				CharacterID:     payloadBytes[0x64+gameInfoOffset],
+				Index:           uint8(playerIndex),
+				Port:            uint8(playerIndex + 1),
+				CharacterID:     CharacterID(payloadBytes[0x64+gameInfoOffset]),
 				PlayerType:      PlayerType(payloadBytes[0x65+gameInfoOffset]),
 				StockStartCount: payloadBytes[0x66+gameInfoOffset],
 				CostumeIndex:    payloadBytes[0x67+gameInfoOffset],
@@ -396,7 +618,7 @@ func parsePayload(command Command, payloadBytes []byte) (*SlpEvent, error) {
 				IsTeams:                payloadBytes[0xC] != 0,
 				ItemSpawnBehavior:      ItemSpawnBehavior(payloadBytes[0xF]),
 				SelfDestructScoreValue: int8(payloadBytes[0x10]),
-				Stage:                  binary.BigEndian.Uint16(payloadBytes[0x12:0x14]),
+				Stage:                  StageID(binary.BigEndian.Uint16(payloadBytes[0x12:0x14])),
 				GameTimer:              binary.BigEndian.Uint32(payloadBytes[0x14:0x18]),
 				ItemSpawnBitfield1:     payloadBytes[0x27],
 				ItemSpawnBitfield2:     payloadBytes[0x28],
@@ -409,8 +631,8 @@ func parsePayload(command Command, payloadBytes []byte) (*SlpEvent, error) {
 			RandomSeed:     binary.BigEndian.Uint32(payloadBytes[0x13C:0x140]),
 			PAL:            payloadBytes[0x1A0] != 0,
 			FrozenPS:       payloadBytes[0x1A1] != 0,
-			MinorScene:     payloadBytes[0x1A2],
-			MajorScene:     payloadBytes[0x1A3],
+			MinorScene:     MinorScene(payloadBytes[0x1A2]),
+			MajorScene:     MajorScene(payloadBytes[0x1A3]),
 			LanguageOption: Language(payloadBytes[0x2BC]),
 		}
 	case PreFrameUpdate:
@@ -461,7 +683,7 @@ func parsePayload(command Command, payloadBytes []byte) (*SlpEvent, error) {
 			},
 			InternalCharacterID:     payloadBytes[0x6],
 			ShieldSize:              readFloat(payloadBytes[0x19:0x1D]),
-			LastHittingAttackID:     payloadBytes[0x1D],
+			LastHittingAttackID:     AttackID(payloadBytes[0x1D]),
 			CurrentComboCount:       payloadBytes[0x1E],
 			LastHitBy:               payloadBytes[0x1F],
 			StocksRemaining:         payloadBytes[0x20],
@@ -486,10 +708,20 @@ func parsePayload(command Command, payloadBytes []byte) (*SlpEvent, error) {
 			AnimationIndex:          binary.BigEndian.Uint32(payloadBytes[0x4C:0x50]),
 		}
 	case GameEnd:
-		payload = GameEndPayload{
+		gameEnd := GameEndPayload{
 			GameEndMethod: GameEndMethod(payloadBytes[0x0]),
 			LRASInitiator: int8(payloadBytes[0x1]),
+			Placements:    [4]int8{-1, -1, -1, -1},
 		}
+
+		// a placement of zero is first place, so placements are only read
+		// from replays that record them
+		if payloadLength >= 0x6 {
+			for port := 0; port < 4; port++ {
+				gameEnd.Placements[port] = int8(payloadBytes[0x2+port])
+			}
+		}
+		payload = gameEnd
 	case FrameStart:
 		frameNumber, err := readInt(payloadBytes[0x0:0x4])
 		if err != nil {
@@ -507,9 +739,9 @@ func parsePayload(command Command, payloadBytes []byte) (*SlpEvent, error) {
 			return nil, err
 		}
 
-		payload = ItemUpdatePayload{
+		item := ItemUpdatePayload{
 			FrameNumber:      frameNumber,
-			TypeID:           binary.BigEndian.Uint16(payloadBytes[0x4:0x6]),
+			TypeID:           ItemType(binary.BigEndian.Uint16(payloadBytes[0x4:0x6])),
 			State:            payloadBytes[0x6],
 			FacingDirection:  readFloat(payloadBytes[0x7:0xB]),
 			XVelocity:        readFloat(payloadBytes[0xB:0xF]),
@@ -525,6 +757,13 @@ func parsePayload(command Command, payloadBytes []byte) (*SlpEvent, error) {
 			ChargedPower:     payloadBytes[0x28],
 			Owner:            int8(payloadBytes[0x29]),
 		}
+
+		// items of replays that predate owners aren't owned by the player
+		// with the index 0
+		if payloadLength <= 0x29 {
+			item.Owner = -1
+		}
+		payload = item
 	case FrameBookend:
 		frameNumber, err := readInt(payloadBytes[0x0:0x4])
 		if err != nil {
@@ -543,7 +782,7 @@ func parsePayload(command Command, payloadBytes []byte) (*SlpEvent, error) {
 	case GeckoList:
 		payload = GeckoListPayload{GeckoCodes: payloadBytes}
 	default:
-		return nil, errors.New(fmt.Sprintf("unknown command: 0x%X", command))
+		return nil, withCode(CodeUnknownCommand, errors.New(fmt.Sprintf("unknown command: 0x%X", command)))
 	}
 
 	return &SlpEvent{
@@ -587,27 +826,6 @@ func nullTerminate(b []byte) []byte {
 	return b
 }
 
-// A Metadata contains metadata about a Slippi game.
-type Metadata struct {
-	StartAt     string                    `ubjson:"startAt"`
-	LastFrame   int32                     `ubjson:"lastFrame"`
-	Players     map[string]PlayerMetadata `ubjson:"players"`
-	PlayedOn    string                    `ubjson:"playedOn"`
-	ConsoleNick string                    `ubjson:"consoleNick"`
-}
-
-// A PlayerMetadata contains metadata about a player.
-type PlayerMetadata struct {
-	Characters map[string]int32 `ubjson:"characters"`
-	Names      Names            `ubjson:"names"`
-}
-
-// A Names contains the names of a player.
-type Names struct {
-	Netplay string `ubjson:"netplay"`
-	Code    string `ubjson:"code"`
-}
-
 // GetMetadata gets metadata from the replay SlpReader is reading.
 func (r SlpReader) GetMetadata() (*Metadata, error) {
 	if r.MetadataLength <= 0 {
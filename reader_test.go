@@ -1 +1,219 @@
 package slippi
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestYieldEventsSplicesMessageSplitter(t *testing.T) {
+	f, err := os.Open("game.slp")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer f.Close()
+
+	reader, err := NewSlpReader(*NewSlpSourceFile(f))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	events, err := reader.YieldEvents(func(*SlpEvent) bool { return false })
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	fragments := 0
+	var geckoList *GeckoListPayload
+	for result := range events {
+		if result.Error != nil {
+			t.Error(result.Error)
+			return
+		}
+
+		switch payload := result.Event.Payload.(type) {
+		case MessageSplitterPayload:
+			fragments++
+		case GeckoListPayload:
+			if fragments == 0 {
+				t.Error("expected gecko list to follow its fragments")
+			}
+			geckoList = &payload
+		}
+	}
+
+	if geckoList == nil {
+		t.Error("expected spliced gecko list event")
+		return
+	}
+
+	codes, err := geckoList.GeckoCodeList()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(codes) == 0 {
+		t.Error("expected gecko codes in spliced gecko list")
+	}
+}
+
+func TestEvents(t *testing.T) {
+	f, err := os.Open("game.slp")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer f.Close()
+
+	reader, err := NewSlpReader(*NewSlpSourceFile(f))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	count := 0
+	for event, err := range reader.Events() {
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if count == 0 && event.Command != EventPayloads {
+			t.Errorf("expected first event to be event payloads, got 0x%X", event.Command)
+		}
+
+		count++
+		if count == 10 {
+			break
+		}
+	}
+
+	if count != 10 {
+		t.Errorf("expected to stop after 10 events, got %d", count)
+	}
+}
+
+func TestTrace(t *testing.T) {
+	f, err := os.Open("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	reader, err := NewSlpReader(*NewSlpSourceFile(f))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := reader.SetInclude(byte(ItemUpdate), false); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	reader.SetTrace(NewTraceWriter(&buf))
+	reader.SetClock(NewFakeClock(time.Unix(0, 0)))
+
+	events, err := reader.YieldEvents(func(*SlpEvent) bool { return false })
+	if err != nil {
+		t.Fatal(err)
+	}
+	for result := range events {
+		if result.Error != nil {
+			t.Fatal(result.Error)
+		}
+	}
+
+	// every byte of the raw data is covered by consecutive events
+	offset := reader.RawStart
+	skipped := 0
+	decoder := json.NewDecoder(&buf)
+	for decoder.More() {
+		var entry TraceEntry
+		if err := decoder.Decode(&entry); err != nil {
+			t.Fatal(err)
+		}
+
+		if entry.Duration != 0 {
+			t.Errorf("expected no time to pass on a fake clock, got %+v", entry)
+		}
+		if entry.Offset != offset {
+			t.Fatalf("expected event at offset %d, got %+v", offset, entry)
+		}
+		offset += int64(entry.Size) + 1
+
+		if entry.Skipped {
+			skipped++
+			if entry.Command != ItemUpdate {
+				t.Errorf("expected only item updates to be skipped, got %+v", entry)
+			}
+		}
+	}
+
+	if offset != reader.RawStart+reader.RawLength {
+		t.Errorf("expected trace to end at offset %d, got %d", reader.RawStart+reader.RawLength, offset)
+	}
+	if skipped == 0 {
+		t.Error("expected skipped item updates to be traced")
+	}
+}
+
+func TestNewSlpReaderRaw(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reader, err := NewSlpReader(*NewSlpSourceBytes(bytes.NewReader(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the bare event stream is the contents of the raw element
+	stream := b[reader.RawStart : reader.RawStart+reader.RawLength]
+	raw, err := NewSlpReaderRaw(*NewSlpSourceBytes(bytes.NewReader(stream)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(raw.PayloadSizes) != len(reader.PayloadSizes) {
+		t.Errorf("expected %d payload sizes, got %d", len(reader.PayloadSizes), len(raw.PayloadSizes))
+	}
+
+	countEvents := func(r *SlpReader) map[Command]int {
+		events, err := r.YieldEvents(func(*SlpEvent) bool { return false })
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		counts := make(map[Command]int)
+		for result := range events {
+			if result.Error != nil {
+				t.Fatal(result.Error)
+			}
+			counts[result.Event.Command]++
+		}
+		return counts
+	}
+
+	expected := countEvents(reader)
+	counts := countEvents(raw)
+	for command, count := range expected {
+		if counts[command] != count {
+			t.Errorf("expected %d events of command 0x%X, got %d", count, byte(command), counts[command])
+		}
+	}
+
+	metadata, err := raw.GetMetadata()
+	if err != nil || metadata != nil {
+		t.Errorf("expected no metadata, got %v, %v", metadata, err)
+	}
+
+	if _, err := NewSlpReaderRaw(*NewSlpSourceBytes(bytes.NewReader(stream[1:]))); err == nil {
+		t.Error("expected error for stream not starting with event payloads")
+	}
+}
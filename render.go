@@ -0,0 +1,193 @@
+package slippi
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+)
+
+// RenderOpts contains options that determine how trajectories are rendered.
+type RenderOpts struct {
+	// Width is the width of the image in pixels. The height is derived from
+	// the bounds of the stage. Defaults to 800.
+	Width int
+	// FrameStride is the interval between frames sampled for player paths.
+	// Defaults to 1.
+	FrameStride int32
+	// Players restricts rendering to the players with the given indices. All
+	// players are rendered if it is empty.
+	Players []uint8
+}
+
+// renderColors are the colors used for each port, matching the in-game port
+// colors.
+var renderColors = []string{"#f15959", "#6565fe", "#fede4a", "#4ce44c"}
+
+type renderPoint struct {
+	x float32
+	y float32
+}
+
+// RenderTrajectorySVG writes an SVG image of the players' paths throughout
+// the game to w, drawn over an outline of the stage, with each stock lost
+// marked where the player was last seen alive.
+func RenderTrajectorySVG(w io.Writer, game *SlpGame, opts RenderOpts) error {
+	if opts.Width <= 0 {
+		opts.Width = 800
+	}
+	if opts.FrameStride <= 0 {
+		opts.FrameStride = 1
+	}
+
+	gameInfo, err := game.GetGameInfo()
+	if err != nil {
+		return err
+	}
+
+	frames, err := game.GetFrames()
+	if err != nil {
+		return err
+	}
+
+	frameNumbers := sortedFrameNumbers(frames)
+	if len(frameNumbers) == 0 {
+		return errors.New("replay does not contain any frames")
+	}
+
+	included := make(map[uint8]bool)
+	for _, index := range opts.Players {
+		included[index] = true
+	}
+
+	paths := make(map[uint8][][]renderPoint)
+	deaths := make(map[uint8][]renderPoint)
+	alive := make(map[uint8]renderPoint)
+	for i, frameNumber := range frameNumbers {
+		frame := frames[frameNumber]
+		for index, updates := range frame.Players {
+			if updates.Post == nil || (len(included) > 0 && !included[index]) {
+				continue
+			}
+
+			if IsDead(updates.Post.ActionStateID) {
+				if last, ok := alive[index]; ok {
+					deaths[index] = append(deaths[index], last)
+					delete(alive, index)
+				}
+				continue
+			}
+
+			point := renderPoint{updates.Post.XPosition, updates.Post.YPosition}
+			if _, ok := alive[index]; !ok {
+				paths[index] = append(paths[index], make([]renderPoint, 0))
+			}
+			alive[index] = point
+
+			if (frameNumber-frameNumbers[0])%opts.FrameStride == 0 || i == len(frameNumbers)-1 {
+				stock := len(paths[index]) - 1
+				paths[index][stock] = append(paths[index][stock], point)
+			}
+		}
+	}
+
+	stage, hasOutline := gameInfo.Stage.Geometry()
+	if !hasOutline {
+		stage = boundsOf(paths)
+	}
+
+	scale := float32(opts.Width) / (stage.BlastZoneRight - stage.BlastZoneLeft)
+	height := int(math.Ceil(float64((stage.BlastZoneTop - stage.BlastZoneBottom) * scale)))
+	toSVG := func(p renderPoint) (float32, float32) {
+		return (p.x - stage.BlastZoneLeft) * scale, (stage.BlastZoneTop - p.y) * scale
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n", opts.Width, height, opts.Width, height)
+	fmt.Fprintf(bw, "<title>%s</title>\n", gameInfo.Stage)
+	fmt.Fprintf(bw, "<rect width=\"%d\" height=\"%d\" fill=\"#1b1b1f\"/>\n", opts.Width, height)
+
+	if hasOutline {
+		x1, y := toSVG(renderPoint{-stage.LedgeX, 0})
+		x2, _ := toSVG(renderPoint{stage.LedgeX, 0})
+		fmt.Fprintf(bw, "<line x1=\"%.2f\" y1=\"%.2f\" x2=\"%.2f\" y2=\"%.2f\" stroke=\"#bbbbbb\" stroke-width=\"3\"/>\n", x1, y, x2, y)
+		for _, platform := range stage.Platforms {
+			x1, y := toSVG(renderPoint{platform.Left, platform.Height})
+			x2, _ := toSVG(renderPoint{platform.Right, platform.Height})
+			fmt.Fprintf(bw, "<line x1=\"%.2f\" y1=\"%.2f\" x2=\"%.2f\" y2=\"%.2f\" stroke=\"#888888\" stroke-width=\"2\"/>\n", x1, y, x2, y)
+		}
+	}
+
+	indices := make([]int, 0, len(paths))
+	for index := range paths {
+		indices = append(indices, int(index))
+	}
+	sort.Ints(indices)
+
+	for _, i := range indices {
+		index := uint8(i)
+		color := renderColors[int(index)%len(renderColors)]
+		for _, path := range paths[index] {
+			if len(path) == 0 {
+				continue
+			}
+
+			fmt.Fprintf(bw, "<polyline fill=\"none\" stroke=\"%s\" stroke-opacity=\"0.6\" stroke-width=\"1\" points=\"", color)
+			for j, point := range path {
+				x, y := toSVG(point)
+				if j > 0 {
+					bw.WriteByte(' ')
+				}
+				fmt.Fprintf(bw, "%.2f,%.2f", x, y)
+			}
+			fmt.Fprint(bw, "\"/>\n")
+		}
+
+		for _, death := range deaths[index] {
+			x, y := toSVG(death)
+			fmt.Fprintf(bw, "<path d=\"M%.2f %.2fl8 8m0 -8l-8 8\" stroke=\"%s\" stroke-width=\"3\"/>\n", x-4, y-4, color)
+		}
+	}
+
+	fmt.Fprint(bw, "</svg>\n")
+
+	return bw.Flush()
+}
+
+// boundsOf returns an outline with blast zones enclosing all points of the
+// given paths, for stages without a known outline.
+func boundsOf(paths map[uint8][][]renderPoint) StageGeometry {
+	bounds := StageGeometry{
+		BlastZoneLeft:   math.MaxFloat32,
+		BlastZoneRight:  -math.MaxFloat32,
+		BlastZoneTop:    -math.MaxFloat32,
+		BlastZoneBottom: math.MaxFloat32,
+	}
+
+	for _, stocks := range paths {
+		for _, path := range stocks {
+			for _, point := range path {
+				bounds.BlastZoneLeft = float32(math.Min(float64(bounds.BlastZoneLeft), float64(point.x)))
+				bounds.BlastZoneRight = float32(math.Max(float64(bounds.BlastZoneRight), float64(point.x)))
+				bounds.BlastZoneBottom = float32(math.Min(float64(bounds.BlastZoneBottom), float64(point.y)))
+				bounds.BlastZoneTop = float32(math.Max(float64(bounds.BlastZoneTop), float64(point.y)))
+			}
+		}
+	}
+
+	if bounds.BlastZoneLeft > bounds.BlastZoneRight {
+		return StageGeometry{-100, 100, 100, -100, 0, nil}
+	}
+
+	// pad the bounds so that paths aren't drawn against the border
+	padX := (bounds.BlastZoneRight-bounds.BlastZoneLeft)*0.05 + 1
+	padY := (bounds.BlastZoneTop-bounds.BlastZoneBottom)*0.05 + 1
+	bounds.BlastZoneLeft -= padX
+	bounds.BlastZoneRight += padX
+	bounds.BlastZoneBottom -= padY
+	bounds.BlastZoneTop += padY
+
+	return bounds
+}
@@ -0,0 +1,52 @@
+package slippi
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRenderTrajectorySVG(t *testing.T) {
+	f, err := os.Open("game.slp")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	game, err := NewSlpGameFromFile(f, nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer game.Close()
+
+	var b bytes.Buffer
+	err = RenderTrajectorySVG(&b, game, RenderOpts{FrameStride: 4})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(b.Bytes()))
+	for {
+		_, err := decoder.Token()
+		if err != nil {
+			if err != io.EOF {
+				t.Errorf("invalid svg: %s", err)
+			}
+			break
+		}
+	}
+
+	svg := b.String()
+	if strings.Count(svg, "<polyline") == 0 {
+		t.Error("expected player paths")
+	}
+
+	if strings.Count(svg, "<path") == 0 {
+		t.Error("expected kill markers")
+	}
+}
@@ -0,0 +1,130 @@
+package slippi
+
+import "github.com/blang/semver/v4"
+
+// MajorScene enumerates the major scenes in Melee, as stored in the GameStart
+// event. Slippi uses the otherwise unused scene 0x8 for online play.
+type MajorScene uint8
+
+// MajorScenes
+const (
+	SceneTitle MajorScene = iota
+	SceneMainMenu
+	SceneVS
+	SceneClassic
+	SceneAdventure
+	SceneAllStar
+	SceneDebug
+	SceneSoundTest
+	SceneOnline
+	SceneCamera
+	SceneTrophyGallery
+	SceneTrophyLottery
+	SceneTrophyCollection
+	SceneDebugVS
+	SceneUnused
+	SceneTargetTest
+	SceneSuperSuddenDeath
+	SceneInvisibleMelee
+	SceneSloMoMelee
+	SceneLightningMelee
+	SceneChallengerApproaching
+	SceneClassicEnding
+	SceneAdventureEnding
+	SceneAllStarEnding
+	SceneOpeningMovie
+	SceneDebugCutscene
+	SceneDebugEndOfGame
+	SceneTournament
+	SceneTraining
+	SceneTinyMelee
+	SceneGiantMelee
+	SceneStamina
+	SceneHomeRunContest
+	SceneTenManMelee
+	SceneHundredManMelee
+	SceneThreeMinuteMelee
+	SceneFifteenMinuteMelee
+	SceneEndlessMelee
+	SceneCruelMelee
+)
+
+// MinorScene enumerates the minor scenes within a major scene, as stored in
+// the GameStart event.
+type MinorScene uint8
+
+// MinorScenes
+const (
+	SceneCharacterSelect MinorScene = 0x0
+	SceneStageSelect     MinorScene = 0x1
+	SceneInGame          MinorScene = 0x2
+)
+
+// minSceneVersion is the earliest replay version that records scenes.
+var minSceneVersion = semver.MustParse("3.7.0")
+
+// GameMode enumerates the modes a game can be played in.
+type GameMode uint8
+
+// GameModes
+const (
+	UnknownMode GameMode = iota
+	VSMode
+	OnlineMode
+	TargetTestMode
+	HomeRunContestMode
+	TrainingMode
+	MultiManMeleeMode
+	SpecialMeleeMode
+	OtherMode
+)
+
+var gameModeNames = map[GameMode]string{
+	UnknownMode:        "Unknown",
+	VSMode:             "VS Mode",
+	OnlineMode:         "Online",
+	TargetTestMode:     "Target Test",
+	HomeRunContestMode: "Home-Run Contest",
+	TrainingMode:       "Training Mode",
+	MultiManMeleeMode:  "Multi-Man Melee",
+	SpecialMeleeMode:   "Special Melee",
+	OtherMode:          "Other",
+}
+
+// String returns the human-readable name of the game mode.
+func (m GameMode) String() string {
+	return gameModeNames[m]
+}
+
+// IsSinglePlayer returns whether the game mode is a single player mode with
+// its own end conditions, rather than a match between players.
+func (m GameMode) IsSinglePlayer() bool {
+	return m == TargetTestMode || m == HomeRunContestMode || m == MultiManMeleeMode
+}
+
+// GameMode returns the mode the game was played in. Replays from before
+// version 3.7.0 don't record scenes, so their mode is unknown.
+func (g GameInfo) GameMode() GameMode {
+	switch g.MajorScene {
+	case SceneVS, SceneTournament:
+		return VSMode
+	case SceneOnline:
+		return OnlineMode
+	case SceneTargetTest:
+		return TargetTestMode
+	case SceneHomeRunContest:
+		return HomeRunContestMode
+	case SceneTraining:
+		return TrainingMode
+	case SceneTenManMelee, SceneHundredManMelee, SceneThreeMinuteMelee, SceneFifteenMinuteMelee, SceneEndlessMelee, SceneCruelMelee:
+		return MultiManMeleeMode
+	case SceneSuperSuddenDeath, SceneInvisibleMelee, SceneSloMoMelee, SceneLightningMelee, SceneTinyMelee, SceneGiantMelee, SceneStamina:
+		return SpecialMeleeMode
+	}
+
+	if g.Version.LT(minSceneVersion) {
+		return UnknownMode
+	}
+
+	return OtherMode
+}
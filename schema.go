@@ -0,0 +1,298 @@
+package slippi
+
+import (
+	"strings"
+
+	"github.com/blang/semver/v4"
+)
+
+// A FieldSchema describes a single field of an event payload.
+type FieldSchema struct {
+	Name string
+	// Type is the Go type of the field, or "struct" for nested fields.
+	Type string
+	// Unit is the unit of the field's value, if it has one.
+	Unit string
+	// Since is the earliest replay version in which the field is present.
+	Since string
+	// Fields are the fields of nested structs.
+	Fields []FieldSchema
+}
+
+// A PayloadSchema describes the payload of an event.
+type PayloadSchema struct {
+	Command Command
+	Name    string
+	// Since is the earliest replay version in which the event is present.
+	Since  string
+	Fields []FieldSchema
+}
+
+// AvailableIn returns whether the field is present in replays of the given
+// version.
+func (f FieldSchema) AvailableIn(version semver.Version) bool {
+	return version.GTE(semver.MustParse(f.Since))
+}
+
+// Field returns the field with the given name, and whether it exists.
+func (p PayloadSchema) Field(name string) (FieldSchema, bool) {
+	for _, field := range p.Fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+
+	return FieldSchema{}, false
+}
+
+// Has returns whether replays of the game's version record the given field of
+// the payload of the given command. Fields of nested structs are named by
+// their path, such as "Players.ConnectCode". Fields that aren't recorded are
+// read as zero values, so Has tells missing values apart from real zeros.
+func (g GameInfo) Has(command Command, field string) bool {
+	schema, ok := SchemaOf(command)
+	if !ok || g.Version.LT(semver.MustParse(schema.Since)) {
+		return false
+	}
+
+	fields := schema.Fields
+	for _, name := range strings.Split(field, ".") {
+		found := false
+		for _, f := range fields {
+			if f.Name == name {
+				if !f.AvailableIn(g.Version) {
+					return false
+				}
+				fields = f.Fields
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Schema returns the schemas of all event payloads, ordered by command.
+func Schema() []PayloadSchema {
+	return append(make([]PayloadSchema, 0, len(payloadSchemas)), payloadSchemas...)
+}
+
+// SchemaOf returns the schema of the payload of the given command, and
+// whether the command is known.
+func SchemaOf(command Command) (PayloadSchema, bool) {
+	for _, schema := range payloadSchemas {
+		if schema.Command == command {
+			return schema, true
+		}
+	}
+
+	return PayloadSchema{}, false
+}
+
+var frameUpdateSchema = []FieldSchema{
+	{Name: "FrameNumber", Type: "int32", Unit: "frames", Since: "0.1.0"},
+	{Name: "PlayerIndex", Type: "uint8", Since: "0.1.0"},
+	{Name: "IsFollower", Type: "bool", Since: "0.1.0"},
+	{Name: "ActionStateID", Type: "uint16", Since: "0.1.0"},
+	{Name: "XPosition", Type: "float32", Unit: "units", Since: "0.1.0"},
+	{Name: "YPosition", Type: "float32", Unit: "units", Since: "0.1.0"},
+	{Name: "FacingDirection", Type: "float32", Unit: "direction", Since: "0.1.0"},
+}
+
+var playerInfoSchema = []FieldSchema{
+	{Name: "Index", Type: "uint8", Since: "0.1.0"},
+	{Name: "Port", Type: "uint8", Since: "0.1.0"},
+	{Name: "CharacterID", Type: "CharacterID", Since: "0.1.0"},
+	{Name: "PlayerType", Type: "PlayerType", Since: "0.1.0"},
+	{Name: "StockStartCount", Type: "uint8", Unit: "stocks", Since: "0.1.0"},
+	{Name: "CostumeIndex", Type: "uint8", Since: "0.1.0"},
+	{Name: "TeamShade", Type: "TeamShade", Since: "0.1.0"},
+	{Name: "Handicap", Type: "uint8", Since: "0.1.0"},
+	{Name: "TeamID", Type: "TeamID", Since: "0.1.0"},
+	{Name: "PlayerBitfield", Type: "uint8", Since: "0.1.0"},
+	{Name: "CPULevel", Type: "uint8", Since: "0.1.0"},
+	{Name: "OffenseRatio", Type: "float32", Unit: "ratio", Since: "0.1.0"},
+	{Name: "DefenseRatio", Type: "float32", Unit: "ratio", Since: "0.1.0"},
+	{Name: "ModelScale", Type: "float32", Unit: "ratio", Since: "0.1.0"},
+	{Name: "DashbackFix", Type: "DashbackFix", Since: "1.0.0"},
+	{Name: "ShieldDropFix", Type: "ShieldDropFix", Since: "1.0.0"},
+	{Name: "Nametag", Type: "string", Since: "1.3.0"},
+	{Name: "DisplayName", Type: "string", Since: "3.9.0"},
+	{Name: "ConnectCode", Type: "string", Since: "3.9.0"},
+	{Name: "SlippiUID", Type: "string", Since: "3.11.0"},
+}
+
+var gameInfoBlockSchema = []FieldSchema{
+	{Name: "GameBitfield1", Type: "uint8", Since: "0.1.0"},
+	{Name: "GameBitfield2", Type: "uint8", Since: "0.1.0"},
+	{Name: "GameBitfield3", Type: "uint8", Since: "0.1.0"},
+	{Name: "GameBitfield4", Type: "uint8", Since: "0.1.0"},
+	{Name: "BombRain", Type: "uint8", Since: "0.1.0"},
+	{Name: "IsTeams", Type: "bool", Since: "0.1.0"},
+	{Name: "ItemSpawnBehavior", Type: "ItemSpawnBehavior", Since: "0.1.0"},
+	{Name: "SelfDestructScoreValue", Type: "int8", Since: "0.1.0"},
+	{Name: "Stage", Type: "StageID", Since: "0.1.0"},
+	{Name: "GameTimer", Type: "uint32", Unit: "seconds", Since: "0.1.0"},
+	{Name: "ItemSpawnBitfield1", Type: "uint8", Since: "0.1.0"},
+	{Name: "ItemSpawnBitfield2", Type: "uint8", Since: "0.1.0"},
+	{Name: "ItemSpawnBitfield3", Type: "uint8", Since: "0.1.0"},
+	{Name: "ItemSpawnBitfield4", Type: "uint8", Since: "0.1.0"},
+	{Name: "ItemSpawnBitfield5", Type: "uint8", Since: "0.1.0"},
+	{Name: "DamageRatio", Type: "float32", Unit: "ratio", Since: "0.1.0"},
+}
+
+var payloadSchemas = []PayloadSchema{
+	{
+		Command: MessageSplitter,
+		Name:    "MessageSplitter",
+		Since:   "3.3.0",
+		Fields: []FieldSchema{
+			{Name: "Data", Type: "[512]uint8", Since: "3.3.0"},
+			{Name: "DataLength", Type: "uint16", Unit: "bytes", Since: "3.3.0"},
+			{Name: "InternalCommand", Type: "uint8", Since: "3.3.0"},
+			{Name: "LastMessage", Type: "bool", Since: "3.3.0"},
+		},
+	},
+	{
+		Command: EventPayloads,
+		Name:    "EventPayloads",
+		Since:   "0.1.0",
+		Fields: []FieldSchema{
+			{Name: "PayloadSize", Type: "uint8", Unit: "bytes", Since: "0.1.0"},
+			{Name: "PayloadSizes", Type: "map[uint8]uint16", Unit: "bytes", Since: "0.1.0"},
+		},
+	},
+	{
+		Command: GameStart,
+		Name:    "GameStart",
+		Since:   "0.1.0",
+		Fields: []FieldSchema{
+			{Name: "Version", Type: "semver.Version", Since: "0.1.0"},
+			{Name: "GameInfoBlock", Type: "struct", Since: "0.1.0", Fields: gameInfoBlockSchema},
+			{Name: "Players", Type: "[4]struct", Since: "0.1.0", Fields: playerInfoSchema},
+			{Name: "RandomSeed", Type: "uint32", Since: "0.1.0"},
+			{Name: "PAL", Type: "bool", Since: "1.5.0"},
+			{Name: "FrozenPS", Type: "bool", Since: "2.0.0"},
+			{Name: "MajorScene", Type: "MajorScene", Since: "3.7.0"},
+			{Name: "MinorScene", Type: "MinorScene", Since: "3.7.0"},
+			{Name: "LanguageOption", Type: "Language", Since: "3.12.0"},
+		},
+	},
+	{
+		Command: PreFrameUpdate,
+		Name:    "PreFrameUpdate",
+		Since:   "0.1.0",
+		Fields: append(append(make([]FieldSchema, 0), frameUpdateSchema...),
+			FieldSchema{Name: "Percent", Type: "float32", Unit: "percent", Since: "1.4.0"},
+			FieldSchema{Name: "RandomSeed", Type: "uint32", Since: "0.1.0"},
+			FieldSchema{Name: "JoystickX", Type: "float32", Unit: "axis", Since: "0.1.0"},
+			FieldSchema{Name: "JoystickY", Type: "float32", Unit: "axis", Since: "0.1.0"},
+			FieldSchema{Name: "CStickX", Type: "float32", Unit: "axis", Since: "0.1.0"},
+			FieldSchema{Name: "CStickY", Type: "float32", Unit: "axis", Since: "0.1.0"},
+			FieldSchema{Name: "Trigger", Type: "float32", Unit: "axis", Since: "0.1.0"},
+			FieldSchema{Name: "ProcessedButtons", Type: "uint32", Unit: "bitfield", Since: "0.1.0"},
+			FieldSchema{Name: "PhysicalButtons", Type: "uint16", Unit: "bitfield", Since: "0.1.0"},
+			FieldSchema{Name: "PhysicalLTrigger", Type: "float32", Unit: "axis", Since: "0.1.0"},
+			FieldSchema{Name: "PhysicalRTrigger", Type: "float32", Unit: "axis", Since: "0.1.0"},
+			FieldSchema{Name: "XAnalogUCF", Type: "uint8", Since: "1.2.0"},
+		),
+	},
+	{
+		Command: PostFrameUpdate,
+		Name:    "PostFrameUpdate",
+		Since:   "0.1.0",
+		Fields: append(append(make([]FieldSchema, 0), frameUpdateSchema...),
+			FieldSchema{Name: "Percent", Type: "float32", Unit: "percent", Since: "0.1.0"},
+			FieldSchema{Name: "InternalCharacterID", Type: "uint8", Since: "0.1.0"},
+			FieldSchema{Name: "ShieldSize", Type: "float32", Unit: "health", Since: "0.1.0"},
+			FieldSchema{Name: "LastHittingAttackID", Type: "AttackID", Since: "0.1.0"},
+			FieldSchema{Name: "CurrentComboCount", Type: "uint8", Unit: "hits", Since: "0.1.0"},
+			FieldSchema{Name: "LastHitBy", Type: "uint8", Since: "0.1.0"},
+			FieldSchema{Name: "StocksRemaining", Type: "uint8", Unit: "stocks", Since: "0.1.0"},
+			FieldSchema{Name: "ActionStateFrameCounter", Type: "float32", Unit: "frames", Since: "0.2.0"},
+			FieldSchema{Name: "StateBitFlags1", Type: "uint8", Unit: "bitfield", Since: "2.0.0"},
+			FieldSchema{Name: "StateBitFlags2", Type: "uint8", Unit: "bitfield", Since: "2.0.0"},
+			FieldSchema{Name: "StateBitFlags3", Type: "uint8", Unit: "bitfield", Since: "2.0.0"},
+			FieldSchema{Name: "StateBitFlags4", Type: "uint8", Unit: "bitfield", Since: "2.0.0"},
+			FieldSchema{Name: "StateBitFlags5", Type: "uint8", Unit: "bitfield", Since: "2.0.0"},
+			FieldSchema{Name: "MiscAS", Type: "float32", Since: "2.0.0"},
+			FieldSchema{Name: "Airborne", Type: "bool", Since: "2.0.0"},
+			FieldSchema{Name: "LastGroundID", Type: "uint16", Since: "2.0.0"},
+			FieldSchema{Name: "JumpsRemaining", Type: "uint8", Unit: "jumps", Since: "2.0.0"},
+			FieldSchema{Name: "LCancelStatus", Type: "LCancelStatus", Since: "2.0.0"},
+			FieldSchema{Name: "HurtboxCollisionState", Type: "HurtboxCollisionState", Since: "2.1.0"},
+			FieldSchema{Name: "SelfInducedAirXSpeed", Type: "float32", Unit: "units/frame", Since: "3.5.0"},
+			FieldSchema{Name: "SelfInducedYSpeed", Type: "float32", Unit: "units/frame", Since: "3.5.0"},
+			FieldSchema{Name: "AttackBasedXSpeed", Type: "float32", Unit: "units/frame", Since: "3.5.0"},
+			FieldSchema{Name: "AttackBasedYSpeed", Type: "float32", Unit: "units/frame", Since: "3.5.0"},
+			FieldSchema{Name: "SelfInducedGroundXSpeed", Type: "float32", Unit: "units/frame", Since: "3.5.0"},
+			FieldSchema{Name: "HitlagFramesRemaining", Type: "float32", Unit: "frames", Since: "3.8.0"},
+			FieldSchema{Name: "AnimationIndex", Type: "uint32", Since: "3.11.0"},
+		),
+	},
+	{
+		Command: GameEnd,
+		Name:    "GameEnd",
+		Since:   "0.1.0",
+		Fields: []FieldSchema{
+			{Name: "GameEndMethod", Type: "GameEndMethod", Since: "0.1.0"},
+			{Name: "LRASInitiator", Type: "int8", Since: "2.0.0"},
+			{Name: "Placements", Type: "[4]int8", Since: "3.13.0"},
+		},
+	},
+	{
+		Command: FrameStart,
+		Name:    "FrameStart",
+		Since:   "2.2.0",
+		Fields: []FieldSchema{
+			{Name: "FrameNumber", Type: "int32", Unit: "frames", Since: "2.2.0"},
+			{Name: "RandomSeed", Type: "uint32", Since: "2.2.0"},
+			{Name: "SceneFrameCounter", Type: "uint32", Unit: "frames", Since: "3.10.0"},
+		},
+	},
+	{
+		Command: ItemUpdate,
+		Name:    "ItemUpdate",
+		Since:   "3.0.0",
+		Fields: []FieldSchema{
+			{Name: "FrameNumber", Type: "int32", Unit: "frames", Since: "3.0.0"},
+			{Name: "TypeID", Type: "ItemType", Since: "3.0.0"},
+			{Name: "State", Type: "uint8", Since: "3.0.0"},
+			{Name: "FacingDirection", Type: "float32", Unit: "direction", Since: "3.0.0"},
+			{Name: "XVelocity", Type: "float32", Unit: "units/frame", Since: "3.0.0"},
+			{Name: "YVelocity", Type: "float32", Unit: "units/frame", Since: "3.0.0"},
+			{Name: "XPosition", Type: "float32", Unit: "units", Since: "3.0.0"},
+			{Name: "YPosition", Type: "float32", Unit: "units", Since: "3.0.0"},
+			{Name: "DamageTaken", Type: "uint16", Unit: "percent", Since: "3.0.0"},
+			{Name: "ExpirationTimer", Type: "float32", Unit: "frames", Since: "3.0.0"},
+			{Name: "SpawnID", Type: "uint32", Since: "3.0.0"},
+			{Name: "SamusMissileType", Type: "uint8", Since: "3.2.0"},
+			{Name: "PeachTurnipFace", Type: "uint8", Since: "3.2.0"},
+			{Name: "IsLaunched", Type: "uint8", Since: "3.2.0"},
+			{Name: "ChargedPower", Type: "uint8", Since: "3.2.0"},
+			{Name: "Owner", Type: "int8", Since: "3.6.0"},
+		},
+	},
+	{
+		Command: FrameBookend,
+		Name:    "FrameBookend",
+		Since:   "3.0.0",
+		Fields: []FieldSchema{
+			{Name: "FrameNumber", Type: "int32", Unit: "frames", Since: "3.0.0"},
+			{Name: "LatestFinalizedFrame", Type: "int32", Unit: "frames", Since: "3.7.0"},
+		},
+	},
+	{
+		Command: GeckoList,
+		Name:    "GeckoList",
+		Since:   "3.3.0",
+		Fields: []FieldSchema{
+			{Name: "GeckoCodes", Type: "[]byte", Since: "3.3.0"},
+		},
+	},
+}
@@ -0,0 +1,106 @@
+package slippi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/blang/semver/v4"
+)
+
+func TestSchemaMatchesPayloads(t *testing.T) {
+	payloads := map[Command]interface{}{
+		MessageSplitter: MessageSplitterPayload{},
+		EventPayloads:   EventPayloadsPayload{},
+		GameStart:       GameStartPayload{},
+		PreFrameUpdate:  PreFrameUpdatePayload{},
+		PostFrameUpdate: PostFrameUpdatePayload{},
+		GameEnd:         GameEndPayload{},
+		FrameStart:      FrameStartPayload{},
+		ItemUpdate:      ItemUpdatePayload{},
+		FrameBookend:    FrameBookendPayload{},
+		GeckoList:       GeckoListPayload{},
+	}
+
+	if len(Schema()) != len(payloads) {
+		t.Errorf("expected %d payload schemas, got %d", len(payloads), len(Schema()))
+	}
+
+	for command, payload := range payloads {
+		schema, ok := SchemaOf(command)
+		if !ok {
+			t.Errorf("missing schema for command 0x%X", command)
+			continue
+		}
+
+		fields := payloadFieldNames(reflect.TypeOf(payload))
+		if len(fields) != len(schema.Fields) {
+			t.Errorf("%s: expected %d fields, got %d", schema.Name, len(fields), len(schema.Fields))
+		}
+
+		for _, name := range fields {
+			field, ok := schema.Field(name)
+			if !ok {
+				t.Errorf("%s: missing schema for field %s", schema.Name, name)
+				continue
+			}
+
+			if _, err := semver.Parse(field.Since); err != nil {
+				t.Errorf("%s.%s: invalid version %q", schema.Name, name, field.Since)
+			}
+		}
+	}
+
+	schema, _ := SchemaOf(PostFrameUpdate)
+	field, _ := schema.Field("HitlagFramesRemaining")
+	if field.AvailableIn(semver.MustParse("3.7.0")) || !field.AvailableIn(semver.MustParse("3.12.0")) {
+		t.Errorf("unexpected availability of %s", field.Name)
+	}
+}
+
+// payloadFieldNames returns the names of the fields of a payload type, with
+// the fields of embedded structs flattened.
+func payloadFieldNames(payloadType reflect.Type) []string {
+	names := make([]string, 0, payloadType.NumField())
+	for i := 0; i < payloadType.NumField(); i++ {
+		field := payloadType.Field(i)
+		if field.Anonymous {
+			names = append(names, payloadFieldNames(field.Type)...)
+		} else {
+			names = append(names, field.Name)
+		}
+	}
+
+	return names
+}
+
+func TestGameInfoHas(t *testing.T) {
+	gameInfo := GameInfo{Version: semver.MustParse("2.0.0")}
+
+	cases := []struct {
+		command  Command
+		field    string
+		expected bool
+	}{
+		{PostFrameUpdate, "LCancelStatus", true},
+		{PostFrameUpdate, "HitlagFramesRemaining", false},
+		{GameStart, "Players.CharacterID", true},
+		{GameStart, "Players.ConnectCode", false},
+		{GameStart, "Players.Unknown", false},
+		{ItemUpdate, "TypeID", false},
+	}
+
+	for _, c := range cases {
+		if has := gameInfo.Has(c.command, c.field); has != c.expected {
+			t.Errorf("%s of 0x%X: expected %t, got %t", c.field, c.command, c.expected, has)
+		}
+	}
+
+	// fields missing from older payloads are read as zero values
+	event, err := parsePayload(PostFrameUpdate, make([]byte, 0x34))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if post := event.Payload.(PostFrameUpdatePayload); post.HitlagFramesRemaining != 0 {
+		t.Errorf("expected missing field to be zero, got %f", post.HitlagFramesRemaining)
+	}
+}
@@ -0,0 +1,47 @@
+// Package slippitest provides helpers for benchmarking the slippi package, so
+// that downstream forks and CI can track parsing performance over time
+// without the slippi package itself depending on testing.
+package slippitest
+
+import (
+	"testing"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+// ParseBench benchmarks parsing replay into frames, reporting allocations,
+// throughput in bytes, and the number of parses and frames per second.
+// Replays of varying length and rollback density can be generated with
+// slippi.SyntheticReplay:
+//
+//	func BenchmarkParse(b *testing.B) {
+//		slippitest.ParseBench(b, replay)
+//	}
+func ParseBench(b *testing.B, replay []byte) {
+	b.Helper()
+	b.ReportAllocs()
+	b.SetBytes(int64(len(replay)))
+
+	frames := 0
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		game, err := slippi.NewSlpGameFromBytes(replay, nil)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		store, err := game.GetFrameStore()
+		if err != nil {
+			b.Fatal(err)
+		}
+		frames = store.FrameCount()
+
+		game.Close()
+	}
+	b.StopTimer()
+
+	if seconds := b.Elapsed().Seconds(); seconds > 0 {
+		b.ReportMetric(float64(b.N)/seconds, "parses/s")
+		b.ReportMetric(float64(b.N*frames)/seconds, "frames/s")
+	}
+}
@@ -0,0 +1,53 @@
+package slippitest
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+// Reference numbers, from a single core of an Intel Xeon with Go 1.27:
+//
+//	                                    parses/s  frames/s  allocs/op
+//	game.slp                                 3.4     41701     763866
+//	frames=3600/rollbacks=none              15.6     56069     176514
+//	frames=3600/rollbacks=sparse            14.5     52018     182438
+//	frames=3600/rollbacks=dense              3.7     13335     803406
+//	frames=28800/rollbacks=none              1.9     54214    1411392
+//	frames=28800/rollbacks=sparse            2.3     65651    1459319
+//	frames=28800/rollbacks=dense             0.4     11094    6435952
+func BenchmarkParse(b *testing.B) {
+	replay, err := os.ReadFile("../game.slp")
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Run("game.slp", func(b *testing.B) {
+		ParseBench(b, replay)
+	})
+
+	densities := []struct {
+		name string
+		opts slippi.SyntheticReplayOpts
+	}{
+		{"none", slippi.SyntheticReplayOpts{}},
+		{"sparse", slippi.SyntheticReplayOpts{RollbackEvery: 60, RollbackLength: 2}},
+		{"dense", slippi.SyntheticReplayOpts{RollbackEvery: 2, RollbackLength: slippi.MaxRollbackFrames}},
+	}
+	for _, frames := range []int{3600, 28800} {
+		for _, density := range densities {
+			opts := density.opts
+			opts.Frames = frames
+
+			replay, err := slippi.SyntheticReplay(opts)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			b.Run(fmt.Sprintf("frames=%d/rollbacks=%s", frames, density.name), func(b *testing.B) {
+				ParseBench(b, replay)
+			})
+		}
+	}
+}
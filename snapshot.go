@@ -0,0 +1,117 @@
+package slippi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// A PlayerSnapshot is the state of a player on a frame, along with context
+// derived from the frames before it.
+type PlayerSnapshot struct {
+	PlayerIndex uint8
+	Pre         *PreFrameUpdatePayload
+	Post        *PostFrameUpdatePayload
+	// Follower holds the updates of the player's follower, such as Nana for
+	// Ice Climbers, if they have one.
+	Follower *FrameUpdates
+	Stocks   uint8
+	Percent  float32
+	// Combo is the conversion in progress against the player, or nil if
+	// they aren't being comboed.
+	Combo *Conversion
+	// LastHit is the last hit the player took on or before the frame, and
+	// LastHitFrame the frame it landed on, or nil if they haven't been hit.
+	LastHit      *Hit
+	LastHitFrame int32
+}
+
+// A FrameSnapshot is the state of the game on a frame, with the context needed
+// to display it without the rest of the game, such as in review tools that
+// scrub through a replay.
+type FrameSnapshot struct {
+	Frame   int32
+	Players map[uint8]*PlayerSnapshot
+	// Items are the items active on the frame.
+	Items []ItemUpdatePayload
+}
+
+// StateAt returns a snapshot of the game on the frame with the given number.
+// The context of the snapshot is derived from every frame up to it, so each
+// call processes the frames from the start of the game.
+func (g *SlpGame) StateAt(frameNumber int32) (*FrameSnapshot, error) {
+	store, err := g.GetFrameStore()
+	if err != nil {
+		return nil, err
+	}
+
+	target := store.FrameAt(frameNumber)
+	if target == nil {
+		return nil, errors.New(fmt.Sprintf("frame %d not found", frameNumber))
+	}
+
+	snapshot := &FrameSnapshot{
+		Frame:   frameNumber,
+		Players: make(map[uint8]*PlayerSnapshot),
+		Items:   append(make([]ItemUpdatePayload, 0), target.Items...),
+	}
+	for index, updates := range target.Players {
+		player := &PlayerSnapshot{
+			PlayerIndex: index,
+			Pre:         updates.Pre,
+			Post:        updates.Post,
+		}
+		if follower, ok := target.Followers[index]; ok {
+			player.Follower = &follower
+		}
+		if updates.Post != nil {
+			player.Stocks = updates.Post.StocksRemaining
+			player.Percent = updates.Post.Percent
+		}
+		snapshot.Players[index] = player
+	}
+
+	// replay the frames up to the target to find the combos in progress and
+	// the last hit each player took
+	tracker := NewConversionTracker(func(Conversion) {})
+	var prev *FrameEntry
+	for n := FirstFrame; n <= frameNumber; n++ {
+		frame := store.FrameAt(n)
+		if frame == nil {
+			continue
+		}
+		tracker.ProcessFrame(*frame)
+
+		if prev != nil {
+			for index, updates := range frame.Players {
+				player, ok := snapshot.Players[index]
+				prevPost := prev.Players[index].Post
+				if !ok || updates.Post == nil || prevPost == nil || updates.Post.Percent <= prevPost.Percent {
+					continue
+				}
+
+				if hit, ok := AttributeHit(*frame, *prev, index); ok {
+					player.LastHit = &hit
+					player.LastHitFrame = n
+				}
+			}
+		}
+		prev = frame
+	}
+
+	for key, state := range tracker.states {
+		player, ok := snapshot.Players[key[1]]
+		if !ok || state.conversion == nil {
+			continue
+		}
+
+		// a player may be comboed by several opponents at once, in which
+		// case the combo that started first is kept
+		if player.Combo == nil || state.conversion.StartFrame < player.Combo.StartFrame {
+			combo := *state.conversion
+			combo.Moves = append(make([]ConversionMove, 0), combo.Moves...)
+			player.Combo = &combo
+		}
+	}
+
+	return snapshot, nil
+}
@@ -0,0 +1,65 @@
+package slippi
+
+import (
+	"os"
+	"testing"
+)
+
+func TestStateAt(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game, err := NewSlpGameFromBytes(b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer game.Close()
+
+	store, err := game.GetFrameStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// find a combo with a few hits to scrub into
+	var combo *Conversion
+	tracker := NewConversionTracker(func(c Conversion) {
+		if combo == nil && len(c.Moves) >= 3 {
+			combo = &c
+		}
+	})
+	for _, frame := range store.All() {
+		tracker.ProcessFrame(frame)
+	}
+	if combo == nil {
+		t.Fatal("expected a combo of at least 3 moves")
+	}
+
+	second := combo.Moves[1].Frame
+	snapshot, err := game.StateAt(second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defender := snapshot.Players[combo.DefenderIndex]
+	if defender == nil || defender.Post == nil || defender.Percent != defender.Post.Percent || defender.Stocks == 0 {
+		t.Fatalf("expected the defender's state on frame %d, got %+v", second, defender)
+	}
+
+	if defender.Combo == nil || defender.Combo.StartFrame != combo.StartFrame || len(defender.Combo.Moves) != 2 {
+		t.Errorf("expected the combo starting on frame %d with 2 moves, got %+v", combo.StartFrame, defender.Combo)
+	}
+
+	if defender.LastHit == nil || defender.LastHit.AttackerIndex != combo.AttackerIndex || defender.LastHitFrame != second {
+		t.Errorf("expected the last hit to land on frame %d, got %+v on %d", second, defender.LastHit, defender.LastHitFrame)
+	}
+
+	if attacker := snapshot.Players[combo.AttackerIndex]; attacker == nil || attacker.Combo != nil {
+		t.Errorf("expected the attacker not to be comboed, got %+v", attacker)
+	}
+
+	if _, err := game.StateAt(FirstFrame - 1); err == nil {
+		t.Error("expected error for a frame before the game")
+	}
+}
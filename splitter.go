@@ -0,0 +1,39 @@
+package slippi
+
+// A MessageSplitterAssembler reassembles events that were split across
+// multiple MessageSplitter events because they were too large to send in one.
+type MessageSplitterAssembler struct {
+	buffers map[uint8][]byte
+}
+
+// NewMessageSplitterAssembler returns a new MessageSplitterAssembler.
+func NewMessageSplitterAssembler() *MessageSplitterAssembler {
+	return &MessageSplitterAssembler{
+		buffers: make(map[uint8][]byte),
+	}
+}
+
+// Add adds a fragment to the event being assembled for the fragment's
+// internal command. It returns the reassembled event once the last fragment
+// has been added, and nil otherwise.
+func (a *MessageSplitterAssembler) Add(fragment MessageSplitterPayload) (*SlpEvent, error) {
+	dataLength := int(fragment.DataLength)
+	if dataLength > len(fragment.Data) {
+		dataLength = len(fragment.Data)
+	}
+
+	buffer := append(a.buffers[fragment.InternalCommand], fragment.Data[:dataLength]...)
+	if !fragment.LastMessage {
+		a.buffers[fragment.InternalCommand] = buffer
+		return nil, nil
+	}
+
+	delete(a.buffers, fragment.InternalCommand)
+
+	return parsePayload(Command(fragment.InternalCommand), buffer)
+}
+
+// Reset discards all partially assembled events.
+func (a *MessageSplitterAssembler) Reset() {
+	a.buffers = make(map[uint8][]byte)
+}
@@ -0,0 +1,79 @@
+package slippi
+
+// A Platform is a platform above a stage's main stage, spanning from Left to
+// Right at Height.
+type Platform struct {
+	Surface GroundSurface
+	Left    float32
+	Right   float32
+	Height  float32
+}
+
+// StageGeometry contains the positions of a stage's blast zones, ledges and
+// platforms, in the same units as character positions. The main stage is at
+// a height of 0, with its ledges at -LedgeX and LedgeX.
+type StageGeometry struct {
+	BlastZoneLeft   float32
+	BlastZoneRight  float32
+	BlastZoneTop    float32
+	BlastZoneBottom float32
+	LedgeX          float32
+	Platforms       []Platform
+}
+
+// stageGeometries contains the geometry of the legal stages. Platforms are
+// ordered left, right, then top.
+var stageGeometries = map[StageID]StageGeometry{
+	FountainOfDreams: {-198.75, 198.75, 202.5, -146.25, 63.35, []Platform{
+		{LeftPlatform, -49.5, -21, 16.125}, {RightPlatform, 21, 49.5, 16.125}, {TopPlatform, -14.25, 14.25, 42.75},
+	}},
+	PokemonStadium: {-230, 230, 180, -111, 87.75, []Platform{
+		{LeftPlatform, -55, -25, 25}, {RightPlatform, 25, 55, 25},
+	}},
+	YoshisStory: {-175.7, 173.6, 168, -91, 56, []Platform{
+		{LeftPlatform, -59.5, -28, 23.45}, {RightPlatform, 28, 59.5, 23.45}, {TopPlatform, -15.75, 15.75, 42},
+	}},
+	DreamLand: {-255, 255, 250, -123, 77.27, []Platform{
+		{LeftPlatform, -61.39, -31.73, 30.14}, {RightPlatform, 31.7, 63.08, 30.14}, {TopPlatform, -19.02, 19.02, 51.43},
+	}},
+	Battlefield: {-224, 224, 200, -108.8, 68.4, []Platform{
+		{LeftPlatform, -57.6, -20, 27.2}, {RightPlatform, 20, 57.6, 27.2}, {TopPlatform, -18.8, 18.8, 54.4},
+	}},
+	FinalDestination: {-246, 246, 188, -140, 85.5657, nil},
+}
+
+// Geometry returns the geometry of the stage, and whether it is known. It is
+// known for the legal stages.
+func (s StageID) Geometry() (StageGeometry, bool) {
+	geometry, ok := stageGeometries[s]
+	return geometry, ok
+}
+
+// IsOffstage returns whether the position is beyond the stage's ledges or
+// below its main stage.
+func (g StageGeometry) IsOffstage(x float32, y float32) bool {
+	return x < -g.LedgeX || x > g.LedgeX || y < 0
+}
+
+// offstageMargin is the distance past a stage's ledges a player must be to be
+// offstage in an edgeguard situation or a recovery, and underStageDepth the
+// distance below the main stage a player between the ledges must be, since
+// players knocked into the ground dip below it.
+const (
+	offstageMargin  = 5
+	underStageDepth = 20
+)
+
+// IsWellOffstage returns whether the position is far enough beside or below
+// the stage's ledges, or under its main stage, that a player there has to
+// recover.
+func (g StageGeometry) IsWellOffstage(x float32, y float32) bool {
+	ledge := g.LedgeX + offstageMargin
+	return x < -ledge || x > ledge || y < -underStageDepth
+}
+
+// IsPastBlastZone returns whether the position is beyond any of the stage's
+// blast zones.
+func (g StageGeometry) IsPastBlastZone(x float32, y float32) bool {
+	return x < g.BlastZoneLeft || x > g.BlastZoneRight || y > g.BlastZoneTop || y < g.BlastZoneBottom
+}
@@ -0,0 +1,56 @@
+package slippi
+
+import (
+	"os"
+	"testing"
+)
+
+func TestStageGeometry(t *testing.T) {
+	for stage := range stageGeometries {
+		if !stage.IsLegalStage() {
+			t.Errorf("%s: expected geometry only for legal stages", stage)
+		}
+
+		geometry, _ := stage.Geometry()
+		if geometry.IsOffstage(0, 0) || !geometry.IsOffstage(geometry.LedgeX+1, 0) || geometry.IsPastBlastZone(geometry.LedgeX+1, 0) {
+			t.Errorf("%s: inconsistent ledges and blast zones", stage)
+		}
+
+		for _, platform := range geometry.Platforms {
+			if platform.Left >= platform.Right || geometry.IsPastBlastZone(platform.Left, platform.Height) || geometry.IsPastBlastZone(platform.Right, platform.Height) {
+				t.Errorf("%s: expected %s to be within the blast zones", stage, platform.Surface)
+			}
+		}
+	}
+
+	if _, ok := Brinstar.Geometry(); ok {
+		t.Errorf("expected no geometry for %s", Brinstar)
+	}
+
+	f, err := os.Open("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	game, err := NewSlpGameFromFile(f, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	frames, err := game.GetFrames()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// characters die once they pass a blast zone
+	geometry, _ := YoshisStory.Geometry()
+	for _, frame := range frames {
+		for index, updates := range frame.Players {
+			post := updates.Post
+			if post != nil && !IsDead(post.ActionStateID) && geometry.IsPastBlastZone(post.XPosition, post.YPosition) {
+				t.Fatalf("frame %d: player %d is alive past the blast zone at (%f, %f)", frame.FrameNumber, index, post.XPosition, post.YPosition)
+			}
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package slippi
+
+import "fmt"
+
+// StageID enumerates the stages in Melee, as stored in the GameStart event.
+type StageID uint16
+
+// StageIDs
+const (
+	FountainOfDreams StageID = iota + 2
+	PokemonStadium
+	PeachsCastle
+	KongoJungle
+	Brinstar
+	Corneria
+	YoshisStory
+	Onett
+	MuteCity
+	RainbowCruise
+	JungleJapes
+	GreatBay
+	HyruleTemple
+	BrinstarDepths
+	YoshisIsland
+	GreenGreens
+	Fourside
+	MushroomKingdom
+	MushroomKingdom2
+	Venom StageID = iota + 3
+	PokeFloats
+	BigBlue
+	IcicleMountain
+	Icetop
+	FlatZone
+	DreamLand
+	YoshisIslandN64
+	KongoJungleN64
+	Battlefield
+	FinalDestination
+	TargetTestMario StageID = iota + 3
+	TargetTestCaptainFalcon
+	TargetTestYoungLink
+	TargetTestDonkeyKong
+	TargetTestDrMario
+	TargetTestFalco
+	TargetTestFox
+	TargetTestIceClimbers
+	TargetTestKirby
+	TargetTestBowser
+	TargetTestLink
+	TargetTestLuigi
+	TargetTestMarth
+	TargetTestMewtwo
+	TargetTestNess
+	TargetTestPeach
+	TargetTestPichu
+	TargetTestPikachu
+	TargetTestJigglypuff
+	TargetTestSamus
+	TargetTestSheik
+	TargetTestYoshi
+	TargetTestZelda
+	TargetTestMrGameAndWatch
+	TargetTestRoy
+	TargetTestGanondorf
+	HomeRunStadium StageID = 84
+)
+
+var stageNames = map[StageID]string{
+	FountainOfDreams:         "Fountain of Dreams",
+	PokemonStadium:           "Pokémon Stadium",
+	PeachsCastle:             "Princess Peach's Castle",
+	KongoJungle:              "Kongo Jungle",
+	Brinstar:                 "Brinstar",
+	Corneria:                 "Corneria",
+	YoshisStory:              "Yoshi's Story",
+	Onett:                    "Onett",
+	MuteCity:                 "Mute City",
+	RainbowCruise:            "Rainbow Cruise",
+	JungleJapes:              "Jungle Japes",
+	GreatBay:                 "Great Bay",
+	HyruleTemple:             "Hyrule Temple",
+	BrinstarDepths:           "Brinstar Depths",
+	YoshisIsland:             "Yoshi's Island",
+	GreenGreens:              "Green Greens",
+	Fourside:                 "Fourside",
+	MushroomKingdom:          "Mushroom Kingdom I",
+	MushroomKingdom2:         "Mushroom Kingdom II",
+	Venom:                    "Venom",
+	PokeFloats:               "Poké Floats",
+	BigBlue:                  "Big Blue",
+	IcicleMountain:           "Icicle Mountain",
+	Icetop:                   "Icetop",
+	FlatZone:                 "Flat Zone",
+	DreamLand:                "Dream Land N64",
+	YoshisIslandN64:          "Yoshi's Island N64",
+	KongoJungleN64:           "Kongo Jungle N64",
+	Battlefield:              "Battlefield",
+	FinalDestination:         "Final Destination",
+	TargetTestMario:          "Target Test (Mario)",
+	TargetTestCaptainFalcon:  "Target Test (Captain Falcon)",
+	TargetTestYoungLink:      "Target Test (Young Link)",
+	TargetTestDonkeyKong:     "Target Test (Donkey Kong)",
+	TargetTestDrMario:        "Target Test (Dr. Mario)",
+	TargetTestFalco:          "Target Test (Falco)",
+	TargetTestFox:            "Target Test (Fox)",
+	TargetTestIceClimbers:    "Target Test (Ice Climbers)",
+	TargetTestKirby:          "Target Test (Kirby)",
+	TargetTestBowser:         "Target Test (Bowser)",
+	TargetTestLink:           "Target Test (Link)",
+	TargetTestLuigi:          "Target Test (Luigi)",
+	TargetTestMarth:          "Target Test (Marth)",
+	TargetTestMewtwo:         "Target Test (Mewtwo)",
+	TargetTestNess:           "Target Test (Ness)",
+	TargetTestPeach:          "Target Test (Peach)",
+	TargetTestPichu:          "Target Test (Pichu)",
+	TargetTestPikachu:        "Target Test (Pikachu)",
+	TargetTestJigglypuff:     "Target Test (Jigglypuff)",
+	TargetTestSamus:          "Target Test (Samus)",
+	TargetTestSheik:          "Target Test (Sheik)",
+	TargetTestYoshi:          "Target Test (Yoshi)",
+	TargetTestZelda:          "Target Test (Zelda)",
+	TargetTestMrGameAndWatch: "Target Test (Mr. Game & Watch)",
+	TargetTestRoy:            "Target Test (Roy)",
+	TargetTestGanondorf:      "Target Test (Ganondorf)",
+	HomeRunStadium:           "Home-Run Stadium",
+}
+
+// String returns the in-game name of the stage.
+func (s StageID) String() string {
+	if name, ok := stageNames[s]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("Unknown Stage (%d)", uint16(s))
+}
+
+// IsKnownStage returns whether the stage has a known name.
+func (s StageID) IsKnownStage() bool {
+	_, ok := stageNames[s]
+	return ok
+}
+
+// IsLegalStage returns whether the stage is legal in the standard competitive
+// singles and doubles ruleset.
+func (s StageID) IsLegalStage() bool {
+	switch s {
+	case FountainOfDreams, PokemonStadium, YoshisStory, DreamLand, Battlefield, FinalDestination:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsTargetTest returns whether the stage is one of the Target Test stages.
+func (s StageID) IsTargetTest() bool {
+	return s >= TargetTestMario && s <= TargetTestGanondorf
+}
+
+// IsFrozenStadium returns whether the game described by gameStart was played
+// on Pokémon Stadium with stage transformations disabled.
+func IsFrozenStadium(gameStart GameStartPayload) bool {
+	return gameStart.GameInfoBlock.Stage == PokemonStadium && gameStart.FrozenPS
+}
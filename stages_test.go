@@ -0,0 +1,31 @@
+package slippi
+
+import "testing"
+
+func TestStageIDs(t *testing.T) {
+	// the first and last stage of each block of IDs
+	expected := map[StageID]uint16{
+		FountainOfDreams:    2,
+		MushroomKingdom2:    20,
+		Venom:               22,
+		FinalDestination:    32,
+		TargetTestMario:     33,
+		TargetTestGanondorf: 58,
+		HomeRunStadium:      84,
+	}
+	for stage, id := range expected {
+		if uint16(stage) != id {
+			t.Errorf("expected %s to be %d, got %d", stage, id, uint16(stage))
+		}
+	}
+
+	if !StageID(33).IsTargetTest() || StageID(32).IsTargetTest() || StageID(59).IsTargetTest() {
+		t.Error("expected only stages 33 to 58 to be Target Test stages")
+	}
+	if name := StageID(84).String(); name != "Home-Run Stadium" {
+		t.Errorf("expected stage 84 to be Home-Run Stadium, got %s", name)
+	}
+	if StageID(21).IsKnownStage() {
+		t.Error("expected stage 21 to be unknown")
+	}
+}
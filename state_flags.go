@@ -0,0 +1,52 @@
+package slippi
+
+// StateFlags contains the named state bit flags of a PostFrameUpdate event.
+type StateFlags struct {
+	// StateBitFlags1
+	ReflectActive bool
+
+	// StateBitFlags2
+	Intangible     bool
+	FastFalling    bool
+	DefenderHitlag bool
+	InHitlag       bool
+
+	// StateBitFlags3
+	GrabHold  bool
+	Shielding bool
+
+	// StateBitFlags4
+	InHitstun         bool
+	ShieldTouched     bool
+	PowershieldBubble bool
+
+	// StateBitFlags5
+	Follower  bool
+	Sleeping  bool
+	Dead      bool
+	Offscreen bool
+}
+
+// StateFlags decodes the state bit flags of the update.
+func (u PostFrameUpdatePayload) StateFlags() StateFlags {
+	return StateFlags{
+		ReflectActive: u.StateBitFlags1&0x10 != 0,
+
+		Intangible:     u.StateBitFlags2&0x04 != 0,
+		FastFalling:    u.StateBitFlags2&0x08 != 0,
+		DefenderHitlag: u.StateBitFlags2&0x10 != 0,
+		InHitlag:       u.StateBitFlags2&0x20 != 0,
+
+		GrabHold:  u.StateBitFlags3&0x04 != 0,
+		Shielding: u.StateBitFlags3&0x80 != 0,
+
+		InHitstun:         u.StateBitFlags4&0x02 != 0,
+		ShieldTouched:     u.StateBitFlags4&0x04 != 0,
+		PowershieldBubble: u.StateBitFlags4&0x20 != 0,
+
+		Follower:  u.StateBitFlags5&0x08 != 0,
+		Sleeping:  u.StateBitFlags5&0x10 != 0,
+		Dead:      u.StateBitFlags5&0x40 != 0,
+		Offscreen: u.StateBitFlags5&0x80 != 0,
+	}
+}
@@ -0,0 +1,384 @@
+package stats
+
+import (
+	"math"
+	"slices"
+	"sort"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+// wavedashWindow is the number of frames, up to and including the landing,
+// within which a jump or air dodge is taken to be part of a wavedash or
+// waveland.
+const wavedashWindow = 8
+
+// A SuccessCount is the number of times an action succeeded and failed.
+type SuccessCount struct {
+	Success int `json:"success"`
+	Fail    int `json:"fail"`
+}
+
+// A GroundTechCount is the number of times a player teched on the ground in
+// each direction, relative to their closest opponent, and missed a tech.
+type GroundTechCount struct {
+	Away    int `json:"away"`
+	In      int `json:"in"`
+	Neutral int `json:"neutral"`
+	Fail    int `json:"fail"`
+}
+
+// AttackCounts are the number of times a player used each of their normal
+// attacks.
+type AttackCounts struct {
+	Jab1   int `json:"jab1"`
+	Jab2   int `json:"jab2"`
+	Jab3   int `json:"jab3"`
+	Jabm   int `json:"jabm"`
+	Dash   int `json:"dash"`
+	Ftilt  int `json:"ftilt"`
+	Utilt  int `json:"utilt"`
+	Dtilt  int `json:"dtilt"`
+	Fsmash int `json:"fsmash"`
+	Usmash int `json:"usmash"`
+	Dsmash int `json:"dsmash"`
+	Nair   int `json:"nair"`
+	Fair   int `json:"fair"`
+	Bair   int `json:"bair"`
+	Uair   int `json:"uair"`
+	Dair   int `json:"dair"`
+}
+
+// count returns the count of the attack of the action state, or nil if it
+// isn't the start of a normal attack.
+func (a *AttackCounts) count(actionStateID uint16) *int {
+	switch {
+	case actionStateID == slippi.StateJab1:
+		return &a.Jab1
+	case actionStateID == slippi.StateJab2:
+		return &a.Jab2
+	case actionStateID == slippi.StateJab3:
+		return &a.Jab3
+	case actionStateID == slippi.StateRapidJabStart:
+		return &a.Jabm
+	case actionStateID == slippi.StateDashAttack:
+		return &a.Dash
+	case actionStateID >= slippi.StateForwardTiltStart && actionStateID <= slippi.StateForwardTiltEnd:
+		return &a.Ftilt
+	case actionStateID == slippi.StateUpTilt:
+		return &a.Utilt
+	case actionStateID == slippi.StateDownTilt:
+		return &a.Dtilt
+	case actionStateID >= slippi.StateForwardSmashStart && actionStateID <= slippi.StateForwardSmashEnd:
+		return &a.Fsmash
+	case actionStateID == slippi.StateUpSmash:
+		return &a.Usmash
+	case actionStateID == slippi.StateDownSmash:
+		return &a.Dsmash
+	case actionStateID == slippi.StateNair:
+		return &a.Nair
+	case actionStateID == slippi.StateFair:
+		return &a.Fair
+	case actionStateID == slippi.StateBair:
+		return &a.Bair
+	case actionStateID == slippi.StateUair:
+		return &a.Uair
+	case actionStateID == slippi.StateDair:
+		return &a.Dair
+	}
+
+	return nil
+}
+
+// ThrowCounts are the number of times a player threw in each direction.
+type ThrowCounts struct {
+	Up      int `json:"up"`
+	Forward int `json:"forward"`
+	Back    int `json:"back"`
+	Down    int `json:"down"`
+}
+
+// ActionCounts are the number of times a player performed each tracked
+// action, as counted by slippi-js.
+type ActionCounts struct {
+	PlayerIndex    uint8 `json:"playerIndex"`
+	WavedashCount  int   `json:"wavedashCount"`
+	WavelandCount  int   `json:"wavelandCount"`
+	AirDodgeCount  int   `json:"airDodgeCount"`
+	DashDanceCount int   `json:"dashDanceCount"`
+	SpotDodgeCount int   `json:"spotDodgeCount"`
+	LedgegrabCount int   `json:"ledgegrabCount"`
+	RollCount      int   `json:"rollCount"`
+	// LCancelCount counts the aerials the player landed with by whether they
+	// L-cancelled them.
+	LCancelCount SuccessCount `json:"lCancelCount"`
+	AttackCount  AttackCounts `json:"attackCount"`
+	// GrabCount counts grabs that caught an opponent as successes, and those
+	// that whiffed as failures.
+	GrabCount       SuccessCount    `json:"grabCount"`
+	ThrowCount      ThrowCounts     `json:"throwCount"`
+	GroundTechCount GroundTechCount `json:"groundTechCount"`
+	WallTechCount   SuccessCount    `json:"wallTechCount"`
+}
+
+type actionState struct {
+	counts ActionCounts
+	// animations are the action states of the player on the last
+	// wavedashWindow frames, oldest first.
+	animations   []uint16
+	frameCounter float32
+}
+
+// An ActionsComputer is a Computer that counts the actions of each
+// player.
+type ActionsComputer struct {
+	gameInfo *slippi.GameInfo
+	states   map[uint8]*actionState
+}
+
+// NewActionsComputer returns an ActionsComputer without any actions counted.
+func NewActionsComputer() *ActionsComputer {
+	c := &ActionsComputer{}
+	c.Setup(nil)
+
+	return c
+}
+
+// Setup implements Computer.
+func (c *ActionsComputer) Setup(gameInfo *slippi.GameInfo) {
+	c.gameInfo = gameInfo
+	c.states = make(map[uint8]*actionState)
+	if gameInfo != nil {
+		for _, player := range gameInfo.Players {
+			c.states[player.Index] = &actionState{counts: ActionCounts{PlayerIndex: player.Index}}
+		}
+	}
+}
+
+// ProcessFrame implements Computer.
+func (c *ActionsComputer) ProcessFrame(frame slippi.FrameEntry) {
+	for index, updates := range frame.Players {
+		if updates.Post == nil {
+			continue
+		}
+
+		state, ok := c.states[index]
+		if !ok {
+			state = &actionState{counts: ActionCounts{PlayerIndex: index}}
+			c.states[index] = state
+		}
+		c.processPlayer(frame, state, *updates.Post)
+	}
+}
+
+func (c *ActionsComputer) processPlayer(frame slippi.FrameEntry, state *actionState, post slippi.PostFrameUpdatePayload) {
+	current := post.ActionStateID
+	hasPrevious := len(state.animations) > 0
+	var previous uint16
+	if hasPrevious {
+		previous = state.animations[len(state.animations)-1]
+	}
+	// a frame counter going back down is the same action starting again
+	isNewAction := !hasPrevious || current != previous || state.frameCounter > post.ActionStateFrameCounter
+
+	if len(state.animations) == wavedashWindow {
+		state.animations = state.animations[1:]
+	}
+	state.animations = append(state.animations, current)
+	state.frameCounter = post.ActionStateFrameCounter
+
+	counts := &state.counts
+	// the L-cancel status is only set on the frame the player lands
+	switch post.LCancelStatus {
+	case slippi.Successful:
+		counts.LCancelCount.Success++
+	case slippi.Unsuccessful:
+		counts.LCancelCount.Fail++
+	}
+
+	if !isNewAction {
+		return
+	}
+
+	last := state.animations[max(len(state.animations)-3, 0):]
+	if slices.Equal(last, []uint16{slippi.StateDash, slippi.StateTurn, slippi.StateDash}) {
+		counts.DashDanceCount++
+	}
+
+	switch current {
+	case slippi.StateRollForward, slippi.StateRollBackward:
+		counts.RollCount++
+	case slippi.StateSpotDodge:
+		counts.SpotDodgeCount++
+	case slippi.StateAirDodge:
+		counts.AirDodgeCount++
+	case slippi.StateCliffCatch:
+		counts.LedgegrabCount++
+	case slippi.StateThrowUp:
+		counts.ThrowCount.Up++
+	case slippi.StateThrowForward:
+		counts.ThrowCount.Forward++
+	case slippi.StateThrowBack:
+		counts.ThrowCount.Back++
+	case slippi.StateThrowDown:
+		counts.ThrowCount.Down++
+	case slippi.StateWallTech:
+		counts.WallTechCount.Success++
+	case slippi.StateMissedWallTech:
+		counts.WallTechCount.Fail++
+	case slippi.StateTechMissUp, slippi.StateTechMissDown:
+		counts.GroundTechCount.Fail++
+	case slippi.StateNeutralTech:
+		counts.GroundTechCount.Neutral++
+	case slippi.StateForwardTech, slippi.StateBackwardTech:
+		// a forward tech is towards the direction faced
+		if (current == slippi.StateForwardTech) == c.facingOpponent(frame, post) {
+			counts.GroundTechCount.In++
+		} else {
+			counts.GroundTechCount.Away++
+		}
+	}
+
+	if attack := counts.AttackCount.count(current); attack != nil {
+		*attack++
+	}
+
+	if previous == slippi.StateGrab || previous == slippi.StateDashGrab {
+		if current == slippi.StateGrabPull || current == slippi.StateDashGrabPull {
+			counts.GrabCount.Success++
+		} else {
+			counts.GrabCount.Fail++
+		}
+	}
+
+	if hasPrevious && current == slippi.StateLandingFallSpecial && isWavedashInitiation(previous) {
+		countWavedash(counts, state.animations)
+	}
+}
+
+// facingOpponent returns whether the player with the given post-frame update
+// is facing their closest opponent on the frame. Players without an opponent
+// are taken to face one.
+func (c *ActionsComputer) facingOpponent(frame slippi.FrameEntry, post slippi.PostFrameUpdatePayload) bool {
+	opponent, ok := closestOpponent(c.gameInfo, frame, post)
+	if !ok {
+		return true
+	}
+
+	return post.FacingDirection == directionTo(post, opponent)
+}
+
+// closestOpponent returns the post-frame update on frame of the opponent
+// closest to the player with the given post-frame update, and whether they
+// have one. Teammates aren't opponents in teams games.
+func closestOpponent(gameInfo *slippi.GameInfo, frame slippi.FrameEntry, post slippi.PostFrameUpdatePayload) (slippi.PostFrameUpdatePayload, bool) {
+	closest := math.Inf(1)
+	var opponent slippi.PostFrameUpdatePayload
+	found := false
+	for index, updates := range frame.Players {
+		if index == post.PlayerIndex || updates.Post == nil || isTeammate(gameInfo, index, post.PlayerIndex) {
+			continue
+		}
+
+		if distance := distanceBetween(post, *updates.Post); distance < closest {
+			closest = distance
+			opponent = *updates.Post
+			found = true
+		}
+	}
+
+	return opponent, found
+}
+
+// distanceBetween returns the distance between the characters of the given
+// post-frame updates.
+func distanceBetween(post slippi.PostFrameUpdatePayload, other slippi.PostFrameUpdatePayload) float64 {
+	return math.Hypot(float64(other.XPosition-post.XPosition), float64(other.YPosition-post.YPosition))
+}
+
+// directionTo returns the facing direction, 1 for right and -1 for left, of
+// the character of post towards that of other.
+func directionTo(post slippi.PostFrameUpdatePayload, other slippi.PostFrameUpdatePayload) float32 {
+	if post.XPosition > other.XPosition {
+		return -1
+	}
+
+	return 1
+}
+
+// isTeammate returns whether the players with the given indices are on the
+// same team of a teams game.
+func isTeammate(gameInfo *slippi.GameInfo, index uint8, other uint8) bool {
+	if gameInfo == nil || !gameInfo.Teams {
+		return false
+	}
+
+	teams := make(map[uint8]slippi.TeamID, len(gameInfo.Players))
+	for _, player := range gameInfo.Players {
+		teams[player.Index] = player.TeamID
+	}
+
+	team, ok := teams[index]
+	otherTeam, otherOk := teams[other]
+	return ok && otherOk && team == otherTeam
+}
+
+// isWavedashInitiation returns whether a special landing from the action
+// state could be a wavedash or waveland.
+func isWavedashInitiation(actionStateID uint16) bool {
+	return actionStateID == slippi.StateAirDodge || (actionStateID >= slippi.StateKneeBend && actionStateID <= slippi.StateFallAerialBackward)
+}
+
+// countWavedash counts a special landing as a wavedash if the player jumped
+// within the window before it, or otherwise as a waveland. An air dodge
+// landing from so late in the air dodge that the window has nothing else is
+// neither.
+func countWavedash(counts *ActionCounts, animations []uint16) {
+	recent := make(map[uint16]bool, len(animations))
+	for _, animation := range animations {
+		recent[animation] = true
+	}
+
+	if len(recent) == 2 && recent[slippi.StateAirDodge] {
+		return
+	}
+
+	// air dodges that are part of a wavedash or waveland aren't counted
+	if recent[slippi.StateAirDodge] {
+		counts.AirDodgeCount--
+	}
+
+	if recent[slippi.StateKneeBend] {
+		counts.WavedashCount++
+	} else {
+		counts.WavelandCount++
+	}
+}
+
+// ActionCounts returns the actions counted so far for each player, in order of
+// their index.
+func (c *ActionsComputer) ActionCounts() []ActionCounts {
+	indices := make([]int, 0, len(c.states))
+	for index := range c.states {
+		indices = append(indices, int(index))
+	}
+	sort.Ints(indices)
+
+	counts := make([]ActionCounts, 0, len(indices))
+	for _, index := range indices {
+		counts = append(counts, c.states[uint8(index)].counts)
+	}
+
+	return counts
+}
+
+// ComputeActionCounts returns the actions counted for each player of the game,
+// in order of their index.
+func ComputeActionCounts(game *slippi.SlpGame) ([]ActionCounts, error) {
+	computer := NewActionsComputer()
+	if err := Run(game, computer); err != nil {
+		return nil, err
+	}
+
+	return computer.ActionCounts(), nil
+}
@@ -0,0 +1,26 @@
+package stats
+
+import "testing"
+
+func TestActionCounts(t *testing.T) {
+	game := openFixture(t)
+
+	counts, err := ComputeActionCounts(game)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(counts) != 2 || counts[0].PlayerIndex != 0 || counts[1].PlayerIndex != 1 {
+		t.Fatalf("expected the counts of both players, got %+v", counts)
+	}
+
+	fox, falco := counts[0], counts[1]
+	if fox.DashDanceCount != 73 || fox.WavedashCount != 0 || fox.GrabCount != (SuccessCount{Success: 4, Fail: 1}) {
+		t.Errorf("expected Fox to dash dance and grab, got %+v", fox)
+	}
+	if falco.WavedashCount != 19 || falco.WavelandCount != 0 || falco.AirDodgeCount != 10 || falco.LedgegrabCount != 18 {
+		t.Errorf("expected Falco to wavedash and grab the ledge, got %+v", falco)
+	}
+	if falco.GroundTechCount != (GroundTechCount{Away: 1, In: 2, Neutral: 6, Fail: 18}) {
+		t.Errorf("expected Falco's techs, got %+v", falco.GroundTechCount)
+	}
+}
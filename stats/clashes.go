@@ -0,0 +1,300 @@
+package stats
+
+import (
+	"sort"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+// counterHitStartupFrames is the number of frames of a move that are taken to
+// be its startup, since replays don't record when hitboxes come out. It covers
+// the startup of most normals.
+const counterHitStartupFrames = 10
+
+// ClashKind enumerates the kinds of clashes between the attacks of players.
+type ClashKind uint8
+
+// ClashKinds
+const (
+	// ClashTrade is the kind of clashes in which both players hit each other
+	// before either's hitlag from the other's hit ended.
+	ClashTrade ClashKind = iota
+	// ClashCounterHit is the kind of clashes in which one player hit the
+	// other during the startup of the other's move.
+	ClashCounterHit
+)
+
+var clashKindNames = map[ClashKind]string{
+	ClashTrade:      "trade",
+	ClashCounterHit: "counter-hit",
+}
+
+// String returns the name of the clash kind.
+func (k ClashKind) String() string {
+	return clashKindNames[k]
+}
+
+// MarshalText encodes the clash kind as its name.
+func (k ClashKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// A Clash is a hit a player landed on another who was attacking them, either
+// trading hits with them or hitting them during the startup of their move.
+type Clash struct {
+	Kind ClashKind `json:"kind"`
+	// Frame is the frame of the hit, which is the first hit of a trade.
+	Frame int32 `json:"frame"`
+	// AttackerIndex is the index of the player who landed the hit, and
+	// DefenderIndex that of the player they hit.
+	AttackerIndex uint8 `json:"attackerIndex"`
+	DefenderIndex uint8 `json:"defenderIndex"`
+	// Move is the move the attacker hit with, and Damage the damage it
+	// dealt.
+	Move   slippi.AttackID `json:"move"`
+	Damage float32         `json:"damage"`
+	// DefenderMove is the move of the defender, which hit back in a trade
+	// and was countered in a counter-hit, and DefenderDamage the damage it
+	// dealt back in a trade.
+	DefenderMove   slippi.AttackID `json:"defenderMove"`
+	DefenderDamage float32         `json:"defenderDamage"`
+}
+
+// ClashCounts are the clashes a player took part in, as found by a
+// ClashComputer.
+type ClashCounts struct {
+	PlayerIndex uint8 `json:"playerIndex"`
+	// Trades is the number of ClashTrade clashes the player took part in,
+	// in which both players hit each other before either's hitlag ended,
+	// and CounterHits the number of ClashCounterHit clashes the player
+	// landed, hitting an opponent during the startup of their move.
+	Trades      int `json:"trades"`
+	CounterHits int `json:"counterHits"`
+}
+
+// clashHit is a hit taken by a player from another's attack.
+type clashHit struct {
+	frame     int32
+	attacker  uint8
+	hitlagEnd int32
+	move      slippi.AttackID
+	damage    float32
+	clash     *Clash
+}
+
+type clashState struct {
+	last *slippi.PostFrameUpdatePayload
+	// connected is whether the player's move has hit someone or a shield.
+	connected bool
+	// hit is the last hit the player took, if any.
+	hit *clashHit
+}
+
+// A ClashComputer is a Computer that detects the trades and counter-hits
+// between players. Only hits landed directly, rather than with a projectile,
+// can clash, and only normals can be counter-hit.
+type ClashComputer struct {
+	clashes   []*Clash
+	states    map[uint8]*clashState
+	players   map[uint8]bool
+	lastFrame slippi.FrameEntry
+}
+
+// NewClashComputer returns a ClashComputer without any clashes.
+func NewClashComputer() *ClashComputer {
+	c := &ClashComputer{}
+	c.Setup(nil)
+
+	return c
+}
+
+// Setup implements Computer.
+func (c *ClashComputer) Setup(gameInfo *slippi.GameInfo) {
+	c.clashes = make([]*Clash, 0)
+	c.states = make(map[uint8]*clashState)
+	c.players = make(map[uint8]bool)
+	c.lastFrame = slippi.FrameEntry{}
+	if gameInfo != nil {
+		for _, player := range gameInfo.Players {
+			c.players[player.Index] = true
+		}
+	}
+}
+
+// ProcessFrame implements Computer.
+func (c *ClashComputer) ProcessFrame(frame slippi.FrameEntry) {
+	indices := make([]int, 0, len(frame.Players))
+	for index, updates := range frame.Players {
+		if updates.Post != nil {
+			indices = append(indices, int(index))
+		}
+	}
+	sort.Ints(indices)
+
+	for _, index := range indices {
+		state, ok := c.states[uint8(index)]
+		if !ok {
+			state = &clashState{}
+			c.states[uint8(index)] = state
+			c.players[uint8(index)] = true
+		}
+
+		c.processPlayer(frame, uint8(index), state)
+	}
+	c.lastFrame = frame
+}
+
+func (c *ClashComputer) processPlayer(frame slippi.FrameEntry, index uint8, state *clashState) {
+	post := frame.Players[index].Post
+	last := state.last
+	state.last = post
+	if last == nil {
+		return
+	}
+
+	hitlag := post.StateFlags().InHitlag
+	started := hitlag && (!last.StateFlags().InHitlag || post.HitlagFramesRemaining > last.HitlagFramesRemaining)
+	if started && post.Percent > last.Percent {
+		c.processHit(frame, index, state, *last)
+	}
+
+	if post.ActionStateID != last.ActionStateID {
+		state.connected = false
+	}
+	if hitlag && post.Percent <= last.Percent {
+		state.connected = true
+	}
+}
+
+// processHit records the hit the player with the given index took on frame,
+// given their post-frame update on the frame before, as a clash if it was
+// one.
+func (c *ClashComputer) processHit(frame slippi.FrameEntry, index uint8, state *clashState, last slippi.PostFrameUpdatePayload) {
+	attribution, ok := slippi.AttributeHit(frame, c.lastFrame, index)
+	if !ok || attribution.IsSelfDamage() || attribution.Item != nil {
+		state.hit = nil
+		return
+	}
+
+	post := frame.Players[index].Post
+	attacker := frame.Players[attribution.AttackerIndex].Post
+	if attribution.ByFollower {
+		attacker = frame.Followers[attribution.AttackerIndex].Post
+	}
+	if attacker == nil {
+		state.hit = nil
+		return
+	}
+
+	hit := &clashHit{
+		frame:     frame.FrameNumber,
+		attacker:  attribution.AttackerIndex,
+		hitlagEnd: frame.FrameNumber + int32(post.HitlagFramesRemaining),
+		move:      attacker.LastHittingAttackID,
+		damage:    post.Percent - last.Percent,
+	}
+
+	// the attacker traded if this player hit them back before their hitlag
+	// ended
+	var attackerHit *clashHit
+	if attackerState, ok := c.states[attribution.AttackerIndex]; ok {
+		attackerHit = attackerState.hit
+	}
+	if attackerHit != nil && attackerHit.attacker == index && frame.FrameNumber <= attackerHit.hitlagEnd && (attackerHit.clash == nil || attackerHit.clash.Kind != ClashTrade) {
+		clash := attackerHit.clash
+		if clash == nil {
+			clash = &Clash{}
+			c.clashes = append(c.clashes, clash)
+		}
+		*clash = Clash{
+			Kind:           ClashTrade,
+			Frame:          attackerHit.frame,
+			AttackerIndex:  index,
+			DefenderIndex:  attribution.AttackerIndex,
+			Move:           attackerHit.move,
+			Damage:         attackerHit.damage,
+			DefenderMove:   hit.move,
+			DefenderDamage: hit.damage,
+		}
+		hit.clash = clash
+	} else if move := slippi.AttackOfActionState(last.ActionStateID); move != slippi.NoAttack && !state.connected && last.ActionStateFrameCounter <= counterHitStartupFrames {
+		hit.clash = &Clash{
+			Kind:          ClashCounterHit,
+			Frame:         frame.FrameNumber,
+			AttackerIndex: attribution.AttackerIndex,
+			DefenderIndex: index,
+			Move:          hit.move,
+			Damage:        hit.damage,
+			DefenderMove:  move,
+		}
+		c.clashes = append(c.clashes, hit.clash)
+	}
+
+	state.hit = hit
+}
+
+// Clashes returns the clashes processed so far, in order of their frame.
+func (c *ClashComputer) Clashes() []Clash {
+	clashes := make([]Clash, 0, len(c.clashes))
+	for _, clash := range c.clashes {
+		clashes = append(clashes, *clash)
+	}
+	sort.SliceStable(clashes, func(i, j int) bool {
+		return clashes[i].Frame < clashes[j].Frame
+	})
+
+	return clashes
+}
+
+// PlayerCounts returns the clashes each player took part in, in order of their
+// index.
+func (c *ClashComputer) PlayerCounts() []ClashCounts {
+	indices := make([]int, 0, len(c.players))
+	for index := range c.players {
+		indices = append(indices, int(index))
+	}
+	sort.Ints(indices)
+
+	counts := make(map[uint8]*ClashCounts, len(indices))
+	for _, index := range indices {
+		counts[uint8(index)] = &ClashCounts{PlayerIndex: uint8(index)}
+	}
+	for _, clash := range c.clashes {
+		switch clash.Kind {
+		case ClashTrade:
+			counts[clash.AttackerIndex].Trades++
+			counts[clash.DefenderIndex].Trades++
+		case ClashCounterHit:
+			counts[clash.AttackerIndex].CounterHits++
+		}
+	}
+
+	players := make([]ClashCounts, 0, len(indices))
+	for _, index := range indices {
+		players = append(players, *counts[uint8(index)])
+	}
+
+	return players
+}
+
+// ComputeClashes returns the trades and counter-hits of the game, in order of
+// their frame.
+func ComputeClashes(game *slippi.SlpGame) ([]Clash, error) {
+	computer := NewClashComputer()
+	if err := Run(game, computer); err != nil {
+		return nil, err
+	}
+
+	return computer.Clashes(), nil
+}
+
+// ComputeClashCounts returns the clashes each player of the game took part in,
+// in order of their index.
+func ComputeClashCounts(game *slippi.SlpGame) ([]ClashCounts, error) {
+	computer := NewClashComputer()
+	if err := Run(game, computer); err != nil {
+		return nil, err
+	}
+
+	return computer.PlayerCounts(), nil
+}
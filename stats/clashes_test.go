@@ -0,0 +1,61 @@
+package stats
+
+import (
+	"testing"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+func TestClashes(t *testing.T) {
+	game := openFixture(t)
+
+	clashes, err := ComputeClashes(game)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(clashes) != 9 {
+		t.Fatalf("expected 9 clashes, got %+v", clashes)
+	}
+
+	trades := 0
+	for i, clash := range clashes {
+		if i > 0 && clash.Frame < clashes[i-1].Frame {
+			t.Errorf("clash %d: expected clashes in order, got %+v", i, clashes)
+		}
+		if clash.AttackerIndex == clash.DefenderIndex || clash.DefenderMove == slippi.NoAttack || clash.Damage <= 0 {
+			t.Errorf("clash %d: expected a hit on an attacking opponent, got %+v", i, clash)
+		}
+
+		switch clash.Kind {
+		case ClashTrade:
+			trades++
+			if clash.DefenderDamage <= 0 {
+				t.Errorf("clash %d: expected the defender to hit back, got %+v", i, clash)
+			}
+		case ClashCounterHit:
+			if clash.DefenderDamage != 0 {
+				t.Errorf("clash %d: expected the defender not to hit back, got %+v", i, clash)
+			}
+		}
+	}
+	if trades != 1 {
+		t.Errorf("expected a single trade, got %d", trades)
+	}
+
+	// Falco's down air and Fox's back air trade on frame 3767
+	if trade := clashes[3]; trade.Kind != ClashTrade || trade.Frame != 3767 || trade.AttackerIndex != 1 || trade.Move != slippi.DownAir || trade.DefenderMove != slippi.BackAir || int(trade.Damage) != 9 || int(trade.DefenderDamage) != 14 {
+		t.Errorf("expected Falco and Fox to trade on frame 3767, got %+v", trade)
+	}
+	// Fox back airs Falco out of the startup of his dash attack
+	if counterHit := clashes[2]; counterHit.Kind != ClashCounterHit || counterHit.Frame != 2095 || counterHit.AttackerIndex != 0 || counterHit.Move != slippi.BackAir || counterHit.DefenderMove != slippi.DashAttack {
+		t.Errorf("expected Fox to counter-hit Falco's dash attack on frame 2095, got %+v", counterHit)
+	}
+
+	counts, err := ComputeClashCounts(game)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(counts) != 2 || counts[0] != (ClashCounts{PlayerIndex: 0, Trades: 1, CounterHits: 3}) || counts[1] != (ClashCounts{PlayerIndex: 1, Trades: 1, CounterHits: 5}) {
+		t.Errorf("expected each player to trade once, and Fox and Falco to counter-hit 3 and 5 times, got %+v", counts)
+	}
+}
@@ -0,0 +1,223 @@
+package stats
+
+import (
+	"sort"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+// ComboResetFrames is the number of frames a defender must go without being
+// hit, grabbed, teching, knocked down or dying before a combo against them
+// ends.
+const ComboResetFrames = 45
+
+// A Combo is a sequence of hits by one player on another, beginning with an
+// opening and ending when the defender loses a stock or goes ComboResetFrames
+// frames without being punished, as computed by slippi-js. Unlike a
+// Conversion, a combo ends once the defender escapes, even if they aren't
+// back in control on the ground.
+type Combo struct {
+	// PlayerIndex is the index of the player being comboed, and LastHitBy
+	// the index of the player comboing them.
+	PlayerIndex uint8 `json:"playerIndex"`
+	LastHitBy   uint8 `json:"lastHitBy"`
+	StartFrame  int32 `json:"startFrame"`
+	// EndFrame and EndPercent are nil while the combo is in progress.
+	EndFrame       *int32                  `json:"endFrame"`
+	StartPercent   float32                 `json:"startPercent"`
+	CurrentPercent float32                 `json:"currentPercent"`
+	EndPercent     *float32                `json:"endPercent"`
+	Moves          []slippi.ConversionMove `json:"moves"`
+	DidKill        bool                    `json:"didKill"`
+}
+
+// Damage returns the damage dealt by the moves of the combo.
+func (c Combo) Damage() float32 {
+	var damage float32
+	for _, move := range c.Moves {
+		damage += move.Damage
+	}
+
+	return damage
+}
+
+type comboState struct {
+	combo            *Combo
+	move             int
+	resetCounter     int
+	lastHitAnimation int32
+}
+
+// A ComboComputer is a Computer that detects the combos between every
+// ordered pair of players.
+type ComboComputer struct {
+	combos []*Combo
+	states map[[2]uint8]*comboState
+	prev   map[uint8]*slippi.PostFrameUpdatePayload
+}
+
+// NewComboComputer returns a ComboComputer without any combos.
+func NewComboComputer() *ComboComputer {
+	c := &ComboComputer{}
+	c.Setup(nil)
+
+	return c
+}
+
+// Setup implements Computer.
+func (c *ComboComputer) Setup(*slippi.GameInfo) {
+	c.combos = make([]*Combo, 0)
+	c.states = make(map[[2]uint8]*comboState)
+	c.prev = make(map[uint8]*slippi.PostFrameUpdatePayload)
+}
+
+// ProcessFrame implements Computer.
+func (c *ComboComputer) ProcessFrame(frame slippi.FrameEntry) {
+	indices := make([]int, 0, len(frame.Players))
+	for index, updates := range frame.Players {
+		if updates.Post != nil {
+			indices = append(indices, int(index))
+		}
+	}
+	sort.Ints(indices)
+
+	for _, attacker := range indices {
+		for _, defender := range indices {
+			if attacker != defender {
+				c.processPair(frame, uint8(attacker), uint8(defender))
+			}
+		}
+	}
+
+	for _, index := range indices {
+		c.prev[uint8(index)] = frame.Players[uint8(index)].Post
+	}
+}
+
+func (c *ComboComputer) processPair(frame slippi.FrameEntry, attacker uint8, defender uint8) {
+	key := [2]uint8{attacker, defender}
+	state, ok := c.states[key]
+	if !ok {
+		state = &comboState{move: -1, lastHitAnimation: -1}
+		c.states[key] = state
+	}
+
+	playerFrame := frame.Players[attacker].Post
+	opponentFrame := frame.Players[defender].Post
+	prevPlayerFrame := c.prev[attacker]
+	prevOpponentFrame := c.prev[defender]
+
+	opponentActionState := opponentFrame.ActionStateID
+	opponentIsPunished := slippi.IsDamaged(opponentActionState) || slippi.IsGrabbed(opponentActionState) || slippi.IsCommandGrabbed(opponentActionState)
+
+	var damageTaken float32
+	if prevOpponentFrame != nil {
+		damageTaken = opponentFrame.Percent - prevOpponentFrame.Percent
+	}
+
+	// repeated uses of the same move are counted separately once the
+	// attacker's animation changes or restarts
+	actionChangedSinceHit := int32(playerFrame.ActionStateID) != state.lastHitAnimation
+	actionCounterReset := prevPlayerFrame != nil && playerFrame.ActionStateFrameCounter < prevPlayerFrame.ActionStateFrameCounter
+	if actionChangedSinceHit || actionCounterReset {
+		state.lastHitAnimation = -1
+	}
+
+	if opponentIsPunished {
+		if state.combo == nil {
+			startPercent := float32(0)
+			if prevOpponentFrame != nil {
+				startPercent = prevOpponentFrame.Percent
+			}
+
+			state.combo = &Combo{
+				PlayerIndex:    defender,
+				LastHitBy:      attacker,
+				StartFrame:     frame.FrameNumber,
+				StartPercent:   startPercent,
+				CurrentPercent: opponentFrame.Percent,
+				Moves:          make([]slippi.ConversionMove, 0),
+			}
+			c.combos = append(c.combos, state.combo)
+		}
+
+		if damageTaken != 0 {
+			if state.lastHitAnimation == -1 {
+				state.combo.Moves = append(state.combo.Moves, slippi.ConversionMove{
+					PlayerIndex: attacker,
+					Frame:       frame.FrameNumber,
+					MoveID:      playerFrame.LastHittingAttackID,
+				})
+				state.move = len(state.combo.Moves) - 1
+			}
+
+			if state.move >= 0 {
+				state.combo.Moves[state.move].HitCount++
+				state.combo.Moves[state.move].Damage += damageTaken
+			}
+
+			// the previous frame's animation is the one that connected, which
+			// matters in the case of a trade
+			if prevPlayerFrame != nil {
+				state.lastHitAnimation = int32(prevPlayerFrame.ActionStateID)
+			}
+		}
+	}
+
+	if state.combo == nil {
+		return
+	}
+
+	opponentDidLoseStock := prevOpponentFrame != nil && prevOpponentFrame.StocksRemaining > opponentFrame.StocksRemaining
+	if !opponentDidLoseStock {
+		state.combo.CurrentPercent = opponentFrame.Percent
+	}
+
+	// unlike conversions, every frame the defender isn't punished counts
+	// towards ending the combo
+	if opponentIsPunished || slippi.IsTeching(opponentActionState) || slippi.IsDown(opponentActionState) || slippi.IsDead(opponentActionState) {
+		state.resetCounter = 0
+	} else {
+		state.resetCounter++
+	}
+
+	if opponentDidLoseStock {
+		state.combo.DidKill = true
+	}
+
+	if opponentDidLoseStock || state.resetCounter > ComboResetFrames {
+		endFrame := frame.FrameNumber
+		endPercent := float32(0)
+		if prevOpponentFrame != nil {
+			endPercent = prevOpponentFrame.Percent
+		}
+		state.combo.EndFrame = &endFrame
+		state.combo.EndPercent = &endPercent
+
+		state.combo = nil
+		state.move = -1
+	}
+}
+
+// Combos returns the combos processed so far, in the order they started,
+// including those still in progress.
+func (c *ComboComputer) Combos() []Combo {
+	combos := make([]Combo, 0, len(c.combos))
+	for _, combo := range c.combos {
+		copied := *combo
+		copied.Moves = append(make([]slippi.ConversionMove, 0, len(combo.Moves)), combo.Moves...)
+		combos = append(combos, copied)
+	}
+
+	return combos
+}
+
+// ComputeCombos returns the combos in the game, in the order they started.
+func ComputeCombos(game *slippi.SlpGame) ([]Combo, error) {
+	computer := NewComboComputer()
+	if err := Run(game, computer); err != nil {
+		return nil, err
+	}
+
+	return computer.Combos(), nil
+}
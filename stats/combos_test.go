@@ -0,0 +1,48 @@
+package stats
+
+import "testing"
+
+func TestCombos(t *testing.T) {
+	game := openFixture(t)
+
+	combos, err := ComputeCombos(game)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(combos) == 0 {
+		t.Fatal("expected combos")
+	}
+
+	// the stocks Falco lost at 846 and 9864 were taken after he escaped
+	kills := make(map[int32]uint8)
+	for i, combo := range combos {
+		if combo.EndFrame == nil || combo.EndPercent == nil {
+			t.Fatalf("expected every combo to have ended, got %+v", combo)
+		}
+		if *combo.EndFrame < combo.StartFrame || combo.PlayerIndex == combo.LastHitBy {
+			t.Errorf("combo %d: expected a combo by one player on another, got %+v", i, combo)
+		}
+		if i > 0 && combo.StartFrame < combos[i-1].StartFrame {
+			t.Errorf("combo %d: expected combos in the order they started", i)
+		}
+		for _, move := range combo.Moves {
+			if move.PlayerIndex != combo.LastHitBy || move.HitCount == 0 {
+				t.Errorf("combo %d: expected moves landed by player %d, got %+v", i, combo.LastHitBy, move)
+			}
+		}
+
+		if combo.DidKill {
+			kills[*combo.EndFrame] = combo.PlayerIndex
+		}
+	}
+
+	expected := map[int32]uint8{3816: 1, 4781: 0, 12190: 0}
+	if len(kills) != len(expected) {
+		t.Errorf("expected killing combos ending on %v, got %v", expected, kills)
+	}
+	for frame, player := range expected {
+		if killed, ok := kills[frame]; !ok || killed != player {
+			t.Errorf("expected a combo killing player %d on frame %d, got %v", player, frame, kills)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package stats
+
+import slippi "github.com/ZadenRB/go-slippi"
+
+// A ConversionComputer is a Computer that detects the conversions between
+// every ordered pair of players, and classifies their openings as slippi-js
+// does.
+type ConversionComputer struct {
+	tracker     *slippi.ConversionTracker
+	conversions []*slippi.Conversion
+	// classified is the number of conversions whose openings have been
+	// classified, which are never reclassified.
+	classified int
+	// lastEndFrames are the end frames of the last conversion classified
+	// against each player, if it ended.
+	lastEndFrames map[uint8]int32
+}
+
+// NewConversionComputer returns a ConversionComputer without any conversions.
+func NewConversionComputer() *ConversionComputer {
+	c := &ConversionComputer{}
+	c.Setup(nil)
+
+	return c
+}
+
+// Setup implements Computer.
+func (c *ConversionComputer) Setup(*slippi.GameInfo) {
+	c.tracker = slippi.NewConversionTracker(func(slippi.Conversion) {})
+	c.tracker.OnStart = func(conversion *slippi.Conversion) {
+		c.conversions = append(c.conversions, conversion)
+	}
+	c.conversions = make([]*slippi.Conversion, 0)
+	c.classified = 0
+	c.lastEndFrames = make(map[uint8]int32)
+}
+
+// ProcessFrame implements Computer.
+func (c *ConversionComputer) ProcessFrame(frame slippi.FrameEntry) {
+	c.tracker.ProcessFrame(frame)
+}
+
+// classifyOpenings classifies the openings of the conversions that have begun
+// since the last call. Conversions begun on the same frame are trades, and
+// the others are counter-attacks if the last conversion against the attacker
+// ended after they began.
+func (c *ConversionComputer) classifyOpenings() {
+	// conversions are begun in frame order, so those begun on the same frame
+	// are adjacent
+	for start := c.classified; start < len(c.conversions); {
+		end := start + 1
+		for end < len(c.conversions) && c.conversions[end].StartFrame == c.conversions[start].StartFrame {
+			end++
+		}
+
+		for _, conversion := range c.conversions[start:end] {
+			if conversion.IsComplete {
+				c.lastEndFrames[conversion.DefenderIndex] = conversion.EndFrame
+			} else {
+				delete(c.lastEndFrames, conversion.DefenderIndex)
+			}
+
+			if end-start > 1 {
+				conversion.OpeningType = slippi.Trade
+				continue
+			}
+
+			// slippi-js looks up the defender for conversions without moves
+			attacker := conversion.DefenderIndex
+			if len(conversion.Moves) > 0 {
+				attacker = conversion.Moves[len(conversion.Moves)-1].PlayerIndex
+			}
+
+			if lastEndFrame, ok := c.lastEndFrames[attacker]; ok && lastEndFrame > conversion.StartFrame {
+				conversion.OpeningType = slippi.CounterAttack
+			} else {
+				conversion.OpeningType = slippi.NeutralWin
+			}
+		}
+
+		start = end
+	}
+	c.classified = len(c.conversions)
+}
+
+// Conversions returns the conversions processed so far, in the order they
+// started, including those still in progress.
+func (c *ConversionComputer) Conversions() []slippi.Conversion {
+	c.classifyOpenings()
+
+	conversions := make([]slippi.Conversion, 0, len(c.conversions))
+	for _, conversion := range c.conversions {
+		copied := *conversion
+		copied.Moves = append(make([]slippi.ConversionMove, 0, len(conversion.Moves)), conversion.Moves...)
+		conversions = append(conversions, copied)
+	}
+
+	return conversions
+}
+
+// ComputeConversions returns the conversions in the game, in the order they
+// started, with their openings classified. Conversions still in progress at
+// the end of the game aren't complete.
+func ComputeConversions(game *slippi.SlpGame) ([]slippi.Conversion, error) {
+	computer := NewConversionComputer()
+	if err := Run(game, computer); err != nil {
+		return nil, err
+	}
+
+	return computer.Conversions(), nil
+}
@@ -0,0 +1,66 @@
+package stats
+
+import (
+	"testing"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+func TestConversions(t *testing.T) {
+	game := openFixture(t)
+
+	conversions, err := ComputeConversions(game)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conversions) == 0 {
+		t.Fatal("expected conversions")
+	}
+
+	openings := make(map[slippi.OpeningType]int)
+	kills := make(map[int32]slippi.OpeningType)
+	for i, conversion := range conversions {
+		if !conversion.IsComplete || conversion.EndFrame < conversion.StartFrame {
+			t.Errorf("conversion %d: expected every conversion to have ended, got %+v", i, conversion)
+		}
+		if i > 0 && conversion.StartFrame < conversions[i-1].StartFrame {
+			t.Errorf("conversion %d: expected conversions in the order they started", i)
+		}
+		openings[conversion.OpeningType]++
+
+		if conversion.DidKill {
+			kills[conversion.EndFrame] = conversion.OpeningType
+		}
+	}
+
+	if openings[slippi.UnknownOpening] != 0 || openings[slippi.NeutralWin] == 0 || openings[slippi.CounterAttack] == 0 {
+		t.Errorf("expected neutral wins and counter-attacks, got %v", openings)
+	}
+
+	// Fox's stock at 12190 was taken while he was punishing Falco
+	expected := map[int32]slippi.OpeningType{846: slippi.NeutralWin, 4781: slippi.NeutralWin, 12190: slippi.CounterAttack}
+	for frame, opening := range expected {
+		if kills[frame] != opening {
+			t.Errorf("expected the killing conversion ending on frame %d to be a %s, got %s", frame, opening, kills[frame])
+		}
+	}
+}
+
+func TestClassifyOpenings(t *testing.T) {
+	computer := NewConversionComputer()
+	computer.conversions = []*slippi.Conversion{
+		{AttackerIndex: 0, DefenderIndex: 1, StartFrame: 10, EndFrame: 40, IsComplete: true, Moves: []slippi.ConversionMove{{PlayerIndex: 0}}},
+		// a hit on the attacker of the conversion in progress
+		{AttackerIndex: 1, DefenderIndex: 0, StartFrame: 20, EndFrame: 30, IsComplete: true, Moves: []slippi.ConversionMove{{PlayerIndex: 1}}},
+		{AttackerIndex: 0, DefenderIndex: 1, StartFrame: 50, EndFrame: 60, IsComplete: true, Moves: []slippi.ConversionMove{{PlayerIndex: 0}}},
+		{AttackerIndex: 1, DefenderIndex: 0, StartFrame: 50, EndFrame: 70, IsComplete: true, Moves: []slippi.ConversionMove{{PlayerIndex: 1}}},
+		{AttackerIndex: 0, DefenderIndex: 1, StartFrame: 80, Moves: []slippi.ConversionMove{{PlayerIndex: 0}}},
+	}
+
+	expected := []slippi.OpeningType{slippi.NeutralWin, slippi.CounterAttack, slippi.Trade, slippi.Trade, slippi.NeutralWin}
+	for i, conversion := range computer.Conversions() {
+		if conversion.OpeningType != expected[i] {
+			t.Errorf("conversion %d: expected a %s, got %s", i, expected[i], conversion.OpeningType)
+		}
+	}
+}
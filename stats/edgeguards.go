@@ -0,0 +1,258 @@
+package stats
+
+import (
+	"sort"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+// EdgeguardOutcome enumerates the ways an edgeguard situation can end.
+type EdgeguardOutcome uint8
+
+// EdgeguardOutcomes
+const (
+	// EdgeguardUnresolved is the outcome of edgeguard situations still in
+	// progress.
+	EdgeguardUnresolved EdgeguardOutcome = iota
+	// EdgeguardSuccess is the outcome of edgeguard situations in which the
+	// recovering player lost their stock.
+	EdgeguardSuccess
+	// EdgeguardReversal is the outcome of edgeguard situations in which the
+	// recovering player hit the player edgeguarding them.
+	EdgeguardReversal
+	// EdgeguardRecovery is the outcome of edgeguard situations in which the
+	// recovering player made it back to the ground onstage.
+	EdgeguardRecovery
+)
+
+var edgeguardOutcomeNames = map[EdgeguardOutcome]string{
+	EdgeguardUnresolved: "unresolved",
+	EdgeguardSuccess:    "success",
+	EdgeguardReversal:   "reversal",
+	EdgeguardRecovery:   "recovery",
+}
+
+// String returns the name of the edgeguard outcome.
+func (o EdgeguardOutcome) String() string {
+	return edgeguardOutcomeNames[o]
+}
+
+// MarshalText encodes the edgeguard outcome as its name.
+func (o EdgeguardOutcome) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+// An Edgeguard is a situation in which a player was knocked offstage, beyond
+// the stage's ledges or below its main stage, and had to recover while the
+// player who hit them tried to stop them.
+type Edgeguard struct {
+	EdgeguarderIndex uint8   `json:"edgeguarderIndex"`
+	RecovererIndex   uint8   `json:"recovererIndex"`
+	StartFrame       int32   `json:"startFrame"`
+	StartPercent     float32 `json:"startPercent"`
+	// EndFrame is the frame the situation was resolved on, and is unset
+	// while the situation is unresolved.
+	EndFrame int32            `json:"endFrame"`
+	Outcome  EdgeguardOutcome `json:"outcome"`
+}
+
+// PlayerEdgeguardStats are the edgeguard situations of a player in a game,
+// counting only resolved situations.
+type PlayerEdgeguardStats struct {
+	PlayerIndex uint8 `json:"playerIndex"`
+	// Situations are the situations in which the player was edgeguarding,
+	// and Successes, Reversals and Recoveries those of them with each
+	// outcome.
+	Situations int `json:"situations"`
+	Successes  int `json:"successes"`
+	Reversals  int `json:"reversals"`
+	Recoveries int `json:"recoveries"`
+	// ConversionRate is the player's successful edgeguards out of the
+	// situations they were edgeguarding in, and RecoveryRate the player's
+	// recoveries out of the situations they were edgeguarded in.
+	ConversionRate Ratio `json:"conversionRate"`
+	RecoveryRate   Ratio `json:"recoveryRate"`
+}
+
+// An EdgeguardComputer is a Computer that detects the edgeguard
+// situations between every pair of players. Situations are only detected on
+// stages whose geometry is known.
+type EdgeguardComputer struct {
+	geometry    slippi.StageGeometry
+	hasGeometry bool
+	edgeguards  []*Edgeguard
+	// active are the unresolved situations, by the index of the recovering
+	// player.
+	active map[uint8]*Edgeguard
+	prev   map[uint8]*slippi.PostFrameUpdatePayload
+}
+
+// NewEdgeguardComputer returns an EdgeguardComputer without any edgeguard
+// situations.
+func NewEdgeguardComputer() *EdgeguardComputer {
+	c := &EdgeguardComputer{}
+	c.Setup(nil)
+
+	return c
+}
+
+// Setup implements Computer.
+func (c *EdgeguardComputer) Setup(gameInfo *slippi.GameInfo) {
+	c.geometry, c.hasGeometry = slippi.StageGeometry{}, false
+	if gameInfo != nil {
+		c.geometry, c.hasGeometry = gameInfo.Stage.Geometry()
+	}
+	c.edgeguards = make([]*Edgeguard, 0)
+	c.active = make(map[uint8]*Edgeguard)
+	c.prev = make(map[uint8]*slippi.PostFrameUpdatePayload)
+}
+
+// ProcessFrame implements Computer.
+func (c *EdgeguardComputer) ProcessFrame(frame slippi.FrameEntry) {
+	indices := make([]int, 0, len(frame.Players))
+	for index, updates := range frame.Players {
+		if updates.Post != nil {
+			indices = append(indices, int(index))
+		}
+	}
+	sort.Ints(indices)
+
+	if c.hasGeometry {
+		for _, index := range indices {
+			c.processPlayer(frame, uint8(index))
+		}
+	}
+
+	for _, index := range indices {
+		c.prev[uint8(index)] = frame.Players[uint8(index)].Post
+	}
+}
+
+func (c *EdgeguardComputer) processPlayer(frame slippi.FrameEntry, index uint8) {
+	post := frame.Players[index].Post
+	prev := c.prev[index]
+	offstage := c.geometry.IsWellOffstage(post.XPosition, post.YPosition)
+
+	if edgeguard, ok := c.active[index]; ok {
+		outcome := EdgeguardUnresolved
+		switch {
+		case prev != nil && post.StocksRemaining < prev.StocksRemaining:
+			outcome = EdgeguardSuccess
+		case c.wasHitBy(frame, edgeguard.EdgeguarderIndex, index):
+			outcome = EdgeguardReversal
+		case !post.Airborne && !offstage && !slippi.IsDead(post.ActionStateID):
+			outcome = EdgeguardRecovery
+		}
+
+		if outcome != EdgeguardUnresolved {
+			edgeguard.EndFrame = frame.FrameNumber
+			edgeguard.Outcome = outcome
+			delete(c.active, index)
+		}
+		return
+	}
+
+	// situations begin once a player is knocked offstage by another player
+	edgeguarder := post.LastHitBy
+	if !offstage || !post.Airborne || !slippi.IsDamaged(post.ActionStateID) || edgeguarder == index {
+		return
+	} else if updates, ok := frame.Players[edgeguarder]; !ok || updates.Post == nil {
+		return
+	}
+
+	edgeguard := &Edgeguard{
+		EdgeguarderIndex: edgeguarder,
+		RecovererIndex:   index,
+		StartFrame:       frame.FrameNumber,
+		StartPercent:     post.Percent,
+	}
+	c.edgeguards = append(c.edgeguards, edgeguard)
+	c.active[index] = edgeguard
+}
+
+// wasHitBy returns whether the player with the index victim took damage on
+// the frame from the player with the index attacker.
+func (c *EdgeguardComputer) wasHitBy(frame slippi.FrameEntry, victim uint8, attacker uint8) bool {
+	updates, ok := frame.Players[victim]
+	prev, hasPrev := c.prev[victim]
+	if !ok || updates.Post == nil || !hasPrev {
+		return false
+	}
+
+	return updates.Post.Percent > prev.Percent && updates.Post.LastHitBy == attacker
+}
+
+// Edgeguards returns the edgeguard situations processed so far, in the order
+// they began, including those still unresolved.
+func (c *EdgeguardComputer) Edgeguards() []Edgeguard {
+	edgeguards := make([]Edgeguard, 0, len(c.edgeguards))
+	for _, edgeguard := range c.edgeguards {
+		edgeguards = append(edgeguards, *edgeguard)
+	}
+
+	return edgeguards
+}
+
+// PlayerStats returns the edgeguard stats of each player in the situations
+// processed so far, in order of their index.
+func (c *EdgeguardComputer) PlayerStats() []PlayerEdgeguardStats {
+	stats := make(map[uint8]*PlayerEdgeguardStats)
+	recovering := make(map[uint8]int)
+	recovered := make(map[uint8]int)
+	player := func(index uint8) *PlayerEdgeguardStats {
+		if _, ok := stats[index]; !ok {
+			stats[index] = &PlayerEdgeguardStats{PlayerIndex: index}
+		}
+		return stats[index]
+	}
+
+	for index := range c.prev {
+		player(index)
+	}
+
+	for _, edgeguard := range c.edgeguards {
+		if edgeguard.Outcome == EdgeguardUnresolved {
+			continue
+		}
+
+		edgeguarder := player(edgeguard.EdgeguarderIndex)
+		edgeguarder.Situations++
+		recovering[edgeguard.RecovererIndex]++
+		switch edgeguard.Outcome {
+		case EdgeguardSuccess:
+			edgeguarder.Successes++
+		case EdgeguardReversal:
+			edgeguarder.Reversals++
+		case EdgeguardRecovery:
+			edgeguarder.Recoveries++
+			recovered[edgeguard.RecovererIndex]++
+		}
+	}
+
+	indices := make([]int, 0, len(stats))
+	for index := range stats {
+		indices = append(indices, int(index))
+	}
+	sort.Ints(indices)
+
+	players := make([]PlayerEdgeguardStats, 0, len(indices))
+	for _, index := range indices {
+		s := stats[uint8(index)]
+		s.ConversionRate = newRatio(float64(s.Successes), float64(s.Situations))
+		s.RecoveryRate = newRatio(float64(recovered[uint8(index)]), float64(recovering[uint8(index)]))
+		players = append(players, *s)
+	}
+
+	return players
+}
+
+// ComputeEdgeguardStats returns the edgeguard stats of each player of the
+// game, in order of their index.
+func ComputeEdgeguardStats(game *slippi.SlpGame) ([]PlayerEdgeguardStats, error) {
+	computer := NewEdgeguardComputer()
+	if err := Run(game, computer); err != nil {
+		return nil, err
+	}
+
+	return computer.PlayerStats(), nil
+}
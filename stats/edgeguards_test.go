@@ -0,0 +1,54 @@
+package stats
+
+import "testing"
+
+func TestEdgeguards(t *testing.T) {
+	game := openFixture(t)
+
+	computer := NewEdgeguardComputer()
+	if err := Run(game, computer); err != nil {
+		t.Fatal(err)
+	}
+
+	// Falco lost his stocks at 3816 and 9864, and Fox his at 4781 and 12190,
+	// offstage
+	successes := make(map[int32]uint8)
+	for i, edgeguard := range computer.Edgeguards() {
+		if edgeguard.Outcome == EdgeguardUnresolved || edgeguard.EndFrame <= edgeguard.StartFrame {
+			t.Errorf("edgeguard %d: expected every situation to be resolved, got %+v", i, edgeguard)
+		}
+		if edgeguard.Outcome == EdgeguardSuccess {
+			successes[edgeguard.EndFrame] = edgeguard.RecovererIndex
+		}
+	}
+
+	expected := map[int32]uint8{3816: 1, 4781: 0, 9864: 1, 12190: 0}
+	if len(successes) != len(expected) {
+		t.Errorf("expected successful edgeguards ending on %v, got %v", expected, successes)
+	}
+	for frame, player := range expected {
+		if recoverer, ok := successes[frame]; !ok || recoverer != player {
+			t.Errorf("expected player %d to be edgeguarded on frame %d, got %v", player, frame, successes)
+		}
+	}
+
+	stats := computer.PlayerStats()
+	if len(stats) != 2 {
+		t.Fatalf("expected the stats of both players, got %+v", stats)
+	}
+	for _, player := range stats {
+		if player.Successes+player.Reversals+player.Recoveries != player.Situations {
+			t.Errorf("expected the outcomes of player %d to add up, got %+v", player.PlayerIndex, player)
+		}
+		if player.ConversionRate.Total != float64(player.Situations) || player.ConversionRate.Count != float64(player.Successes) {
+			t.Errorf("expected the conversion rate of player %d, got %+v", player.PlayerIndex, player.ConversionRate)
+		}
+	}
+
+	// Falco recovered from every situation he didn't lose a stock in or
+	// reverse
+	fox, falco := stats[0], stats[1]
+	if falco.RecoveryRate.Count != float64(fox.Recoveries) || falco.RecoveryRate.Total != float64(fox.Situations) {
+		t.Errorf("expected Falco's recoveries from Fox's edgeguards, got %+v", falco.RecoveryRate)
+	}
+}
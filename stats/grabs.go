@@ -0,0 +1,451 @@
+package stats
+
+import (
+	"sort"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+// throwFollowUpFrames is the number of frames after a throw in which the
+// damage its victim takes is that of its follow-ups.
+const throwFollowUpFrames = 120
+
+// A Throw is a throw of an opponent, with the damage of its follow-ups, such
+// as chaingrabs and tech chases.
+type Throw struct {
+	PlayerIndex uint8 `json:"playerIndex"`
+	VictimIndex uint8 `json:"victimIndex"`
+	// Frame is the frame the throw started on, and ReleaseFrame the frame
+	// the thrower left it.
+	Frame        int32 `json:"frame"`
+	ReleaseFrame int32 `json:"releaseFrame"`
+	// Direction is the action state of the throw.
+	Direction uint16 `json:"direction"`
+	// ReleasePercent is the percent of the victim when they were released,
+	// and FollowUpDamage the damage they took in the throwFollowUpFrames
+	// frames after it, or until they died.
+	ReleasePercent float32 `json:"releasePercent"`
+	FollowUpDamage float32 `json:"followUpDamage"`
+	Killed         bool    `json:"killed"`
+}
+
+// ThrowFollowUps are the damage of the follow-ups of throws in each direction.
+type ThrowFollowUps struct {
+	Up      float32 `json:"up"`
+	Forward float32 `json:"forward"`
+	Back    float32 `json:"back"`
+	Down    float32 `json:"down"`
+}
+
+// GrabStats are the grabs and throws of a player.
+type GrabStats struct {
+	// Grabs counts grabs that caught an opponent as successes, and those
+	// that whiffed as failures.
+	Grabs  SuccessCount `json:"grabs"`
+	Throws ThrowCounts  `json:"throws"`
+	// FollowUpDamage is the total damage of the follow-ups of the throws in
+	// each direction, and ThrowKills the number of throws followed by a kill.
+	FollowUpDamage ThrowFollowUps `json:"followUpDamage"`
+	ThrowKills     int            `json:"throwKills"`
+}
+
+// addThrow counts the throw.
+func (s *GrabStats) addThrow(throw Throw) {
+	switch throw.Direction {
+	case slippi.StateThrowUp:
+		s.Throws.Up++
+		s.FollowUpDamage.Up += throw.FollowUpDamage
+	case slippi.StateThrowForward:
+		s.Throws.Forward++
+		s.FollowUpDamage.Forward += throw.FollowUpDamage
+	case slippi.StateThrowBack:
+		s.Throws.Back++
+		s.FollowUpDamage.Back += throw.FollowUpDamage
+	case slippi.StateThrowDown:
+		s.Throws.Down++
+		s.FollowUpDamage.Down += throw.FollowUpDamage
+	}
+	if throw.Killed {
+		s.ThrowKills++
+	}
+}
+
+// merge adds the grabs and throws of other.
+func (s *GrabStats) merge(other GrabStats) {
+	s.Grabs.Success += other.Grabs.Success
+	s.Grabs.Fail += other.Grabs.Fail
+	s.Throws.Up += other.Throws.Up
+	s.Throws.Forward += other.Throws.Forward
+	s.Throws.Back += other.Throws.Back
+	s.Throws.Down += other.Throws.Down
+	s.FollowUpDamage.Up += other.FollowUpDamage.Up
+	s.FollowUpDamage.Forward += other.FollowUpDamage.Forward
+	s.FollowUpDamage.Back += other.FollowUpDamage.Back
+	s.FollowUpDamage.Down += other.FollowUpDamage.Down
+	s.ThrowKills += other.ThrowKills
+}
+
+// AverageFollowUpDamage returns the average damage of the follow-ups of the
+// throws, or 0 if there are none.
+func (s GrabStats) AverageFollowUpDamage() float64 {
+	throws := s.Throws.Up + s.Throws.Forward + s.Throws.Back + s.Throws.Down
+	if throws == 0 {
+		return 0
+	}
+
+	total := s.FollowUpDamage.Up + s.FollowUpDamage.Forward + s.FollowUpDamage.Back + s.FollowUpDamage.Down
+	return float64(total) / float64(throws)
+}
+
+// OpponentGrabStats are the grabs and throws of a player against a single
+// opponent.
+type OpponentGrabStats struct {
+	OpponentIndex uint8              `json:"opponentIndex"`
+	Character     slippi.CharacterID `json:"character"`
+	GrabStats
+}
+
+// PlayerGrabStats are the grabs and throws of a player in a game, in total and
+// against each opponent, in order of their index. Whiffed grabs count against
+// the closest opponent.
+type PlayerGrabStats struct {
+	PlayerIndex uint8              `json:"playerIndex"`
+	Character   slippi.CharacterID `json:"character"`
+	GrabStats
+	Opponents []OpponentGrabStats `json:"opponents"`
+}
+
+type grabState struct {
+	// grabbing is whether the player is in a grab that hasn't caught or
+	// whiffed yet, and victim the index of the opponent they hold, or -1.
+	grabbing bool
+	victim   int
+	// throw is the throw the player is in or following up on, if any, and
+	// throwing whether they haven't released its victim yet.
+	throw    *Throw
+	throwing bool
+}
+
+// A GrabComputer is a Computer that counts the grabs and throws of each
+// player, and the damage of the follow-ups of each throw.
+type GrabComputer struct {
+	gameInfo *slippi.GameInfo
+	throws   []*Throw
+	// grabs are the grabs of each player against each opponent, by the
+	// index of the player and then the opponent.
+	grabs  map[uint8]map[uint8]*SuccessCount
+	states map[uint8]*grabState
+	prev   map[uint8]*slippi.PostFrameUpdatePayload
+}
+
+// NewGrabComputer returns a GrabComputer without any grabs.
+func NewGrabComputer() *GrabComputer {
+	c := &GrabComputer{}
+	c.Setup(nil)
+
+	return c
+}
+
+// Setup implements Computer.
+func (c *GrabComputer) Setup(gameInfo *slippi.GameInfo) {
+	c.gameInfo = gameInfo
+	c.throws = make([]*Throw, 0)
+	c.grabs = make(map[uint8]map[uint8]*SuccessCount)
+	c.states = make(map[uint8]*grabState)
+	c.prev = make(map[uint8]*slippi.PostFrameUpdatePayload)
+}
+
+// ProcessFrame implements Computer.
+func (c *GrabComputer) ProcessFrame(frame slippi.FrameEntry) {
+	indices := make([]int, 0, len(frame.Players))
+	for index, updates := range frame.Players {
+		if updates.Post != nil {
+			indices = append(indices, int(index))
+		}
+	}
+	sort.Ints(indices)
+
+	for _, index := range indices {
+		state, ok := c.states[uint8(index)]
+		if !ok {
+			state = &grabState{victim: -1}
+			c.states[uint8(index)] = state
+		}
+
+		c.processPlayer(frame, state, *frame.Players[uint8(index)].Post)
+	}
+	for _, index := range indices {
+		c.prev[uint8(index)] = frame.Players[uint8(index)].Post
+	}
+}
+
+func (c *GrabComputer) processPlayer(frame slippi.FrameEntry, state *grabState, post slippi.PostFrameUpdatePayload) {
+	if state.throw != nil && !state.throwing {
+		c.followUp(frame, state)
+	}
+
+	prev, ok := c.prev[post.PlayerIndex]
+	if !ok || prev.ActionStateID == post.ActionStateID {
+		return
+	}
+
+	current := post.ActionStateID
+	if state.grabbing {
+		state.grabbing = false
+		if current == slippi.StateGrabPull || current == slippi.StateDashGrabPull {
+			state.victim = c.victimOf(frame, post)
+			if state.victim >= 0 {
+				c.grabCount(post.PlayerIndex, uint8(state.victim)).Success++
+			}
+		} else if opponent, ok := closestOpponent(c.gameInfo, frame, post); ok {
+			c.grabCount(post.PlayerIndex, opponent.PlayerIndex).Fail++
+		}
+	}
+
+	switch {
+	case current == slippi.StateGrab || current == slippi.StateDashGrab:
+		state.grabbing = true
+		state.victim = -1
+	case current >= slippi.StateThrowForward && current <= slippi.StateThrowDown:
+		victim := state.victim
+		if victim < 0 {
+			victim = c.victimOf(frame, post)
+		}
+		if victim >= 0 {
+			state.throw = &Throw{PlayerIndex: post.PlayerIndex, VictimIndex: uint8(victim), Frame: frame.FrameNumber, Direction: current}
+			state.throwing = true
+			c.throws = append(c.throws, state.throw)
+		}
+		state.victim = -1
+	case state.throwing:
+		// the thrower releases the victim as they leave the throw
+		state.throwing = false
+		state.throw.ReleaseFrame = frame.FrameNumber
+		if victim := frame.Players[state.throw.VictimIndex].Post; victim != nil {
+			state.throw.ReleasePercent = victim.Percent
+		}
+	case current < slippi.StateGrabPull || current > slippi.StateThrowDown:
+		state.victim = -1
+	}
+}
+
+// followUp adds the damage the victim of the throw of state took on frame to
+// the damage of its follow-ups, until the end of the follow-ups.
+func (c *GrabComputer) followUp(frame slippi.FrameEntry, state *grabState) {
+	throw := state.throw
+	if frame.FrameNumber-throw.ReleaseFrame > throwFollowUpFrames {
+		state.throw = nil
+		return
+	}
+
+	victim := frame.Players[throw.VictimIndex].Post
+	prev := c.prev[throw.VictimIndex]
+	if victim == nil || prev == nil {
+		return
+	}
+
+	if slippi.IsDead(victim.ActionStateID) {
+		throw.Killed = true
+		state.throw = nil
+		return
+	}
+	throw.FollowUpDamage += max(victim.Percent-prev.Percent, 0)
+}
+
+// victimOf returns the index of the opponent held by the player with the given
+// post-frame update, which is the closest grabbed opponent, or -1 if there is
+// none.
+func (c *GrabComputer) victimOf(frame slippi.FrameEntry, post slippi.PostFrameUpdatePayload) int {
+	if opponent, ok := closestOpponent(c.gameInfo, frame, post); ok && slippi.IsGrabbed(opponent.ActionStateID) {
+		return int(opponent.PlayerIndex)
+	}
+
+	return -1
+}
+
+// grabCount returns the grabs of the player with the given index against the
+// opponent with the given index.
+func (c *GrabComputer) grabCount(index uint8, opponent uint8) *SuccessCount {
+	opponents, ok := c.grabs[index]
+	if !ok {
+		opponents = make(map[uint8]*SuccessCount)
+		c.grabs[index] = opponents
+	}
+
+	count, ok := opponents[opponent]
+	if !ok {
+		count = &SuccessCount{}
+		opponents[opponent] = count
+	}
+
+	return count
+}
+
+// Throws returns the throws processed so far, in order, including those
+// whose follow-ups haven't ended yet.
+func (c *GrabComputer) Throws() []Throw {
+	throws := make([]Throw, 0, len(c.throws))
+	for _, throw := range c.throws {
+		throws = append(throws, *throw)
+	}
+
+	return throws
+}
+
+// PlayerStats returns the grab stats of each player, in order of their index.
+func (c *GrabComputer) PlayerStats() []PlayerGrabStats {
+	characters := make(map[uint8]slippi.CharacterID)
+	if c.gameInfo != nil {
+		for _, player := range c.gameInfo.Players {
+			characters[player.Index] = player.CharacterID
+		}
+	}
+	character := func(index uint8) slippi.CharacterID {
+		if character, ok := characters[index]; ok {
+			return character
+		}
+		return slippi.NoCharacter
+	}
+
+	opponents := make(map[uint8]map[uint8]*GrabStats)
+	opponent := func(index uint8, other uint8) *GrabStats {
+		if _, ok := opponents[index]; !ok {
+			opponents[index] = make(map[uint8]*GrabStats)
+		}
+		if _, ok := opponents[index][other]; !ok {
+			opponents[index][other] = &GrabStats{}
+		}
+		return opponents[index][other]
+	}
+	for index := range characters {
+		opponents[index] = make(map[uint8]*GrabStats)
+	}
+	for index, counts := range c.grabs {
+		for other, count := range counts {
+			opponent(index, other).Grabs = *count
+		}
+	}
+	for _, throw := range c.throws {
+		opponent(throw.PlayerIndex, throw.VictimIndex).addThrow(*throw)
+	}
+
+	indices := make([]int, 0, len(opponents))
+	for index := range opponents {
+		indices = append(indices, int(index))
+	}
+	sort.Ints(indices)
+
+	players := make([]PlayerGrabStats, 0, len(indices))
+	for _, index := range indices {
+		player := PlayerGrabStats{PlayerIndex: uint8(index), Character: character(uint8(index)), Opponents: make([]OpponentGrabStats, 0)}
+
+		others := make([]int, 0, len(opponents[uint8(index)]))
+		for other := range opponents[uint8(index)] {
+			others = append(others, int(other))
+		}
+		sort.Ints(others)
+		for _, other := range others {
+			stats := *opponents[uint8(index)][uint8(other)]
+			player.GrabStats.merge(stats)
+			player.Opponents = append(player.Opponents, OpponentGrabStats{OpponentIndex: uint8(other), Character: character(uint8(other)), GrabStats: stats})
+		}
+		players = append(players, player)
+	}
+
+	return players
+}
+
+// ComputeGrabStats returns the grab stats of each player of the game, in order
+// of their index.
+func ComputeGrabStats(game *slippi.SlpGame) ([]PlayerGrabStats, error) {
+	computer := NewGrabComputer()
+	if err := Run(game, computer); err != nil {
+		return nil, err
+	}
+
+	return computer.PlayerStats(), nil
+}
+
+// MatchupGrabStats are the grabs and throws of players of a single character
+// against opponents of another across games.
+type MatchupGrabStats struct {
+	Character             slippi.CharacterID `json:"character"`
+	CharacterName         string             `json:"characterName"`
+	OpponentCharacter     slippi.CharacterID `json:"opponentCharacter"`
+	OpponentCharacterName string             `json:"opponentCharacterName"`
+	Games                 int                `json:"games"`
+	GrabStats
+}
+
+// A GrabStatsCollection aggregates grab stats across games, grouped by
+// matchup.
+type GrabStatsCollection struct {
+	Matchups []*MatchupGrabStats `json:"matchups"`
+}
+
+// NewGrabStatsCollection returns an empty GrabStatsCollection.
+func NewGrabStatsCollection() *GrabStatsCollection {
+	return &GrabStatsCollection{Matchups: make([]*MatchupGrabStats, 0)}
+}
+
+// Matchup returns the grab stats of the given character against the given
+// opponent character, or nil if no games of the matchup have been added.
+func (g *GrabStatsCollection) Matchup(character slippi.CharacterID, opponent slippi.CharacterID) *MatchupGrabStats {
+	for _, m := range g.Matchups {
+		if m.Character == character && m.OpponentCharacter == opponent {
+			return m
+		}
+	}
+
+	return nil
+}
+
+// AddGame adds the grabs and throws of each player in game against each of
+// their opponents to the stats of the matchup of their characters.
+func (g *GrabStatsCollection) AddGame(game *slippi.SlpGame) error {
+	stats, err := ComputeGrabStats(game)
+	if err != nil {
+		return err
+	}
+
+	gameInfo, err := game.GetGameInfo()
+	if err != nil {
+		return err
+	}
+
+	for _, player := range stats {
+		counted := make(map[slippi.CharacterID]bool)
+		for _, other := range gameInfo.Players {
+			if other.Index == player.PlayerIndex || isTeammate(gameInfo, other.Index, player.PlayerIndex) || counted[other.CharacterID] {
+				continue
+			}
+			counted[other.CharacterID] = true
+
+			matchup := g.Matchup(player.Character, other.CharacterID)
+			if matchup == nil {
+				matchup = &MatchupGrabStats{
+					Character:             player.Character,
+					CharacterName:         player.Character.String(),
+					OpponentCharacter:     other.CharacterID,
+					OpponentCharacterName: other.CharacterID.String(),
+				}
+				g.Matchups = append(g.Matchups, matchup)
+				sort.Slice(g.Matchups, func(i, j int) bool {
+					if g.Matchups[i].Character != g.Matchups[j].Character {
+						return g.Matchups[i].Character < g.Matchups[j].Character
+					}
+					return g.Matchups[i].OpponentCharacter < g.Matchups[j].OpponentCharacter
+				})
+			}
+			matchup.Games++
+		}
+
+		for _, opponent := range player.Opponents {
+			if matchup := g.Matchup(player.Character, opponent.Character); matchup != nil {
+				matchup.GrabStats.merge(opponent.GrabStats)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package stats
+
+import (
+	"testing"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+func TestGrabStats(t *testing.T) {
+	game := openFixture(t)
+
+	computer := NewGrabComputer()
+	if err := Run(game, computer); err != nil {
+		t.Fatal(err)
+	}
+
+	throws := computer.Throws()
+	if len(throws) != 4 {
+		t.Fatalf("expected 4 throws, got %+v", throws)
+	}
+	// Fox up throws Falco into 16.83% of up airs
+	if first := throws[0]; first.PlayerIndex != 0 || first.VictimIndex != 1 || first.Frame != 1434 || first.ReleaseFrame != 1465 || first.Direction != slippi.StateThrowUp || int(first.FollowUpDamage) != 16 || first.Killed {
+		t.Errorf("expected Fox's up throw on frame 1434, got %+v", first)
+	}
+
+	stats := computer.PlayerStats()
+	if len(stats) != 2 {
+		t.Fatalf("expected the grab stats of both players, got %+v", stats)
+	}
+
+	// Fox's grabs and throws agree with his action counts
+	fox, falco := stats[0], stats[1]
+	if fox.Grabs != (SuccessCount{Success: 4, Fail: 1}) || fox.Throws != (ThrowCounts{Up: 3, Forward: 1}) {
+		t.Errorf("expected Fox to land 4 of 5 grabs into 3 up throws and a forward throw, got %+v", fox)
+	}
+	if len(fox.Opponents) != 1 || fox.Opponents[0].OpponentIndex != 1 || fox.Opponents[0].Character != slippi.Falco || fox.Opponents[0].GrabStats != fox.GrabStats {
+		t.Errorf("expected all of Fox's grabs to be against Falco, got %+v", fox.Opponents)
+	}
+	if fox.FollowUpDamage.Forward != 0 || int(fox.AverageFollowUpDamage()) != 8 {
+		t.Errorf("expected Fox to follow up on up throws only, got %+v", fox.FollowUpDamage)
+	}
+	if falco.Grabs != (SuccessCount{}) || len(falco.Opponents) != 0 || falco.AverageFollowUpDamage() != 0 {
+		t.Errorf("expected Falco not to grab, got %+v", falco)
+	}
+
+	collection := NewGrabStatsCollection()
+	for i := 0; i < 2; i++ {
+		if err := collection.AddGame(game); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(collection.Matchups) != 2 {
+		t.Fatalf("expected both sides of the matchup, got %+v", collection.Matchups)
+	}
+
+	matchup := collection.Matchup(slippi.Fox, slippi.Falco)
+	if matchup == nil || matchup.Games != 2 || matchup.Grabs != (SuccessCount{Success: 8, Fail: 2}) || matchup.Throws.Up != 6 {
+		t.Errorf("expected Fox's grabs of both games against Falco, got %+v", matchup)
+	}
+	if matchup := collection.Matchup(slippi.Falco, slippi.Fox); matchup == nil || matchup.Games != 2 || matchup.Grabs != (SuccessCount{}) {
+		t.Errorf("expected Falco's games against Fox without grabs, got %+v", matchup)
+	}
+	if collection.Matchup(slippi.Fox, slippi.Fox) != nil {
+		t.Error("expected no ditto")
+	}
+}
@@ -0,0 +1,141 @@
+package stats
+
+import slippi "github.com/ZadenRB/go-slippi"
+
+// HandwarmerSign enumerates the signs of a game that wasn't played
+// competitively, such as a handwarmer before a set or friendlies.
+type HandwarmerSign uint8
+
+// HandwarmerSigns
+const (
+	// HandwarmerSelfDestructs is the sign of games in which a player
+	// repeatedly self destructed.
+	HandwarmerSelfDestructs HandwarmerSign = iota
+	// HandwarmerEarlyQuitOut is the sign of games quit out of within
+	// seconds of starting.
+	HandwarmerEarlyQuitOut
+	// HandwarmerIdle is the sign of games in which every player spent much
+	// of the game not touching their controller.
+	HandwarmerIdle
+)
+
+var handwarmerSignNames = map[HandwarmerSign]string{
+	HandwarmerSelfDestructs: "self-destructs",
+	HandwarmerEarlyQuitOut:  "early-quit-out",
+	HandwarmerIdle:          "idle",
+}
+
+// String returns the name of the sign.
+func (s HandwarmerSign) String() string {
+	return handwarmerSignNames[s]
+}
+
+// MarshalText encodes the sign as its name.
+func (s HandwarmerSign) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// HandwarmerOpts are the thresholds at which each sign of a handwarmer is
+// found. A threshold of 0 disables its sign.
+type HandwarmerOpts struct {
+	// SelfDestructs is the number of self destructs of a single player at
+	// which they were repeated.
+	SelfDestructs int `json:"selfDestructs"`
+	// QuitOutFrames is the number of playable frames before which a game
+	// that was quit out of was quit out of early.
+	QuitOutFrames int32 `json:"quitOutFrames"`
+	// IdleRatio is the ratio of the playable frames on which every player was
+	// idle at which the game is idle.
+	IdleRatio float64 `json:"idleRatio"`
+}
+
+// DefaultHandwarmerOpts flags games in which a player self destructed twice,
+// that were quit out of within 20 seconds, or in which the players were idle
+// together for a quarter of the game.
+var DefaultHandwarmerOpts = HandwarmerOpts{SelfDestructs: 2, QuitOutFrames: 20 * 60, IdleRatio: 0.25}
+
+// A HandwarmerVerdict is whether a game is likely a handwarmer, so that stats
+// aggregated across games can leave it out of players' records.
+type HandwarmerVerdict struct {
+	// Signs are the signs of a handwarmer found in the game, of which there
+	// are none if it was likely played competitively.
+	Signs []HandwarmerSign `json:"signs"`
+	// SelfDestructs are the self destructs of the player who self destructed
+	// the most, and IdleFrames the playable frames on which every player
+	// was idle, out of all playable frames.
+	SelfDestructs int   `json:"selfDestructs"`
+	IdleFrames    Ratio `json:"idleFrames"`
+}
+
+// IsHandwarmer returns whether any sign of a handwarmer was found.
+func (v HandwarmerVerdict) IsHandwarmer() bool {
+	return len(v.Signs) > 0
+}
+
+// idleComputer is a Computer that counts the playable frames on which
+// every player was idle, with their sticks at rest and no buttons pressed.
+type idleComputer struct {
+	idle     int32
+	playable int32
+}
+
+// Setup implements Computer.
+func (c *idleComputer) Setup(*slippi.GameInfo) {
+	c.idle = 0
+	c.playable = 0
+}
+
+// ProcessFrame implements Computer.
+func (c *idleComputer) ProcessFrame(frame slippi.FrameEntry) {
+	if frame.FrameNumber < slippi.FirstPlayableFrame {
+		return
+	}
+	c.playable++
+
+	for _, updates := range frame.Players {
+		if pre := updates.Pre; pre != nil && !slippi.IsIdle(pre) {
+			return
+		}
+	}
+	c.idle++
+}
+
+// ComputeHandwarmer returns whether the game is likely a handwarmer, by the
+// signs of one found at the thresholds of opts.
+func ComputeHandwarmer(game *slippi.SlpGame, opts HandwarmerOpts) (*HandwarmerVerdict, error) {
+	stocks := NewStocksComputer()
+	idle := &idleComputer{}
+	if err := Run(game, stocks, idle); err != nil {
+		return nil, err
+	}
+
+	quitOut, err := game.IsQuitOut()
+	if err != nil {
+		return nil, err
+	}
+
+	verdict := &HandwarmerVerdict{
+		Signs:      make([]HandwarmerSign, 0),
+		IdleFrames: newRatio(float64(idle.idle), float64(idle.playable)),
+	}
+
+	selfDestructs := make(map[uint8]int)
+	for _, stock := range stocks.Stocks() {
+		if stock.EndFrame != nil && stock.SelfDestruct {
+			selfDestructs[stock.PlayerIndex]++
+			verdict.SelfDestructs = max(verdict.SelfDestructs, selfDestructs[stock.PlayerIndex])
+		}
+	}
+
+	if opts.SelfDestructs > 0 && verdict.SelfDestructs >= opts.SelfDestructs {
+		verdict.Signs = append(verdict.Signs, HandwarmerSelfDestructs)
+	}
+	if opts.QuitOutFrames > 0 && quitOut && idle.playable < opts.QuitOutFrames {
+		verdict.Signs = append(verdict.Signs, HandwarmerEarlyQuitOut)
+	}
+	if ratio := verdict.IdleFrames.Ratio; opts.IdleRatio > 0 && ratio != nil && *ratio >= opts.IdleRatio {
+		verdict.Signs = append(verdict.Signs, HandwarmerIdle)
+	}
+
+	return verdict, nil
+}
@@ -0,0 +1,63 @@
+package stats
+
+import (
+	"slices"
+	"testing"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+func TestHandwarmer(t *testing.T) {
+	replay := readFixture(t)
+
+	quitOut := rewriteEvents(t, replay, func(event []byte) []byte {
+		if slippi.Command(event[0]) == slippi.GameEnd {
+			event[1] = byte(slippi.NoContest)
+			event[2] = 0
+		}
+		return event
+	})
+
+	cases := []struct {
+		name   string
+		replay []byte
+		opts   HandwarmerOpts
+		signs  []HandwarmerSign
+	}{
+		// Fox self destructs once, and the players are rarely idle together
+		{"competitive", replay, DefaultHandwarmerOpts, []HandwarmerSign{}},
+		{"self destructs", replay, HandwarmerOpts{SelfDestructs: 1}, []HandwarmerSign{HandwarmerSelfDestructs}},
+		{"idle", replay, HandwarmerOpts{IdleRatio: 0.1}, []HandwarmerSign{HandwarmerIdle}},
+		// the game lasts less than 13000 playable frames
+		{"early quit out", quitOut, HandwarmerOpts{QuitOutFrames: 13000}, []HandwarmerSign{HandwarmerEarlyQuitOut}},
+		{"late quit out", quitOut, DefaultHandwarmerOpts, []HandwarmerSign{}},
+		{"not quit out", replay, HandwarmerOpts{QuitOutFrames: 13000}, []HandwarmerSign{}},
+	}
+
+	for _, c := range cases {
+		game, err := slippi.NewSlpGameFromBytes(c.replay, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		verdict, err := ComputeHandwarmer(game, c.opts)
+		game.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !slices.Equal(verdict.Signs, c.signs) || verdict.IsHandwarmer() != (len(c.signs) > 0) {
+			t.Errorf("%s: expected the signs %v, got %v", c.name, c.signs, verdict.Signs)
+		}
+		if verdict.SelfDestructs != 1 || verdict.IdleFrames.Total != float64(12219-slippi.FirstPlayableFrame+1) {
+			t.Errorf("%s: expected a self destruct out of the whole game, got %+v", c.name, verdict)
+		}
+		if ratio := verdict.IdleFrames.Ratio; ratio == nil || *ratio <= 0.1 || *ratio >= 0.25 {
+			t.Errorf("%s: expected the players to be idle together for between 10%% and 25%% of the game, got %+v", c.name, verdict.IdleFrames)
+		}
+	}
+
+	if name, _ := HandwarmerEarlyQuitOut.MarshalText(); string(name) != "early-quit-out" {
+		t.Errorf("expected early quit outs to be encoded as early-quit-out, got %s", name)
+	}
+}
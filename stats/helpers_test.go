@@ -0,0 +1,72 @@
+package stats
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"testing"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+// fixture is the path of the replay the tests compute the stats of.
+const fixture = "../game.slp"
+
+// readFixture returns the bytes of the fixture replay.
+func readFixture(t *testing.T) []byte {
+	t.Helper()
+
+	replay, err := os.ReadFile(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return replay
+}
+
+// openFixture returns the game of the fixture replay, which is closed once
+// the test ends.
+func openFixture(t *testing.T) *slippi.SlpGame {
+	t.Helper()
+
+	game, err := slippi.NewSlpGameFromBytes(readFixture(t), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(game.Close)
+
+	return game
+}
+
+// rewriteEvents returns a copy of the replay with each raw event passed
+// through rewrite, which returns the event's new bytes or nil to drop it.
+func rewriteEvents(t *testing.T, replay []byte, rewrite func(event []byte) []byte) []byte {
+	raw := replay[15 : 15+int(binary.BigEndian.Uint32(replay[11:15]))]
+
+	payloadSizes := map[byte]int{raw[0]: int(raw[1])}
+	for position := 2; position < int(raw[1]); position += 3 {
+		payloadSizes[raw[position]] = int(binary.BigEndian.Uint16(raw[position+1 : position+3]))
+	}
+
+	var b bytes.Buffer
+	writer := slippi.NewSlpWriter(&b)
+	for position := 0; position < len(raw); {
+		size, ok := payloadSizes[raw[position]]
+		if !ok {
+			t.Fatalf("unknown command 0x%X", raw[position])
+		}
+
+		event := append([]byte{}, raw[position:position+size+1]...)
+		if rewritten := rewrite(event); rewritten != nil {
+			writer.Write(rewritten)
+		}
+		position += size + 1
+	}
+
+	err := writer.Finish(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b.Bytes()
+}
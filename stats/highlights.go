@@ -0,0 +1,109 @@
+package stats
+
+import (
+	"slices"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+// chaingrabThrows is the number of throws in a combo from which it is a
+// chaingrab, and wobblePummels the number of pummels from which it is a
+// wobble, which counts each of Nana's hits while Popo holds the defender as
+// a pummel.
+const (
+	chaingrabThrows = 3
+	wobblePummels   = 5
+)
+
+// A ComboFilter selects the combos worth highlighting. The zero value selects
+// every combo.
+type ComboFilter struct {
+	// MinHits is the fewest moves a combo may have, counting each multi-hit
+	// move once, and MinDamage the least damage it may deal.
+	MinHits   int
+	MinDamage float32
+	// MustKill selects only combos that took a stock.
+	MustKill bool
+	// ExcludeChaingrabs excludes chaingrabs and wobbles, which repeat the
+	// same grab rather than combo.
+	ExcludeChaingrabs bool
+	// Characters, unless empty, are the characters the player doing the
+	// combo may play, and OpponentCharacters those the player being comboed
+	// may play.
+	Characters         []slippi.CharacterID
+	OpponentCharacters []slippi.CharacterID
+}
+
+// Match returns whether the filter selects the combo, which is of a game with
+// the given game info.
+func (f ComboFilter) Match(gameInfo *slippi.GameInfo, combo Combo) bool {
+	if len(combo.Moves) < f.MinHits || combo.Damage() < f.MinDamage || (f.MustKill && !combo.DidKill) {
+		return false
+	}
+	if f.ExcludeChaingrabs && isGrabLoop(combo) {
+		return false
+	}
+
+	if len(f.Characters) > 0 || len(f.OpponentCharacters) > 0 {
+		if gameInfo == nil {
+			return false
+		}
+
+		characters := make(map[uint8]slippi.CharacterID)
+		for _, player := range gameInfo.Players {
+			characters[player.Index] = player.CharacterID
+		}
+		if len(f.Characters) > 0 && !slices.Contains(f.Characters, characters[combo.LastHitBy]) {
+			return false
+		}
+		if len(f.OpponentCharacters) > 0 && !slices.Contains(f.OpponentCharacters, characters[combo.PlayerIndex]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Select returns the combos the filter selects, in order, of a game with the
+// given game info.
+func (f ComboFilter) Select(gameInfo *slippi.GameInfo, combos []Combo) []Combo {
+	selected := make([]Combo, 0)
+	for _, combo := range combos {
+		if f.Match(gameInfo, combo) {
+			selected = append(selected, combo)
+		}
+	}
+
+	return selected
+}
+
+// isGrabLoop returns whether the combo is a chaingrab or a wobble.
+func isGrabLoop(combo Combo) bool {
+	throws, pummels := 0, 0
+	for _, move := range combo.Moves {
+		switch {
+		case move.MoveID.IsThrow():
+			throws++
+		case move.MoveID == slippi.Pummel:
+			pummels++
+		}
+	}
+
+	return throws >= chaingrabThrows || pummels >= wobblePummels
+}
+
+// ComputeHighlights returns the combos in the game that filter selects, in the
+// order they started.
+func ComputeHighlights(game *slippi.SlpGame, filter ComboFilter) ([]Combo, error) {
+	combos, err := ComputeCombos(game)
+	if err != nil {
+		return nil, err
+	}
+
+	gameInfo, err := game.GetGameInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	return filter.Select(gameInfo, combos), nil
+}
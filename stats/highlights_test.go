@@ -0,0 +1,70 @@
+package stats
+
+import (
+	"testing"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+func TestComboFilter(t *testing.T) {
+	game := openFixture(t)
+
+	all, err := ComputeHighlights(game, ComboFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 60 {
+		t.Errorf("expected the zero filter to select all 60 combos, got %d", len(all))
+	}
+
+	cases := []struct {
+		name   string
+		filter ComboFilter
+		starts []int32
+	}{
+		{"hits", ComboFilter{MinHits: 4}, []int32{1389, 2694, 4279}},
+		{"damage", ComboFilter{MinHits: 4, MinDamage: 45}, []int32{1389, 4279}},
+		{"kills", ComboFilter{MustKill: true}, []int32{3767, 4732, 12173}},
+		{"characters", ComboFilter{MinHits: 4, Characters: []slippi.CharacterID{slippi.Fox}}, []int32{1389, 4279}},
+		{"opponents", ComboFilter{MustKill: true, OpponentCharacters: []slippi.CharacterID{slippi.Fox}}, []int32{4732, 12173}},
+		{"other characters", ComboFilter{Characters: []slippi.CharacterID{slippi.Marth, slippi.Sheik}}, []int32{}},
+	}
+	for _, c := range cases {
+		highlights, err := ComputeHighlights(game, c.filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		starts := make([]int32, 0, len(highlights))
+		for _, combo := range highlights {
+			starts = append(starts, combo.StartFrame)
+		}
+		if len(starts) != len(c.starts) {
+			t.Errorf("%s: expected combos starting on %v, got %v", c.name, c.starts, starts)
+			continue
+		}
+		for i := range starts {
+			if starts[i] != c.starts[i] {
+				t.Errorf("%s: expected combos starting on %v, got %v", c.name, c.starts, starts)
+				break
+			}
+		}
+	}
+
+	// Ice Climbers wobble, and Sheik chaingrabs, but neither is a highlight
+	wobble := Combo{Moves: []slippi.ConversionMove{{MoveID: slippi.ForwardThrow}}}
+	for i := 0; i < wobblePummels; i++ {
+		wobble.Moves = append(wobble.Moves, slippi.ConversionMove{MoveID: slippi.Pummel, Damage: 3})
+	}
+	chaingrab := Combo{Moves: []slippi.ConversionMove{{MoveID: slippi.DownThrow}, {MoveID: slippi.DownThrow}, {MoveID: slippi.DownThrow}, {MoveID: slippi.ForwardAir}}}
+	filter := ComboFilter{ExcludeChaingrabs: true}
+	if filter.Match(nil, wobble) || filter.Match(nil, chaingrab) {
+		t.Error("expected wobbles and chaingrabs to be excluded")
+	}
+	if !(ComboFilter{}).Match(nil, chaingrab) || !filter.Match(nil, Combo{Moves: chaingrab.Moves[2:]}) {
+		t.Error("expected a single throw into an aerial to be selected")
+	}
+	if (ComboFilter{Characters: []slippi.CharacterID{slippi.Sheik}}).Match(nil, chaingrab) {
+		t.Error("expected a combo without a game not to match a character")
+	}
+}
@@ -0,0 +1,222 @@
+package stats
+
+import (
+	"math/bits"
+	"sort"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+// framesPerMinute is the number of frames in a minute of a game.
+const framesPerMinute = 60 * 60
+
+// stickDeadzone is the deflection of a stick on either axis below which it
+// is taken to be at rest.
+const stickDeadzone = 0.2875
+
+// triggerThreshold is the analog trigger press at or above which the trigger
+// is taken to be pressed.
+const triggerThreshold = 0.3
+
+// stickRegion enumerates the regions a stick can be in, between which a move
+// is an input.
+type stickRegion uint8
+
+// stickRegions
+const (
+	regionDeadzone stickRegion = iota
+	regionNorthEast
+	regionSouthEast
+	regionSouthWest
+	regionNorthWest
+	regionNorth
+	regionEast
+	regionSouth
+	regionWest
+)
+
+// regionOf returns the region of a stick at the given coordinates.
+func regionOf(x float32, y float32) stickRegion {
+	switch {
+	case x >= stickDeadzone && y >= stickDeadzone:
+		return regionNorthEast
+	case x >= stickDeadzone && y <= -stickDeadzone:
+		return regionSouthEast
+	case x <= -stickDeadzone && y <= -stickDeadzone:
+		return regionSouthWest
+	case x <= -stickDeadzone && y >= stickDeadzone:
+		return regionNorthWest
+	case y >= stickDeadzone:
+		return regionNorth
+	case x >= stickDeadzone:
+		return regionEast
+	case y <= -stickDeadzone:
+		return regionSouth
+	case x <= -stickDeadzone:
+		return regionWest
+	}
+
+	return regionDeadzone
+}
+
+// PlayerInputs are the inputs of a player, counted as slippi-js does: each
+// digital button pressed, each move of a stick into another region other than
+// its deadzone, and each analog trigger press.
+type PlayerInputs struct {
+	PlayerIndex        uint8 `json:"playerIndex"`
+	InputCount         int   `json:"inputCount"`
+	JoystickInputCount int   `json:"joystickInputCount"`
+	CStickInputCount   int   `json:"cstickInputCount"`
+	ButtonInputCount   int   `json:"buttonInputCount"`
+	TriggerInputCount  int   `json:"triggerInputCount"`
+	// APM is the number of inputs per minute of playable frames.
+	APM float64 `json:"apm"`
+	// Timeline is the number of inputs per minute in each minute of
+	// playable frames, the last of which may be partial.
+	Timeline []float64 `json:"timeline"`
+}
+
+type inputsState struct {
+	inputs PlayerInputs
+	// minutes are the number of inputs in each minute of playable frames.
+	minutes []int
+	prev    *slippi.PreFrameUpdatePayload
+}
+
+// An InputsComputer is a Computer that counts the inputs of each player
+// from their pre-frame updates.
+type InputsComputer struct {
+	states    map[uint8]*inputsState
+	lastFrame int32
+}
+
+// NewInputsComputer returns an InputsComputer without any inputs counted.
+func NewInputsComputer() *InputsComputer {
+	c := &InputsComputer{}
+	c.Setup(nil)
+
+	return c
+}
+
+// Setup implements Computer.
+func (c *InputsComputer) Setup(gameInfo *slippi.GameInfo) {
+	c.states = make(map[uint8]*inputsState)
+	c.lastFrame = slippi.FirstFrame - 1
+	if gameInfo != nil {
+		for _, player := range gameInfo.Players {
+			c.states[player.Index] = &inputsState{inputs: PlayerInputs{PlayerIndex: player.Index}}
+		}
+	}
+}
+
+// ProcessFrame implements Computer.
+func (c *InputsComputer) ProcessFrame(frame slippi.FrameEntry) {
+	c.lastFrame = frame.FrameNumber
+	for index, updates := range frame.Players {
+		if updates.Pre == nil {
+			continue
+		}
+
+		state, ok := c.states[index]
+		if !ok {
+			state = &inputsState{inputs: PlayerInputs{PlayerIndex: index}}
+			c.states[index] = state
+		}
+
+		prev := state.prev
+		state.prev = updates.Pre
+		// inputs aren't counted until the game starts
+		if frame.FrameNumber < slippi.FirstPlayableFrame || prev == nil {
+			continue
+		}
+
+		c.countInputs(frame.FrameNumber, state, *prev, *updates.Pre)
+	}
+}
+
+func (c *InputsComputer) countInputs(frameNumber int32, state *inputsState, prev slippi.PreFrameUpdatePayload, pre slippi.PreFrameUpdatePayload) {
+	inputs := &state.inputs
+	count := 0
+
+	// only buttons going from released to pressed count
+	pressed := bits.OnesCount16(^prev.PhysicalButtons & pre.PhysicalButtons & 0xFFF)
+	inputs.ButtonInputCount += pressed
+	count += pressed
+
+	if region := regionOf(pre.JoystickX, pre.JoystickY); region != regionDeadzone && region != regionOf(prev.JoystickX, prev.JoystickY) {
+		inputs.JoystickInputCount++
+		count++
+	}
+	if region := regionOf(pre.CStickX, pre.CStickY); region != regionDeadzone && region != regionOf(prev.CStickX, prev.CStickY) {
+		inputs.CStickInputCount++
+		count++
+	}
+
+	if prev.PhysicalLTrigger < triggerThreshold && pre.PhysicalLTrigger >= triggerThreshold {
+		inputs.TriggerInputCount++
+		count++
+	}
+	if prev.PhysicalRTrigger < triggerThreshold && pre.PhysicalRTrigger >= triggerThreshold {
+		inputs.TriggerInputCount++
+		count++
+	}
+
+	inputs.InputCount += count
+
+	minute := int((frameNumber - slippi.FirstPlayableFrame) / framesPerMinute)
+	for len(state.minutes) <= minute {
+		state.minutes = append(state.minutes, 0)
+	}
+	state.minutes[minute] += count
+}
+
+// playableFrames returns the number of playable frames processed so far,
+// counted as GetPlayableFrameCount counts them.
+func (c *InputsComputer) playableFrames() int32 {
+	return max(c.lastFrame-slippi.FirstPlayableFrame, 0)
+}
+
+// Inputs returns the inputs counted so far for each player, in order of their
+// index.
+func (c *InputsComputer) Inputs() []PlayerInputs {
+	playableFrames := c.playableFrames()
+	minuteCount := int((playableFrames + framesPerMinute - 1) / framesPerMinute)
+
+	indices := make([]int, 0, len(c.states))
+	for index := range c.states {
+		indices = append(indices, int(index))
+	}
+	sort.Ints(indices)
+
+	players := make([]PlayerInputs, 0, len(indices))
+	for _, index := range indices {
+		state := c.states[uint8(index)]
+		inputs := state.inputs
+		if playableFrames > 0 {
+			inputs.APM = float64(inputs.InputCount) / (float64(playableFrames) / framesPerMinute)
+		}
+
+		inputs.Timeline = make([]float64, minuteCount)
+		for minute := range inputs.Timeline {
+			frames := min(playableFrames-int32(minute)*framesPerMinute, framesPerMinute)
+			if minute < len(state.minutes) {
+				inputs.Timeline[minute] = float64(state.minutes[minute]) / (float64(frames) / framesPerMinute)
+			}
+		}
+
+		players = append(players, inputs)
+	}
+
+	return players
+}
+
+// ComputeInputs returns the inputs counted for each player of the game, in
+// order of their index.
+func ComputeInputs(game *slippi.SlpGame) ([]PlayerInputs, error) {
+	computer := NewInputsComputer()
+	if err := Run(game, computer); err != nil {
+		return nil, err
+	}
+
+	return computer.Inputs(), nil
+}
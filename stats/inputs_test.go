@@ -0,0 +1,38 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestInputs(t *testing.T) {
+	game := openFixture(t)
+
+	players, err := ComputeInputs(game)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(players) != 2 {
+		t.Fatalf("expected the inputs of both players, got %+v", players)
+	}
+
+	fox := players[0]
+	if fox.InputCount != 1327 || fox.JoystickInputCount != 823 || fox.CStickInputCount != 97 || fox.ButtonInputCount != 317 || fox.TriggerInputCount != 90 {
+		t.Errorf("expected Fox's inputs, got %+v", fox)
+	}
+
+	for _, inputs := range players {
+		if inputs.InputCount != inputs.JoystickInputCount+inputs.CStickInputCount+inputs.ButtonInputCount+inputs.TriggerInputCount {
+			t.Errorf("expected the inputs of player %d to add up, got %+v", inputs.PlayerIndex, inputs)
+		}
+
+		// the game lasts 12258 playable frames, the last 1458 of which are
+		// in the fourth minute
+		if expected := float64(inputs.InputCount) * framesPerMinute / 12258; math.Abs(inputs.APM-expected) > 1e-9 {
+			t.Errorf("expected player %d to have an APM of %f, got %f", inputs.PlayerIndex, expected, inputs.APM)
+		}
+		if len(inputs.Timeline) != 4 {
+			t.Errorf("expected player %d to have a timeline of 4 minutes, got %v", inputs.PlayerIndex, inputs.Timeline)
+		}
+	}
+}
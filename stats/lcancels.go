@@ -0,0 +1,223 @@
+package stats
+
+import (
+	"sort"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+// An LCancelCount is the number of aerials landed with an L-cancel attempt,
+// and how many of those were L-cancelled.
+type LCancelCount struct {
+	Attempts  int `json:"attempts"`
+	Successes int `json:"successes"`
+	// SuccessPercent is the percentage of attempts that succeeded, or 0 if
+	// there were none.
+	SuccessPercent float64 `json:"successPercent"`
+}
+
+// add counts an attempt, which succeeded if success is set.
+func (c *LCancelCount) add(success bool) {
+	c.Attempts++
+	if success {
+		c.Successes++
+	}
+	c.updatePercent()
+}
+
+// merge adds the attempts of other to c.
+func (c *LCancelCount) merge(other LCancelCount) {
+	c.Attempts += other.Attempts
+	c.Successes += other.Successes
+	c.updatePercent()
+}
+
+func (c *LCancelCount) updatePercent() {
+	c.SuccessPercent = 0
+	if c.Attempts > 0 {
+		c.SuccessPercent = float64(c.Successes) / float64(c.Attempts) * 100
+	}
+}
+
+// AerialLCancels are the L-cancels of each aerial.
+type AerialLCancels struct {
+	Nair LCancelCount `json:"nair"`
+	Fair LCancelCount `json:"fair"`
+	Bair LCancelCount `json:"bair"`
+	Uair LCancelCount `json:"uair"`
+	Dair LCancelCount `json:"dair"`
+}
+
+// count returns the L-cancels of the aerial whose landing is the action state,
+// or nil if it isn't an aerial landing.
+func (a *AerialLCancels) count(actionStateID uint16) *LCancelCount {
+	switch actionStateID {
+	case slippi.StateNairLanding:
+		return &a.Nair
+	case slippi.StateFairLanding:
+		return &a.Fair
+	case slippi.StateBairLanding:
+		return &a.Bair
+	case slippi.StateUairLanding:
+		return &a.Uair
+	case slippi.StateDairLanding:
+		return &a.Dair
+	}
+
+	return nil
+}
+
+// merge adds the L-cancels of other to a.
+func (a *AerialLCancels) merge(other AerialLCancels) {
+	a.Nair.merge(other.Nair)
+	a.Fair.merge(other.Fair)
+	a.Bair.merge(other.Bair)
+	a.Uair.merge(other.Uair)
+	a.Dair.merge(other.Dair)
+}
+
+// LCancelStats are the L-cancels of a player in a game.
+type LCancelStats struct {
+	PlayerIndex uint8              `json:"playerIndex"`
+	Character   slippi.CharacterID `json:"character"`
+	All         LCancelCount       `json:"all"`
+	// Aerials only count landings in the landing state of an aerial, which
+	// some characters' special landings aren't.
+	Aerials AerialLCancels `json:"aerials"`
+}
+
+// An LCancelComputer is a Computer that counts the L-cancels of each
+// player from the L-cancel status of their post-frame updates, which is only
+// set on the frame they land.
+type LCancelComputer struct {
+	stats map[uint8]*LCancelStats
+}
+
+// NewLCancelComputer returns an LCancelComputer without any L-cancels counted.
+func NewLCancelComputer() *LCancelComputer {
+	c := &LCancelComputer{}
+	c.Setup(nil)
+
+	return c
+}
+
+// Setup implements Computer.
+func (c *LCancelComputer) Setup(gameInfo *slippi.GameInfo) {
+	c.stats = make(map[uint8]*LCancelStats)
+	if gameInfo != nil {
+		for _, player := range gameInfo.Players {
+			c.stats[player.Index] = &LCancelStats{PlayerIndex: player.Index, Character: player.CharacterID}
+		}
+	}
+}
+
+// ProcessFrame implements Computer.
+func (c *LCancelComputer) ProcessFrame(frame slippi.FrameEntry) {
+	for index, updates := range frame.Players {
+		if updates.Post == nil || updates.Post.LCancelStatus == slippi.None {
+			continue
+		}
+
+		stats, ok := c.stats[index]
+		if !ok {
+			stats = &LCancelStats{PlayerIndex: index, Character: slippi.NoCharacter}
+			c.stats[index] = stats
+		}
+
+		success := updates.Post.LCancelStatus == slippi.Successful
+		stats.All.add(success)
+		if aerial := stats.Aerials.count(updates.Post.ActionStateID); aerial != nil {
+			aerial.add(success)
+		}
+	}
+}
+
+// LCancels returns the L-cancels counted so far for each player, in order of
+// their index.
+func (c *LCancelComputer) LCancels() []LCancelStats {
+	indices := make([]int, 0, len(c.stats))
+	for index := range c.stats {
+		indices = append(indices, int(index))
+	}
+	sort.Ints(indices)
+
+	stats := make([]LCancelStats, 0, len(indices))
+	for _, index := range indices {
+		stats = append(stats, *c.stats[uint8(index)])
+	}
+
+	return stats
+}
+
+// ComputeLCancels returns the L-cancels of each player of the game, in order
+// of their index.
+func ComputeLCancels(game *slippi.SlpGame) ([]LCancelStats, error) {
+	computer := NewLCancelComputer()
+	if err := Run(game, computer); err != nil {
+		return nil, err
+	}
+
+	return computer.LCancels(), nil
+}
+
+// CharacterLCancelStats are the L-cancels of players of a single character
+// across games.
+type CharacterLCancelStats struct {
+	Character     slippi.CharacterID `json:"character"`
+	CharacterName string             `json:"characterName"`
+	Games         int                `json:"games"`
+	All           LCancelCount       `json:"all"`
+	Aerials       AerialLCancels     `json:"aerials"`
+}
+
+// An LCancelStatsCollection aggregates L-cancel stats across games, grouped by
+// character.
+type LCancelStatsCollection struct {
+	Characters []*CharacterLCancelStats `json:"characters"`
+}
+
+// NewLCancelStatsCollection returns an empty LCancelStatsCollection.
+func NewLCancelStatsCollection() *LCancelStatsCollection {
+	return &LCancelStatsCollection{Characters: make([]*CharacterLCancelStats, 0)}
+}
+
+// Character returns the L-cancel stats of the given character, or nil if no
+// games with the character have been added.
+func (l *LCancelStatsCollection) Character(character slippi.CharacterID) *CharacterLCancelStats {
+	for _, c := range l.Characters {
+		if c.Character == character {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// AddGame adds the L-cancels of each player in game to the stats of their
+// character.
+func (l *LCancelStatsCollection) AddGame(game *slippi.SlpGame) error {
+	stats, err := ComputeLCancels(game)
+	if err != nil {
+		return err
+	}
+
+	for _, player := range stats {
+		character := l.Character(player.Character)
+		if character == nil {
+			character = &CharacterLCancelStats{
+				Character:     player.Character,
+				CharacterName: player.Character.String(),
+			}
+			l.Characters = append(l.Characters, character)
+			sort.Slice(l.Characters, func(i, j int) bool {
+				return l.Characters[i].Character < l.Characters[j].Character
+			})
+		}
+		character.Games++
+
+		character.All.merge(player.All)
+		character.Aerials.merge(player.Aerials)
+	}
+
+	return nil
+}
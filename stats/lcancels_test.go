@@ -0,0 +1,48 @@
+package stats
+
+import (
+	"testing"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+func TestLCancels(t *testing.T) {
+	game := openFixture(t)
+
+	stats, err := ComputeLCancels(game)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats) != 2 {
+		t.Fatalf("expected the L-cancels of both players, got %+v", stats)
+	}
+
+	fox := stats[0]
+	if fox.Character != slippi.Fox || fox.All.Attempts != 58 || fox.All.Successes != 53 {
+		t.Errorf("expected Fox to L-cancel 53 of 58 aerials, got %+v", fox.All)
+	}
+	if fox.Aerials.Dair != (LCancelCount{Attempts: 9, Successes: 9, SuccessPercent: 100}) {
+		t.Errorf("expected Fox to L-cancel every dair, got %+v", fox.Aerials.Dair)
+	}
+
+	for _, player := range stats {
+		aerials := player.Aerials
+		attempts := aerials.Nair.Attempts + aerials.Fair.Attempts + aerials.Bair.Attempts + aerials.Uair.Attempts + aerials.Dair.Attempts
+		if attempts != player.All.Attempts {
+			t.Errorf("expected the aerials of player %d to add up to %d attempts, got %d", player.PlayerIndex, player.All.Attempts, attempts)
+		}
+	}
+
+	// the collection has the L-cancels of the same game added twice
+	collection := NewLCancelStatsCollection()
+	for range 2 {
+		if err := collection.AddGame(game); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	falco := collection.Character(slippi.Falco)
+	if falco == nil || falco.Games != 2 || falco.All.Attempts != 2*stats[1].All.Attempts || falco.All.SuccessPercent != stats[1].All.SuccessPercent {
+		t.Errorf("expected Falco's L-cancels of both games, got %+v", falco)
+	}
+}
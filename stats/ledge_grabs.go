@@ -0,0 +1,180 @@
+package stats
+
+import (
+	"sort"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+// A LedgeRuleset contains the ledge rules of a tournament ruleset.
+type LedgeRuleset struct {
+	// GrabLimit is the most ledge grabs a player may make in a game, or 0
+	// for no limit.
+	GrabLimit int `json:"grabLimit"`
+	// StallFrames is the most frames a player may stall on the ledge for, by
+	// regrabbing it without returning to the stage, or 0 for no limit.
+	StallFrames int32 `json:"stallFrames"`
+}
+
+// DefaultLedgeRuleset is the common tournament ruleset, with a ledge-grab
+// limit of 60 and stalls limited to 5 seconds.
+var DefaultLedgeRuleset = LedgeRuleset{GrabLimit: 60, StallFrames: 300}
+
+// A LedgeStall is a sequence of ledge grabs a player made without returning to
+// the stage, being hit or dying in between.
+type LedgeStall struct {
+	PlayerIndex uint8 `json:"playerIndex"`
+	// StartFrame is the frame of the first ledge grab, and EndFrame the last
+	// frame the player was on the ledge.
+	StartFrame int32 `json:"startFrame"`
+	EndFrame   int32 `json:"endFrame"`
+	Grabs      int   `json:"grabs"`
+}
+
+// Duration returns the number of frames the stall lasted.
+func (s LedgeStall) Duration() int32 {
+	return s.EndFrame - s.StartFrame + 1
+}
+
+// PlayerLedgeGrabs are the ledge grabs of a player in a game, and whether they
+// broke the rules of a LedgeRuleset.
+type PlayerLedgeGrabs struct {
+	PlayerIndex uint8 `json:"playerIndex"`
+	Grabs       int   `json:"grabs"`
+	// ExceededLimit is whether the player made more ledge grabs than the
+	// ruleset's limit, and Stalls are the stalls of the player that lasted
+	// longer than the ruleset allows.
+	ExceededLimit bool         `json:"exceededLimit"`
+	Stalls        []LedgeStall `json:"stalls"`
+}
+
+// Compliant returns whether the player followed the ledge rules.
+func (p PlayerLedgeGrabs) Compliant() bool {
+	return !p.ExceededLimit && len(p.Stalls) == 0
+}
+
+type ledgeGrabState struct {
+	grabs  int
+	stall  *LedgeStall
+	stalls []LedgeStall
+	last   uint16
+}
+
+// A LedgeGrabComputer is a Computer that counts the ledge grabs of each
+// player and checks them against a LedgeRuleset.
+type LedgeGrabComputer struct {
+	ruleset LedgeRuleset
+	players []uint8
+	states  map[uint8]*ledgeGrabState
+}
+
+// NewLedgeGrabComputer returns a LedgeGrabComputer without any ledge grabs,
+// which checks them against ruleset.
+func NewLedgeGrabComputer(ruleset LedgeRuleset) *LedgeGrabComputer {
+	c := &LedgeGrabComputer{ruleset: ruleset}
+	c.Setup(nil)
+
+	return c
+}
+
+// Setup implements Computer.
+func (c *LedgeGrabComputer) Setup(gameInfo *slippi.GameInfo) {
+	c.players = make([]uint8, 0)
+	c.states = make(map[uint8]*ledgeGrabState)
+	if gameInfo != nil {
+		for _, player := range gameInfo.Players {
+			c.state(player.Index)
+		}
+	}
+}
+
+// state returns the state of the player with the given index.
+func (c *LedgeGrabComputer) state(index uint8) *ledgeGrabState {
+	state, ok := c.states[index]
+	if !ok {
+		state = &ledgeGrabState{stalls: make([]LedgeStall, 0)}
+		c.states[index] = state
+		c.players = append(c.players, index)
+		sort.Slice(c.players, func(i, j int) bool { return c.players[i] < c.players[j] })
+	}
+
+	return state
+}
+
+// ProcessFrame implements Computer.
+func (c *LedgeGrabComputer) ProcessFrame(frame slippi.FrameEntry) {
+	indices := make([]int, 0, len(frame.Players))
+	for index, updates := range frame.Players {
+		if updates.Post != nil {
+			indices = append(indices, int(index))
+		}
+	}
+	sort.Ints(indices)
+
+	for _, index := range indices {
+		c.processPlayer(frame.FrameNumber, c.state(uint8(index)), *frame.Players[uint8(index)].Post)
+	}
+}
+
+func (c *LedgeGrabComputer) processPlayer(frameNumber int32, state *ledgeGrabState, post slippi.PostFrameUpdatePayload) {
+	actionState := post.ActionStateID
+	defer func() { state.last = actionState }()
+
+	switch {
+	case actionState == slippi.StateCliffCatch && state.last != slippi.StateCliffCatch:
+		state.grabs++
+		if state.stall == nil {
+			state.stall = &LedgeStall{PlayerIndex: post.PlayerIndex, StartFrame: frameNumber}
+		}
+		state.stall.Grabs++
+		state.stall.EndFrame = frameNumber
+	case state.stall == nil:
+	case slippi.IsOnLedge(actionState):
+		state.stall.EndFrame = frameNumber
+	case slippi.IsDead(actionState) || slippi.IsDamaged(actionState) || !post.Airborne:
+		c.finishStall(state)
+	}
+}
+
+// finishStall ends the stall of the player, keeping it if it lasted longer
+// than the ruleset allows.
+func (c *LedgeGrabComputer) finishStall(state *ledgeGrabState) {
+	if c.ruleset.StallFrames > 0 && state.stall.Duration() > c.ruleset.StallFrames {
+		state.stalls = append(state.stalls, *state.stall)
+	}
+	state.stall = nil
+}
+
+// PlayerStats returns the ledge grabs of each player, by the index of the
+// player, including the stall each player is in if it has already lasted
+// longer than the ruleset allows.
+func (c *LedgeGrabComputer) PlayerStats() []PlayerLedgeGrabs {
+	stats := make([]PlayerLedgeGrabs, 0, len(c.players))
+	for _, index := range c.players {
+		state := c.states[index]
+		player := PlayerLedgeGrabs{
+			PlayerIndex:   index,
+			Grabs:         state.grabs,
+			ExceededLimit: c.ruleset.GrabLimit > 0 && state.grabs > c.ruleset.GrabLimit,
+			Stalls:        append(make([]LedgeStall, 0, len(state.stalls)), state.stalls...),
+		}
+		if stall := state.stall; stall != nil && c.ruleset.StallFrames > 0 && stall.Duration() > c.ruleset.StallFrames {
+			player.Stalls = append(player.Stalls, *stall)
+		}
+
+		stats = append(stats, player)
+	}
+
+	return stats
+}
+
+// ComputeLedgeGrabs returns the ledge grabs of each player in the game, by the
+// index of the player, checked against ruleset.
+func ComputeLedgeGrabs(game *slippi.SlpGame, ruleset LedgeRuleset) ([]PlayerLedgeGrabs, error) {
+	computer := NewLedgeGrabComputer(ruleset)
+	if err := Run(game, computer); err != nil {
+		return nil, err
+	}
+
+	return computer.PlayerStats(), nil
+}
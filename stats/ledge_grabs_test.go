@@ -0,0 +1,49 @@
+package stats
+
+import "testing"
+
+func TestLedgeGrabs(t *testing.T) {
+	game := openFixture(t)
+
+	actions, err := ComputeActionCounts(game)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Fox never grabs the ledge, and Falco grabs it 18 times
+	players, err := ComputeLedgeGrabs(game, DefaultLedgeRuleset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(players) != 2 {
+		t.Fatalf("expected the ledge grabs of 2 players, got %d", len(players))
+	}
+	for i, player := range players {
+		if player.PlayerIndex != uint8(i) || player.Grabs != actions[i].LedgegrabCount {
+			t.Errorf("expected player %d to grab the ledge %d times, got %+v", i, actions[i].LedgegrabCount, player)
+		}
+		if !player.Compliant() {
+			t.Errorf("expected player %d to follow the default ruleset, got %+v", i, player)
+		}
+	}
+	if players[1].Grabs != 18 {
+		t.Errorf("expected Falco to grab the ledge 18 times, got %d", players[1].Grabs)
+	}
+
+	strict := LedgeRuleset{GrabLimit: 10, StallFrames: 60}
+	players, err = ComputeLedgeGrabs(game, strict)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if falco := players[1]; !falco.ExceededLimit || len(falco.Stalls) != 5 {
+		t.Errorf("expected Falco to exceed the limit and stall 5 times, got %+v", falco)
+	}
+	for _, stall := range players[1].Stalls {
+		if stall.PlayerIndex != 1 || stall.Duration() <= strict.StallFrames || stall.Grabs == 0 {
+			t.Errorf("expected a stall of Falco longer than %d frames, got %+v", strict.StallFrames, stall)
+		}
+	}
+	if !players[0].Compliant() {
+		t.Errorf("expected Fox to follow the strict ruleset, got %+v", players[0])
+	}
+}
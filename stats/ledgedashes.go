@@ -0,0 +1,242 @@
+package stats
+
+import (
+	"sort"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+// ledgedashWindow is the number of frames after leaving the ledge within which
+// a player must land from an air dodge for it to be a ledgedash.
+const ledgedashWindow = 40
+
+// A Ledgedash is a drop or jump from the ledge into an air dodge that lands
+// back onstage.
+type Ledgedash struct {
+	PlayerIndex uint8 `json:"playerIndex"`
+	// LedgeFrame is the last frame the player was on the ledge.
+	LedgeFrame      int32 `json:"ledgeFrame"`
+	AirDodgeFrame   int32 `json:"airDodgeFrame"`
+	LandingFrame    int32 `json:"landingFrame"`
+	ActionableFrame int32 `json:"actionableFrame"`
+	// GALINT is the number of frames of ledge intangibility the player had
+	// left as of their first actionable frame, including it, which is
+	// negative if the intangibility ran out that many frames before.
+	GALINT int `json:"galint"`
+}
+
+// PlayerLedgedashStats are the ledgedashes of a player in a game.
+type PlayerLedgedashStats struct {
+	PlayerIndex uint8 `json:"playerIndex"`
+	Count       int   `json:"count"`
+	// AverageGALINT is the average GALINT of the player's ledgedashes, or 0
+	// if they have none.
+	AverageGALINT float64 `json:"averageGalint"`
+}
+
+// ledgedashPhase enumerates the steps of a ledgedash a player is at.
+type ledgedashPhase uint8
+
+// ledgedashPhases
+const (
+	ledgedashNone ledgedashPhase = iota
+	ledgedashLeftLedge
+	ledgedashAirDodge
+	ledgedashLanding
+	// ledgedashIntangible is the phase of ledgedashes that are already
+	// actionable, but still intangible.
+	ledgedashIntangible
+)
+
+type ledgedashState struct {
+	phase     ledgedashPhase
+	ledgedash Ledgedash
+	// lastIntangible is the last frame of the intangibility the player had
+	// since leaving the ledge, and intangible whether it hasn't run out.
+	lastIntangible int32
+	intangible     bool
+}
+
+// A LedgedashComputer is a Computer that detects the ledgedashes of each
+// player and measures their GALINT from the hurtbox collision states of the
+// player, which replays before 2.1.0 don't have.
+type LedgedashComputer struct {
+	ledgedashes []Ledgedash
+	states      map[uint8]*ledgedashState
+	players     map[uint8]bool
+}
+
+// NewLedgedashComputer returns a LedgedashComputer without any ledgedashes.
+func NewLedgedashComputer() *LedgedashComputer {
+	c := &LedgedashComputer{}
+	c.Setup(nil)
+
+	return c
+}
+
+// Setup implements Computer.
+func (c *LedgedashComputer) Setup(gameInfo *slippi.GameInfo) {
+	c.ledgedashes = make([]Ledgedash, 0)
+	c.states = make(map[uint8]*ledgedashState)
+	c.players = make(map[uint8]bool)
+	if gameInfo != nil {
+		for _, player := range gameInfo.Players {
+			c.players[player.Index] = true
+		}
+	}
+}
+
+// ProcessFrame implements Computer.
+func (c *LedgedashComputer) ProcessFrame(frame slippi.FrameEntry) {
+	indices := make([]int, 0, len(frame.Players))
+	for index, updates := range frame.Players {
+		if updates.Post != nil {
+			indices = append(indices, int(index))
+		}
+	}
+	sort.Ints(indices)
+
+	for _, index := range indices {
+		state, ok := c.states[uint8(index)]
+		if !ok {
+			state = &ledgedashState{}
+			c.states[uint8(index)] = state
+			c.players[uint8(index)] = true
+		}
+		c.processPlayer(frame.FrameNumber, state, *frame.Players[uint8(index)].Post)
+	}
+}
+
+func (c *LedgedashComputer) processPlayer(frameNumber int32, state *ledgedashState, post slippi.PostFrameUpdatePayload) {
+	actionState := post.ActionStateID
+	intangible := post.HurtboxCollisionState == slippi.Intangible
+	if state.intangible {
+		if intangible {
+			state.lastIntangible = frameNumber
+		} else {
+			state.intangible = false
+		}
+	}
+
+	if state.phase == ledgedashIntangible {
+		if !state.intangible {
+			c.finish(state)
+		}
+		return
+	}
+
+	// every grab of the ledge starts a possible ledgedash
+	if slippi.IsOnLedge(actionState) {
+		state.phase = ledgedashLeftLedge
+		state.ledgedash = Ledgedash{PlayerIndex: post.PlayerIndex, LedgeFrame: frameNumber}
+		state.lastIntangible = frameNumber
+		state.intangible = intangible
+		return
+	}
+
+	if state.phase == ledgedashNone {
+		return
+	} else if slippi.IsDamaged(actionState) || slippi.IsGrabbed(actionState) || slippi.IsCommandGrabbed(actionState) || slippi.IsDead(actionState) {
+		state.phase = ledgedashNone
+		return
+	}
+
+	ledgedash := &state.ledgedash
+	switch state.phase {
+	case ledgedashLeftLedge:
+		switch {
+		case actionState == slippi.StateAirDodge:
+			state.phase = ledgedashAirDodge
+			ledgedash.AirDodgeFrame = frameNumber
+		case !post.Airborne || frameNumber-ledgedash.LedgeFrame > ledgedashWindow:
+			state.phase = ledgedashNone
+		}
+	case ledgedashAirDodge:
+		switch {
+		case actionState == slippi.StateLandingFallSpecial:
+			state.phase = ledgedashLanding
+			ledgedash.LandingFrame = frameNumber
+		case actionState != slippi.StateAirDodge || frameNumber-ledgedash.LedgeFrame > ledgedashWindow:
+			state.phase = ledgedashNone
+		}
+	case ledgedashLanding:
+		if actionState == slippi.StateLandingFallSpecial {
+			return
+		}
+
+		// the first frame out of the landing lag is the first actionable
+		// frame of players who act as soon as they can
+		ledgedash.ActionableFrame = frameNumber
+		state.phase = ledgedashIntangible
+		if !state.intangible {
+			c.finish(state)
+		}
+	}
+}
+
+// finish records the ledgedash of state, whose ledge intangibility has run
+// out.
+func (c *LedgedashComputer) finish(state *ledgedashState) {
+	state.ledgedash.GALINT = int(state.lastIntangible - state.ledgedash.ActionableFrame + 1)
+	c.ledgedashes = append(c.ledgedashes, state.ledgedash)
+	state.phase = ledgedashNone
+}
+
+// Ledgedashes returns the ledgedashes processed so far, in the order they were
+// actionable, including those whose intangibility hasn't run out yet.
+func (c *LedgedashComputer) Ledgedashes() []Ledgedash {
+	ledgedashes := append(make([]Ledgedash, 0, len(c.ledgedashes)), c.ledgedashes...)
+	for _, state := range c.states {
+		if state.phase == ledgedashIntangible {
+			ledgedash := state.ledgedash
+			ledgedash.GALINT = int(state.lastIntangible - ledgedash.ActionableFrame + 1)
+			ledgedashes = append(ledgedashes, ledgedash)
+		}
+	}
+	sort.SliceStable(ledgedashes, func(i, j int) bool {
+		return ledgedashes[i].ActionableFrame < ledgedashes[j].ActionableFrame
+	})
+
+	return ledgedashes
+}
+
+// PlayerStats returns the ledgedash stats of each player, in order of their
+// index.
+func (c *LedgedashComputer) PlayerStats() []PlayerLedgedashStats {
+	indices := make([]int, 0, len(c.players))
+	for index := range c.players {
+		indices = append(indices, int(index))
+	}
+	sort.Ints(indices)
+
+	stats := make(map[uint8]*PlayerLedgedashStats)
+	galint := make(map[uint8]int)
+	players := make([]PlayerLedgedashStats, 0, len(indices))
+	for _, index := range indices {
+		stats[uint8(index)] = &PlayerLedgedashStats{PlayerIndex: uint8(index)}
+	}
+	for _, ledgedash := range c.Ledgedashes() {
+		stats[ledgedash.PlayerIndex].Count++
+		galint[ledgedash.PlayerIndex] += ledgedash.GALINT
+	}
+	for _, index := range indices {
+		s := stats[uint8(index)]
+		if s.Count > 0 {
+			s.AverageGALINT = float64(galint[uint8(index)]) / float64(s.Count)
+		}
+		players = append(players, *s)
+	}
+
+	return players
+}
+
+// ComputeLedgedashStats returns the ledgedash stats of each player of the
+// game, in order of their index.
+func ComputeLedgedashStats(game *slippi.SlpGame) ([]PlayerLedgedashStats, error) {
+	computer := NewLedgedashComputer()
+	if err := Run(game, computer); err != nil {
+		return nil, err
+	}
+
+	return computer.PlayerStats(), nil
+}
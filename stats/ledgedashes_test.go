@@ -0,0 +1,37 @@
+package stats
+
+import "testing"
+
+func TestLedgedashes(t *testing.T) {
+	game := openFixture(t)
+
+	computer := NewLedgedashComputer()
+	if err := Run(game, computer); err != nil {
+		t.Fatal(err)
+	}
+
+	// Falco ledgedashes three times, all well after his ledge intangibility
+	// ran out
+	expected := []Ledgedash{
+		{PlayerIndex: 1, LedgeFrame: 7089, AirDodgeFrame: 7103, LandingFrame: 7112, ActionableFrame: 7122, GALINT: -24},
+		{PlayerIndex: 1, LedgeFrame: 9062, AirDodgeFrame: 9076, LandingFrame: 9085, ActionableFrame: 9095, GALINT: -32},
+		{PlayerIndex: 1, LedgeFrame: 9363, AirDodgeFrame: 9378, LandingFrame: 9387, ActionableFrame: 9397, GALINT: -33},
+	}
+	ledgedashes := computer.Ledgedashes()
+	if len(ledgedashes) != len(expected) {
+		t.Fatalf("expected ledgedashes %+v, got %+v", expected, ledgedashes)
+	}
+	for i, ledgedash := range ledgedashes {
+		if ledgedash != expected[i] {
+			t.Errorf("ledgedash %d: expected %+v, got %+v", i, expected[i], ledgedash)
+		}
+	}
+
+	stats := computer.PlayerStats()
+	if len(stats) != 2 || stats[0].Count != 0 || stats[0].AverageGALINT != 0 {
+		t.Fatalf("expected Fox not to ledgedash, got %+v", stats)
+	}
+	if stats[1].Count != 3 || stats[1].AverageGALINT != -89.0/3 {
+		t.Errorf("expected Falco to average a GALINT of %f, got %+v", -89.0/3, stats[1])
+	}
+}
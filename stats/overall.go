@@ -0,0 +1,285 @@
+package stats
+
+import slippi "github.com/ZadenRB/go-slippi"
+
+// A Ratio is a count out of a total, such as the number of openings won out of
+// all openings. Ratio is nil if the total is 0.
+type Ratio struct {
+	Count float64  `json:"count"`
+	Total float64  `json:"total"`
+	Ratio *float64 `json:"ratio"`
+}
+
+// newRatio returns the ratio of count to total.
+func newRatio(count float64, total float64) Ratio {
+	ratio := Ratio{Count: count, Total: total}
+	if total != 0 {
+		value := count / total
+		ratio.Ratio = &value
+	}
+
+	return ratio
+}
+
+// InputCounts are the total inputs of a player of each kind.
+type InputCounts struct {
+	Buttons  int `json:"buttons"`
+	Triggers int `json:"triggers"`
+	CStick   int `json:"cstick"`
+	Joystick int `json:"joystick"`
+	Total    int `json:"total"`
+}
+
+// PlayerOverall are the headline stats of a player in a game, as computed by
+// slippi-js. Conversions are counted by the player unless they were on the
+// player, which is the same in singles games.
+type PlayerOverall struct {
+	PlayerIndex            uint8       `json:"playerIndex"`
+	InputCounts            InputCounts `json:"inputCounts"`
+	ConversionCount        int         `json:"conversionCount"`
+	TotalDamage            float32     `json:"totalDamage"`
+	KillCount              int         `json:"killCount"`
+	SuccessfulConversions  Ratio       `json:"successfulConversions"`
+	InputsPerMinute        Ratio       `json:"inputsPerMinute"`
+	DigitalInputsPerMinute Ratio       `json:"digitalInputsPerMinute"`
+	OpeningsPerKill        Ratio       `json:"openingsPerKill"`
+	DamagePerOpening       Ratio       `json:"damagePerOpening"`
+	// NeutralWinRatio and CounterHitRatio are the neutral wins and
+	// counter-attacks of the player out of those of the player and their
+	// opponents, and BeneficialTradeRatio the trades of the player that
+	// benefited them out of all of their trades. As in slippi-js, they are
+	// counted from openings, so a trade is a conversion begun on the same
+	// frame as one by an opponent, and a counter-hit a conversion begun
+	// while the player was being punished.
+	NeutralWinRatio      Ratio `json:"neutralWinRatio"`
+	CounterHitRatio      Ratio `json:"counterHitRatio"`
+	BeneficialTradeRatio Ratio `json:"beneficialTradeRatio"`
+	// Clashes are the trades and counter-hits of the player as detected
+	// from the hits themselves. They aren't stats of slippi-js, so they
+	// aren't encoded with the others, and are encoded on their own by
+	// ComputeClashCounts.
+	Clashes ClashCounts `json:"-"`
+}
+
+// GameOverall are the headline stats of a game as a whole.
+type GameOverall struct {
+	ConversionCount  int     `json:"conversionCount"`
+	TotalDamage      float32 `json:"totalDamage"`
+	KillCount        int     `json:"killCount"`
+	InputsPerMinute  Ratio   `json:"inputsPerMinute"`
+	OpeningsPerKill  Ratio   `json:"openingsPerKill"`
+	DamagePerOpening Ratio   `json:"damagePerOpening"`
+}
+
+// An OverallComputer is a Computer that computes the headline stats of
+// each player from their inputs, conversions and clashes.
+type OverallComputer struct {
+	gameInfo    *slippi.GameInfo
+	inputs      *InputsComputer
+	conversions *ConversionComputer
+	clashes     *ClashComputer
+}
+
+// NewOverallComputer returns an OverallComputer that hasn't processed any
+// frames.
+func NewOverallComputer() *OverallComputer {
+	c := &OverallComputer{
+		inputs:      NewInputsComputer(),
+		conversions: NewConversionComputer(),
+		clashes:     NewClashComputer(),
+	}
+	c.Setup(nil)
+
+	return c
+}
+
+// Setup implements Computer.
+func (c *OverallComputer) Setup(gameInfo *slippi.GameInfo) {
+	c.gameInfo = gameInfo
+	c.inputs.Setup(gameInfo)
+	c.conversions.Setup(gameInfo)
+	c.clashes.Setup(gameInfo)
+}
+
+// ProcessFrame implements Computer.
+func (c *OverallComputer) ProcessFrame(frame slippi.FrameEntry) {
+	c.inputs.ProcessFrame(frame)
+	c.conversions.ProcessFrame(frame)
+	c.clashes.ProcessFrame(frame)
+}
+
+// Overall returns the headline stats of each player of the game, in the order
+// of the game info's players.
+func (c *OverallComputer) Overall() []PlayerOverall {
+	overall := make([]PlayerOverall, 0)
+	if c.gameInfo == nil {
+		return overall
+	}
+
+	inputs := make(map[uint8]PlayerInputs)
+	for _, playerInputs := range c.inputs.Inputs() {
+		inputs[playerInputs.PlayerIndex] = playerInputs
+	}
+	minutes := float64(c.inputs.playableFrames()) / framesPerMinute
+
+	conversions := c.conversions.Conversions()
+	// openings are grouped by the player who landed the first move, as
+	// slippi-js does
+	openings := make(map[uint8]map[slippi.OpeningType][]slippi.Conversion)
+	for _, conversion := range conversions {
+		if len(conversion.Moves) == 0 {
+			continue
+		}
+
+		attacker := conversion.Moves[0].PlayerIndex
+		if openings[attacker] == nil {
+			openings[attacker] = make(map[slippi.OpeningType][]slippi.Conversion)
+		}
+		openings[attacker][conversion.OpeningType] = append(openings[attacker][conversion.OpeningType], conversion)
+	}
+
+	clashes := make(map[uint8]ClashCounts)
+	for _, counts := range c.clashes.PlayerCounts() {
+		clashes[counts.PlayerIndex] = counts
+	}
+
+	for _, player := range c.gameInfo.Players {
+		playerInputs := inputs[player.Index]
+		stats := PlayerOverall{
+			PlayerIndex: player.Index,
+			InputCounts: InputCounts{
+				Buttons:  playerInputs.ButtonInputCount,
+				Triggers: playerInputs.TriggerInputCount,
+				CStick:   playerInputs.CStickInputCount,
+				Joystick: playerInputs.JoystickInputCount,
+				Total:    playerInputs.InputCount,
+			},
+		}
+
+		successful := 0
+		for _, conversion := range conversions {
+			if conversion.DefenderIndex == player.Index {
+				continue
+			}
+
+			stats.ConversionCount++
+			if conversion.DidKill && conversion.AttackerIndex == player.Index {
+				stats.KillCount++
+			}
+			if len(conversion.Moves) > 1 && conversion.Moves[0].PlayerIndex == player.Index {
+				successful++
+			}
+			for _, move := range conversion.Moves {
+				if move.PlayerIndex == player.Index {
+					stats.TotalDamage += move.Damage
+				}
+			}
+		}
+
+		opponents := make([]uint8, 0, len(c.gameInfo.Players))
+		for _, opponent := range c.gameInfo.Players {
+			if opponent.Index != player.Index && (!c.gameInfo.Teams || opponent.TeamID != player.TeamID) {
+				opponents = append(opponents, opponent.Index)
+			}
+		}
+
+		stats.SuccessfulConversions = newRatio(float64(successful), float64(stats.ConversionCount))
+		stats.InputsPerMinute = newRatio(float64(stats.InputCounts.Total), minutes)
+		stats.DigitalInputsPerMinute = newRatio(float64(stats.InputCounts.Buttons), minutes)
+		stats.OpeningsPerKill = newRatio(float64(stats.ConversionCount), float64(stats.KillCount))
+		stats.DamagePerOpening = newRatio(float64(stats.TotalDamage), float64(stats.ConversionCount))
+		stats.NeutralWinRatio = openingRatio(openings, player.Index, opponents, slippi.NeutralWin)
+		stats.CounterHitRatio = openingRatio(openings, player.Index, opponents, slippi.CounterAttack)
+		stats.BeneficialTradeRatio = beneficialTradeRatio(openings, player.Index, opponents)
+
+		stats.Clashes = clashes[player.Index]
+		stats.Clashes.PlayerIndex = player.Index
+
+		overall = append(overall, stats)
+	}
+
+	return overall
+}
+
+// Game returns the headline stats of the game as a whole.
+func (c *OverallComputer) Game() GameOverall {
+	var game GameOverall
+	inputCount := 0
+	for _, playerInputs := range c.inputs.Inputs() {
+		inputCount += playerInputs.InputCount
+	}
+
+	for _, conversion := range c.conversions.Conversions() {
+		game.ConversionCount++
+		if conversion.DidKill {
+			game.KillCount++
+		}
+		for _, move := range conversion.Moves {
+			game.TotalDamage += move.Damage
+		}
+	}
+
+	game.InputsPerMinute = newRatio(float64(inputCount), float64(c.inputs.playableFrames())/framesPerMinute)
+	game.OpeningsPerKill = newRatio(float64(game.ConversionCount), float64(game.KillCount))
+	game.DamagePerOpening = newRatio(float64(game.TotalDamage), float64(game.ConversionCount))
+
+	return game
+}
+
+// openingRatio returns the openings of the given type by the player with the
+// given index out of those by the player and their opponents.
+func openingRatio(openings map[uint8]map[slippi.OpeningType][]slippi.Conversion, index uint8, opponents []uint8, openingType slippi.OpeningType) Ratio {
+	count := len(openings[index][openingType])
+	total := count
+	for _, opponent := range opponents {
+		total += len(openings[opponent][openingType])
+	}
+
+	return newRatio(float64(count), float64(total))
+}
+
+// beneficialTradeRatio returns the trades of the player with the given index
+// that benefited them out of all of their trades. A trade benefits the player
+// if their side of it killed and their opponent's didn't, or it did more
+// damage. Trades are paired with those of opponents in order, as slippi-js
+// pairs them.
+func beneficialTradeRatio(openings map[uint8]map[slippi.OpeningType][]slippi.Conversion, index uint8, opponents []uint8) Ratio {
+	trades := openings[index][slippi.Trade]
+	opponentTrades := make([]slippi.Conversion, 0)
+	for _, opponent := range opponents {
+		opponentTrades = append(opponentTrades, openings[opponent][slippi.Trade]...)
+	}
+
+	beneficial := 0
+	for i := 0; i < len(trades) && i < len(opponentTrades); i++ {
+		damage := trades[i].CurrentPercent - trades[i].StartPercent
+		opponentDamage := opponentTrades[i].CurrentPercent - opponentTrades[i].StartPercent
+		if (trades[i].DidKill && !opponentTrades[i].DidKill) || damage > opponentDamage {
+			beneficial++
+		}
+	}
+
+	return newRatio(float64(beneficial), float64(len(trades)))
+}
+
+// ComputeOverall returns the headline stats of each player of the game, in the
+// order of the game info's players.
+func ComputeOverall(game *slippi.SlpGame) ([]PlayerOverall, error) {
+	computer := NewOverallComputer()
+	if err := Run(game, computer); err != nil {
+		return nil, err
+	}
+
+	return computer.Overall(), nil
+}
+
+// ComputeGameOverall returns the headline stats of the game as a whole.
+func ComputeGameOverall(game *slippi.SlpGame) (*GameOverall, error) {
+	computer := NewOverallComputer()
+	if err := Run(game, computer); err != nil {
+		return nil, err
+	}
+
+	overall := computer.Game()
+	return &overall, nil
+}
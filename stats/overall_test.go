@@ -0,0 +1,48 @@
+package stats
+
+import "testing"
+
+func TestOverall(t *testing.T) {
+	game := openFixture(t)
+
+	computer := NewOverallComputer()
+	if err := Run(game, computer); err != nil {
+		t.Fatal(err)
+	}
+
+	overall := computer.Overall()
+	if len(overall) != 2 {
+		t.Fatalf("expected the stats of both players, got %+v", overall)
+	}
+
+	// Fox took all four of Falco's stocks, and Falco two of Fox's three
+	fox, falco := overall[0], overall[1]
+	if fox.KillCount != 4 || falco.KillCount != 2 {
+		t.Errorf("expected Fox and Falco to have 4 and 2 kills, got %d and %d", fox.KillCount, falco.KillCount)
+	}
+	if fox.ConversionCount != 33 || fox.OpeningsPerKill.Ratio == nil || *fox.OpeningsPerKill.Ratio != 8.25 {
+		t.Errorf("expected Fox to take 8.25 openings per kill, got %+v", fox.OpeningsPerKill)
+	}
+	if fox.InputCounts.Total != 1327 || fox.InputsPerMinute.Count != 1327 {
+		t.Errorf("expected Fox's inputs, got %+v", fox.InputCounts)
+	}
+
+	// every neutral win is won by one player and lost by the other
+	if fox.NeutralWinRatio.Total != falco.NeutralWinRatio.Total || fox.NeutralWinRatio.Count+falco.NeutralWinRatio.Count != fox.NeutralWinRatio.Total {
+		t.Errorf("expected the neutral wins to add up, got %+v and %+v", fox.NeutralWinRatio, falco.NeutralWinRatio)
+	}
+	if fox.BeneficialTradeRatio.Ratio != nil {
+		t.Errorf("expected no conversions begun by trades, got %+v", fox.BeneficialTradeRatio)
+	}
+
+	// the players trade hits once, which doesn't begin a conversion for
+	// either of them, and otherwise counter-hit each other
+	if fox.Clashes.Trades != 1 || falco.Clashes.Trades != 1 || fox.Clashes.CounterHits != 3 || falco.Clashes.CounterHits != 5 {
+		t.Errorf("expected 1 trade, and 3 and 5 counter-hits, got %+v and %+v", fox.Clashes, falco.Clashes)
+	}
+
+	summary := computer.Game()
+	if summary.ConversionCount != fox.ConversionCount+falco.ConversionCount || summary.KillCount != 6 {
+		t.Errorf("expected the game's conversions and kills, got %+v", summary)
+	}
+}
@@ -0,0 +1,221 @@
+package stats
+
+import (
+	"sort"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+// A Powershield is an attack a player powershielded, either physically or by
+// reflecting a projectile.
+type Powershield struct {
+	PlayerIndex uint8 `json:"playerIndex"`
+	Frame       int32 `json:"frame"`
+	// AttackerIndex is the index of the player whose attack was powershielded,
+	// or -1 if it couldn't be attributed.
+	AttackerIndex int8 `json:"attackerIndex"`
+	// Move is the attack that was powershielded, which is NoAttack for
+	// projectiles and moves that aren't normal attacks, such as specials,
+	// whose action state is AttackerActionState.
+	Move                slippi.AttackID `json:"moveId"`
+	AttackerActionState uint16          `json:"attackerActionState"`
+	// Projectile is whether the attack was a projectile, of type ItemType,
+	// which is nil for attacks that weren't projectiles.
+	Projectile bool             `json:"projectile"`
+	ItemType   *slippi.ItemType `json:"itemType"`
+}
+
+// PlayerPowershieldStats are the attacks a player blocked with their shield in
+// a game, counted as successes if they were powershielded.
+type PlayerPowershieldStats struct {
+	PlayerIndex uint8        `json:"playerIndex"`
+	Physical    SuccessCount `json:"physical"`
+	Projectile  SuccessCount `json:"projectile"`
+}
+
+// Attempts returns the number of attacks the player blocked with their
+// shield.
+func (s PlayerPowershieldStats) Attempts() int {
+	return s.Physical.Success + s.Physical.Fail + s.Projectile.Success + s.Projectile.Fail
+}
+
+// Successes returns the number of attacks the player powershielded.
+func (s PlayerPowershieldStats) Successes() int {
+	return s.Physical.Success + s.Projectile.Success
+}
+
+// A PowershieldComputer is a Computer that detects the attacks each
+// player blocked with their shield and which of them they powershielded. An
+// attack lands on a shield when its player enters shield stun, and is
+// powershielded if it lands in the first frames of the shield, while the
+// player's powershield bubble flag is set. Projectiles powershielded before
+// that are reflected instead, which changes their owner to the player.
+type PowershieldComputer struct {
+	powershields []Powershield
+	stats        map[uint8]*PlayerPowershieldStats
+	lastFrame    slippi.FrameEntry
+	// owners are the owners of the items on the last frame, by spawn ID
+	owners map[uint32]int8
+}
+
+// NewPowershieldComputer returns a PowershieldComputer without any
+// powershields.
+func NewPowershieldComputer() *PowershieldComputer {
+	c := &PowershieldComputer{}
+	c.Setup(nil)
+
+	return c
+}
+
+// Setup implements Computer.
+func (c *PowershieldComputer) Setup(gameInfo *slippi.GameInfo) {
+	c.powershields = make([]Powershield, 0)
+	c.stats = make(map[uint8]*PlayerPowershieldStats)
+	c.lastFrame = slippi.FrameEntry{}
+	c.owners = make(map[uint32]int8)
+	if gameInfo != nil {
+		for _, player := range gameInfo.Players {
+			c.stats[player.Index] = &PlayerPowershieldStats{PlayerIndex: player.Index}
+		}
+	}
+}
+
+// ProcessFrame implements Computer.
+func (c *PowershieldComputer) ProcessFrame(frame slippi.FrameEntry) {
+	indices := make([]int, 0, len(frame.Players))
+	for index, updates := range frame.Players {
+		if updates.Post != nil {
+			indices = append(indices, int(index))
+		}
+	}
+	sort.Ints(indices)
+
+	for _, index := range indices {
+		c.processShield(frame, uint8(index))
+	}
+	c.processReflects(frame)
+
+	c.lastFrame = frame
+	for owner := range c.owners {
+		delete(c.owners, owner)
+	}
+	for _, item := range frame.Items {
+		c.owners[item.SpawnID] = item.Owner
+	}
+}
+
+// processShield detects an attack landing on the shield of the player with
+// the given index on frame.
+func (c *PowershieldComputer) processShield(frame slippi.FrameEntry, index uint8) {
+	post := frame.Players[index].Post
+	if !isShieldHit(*post, c.lastFrame.Players[index].Post) {
+		return
+	}
+
+	powershield := Powershield{PlayerIndex: index, Frame: frame.FrameNumber, AttackerIndex: -1}
+	if hit, ok := slippi.AttributeHit(frame, c.lastFrame, index); ok && !hit.IsSelfDamage() {
+		powershield.AttackerIndex = int8(hit.AttackerIndex)
+		if hit.Item != nil {
+			powershield.Projectile = true
+			itemType := hit.Item.TypeID
+			powershield.ItemType = &itemType
+		} else if attacker := frame.Players[hit.AttackerIndex].Post; attacker != nil {
+			powershield.AttackerActionState = attacker.ActionStateID
+			powershield.Move = slippi.AttackOfActionState(attacker.ActionStateID)
+		}
+	}
+
+	count := &c.player(index).Physical
+	if powershield.Projectile {
+		count = &c.player(index).Projectile
+	}
+	if !post.StateFlags().PowershieldBubble {
+		count.Fail++
+		return
+	}
+
+	count.Success++
+	c.powershields = append(c.powershields, powershield)
+}
+
+// processReflects detects projectiles reflected by powershields on frame.
+func (c *PowershieldComputer) processReflects(frame slippi.FrameEntry) {
+	for _, item := range frame.Items {
+		owner, ok := c.owners[item.SpawnID]
+		if !ok || owner == item.Owner || item.Owner < 0 || !item.TypeID.IsCharacterProjectile() {
+			continue
+		}
+
+		updates, ok := frame.Players[uint8(item.Owner)]
+		if !ok || updates.Post == nil || updates.Post.ActionStateID != slippi.StateGuardReflect {
+			continue
+		}
+
+		itemType := item.TypeID
+		c.player(uint8(item.Owner)).Projectile.Success++
+		c.powershields = append(c.powershields, Powershield{
+			PlayerIndex:   uint8(item.Owner),
+			Frame:         frame.FrameNumber,
+			AttackerIndex: owner,
+			Projectile:    true,
+			ItemType:      &itemType,
+		})
+	}
+}
+
+// isShieldHit returns whether an attack landed on the shield of the player
+// with the given post-frame update, whose update on the frame before was last,
+// if any, which puts them in shield stun and hitlag.
+func isShieldHit(post slippi.PostFrameUpdatePayload, last *slippi.PostFrameUpdatePayload) bool {
+	if post.ActionStateID != slippi.StateGuardSetOff || !post.StateFlags().InHitlag {
+		return false
+	}
+
+	// multi-hit attacks put the player in hitlag again without leaving
+	// shield stun
+	return last == nil || last.ActionStateID != slippi.StateGuardSetOff || !last.StateFlags().InHitlag
+}
+
+// player returns the stats of the player with the given index.
+func (c *PowershieldComputer) player(index uint8) *PlayerPowershieldStats {
+	stats, ok := c.stats[index]
+	if !ok {
+		stats = &PlayerPowershieldStats{PlayerIndex: index}
+		c.stats[index] = stats
+	}
+
+	return stats
+}
+
+// Powershields returns the powershields processed so far, in order.
+func (c *PowershieldComputer) Powershields() []Powershield {
+	return append(make([]Powershield, 0, len(c.powershields)), c.powershields...)
+}
+
+// PlayerStats returns the powershield stats of each player, in order of their
+// index.
+func (c *PowershieldComputer) PlayerStats() []PlayerPowershieldStats {
+	indices := make([]int, 0, len(c.stats))
+	for index := range c.stats {
+		indices = append(indices, int(index))
+	}
+	sort.Ints(indices)
+
+	stats := make([]PlayerPowershieldStats, 0, len(indices))
+	for _, index := range indices {
+		stats = append(stats, *c.stats[uint8(index)])
+	}
+
+	return stats
+}
+
+// ComputePowershieldStats returns the powershield stats of each player of the
+// game, in order of their index.
+func ComputePowershieldStats(game *slippi.SlpGame) ([]PlayerPowershieldStats, error) {
+	computer := NewPowershieldComputer()
+	if err := Run(game, computer); err != nil {
+		return nil, err
+	}
+
+	return computer.PlayerStats(), nil
+}
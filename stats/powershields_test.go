@@ -0,0 +1,42 @@
+package stats
+
+import (
+	"testing"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+func TestPowershields(t *testing.T) {
+	game := openFixture(t)
+
+	computer := NewPowershieldComputer()
+	if err := Run(game, computer); err != nil {
+		t.Fatal(err)
+	}
+
+	// Falco powershields two of Fox's dash attacks, taking no shield damage
+	powershields := computer.Powershields()
+	if len(powershields) != 2 {
+		t.Fatalf("expected 2 powershields, got %+v", powershields)
+	}
+	for i, frame := range []int32{9268, 12017} {
+		powershield := powershields[i]
+		if powershield.PlayerIndex != 1 || powershield.Frame != frame || powershield.AttackerIndex != 0 {
+			t.Errorf("expected Falco to powershield Fox on frame %d, got %+v", frame, powershield)
+		}
+		if powershield.Move != slippi.DashAttack || powershield.AttackerActionState != slippi.StateDashAttack || powershield.Projectile || powershield.ItemType != nil {
+			t.Errorf("expected a powershielded dash attack on frame %d, got %+v", frame, powershield)
+		}
+	}
+
+	stats := computer.PlayerStats()
+	if len(stats) != 2 {
+		t.Fatalf("expected the stats of both players, got %+v", stats)
+	}
+	if stats[0].Physical != (SuccessCount{Fail: 1}) || stats[0].Attempts() != 1 || stats[0].Successes() != 0 {
+		t.Errorf("expected Fox to shield a single attack, got %+v", stats[0])
+	}
+	if stats[1].Physical != (SuccessCount{Success: 2, Fail: 12}) || stats[1].Projectile != (SuccessCount{}) {
+		t.Errorf("expected Falco to powershield 2 of 14 attacks, got %+v", stats[1])
+	}
+}
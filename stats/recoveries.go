@@ -0,0 +1,292 @@
+package stats
+
+import (
+	"sort"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+// buttonB is the bit of the B button in the physical buttons of a pre-frame
+// update.
+const buttonB = 0x0200
+
+// upSpecialStickY is how far up the joystick must be for a special to be an
+// up-B. Melee picks an up-B over a side-B even with the stick on a diagonal,
+// so only side-Bs angled up less than this are told apart from up-Bs.
+const upSpecialStickY = 0.5
+
+// RecoveryOutcome enumerates the ways a recovery can end.
+type RecoveryOutcome uint8
+
+// RecoveryOutcomes
+const (
+	// RecoveryUnresolved is the outcome of recoveries still in progress.
+	RecoveryUnresolved RecoveryOutcome = iota
+	// RecoverySuccess is the outcome of recoveries in which the player made
+	// it back to the ledge or to the ground onstage.
+	RecoverySuccess
+	// RecoveryFailure is the outcome of recoveries in which the player lost
+	// their stock.
+	RecoveryFailure
+)
+
+var recoveryOutcomeNames = map[RecoveryOutcome]string{
+	RecoveryUnresolved: "unresolved",
+	RecoverySuccess:    "success",
+	RecoveryFailure:    "failure",
+}
+
+// String returns the name of the recovery outcome.
+func (o RecoveryOutcome) String() string {
+	return recoveryOutcomeNames[o]
+}
+
+// MarshalText encodes the recovery outcome as its name.
+func (o RecoveryOutcome) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+// A RecoveryHit is a hit a player took while recovering.
+type RecoveryHit struct {
+	Frame int32 `json:"frame"`
+	// AttackerIndex is the index of the player who dealt the hit, or -1 if it
+	// couldn't be attributed.
+	AttackerIndex int8    `json:"attackerIndex"`
+	Damage        float32 `json:"damage"`
+	// X and Y are the position of the player when they were hit.
+	X float32 `json:"x"`
+	Y float32 `json:"y"`
+}
+
+// A Recovery is an attempt of a player to make it back to the stage after
+// going offstage, beyond the stage's ledges or below its main stage, whether
+// they were knocked there or went there themselves.
+type Recovery struct {
+	PlayerIndex  uint8   `json:"playerIndex"`
+	StartFrame   int32   `json:"startFrame"`
+	StartPercent float32 `json:"startPercent"`
+	// EndFrame is the frame the recovery was resolved on, and is unset while
+	// the recovery is unresolved.
+	EndFrame int32           `json:"endFrame"`
+	Outcome  RecoveryOutcome `json:"outcome"`
+	// Ledge is whether a successful recovery ended on the ledge rather than
+	// the ground.
+	Ledge bool `json:"ledge"`
+	// UsedJump, UsedUpB and UsedAirDodge are whether the player used their
+	// double jump, their up-B and an air dodge to recover.
+	UsedJump     bool          `json:"usedJump"`
+	UsedUpB      bool          `json:"usedUpB"`
+	UsedAirDodge bool          `json:"usedAirDodge"`
+	Hits         []RecoveryHit `json:"hits"`
+}
+
+// PlayerRecoveryStats are the recoveries of a player in a game, counting only
+// resolved recoveries.
+type PlayerRecoveryStats struct {
+	PlayerIndex uint8 `json:"playerIndex"`
+	Recoveries  int   `json:"recoveries"`
+	Successes   int   `json:"successes"`
+	// SuccessRate is the player's successful recoveries out of all of them,
+	// and HitSuccessRate out of those in which they were hit.
+	SuccessRate    Ratio `json:"successRate"`
+	HitSuccessRate Ratio `json:"hitSuccessRate"`
+	// JumpUses, UpBUses and AirDodgeUses are the number of recoveries in
+	// which the player used each resource.
+	JumpUses     int `json:"jumpUses"`
+	UpBUses      int `json:"upBUses"`
+	AirDodgeUses int `json:"airDodgeUses"`
+}
+
+// A RecoveryComputer is a Computer that detects the recoveries of every
+// player. Recoveries are only detected on stages whose geometry is known.
+type RecoveryComputer struct {
+	geometry    slippi.StageGeometry
+	hasGeometry bool
+	recoveries  []*Recovery
+	// active are the unresolved recoveries, by the index of the recovering
+	// player.
+	active map[uint8]*Recovery
+	prev   map[uint8]slippi.FrameUpdates
+	last   slippi.FrameEntry
+}
+
+// NewRecoveryComputer returns a RecoveryComputer without any recoveries.
+func NewRecoveryComputer() *RecoveryComputer {
+	c := &RecoveryComputer{}
+	c.Setup(nil)
+
+	return c
+}
+
+// Setup implements Computer.
+func (c *RecoveryComputer) Setup(gameInfo *slippi.GameInfo) {
+	c.geometry, c.hasGeometry = slippi.StageGeometry{}, false
+	if gameInfo != nil {
+		c.geometry, c.hasGeometry = gameInfo.Stage.Geometry()
+	}
+	c.recoveries = make([]*Recovery, 0)
+	c.active = make(map[uint8]*Recovery)
+	c.prev = make(map[uint8]slippi.FrameUpdates)
+	c.last = slippi.FrameEntry{}
+}
+
+// ProcessFrame implements Computer.
+func (c *RecoveryComputer) ProcessFrame(frame slippi.FrameEntry) {
+	indices := make([]int, 0, len(frame.Players))
+	for index, updates := range frame.Players {
+		if updates.Post != nil && updates.Pre != nil {
+			indices = append(indices, int(index))
+		}
+	}
+	sort.Ints(indices)
+
+	if c.hasGeometry {
+		for _, index := range indices {
+			if prev, ok := c.prev[uint8(index)]; ok {
+				c.processPlayer(frame, uint8(index), prev)
+			}
+		}
+	}
+
+	for _, index := range indices {
+		c.prev[uint8(index)] = frame.Players[uint8(index)]
+	}
+	c.last = frame
+}
+
+func (c *RecoveryComputer) processPlayer(frame slippi.FrameEntry, index uint8, prev slippi.FrameUpdates) {
+	pre, post := frame.Players[index].Pre, frame.Players[index].Post
+	offstage := c.geometry.IsWellOffstage(post.XPosition, post.YPosition)
+
+	recovery, ok := c.active[index]
+	if !ok {
+		// recoveries begin once a player leaves the stage, rather than the
+		// ledge
+		wasOffstage := c.geometry.IsWellOffstage(prev.Post.XPosition, prev.Post.YPosition) || slippi.IsOnLedge(prev.Post.ActionStateID)
+		if !offstage || wasOffstage || !post.Airborne || slippi.IsDead(post.ActionStateID) || slippi.IsOnLedge(post.ActionStateID) {
+			return
+		}
+
+		recovery = &Recovery{
+			PlayerIndex:  index,
+			StartFrame:   frame.FrameNumber,
+			StartPercent: post.Percent,
+			Hits:         make([]RecoveryHit, 0),
+		}
+		c.recoveries = append(c.recoveries, recovery)
+		c.active[index] = recovery
+	}
+
+	switch {
+	case post.StocksRemaining < prev.Post.StocksRemaining || slippi.IsDead(post.ActionStateID):
+		recovery.Outcome = RecoveryFailure
+	case slippi.IsOnLedge(post.ActionStateID):
+		recovery.Outcome = RecoverySuccess
+		recovery.Ledge = true
+	case !post.Airborne && !offstage:
+		recovery.Outcome = RecoverySuccess
+	}
+	if recovery.Outcome != RecoveryUnresolved {
+		recovery.EndFrame = frame.FrameNumber
+		delete(c.active, index)
+		return
+	}
+
+	if post.Airborne && prev.Post.Airborne && post.JumpsRemaining < prev.Post.JumpsRemaining {
+		recovery.UsedJump = true
+	}
+	if post.ActionStateID == slippi.StateAirDodge {
+		recovery.UsedAirDodge = true
+	}
+	// an up-B is a special started with the stick up, rather than a side-B
+	// angled upwards
+	if slippi.IsCharacterSpecific(post.ActionStateID) && !slippi.IsCharacterSpecific(prev.Post.ActionStateID) && pre.PhysicalButtons&buttonB != 0 && pre.JoystickY >= upSpecialStickY {
+		recovery.UsedUpB = true
+	}
+
+	if post.Percent > prev.Post.Percent {
+		hit := RecoveryHit{Frame: frame.FrameNumber, AttackerIndex: -1, Damage: post.Percent - prev.Post.Percent, X: post.XPosition, Y: post.YPosition}
+		if attribution, ok := slippi.AttributeHit(frame, c.last, index); ok && !attribution.IsSelfDamage() {
+			hit.AttackerIndex = int8(attribution.AttackerIndex)
+		}
+		recovery.Hits = append(recovery.Hits, hit)
+	}
+}
+
+// Recoveries returns the recoveries processed so far, in the order they
+// began, including those still unresolved.
+func (c *RecoveryComputer) Recoveries() []Recovery {
+	recoveries := make([]Recovery, 0, len(c.recoveries))
+	for _, recovery := range c.recoveries {
+		r := *recovery
+		r.Hits = append(make([]RecoveryHit, 0, len(recovery.Hits)), recovery.Hits...)
+		recoveries = append(recoveries, r)
+	}
+
+	return recoveries
+}
+
+// PlayerStats returns the recovery stats of each player in the recoveries
+// processed so far, in order of their index.
+func (c *RecoveryComputer) PlayerStats() []PlayerRecoveryStats {
+	stats := make(map[uint8]*PlayerRecoveryStats)
+	hit := make(map[uint8]int)
+	hitSuccesses := make(map[uint8]int)
+	for index := range c.prev {
+		stats[index] = &PlayerRecoveryStats{PlayerIndex: index}
+	}
+
+	for _, recovery := range c.recoveries {
+		s, ok := stats[recovery.PlayerIndex]
+		if !ok || recovery.Outcome == RecoveryUnresolved {
+			continue
+		}
+
+		s.Recoveries++
+		if recovery.Outcome == RecoverySuccess {
+			s.Successes++
+		}
+		if len(recovery.Hits) > 0 {
+			hit[recovery.PlayerIndex]++
+			if recovery.Outcome == RecoverySuccess {
+				hitSuccesses[recovery.PlayerIndex]++
+			}
+		}
+		if recovery.UsedJump {
+			s.JumpUses++
+		}
+		if recovery.UsedUpB {
+			s.UpBUses++
+		}
+		if recovery.UsedAirDodge {
+			s.AirDodgeUses++
+		}
+	}
+
+	indices := make([]int, 0, len(stats))
+	for index := range stats {
+		indices = append(indices, int(index))
+	}
+	sort.Ints(indices)
+
+	players := make([]PlayerRecoveryStats, 0, len(indices))
+	for _, index := range indices {
+		s := stats[uint8(index)]
+		s.SuccessRate = newRatio(float64(s.Successes), float64(s.Recoveries))
+		s.HitSuccessRate = newRatio(float64(hitSuccesses[uint8(index)]), float64(hit[uint8(index)]))
+		players = append(players, *s)
+	}
+
+	return players
+}
+
+// ComputeRecoveryStats returns the recovery stats of each player of the game,
+// in order of their index.
+func ComputeRecoveryStats(game *slippi.SlpGame) ([]PlayerRecoveryStats, error) {
+	computer := NewRecoveryComputer()
+	if err := Run(game, computer); err != nil {
+		return nil, err
+	}
+
+	return computer.PlayerStats(), nil
+}
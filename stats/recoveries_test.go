@@ -0,0 +1,73 @@
+package stats
+
+import "testing"
+
+func TestRecoveries(t *testing.T) {
+	game := openFixture(t)
+
+	computer := NewRecoveryComputer()
+	if err := Run(game, computer); err != nil {
+		t.Fatal(err)
+	}
+
+	recoveries := computer.Recoveries()
+	if len(recoveries) != 28 {
+		t.Fatalf("expected 28 recoveries, got %d", len(recoveries))
+	}
+
+	// every stock lost offstage is a failed recovery, and ends it
+	failures := make(map[int32]uint8)
+	for i, recovery := range recoveries {
+		if recovery.Outcome == RecoveryUnresolved || recovery.EndFrame <= recovery.StartFrame {
+			t.Errorf("recovery %d: expected every recovery to be resolved, got %+v", i, recovery)
+		}
+		if recovery.Outcome == RecoveryFailure {
+			failures[recovery.EndFrame] = recovery.PlayerIndex
+			if recovery.Ledge {
+				t.Errorf("recovery %d: expected a failed recovery not to end on the ledge, got %+v", i, recovery)
+			}
+		}
+	}
+	expected := map[int32]uint8{846: 1, 3816: 1, 4781: 0, 7137: 0, 9864: 1, 12190: 0, 12219: 1}
+	if len(failures) != len(expected) {
+		t.Errorf("expected failed recoveries ending on %v, got %v", expected, failures)
+	}
+	for frame, player := range expected {
+		if recoverer, ok := failures[frame]; !ok || recoverer != player {
+			t.Errorf("expected player %d to fail to recover on frame %d, got %v", player, frame, failures)
+		}
+	}
+
+	// Falco shines Fox as he recovers with his double jump and up-B
+	var shined *Recovery
+	for i := range recoveries {
+		if recoveries[i].StartFrame == 6345 {
+			shined = &recoveries[i]
+		}
+	}
+	if shined == nil || shined.PlayerIndex != 0 || shined.Outcome != RecoverySuccess || shined.Ledge || !shined.UsedJump || !shined.UsedUpB || shined.UsedAirDodge {
+		t.Fatalf("expected Fox to recover to the stage with his jump and up-B from frame 6345, got %+v", shined)
+	}
+	if len(shined.Hits) != 1 || shined.Hits[0].Frame != 6374 || shined.Hits[0].AttackerIndex != 1 || shined.Hits[0].Damage != 16 || int(shined.Hits[0].X) != 87 {
+		t.Errorf("expected Falco to hit Fox on frame 6374, got %+v", shined.Hits)
+	}
+
+	stats := computer.PlayerStats()
+	if len(stats) != 2 {
+		t.Fatalf("expected the stats of both players, got %+v", stats)
+	}
+
+	fox, falco := stats[0], stats[1]
+	if fox.Recoveries != 9 || fox.Successes != 6 || fox.JumpUses != 6 || fox.UpBUses != 3 || fox.AirDodgeUses != 0 {
+		t.Errorf("expected Fox to recover 6 of 9 times, got %+v", fox)
+	}
+	if fox.HitSuccessRate.Count != 1 || fox.HitSuccessRate.Total != 2 {
+		t.Errorf("expected Fox to recover from 1 of the 2 recoveries he was hit in, got %+v", fox.HitSuccessRate)
+	}
+	if falco.Recoveries != 19 || falco.Successes != 15 || falco.SuccessRate.Total != 19 || falco.UpBUses != 6 || falco.AirDodgeUses != 1 {
+		t.Errorf("expected Falco to recover 15 of 19 times, got %+v", falco)
+	}
+	if falco.HitSuccessRate.Total != 0 || falco.HitSuccessRate.Ratio != nil {
+		t.Errorf("expected Falco not to be hit while recovering, got %+v", falco.HitSuccessRate)
+	}
+}
@@ -0,0 +1,324 @@
+package stats
+
+import (
+	"sort"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+// sdiThreshold is the distance from the center the joystick must be pushed
+// past for a move of it during hitlag to be an SDI input.
+const sdiThreshold = 0.7
+
+// multiHitGap is the most frames after the hitlag of a hit that the next hit
+// of the same move can land in for both to be hits of a multi-hit move.
+const multiHitGap = 8
+
+// sdiRegionOf returns the region of a joystick at the given coordinates,
+// which is its deadzone unless it is pushed past sdiThreshold.
+func sdiRegionOf(x float32, y float32) stickRegion {
+	if x*x+y*y < sdiThreshold*sdiThreshold {
+		return regionDeadzone
+	}
+
+	return regionOf(x, y)
+}
+
+// An SDIHit is a hit a player took, with the SDI inputs they made during its
+// hitlag.
+type SDIHit struct {
+	PlayerIndex uint8 `json:"playerIndex"`
+	// AttackerIndex is the index of the player who landed the hit, or -1 if
+	// it couldn't be attributed.
+	AttackerIndex int8  `json:"attackerIndex"`
+	Frame         int32 `json:"frame"`
+	// HitlagFrames is the number of frames of the hitlag the player could SDI
+	// on, after the frame of the hit.
+	HitlagFrames int `json:"hitlagFrames"`
+	// SDIInputs is the number of moves of the player's joystick past
+	// sdiThreshold into another region during the hitlag.
+	SDIInputs int `json:"sdiInputs"`
+}
+
+// A MultiHit is a sequence of hits from a multi-hit move, such as Fox's
+// up-air or Falco's drill, each of which lands shortly after the hitlag of the
+// one before while the attacker stays in the action state of the move.
+type MultiHit struct {
+	PlayerIndex   uint8 `json:"playerIndex"`
+	AttackerIndex uint8 `json:"attackerIndex"`
+	// AttackerActionState is the action state of the move, and Move its
+	// attack, if it is a normal attack.
+	AttackerActionState uint16          `json:"attackerActionState"`
+	Move                slippi.AttackID `json:"moveId"`
+	StartFrame          int32           `json:"startFrame"`
+	EndFrame            int32           `json:"endFrame"`
+	HitCount            int             `json:"hitCount"`
+	SDIInputs           int             `json:"sdiInputs"`
+	// Escaped is whether the player SDIed out of the move, which is taken to
+	// be when they SDIed during the hitlag of its last hit and the attacker
+	// stayed in the move for longer than multiHitGap frames after it without
+	// hitting them again.
+	Escaped bool `json:"escaped"`
+}
+
+// PlayerSDIStats summarize the SDI of a player in a game.
+type PlayerSDIStats struct {
+	PlayerIndex  uint8 `json:"playerIndex"`
+	HitCount     int   `json:"hitCount"`
+	HitlagFrames int   `json:"hitlagFrames"`
+	SDIInputs    int   `json:"sdiInputs"`
+	// SDIHitRatio is the ratio of hits the player SDIed at least once during.
+	SDIHitRatio Ratio `json:"sdiHitRatio"`
+	// InputsPerHit is the average number of SDI inputs of the player per hit.
+	InputsPerHit float64 `json:"inputsPerHit"`
+	// EscapeRatio is the ratio of multi-hit moves the player escaped.
+	EscapeRatio Ratio `json:"escapeRatio"`
+}
+
+type sdiState struct {
+	last *slippi.PostFrameUpdatePayload
+	// region is the SDI region of the player's joystick on the last frame.
+	region stickRegion
+	// hit is the index in hits of the hit whose hitlag the player is in, or
+	// -1, and lastHit that of the last hit they took.
+	hit     int
+	lastHit int
+	// multiHit is the multi-hit the last hit of the player may belong to, if
+	// any, and hitlagEnd the frame the hitlag of that hit ended on.
+	multiHit  *MultiHit
+	hitlagEnd int32
+}
+
+// An SDIComputer is a Computer that counts the SDI inputs of each player
+// during the hitlag of the hits they take, from their pre-frame updates.
+// Hits are taken to be increases in the percent of a player on the first
+// frame of a hitlag.
+type SDIComputer struct {
+	hits      []SDIHit
+	multiHits []MultiHit
+	states    map[uint8]*sdiState
+	lastFrame slippi.FrameEntry
+}
+
+// NewSDIComputer returns an SDIComputer without any hits.
+func NewSDIComputer() *SDIComputer {
+	c := &SDIComputer{}
+	c.Setup(nil)
+
+	return c
+}
+
+// Setup implements Computer.
+func (c *SDIComputer) Setup(gameInfo *slippi.GameInfo) {
+	c.hits = make([]SDIHit, 0)
+	c.multiHits = make([]MultiHit, 0)
+	c.states = make(map[uint8]*sdiState)
+	c.lastFrame = slippi.FrameEntry{}
+	if gameInfo != nil {
+		for _, player := range gameInfo.Players {
+			c.states[player.Index] = &sdiState{hit: -1}
+		}
+	}
+}
+
+// ProcessFrame implements Computer.
+func (c *SDIComputer) ProcessFrame(frame slippi.FrameEntry) {
+	indices := make([]int, 0, len(frame.Players))
+	for index, updates := range frame.Players {
+		if updates.Pre != nil && updates.Post != nil {
+			indices = append(indices, int(index))
+		}
+	}
+	sort.Ints(indices)
+
+	for _, index := range indices {
+		state, ok := c.states[uint8(index)]
+		if !ok {
+			state = &sdiState{hit: -1}
+			c.states[uint8(index)] = state
+		}
+
+		c.processPlayer(frame, uint8(index), state)
+	}
+	c.lastFrame = frame
+}
+
+func (c *SDIComputer) processPlayer(frame slippi.FrameEntry, index uint8, state *sdiState) {
+	updates := frame.Players[index]
+	pre, post := updates.Pre, updates.Post
+	last := state.last
+	region := sdiRegionOf(pre.JoystickX, pre.JoystickY)
+	lastRegion := state.region
+	state.last = post
+	state.region = region
+	if last == nil {
+		return
+	}
+
+	hitlag := post.StateFlags().InHitlag
+	started := hitlag && (!last.StateFlags().InHitlag || post.HitlagFramesRemaining > last.HitlagFramesRemaining)
+	if started && post.Percent > last.Percent {
+		c.startHit(frame, index, state)
+		return
+	}
+
+	if state.hit >= 0 {
+		if hitlag && last.StateFlags().InHitlag {
+			hit := &c.hits[state.hit]
+			hit.HitlagFrames++
+			if region != regionDeadzone && region != lastRegion {
+				hit.SDIInputs++
+				if state.multiHit != nil {
+					state.multiHit.SDIInputs++
+				}
+			}
+		}
+		if !hitlag {
+			state.hit = -1
+			state.hitlagEnd = frame.FrameNumber
+		}
+	}
+
+	if state.multiHit != nil && state.hit < 0 {
+		c.checkMultiHit(frame, state)
+	}
+}
+
+// startHit records the hit the player with the given index took on frame,
+// continuing their multi-hit if it is the next hit of the same move.
+func (c *SDIComputer) startHit(frame slippi.FrameEntry, index uint8, state *sdiState) {
+	hit := SDIHit{PlayerIndex: index, AttackerIndex: -1, Frame: frame.FrameNumber}
+	var attacker *slippi.PostFrameUpdatePayload
+	if attribution, ok := slippi.AttributeHit(frame, c.lastFrame, index); ok && !attribution.IsSelfDamage() {
+		hit.AttackerIndex = int8(attribution.AttackerIndex)
+		if attribution.Item == nil {
+			attacker = frame.Players[attribution.AttackerIndex].Post
+		}
+	}
+	c.hits = append(c.hits, hit)
+	state.hit = len(c.hits) - 1
+	state.lastHit = state.hit
+
+	multiHit := state.multiHit
+	if multiHit != nil && attacker != nil && uint8(hit.AttackerIndex) == multiHit.AttackerIndex &&
+		attacker.ActionStateID == multiHit.AttackerActionState && frame.FrameNumber-state.hitlagEnd <= multiHitGap {
+		multiHit.HitCount++
+		multiHit.EndFrame = frame.FrameNumber
+		return
+	}
+
+	c.endMultiHit(state, false)
+	if attacker != nil {
+		state.multiHit = &MultiHit{
+			PlayerIndex:         index,
+			AttackerIndex:       uint8(hit.AttackerIndex),
+			AttackerActionState: attacker.ActionStateID,
+			Move:                slippi.AttackOfActionState(attacker.ActionStateID),
+			StartFrame:          frame.FrameNumber,
+			EndFrame:            frame.FrameNumber,
+			HitCount:            1,
+		}
+	}
+}
+
+// checkMultiHit ends the multi-hit of state once its attacker leaves the
+// move, or stays in it for longer than multiHitGap frames without another
+// hit, which is an escape if the player SDIed during the last hit.
+func (c *SDIComputer) checkMultiHit(frame slippi.FrameEntry, state *sdiState) {
+	multiHit := state.multiHit
+	updates, ok := frame.Players[multiHit.AttackerIndex]
+	if !ok || updates.Post == nil || updates.Post.ActionStateID != multiHit.AttackerActionState {
+		c.endMultiHit(state, false)
+	} else if frame.FrameNumber-state.hitlagEnd > multiHitGap {
+		c.endMultiHit(state, c.hits[state.lastHit].SDIInputs > 0)
+	}
+}
+
+// endMultiHit records the multi-hit of state, if it has several hits.
+func (c *SDIComputer) endMultiHit(state *sdiState, escaped bool) {
+	if state.multiHit != nil && state.multiHit.HitCount > 1 {
+		state.multiHit.Escaped = escaped
+		c.multiHits = append(c.multiHits, *state.multiHit)
+	}
+	state.multiHit = nil
+}
+
+// Hits returns the hits processed so far, in order.
+func (c *SDIComputer) Hits() []SDIHit {
+	return append(make([]SDIHit, 0, len(c.hits)), c.hits...)
+}
+
+// MultiHits returns the multi-hits processed so far, in the order they
+// ended, including those still in progress.
+func (c *SDIComputer) MultiHits() []MultiHit {
+	multiHits := append(make([]MultiHit, 0, len(c.multiHits)), c.multiHits...)
+
+	indices := make([]int, 0, len(c.states))
+	for index := range c.states {
+		indices = append(indices, int(index))
+	}
+	sort.Ints(indices)
+	for _, index := range indices {
+		if multiHit := c.states[uint8(index)].multiHit; multiHit != nil && multiHit.HitCount > 1 {
+			multiHits = append(multiHits, *multiHit)
+		}
+	}
+
+	return multiHits
+}
+
+// PlayerStats returns the SDI stats of each player, in order of their index.
+func (c *SDIComputer) PlayerStats() []PlayerSDIStats {
+	indices := make([]int, 0, len(c.states))
+	for index := range c.states {
+		indices = append(indices, int(index))
+	}
+	sort.Ints(indices)
+
+	stats := make(map[uint8]*PlayerSDIStats)
+	sdiHits := make(map[uint8]int)
+	escapes := make(map[uint8]int)
+	multiHits := make(map[uint8]int)
+	for _, index := range indices {
+		stats[uint8(index)] = &PlayerSDIStats{PlayerIndex: uint8(index)}
+	}
+	for _, hit := range c.hits {
+		s := stats[hit.PlayerIndex]
+		s.HitCount++
+		s.HitlagFrames += hit.HitlagFrames
+		s.SDIInputs += hit.SDIInputs
+		if hit.SDIInputs > 0 {
+			sdiHits[hit.PlayerIndex]++
+		}
+	}
+	for _, multiHit := range c.MultiHits() {
+		multiHits[multiHit.PlayerIndex]++
+		if multiHit.Escaped {
+			escapes[multiHit.PlayerIndex]++
+		}
+	}
+
+	players := make([]PlayerSDIStats, 0, len(indices))
+	for _, index := range indices {
+		s := stats[uint8(index)]
+		s.SDIHitRatio = newRatio(float64(sdiHits[uint8(index)]), float64(s.HitCount))
+		s.EscapeRatio = newRatio(float64(escapes[uint8(index)]), float64(multiHits[uint8(index)]))
+		if s.HitCount > 0 {
+			s.InputsPerHit = float64(s.SDIInputs) / float64(s.HitCount)
+		}
+		players = append(players, *s)
+	}
+
+	return players
+}
+
+// ComputeSDIStats returns the SDI stats of each player of the game, in order
+// of their index.
+func ComputeSDIStats(game *slippi.SlpGame) ([]PlayerSDIStats, error) {
+	computer := NewSDIComputer()
+	if err := Run(game, computer); err != nil {
+		return nil, err
+	}
+
+	return computer.PlayerStats(), nil
+}
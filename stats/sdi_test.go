@@ -0,0 +1,64 @@
+package stats
+
+import (
+	"testing"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+func TestSDI(t *testing.T) {
+	game := openFixture(t)
+
+	computer := NewSDIComputer()
+	if err := Run(game, computer); err != nil {
+		t.Fatal(err)
+	}
+
+	hits := computer.Hits()
+	if len(hits) != 94 {
+		t.Fatalf("expected 94 hits, got %d", len(hits))
+	}
+	// Fox's up-air hits twice, and Falco SDIs during both hits
+	for i, expected := range []SDIHit{
+		{PlayerIndex: 1, AttackerIndex: 0, Frame: 1523, HitlagFrames: 3, SDIInputs: 1},
+		{PlayerIndex: 1, AttackerIndex: 0, Frame: 1529, HitlagFrames: 5, SDIInputs: 1},
+	} {
+		if hits[12+i] != expected {
+			t.Errorf("hit %d: expected %+v, got %+v", 12+i, expected, hits[12+i])
+		}
+	}
+
+	multiHits := computer.MultiHits()
+	if len(multiHits) != 6 {
+		t.Fatalf("expected 6 multi-hits, got %+v", multiHits)
+	}
+	expected := MultiHit{
+		PlayerIndex:         1,
+		AttackerIndex:       0,
+		AttackerActionState: slippi.StateUair,
+		Move:                slippi.UpAir,
+		StartFrame:          1523,
+		EndFrame:            1529,
+		HitCount:            2,
+		SDIInputs:           2,
+	}
+	if multiHits[1] != expected {
+		t.Errorf("expected the multi-hit %+v, got %+v", expected, multiHits[1])
+	}
+
+	stats := computer.PlayerStats()
+	if len(stats) != 2 {
+		t.Fatalf("expected the stats of both players, got %+v", stats)
+	}
+	for i, expected := range []struct {
+		hits, hitlagFrames, inputs, sdiHits int
+	}{{34, 176, 14, 10}, {60, 245, 15, 13}} {
+		s := stats[i]
+		if s.HitCount != expected.hits || s.HitlagFrames != expected.hitlagFrames || s.SDIInputs != expected.inputs || s.SDIHitRatio.Count != float64(expected.sdiHits) {
+			t.Errorf("player %d: expected %+v, got %+v", i, expected, s)
+		}
+	}
+	if stats[1].EscapeRatio.Total != 6 || stats[1].EscapeRatio.Count != 0 || stats[1].InputsPerHit != 0.25 {
+		t.Errorf("expected Falco not to escape any of 6 multi-hits, got %+v", stats[1])
+	}
+}
@@ -0,0 +1,269 @@
+package stats
+
+import (
+	"sort"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+// pressureGap is the most frames after an attack lands on a player's shield
+// that the next attack of the same opponent can land in for both to be part
+// of the same shield pressure string.
+const pressureGap = 45
+
+// A PressureString is a string of attacks an opponent landed on a player's
+// shield, each within pressureGap frames of the one before.
+type PressureString struct {
+	AttackerIndex uint8 `json:"attackerIndex"`
+	DefenderIndex uint8 `json:"defenderIndex"`
+	// StartFrame and EndFrame are the frames the first and last attacks of
+	// the string landed on.
+	StartFrame int32 `json:"startFrame"`
+	EndFrame   int32 `json:"endFrame"`
+	HitCount   int   `json:"hitCount"`
+	// ShieldDamage is the shield health the attacks of the string took.
+	ShieldDamage float32 `json:"shieldDamage"`
+}
+
+// OutOfShieldCounts are the number of times a player chose each option out of
+// their shield. Getting hit or grabbed out of shield isn't an option.
+type OutOfShieldCounts struct {
+	Jump      int `json:"jump"`
+	Grab      int `json:"grab"`
+	Roll      int `json:"roll"`
+	SpotDodge int `json:"spotDodge"`
+	// Special counts moves specific to the player's character, such as
+	// Fox's shine.
+	Special int `json:"special"`
+	// Drop counts shields released into other actions.
+	Drop  int `json:"drop"`
+	Other int `json:"other"`
+}
+
+// ShieldStats report how a player shielded in a game, and pressured the
+// shields of their opponents.
+type ShieldStats struct {
+	PlayerIndex uint8 `json:"playerIndex"`
+	// ShieldFrames is the number of frames the player was in a shield state.
+	ShieldFrames int `json:"shieldFrames"`
+	// ShieldSizeTimeline is the shield health of the player at the end of
+	// each full second of playable frames, and MinShieldSize the lowest it
+	// got.
+	ShieldSizeTimeline []float32 `json:"shieldSizeTimeline"`
+	MinShieldSize      float32   `json:"minShieldSize"`
+	// ShieldHits is the number of attacks that landed on the player's
+	// shield, taking ShieldDamageTaken shield health in total.
+	ShieldHits        int     `json:"shieldHits"`
+	ShieldDamageTaken float32 `json:"shieldDamageTaken"`
+	// ShieldStabs is the number of times the player hit an opponent through
+	// their shield, and ShieldStabsTaken the number of times they were hit
+	// through their own.
+	ShieldStabs      int `json:"shieldStabs"`
+	ShieldStabsTaken int `json:"shieldStabsTaken"`
+	ShieldBreaks     int `json:"shieldBreaks"`
+	// PressureStrings are the strings of attacks opponents landed on the
+	// player's shield.
+	PressureStrings []PressureString  `json:"pressureStrings"`
+	OutOfShield     OutOfShieldCounts `json:"outOfShield"`
+}
+
+type shieldState struct {
+	stats ShieldStats
+	last  *slippi.PostFrameUpdatePayload
+	// pressure is the latest pressure string on the player's shield, if any.
+	pressure *PressureString
+}
+
+// A ShieldComputer is a Computer that tracks the shields of each player.
+// A shield stab is a hit a player takes while their shield is up, rather than
+// starting or being released.
+type ShieldComputer struct {
+	states    map[uint8]*shieldState
+	lastFrame slippi.FrameEntry
+}
+
+// NewShieldComputer returns a ShieldComputer without any shield stats.
+func NewShieldComputer() *ShieldComputer {
+	c := &ShieldComputer{}
+	c.Setup(nil)
+
+	return c
+}
+
+// Setup implements Computer.
+func (c *ShieldComputer) Setup(gameInfo *slippi.GameInfo) {
+	c.states = make(map[uint8]*shieldState)
+	c.lastFrame = slippi.FrameEntry{}
+	if gameInfo != nil {
+		for _, player := range gameInfo.Players {
+			c.state(player.Index)
+		}
+	}
+}
+
+// state returns the state of the player with the given index.
+func (c *ShieldComputer) state(index uint8) *shieldState {
+	state, ok := c.states[index]
+	if !ok {
+		state = &shieldState{stats: ShieldStats{
+			PlayerIndex:        index,
+			ShieldSizeTimeline: make([]float32, 0),
+			MinShieldSize:      -1,
+			PressureStrings:    make([]PressureString, 0),
+		}}
+		c.states[index] = state
+	}
+
+	return state
+}
+
+// ProcessFrame implements Computer.
+func (c *ShieldComputer) ProcessFrame(frame slippi.FrameEntry) {
+	indices := make([]int, 0, len(frame.Players))
+	for index, updates := range frame.Players {
+		if updates.Post != nil {
+			indices = append(indices, int(index))
+		}
+	}
+	sort.Ints(indices)
+
+	for _, index := range indices {
+		state := c.state(uint8(index))
+		post := frame.Players[uint8(index)].Post
+		last := state.last
+		state.last = post
+		c.processPlayer(frame, state, *post, last)
+	}
+	c.lastFrame = frame
+}
+
+func (c *ShieldComputer) processPlayer(frame slippi.FrameEntry, state *shieldState, post slippi.PostFrameUpdatePayload, last *slippi.PostFrameUpdatePayload) {
+	stats := &state.stats
+	if frame.FrameNumber >= slippi.FirstPlayableFrame && (frame.FrameNumber-slippi.FirstPlayableFrame+1)%60 == 0 {
+		stats.ShieldSizeTimeline = append(stats.ShieldSizeTimeline, post.ShieldSize)
+	}
+	if stats.MinShieldSize < 0 || post.ShieldSize < stats.MinShieldSize {
+		stats.MinShieldSize = post.ShieldSize
+	}
+
+	current := post.ActionStateID
+	if slippi.IsShielding(current) {
+		stats.ShieldFrames++
+	}
+	if last == nil || current == last.ActionStateID && !slippi.IsShielding(current) {
+		return
+	}
+
+	previous := last.ActionStateID
+	switch {
+	case isShieldHit(post, last):
+		c.processShieldHit(frame, state, post, *last)
+	case isShieldUp(previous) && (slippi.IsDamaged(current) || slippi.IsDead(current)) && post.Percent > last.Percent:
+		stats.ShieldStabsTaken++
+		if hit, ok := slippi.AttributeHit(frame, c.lastFrame, post.PlayerIndex); ok && !hit.IsSelfDamage() {
+			c.state(hit.AttackerIndex).stats.ShieldStabs++
+		}
+	case isShieldBroken(current) && !isShieldBroken(previous):
+		stats.ShieldBreaks++
+	}
+
+	if slippi.IsShielding(previous) && !slippi.IsShielding(current) {
+		c.countOutOfShield(stats, previous, current)
+	}
+}
+
+// processShieldHit records an attack landing on the shield of the player of
+// state on frame.
+func (c *ShieldComputer) processShieldHit(frame slippi.FrameEntry, state *shieldState, post slippi.PostFrameUpdatePayload, last slippi.PostFrameUpdatePayload) {
+	stats := &state.stats
+	damage := max(last.ShieldSize-post.ShieldSize, 0)
+	stats.ShieldHits++
+	stats.ShieldDamageTaken += damage
+
+	hit, ok := slippi.AttributeHit(frame, c.lastFrame, post.PlayerIndex)
+	if !ok || hit.IsSelfDamage() {
+		return
+	}
+
+	pressure := state.pressure
+	if pressure == nil || pressure.AttackerIndex != hit.AttackerIndex || frame.FrameNumber-pressure.EndFrame > pressureGap {
+		stats.PressureStrings = append(stats.PressureStrings, PressureString{
+			AttackerIndex: hit.AttackerIndex,
+			DefenderIndex: post.PlayerIndex,
+			StartFrame:    frame.FrameNumber,
+		})
+		pressure = &stats.PressureStrings[len(stats.PressureStrings)-1]
+		state.pressure = pressure
+	}
+
+	pressure.EndFrame = frame.FrameNumber
+	pressure.HitCount++
+	pressure.ShieldDamage += damage
+}
+
+// countOutOfShield counts the option the player of stats chose out of shield
+// by going from the shield state previous to the action state current.
+func (c *ShieldComputer) countOutOfShield(stats *ShieldStats, previous uint16, current uint16) {
+	counts := &stats.OutOfShield
+	switch {
+	case slippi.IsDamaged(current) || slippi.IsGrabbed(current) || slippi.IsCommandGrabbed(current) || slippi.IsDead(current):
+	case isShieldBroken(current):
+	case current == slippi.StateKneeBend:
+		counts.Jump++
+	case current == slippi.StateGrab:
+		counts.Grab++
+	case current == slippi.StateRollForward || current == slippi.StateRollBackward:
+		counts.Roll++
+	case current == slippi.StateSpotDodge:
+		counts.SpotDodge++
+	case slippi.IsCharacterSpecific(current):
+		counts.Special++
+	case previous == slippi.StateGuardOff:
+		counts.Drop++
+	default:
+		counts.Other++
+	}
+}
+
+// isShieldUp returns whether the action state is one of a shield that is up,
+// rather than starting or being released.
+func isShieldUp(actionStateID uint16) bool {
+	return actionStateID == slippi.StateGuard || actionStateID == slippi.StateGuardSetOff || actionStateID == slippi.StateGuardReflect
+}
+
+// isShieldBroken returns whether the action state is one of a broken shield.
+func isShieldBroken(actionStateID uint16) bool {
+	return actionStateID >= slippi.StateGuardBreakStart && actionStateID <= slippi.StateGuardBreakEnd
+}
+
+// ShieldStats returns the shield stats of each player, in order of their
+// index.
+func (c *ShieldComputer) ShieldStats() []ShieldStats {
+	indices := make([]int, 0, len(c.states))
+	for index := range c.states {
+		indices = append(indices, int(index))
+	}
+	sort.Ints(indices)
+
+	players := make([]ShieldStats, 0, len(indices))
+	for _, index := range indices {
+		stats := c.states[uint8(index)].stats
+		stats.ShieldSizeTimeline = append(make([]float32, 0, len(stats.ShieldSizeTimeline)), stats.ShieldSizeTimeline...)
+		stats.PressureStrings = append(make([]PressureString, 0, len(stats.PressureStrings)), stats.PressureStrings...)
+		stats.MinShieldSize = max(stats.MinShieldSize, 0)
+		players = append(players, stats)
+	}
+
+	return players
+}
+
+// ComputeShieldStats returns the shield stats of each player of the game, in
+// order of their index.
+func ComputeShieldStats(game *slippi.SlpGame) ([]ShieldStats, error) {
+	computer := NewShieldComputer()
+	if err := Run(game, computer); err != nil {
+		return nil, err
+	}
+
+	return computer.ShieldStats(), nil
+}
@@ -0,0 +1,51 @@
+package stats
+
+import "testing"
+
+func TestShieldStats(t *testing.T) {
+	game := openFixture(t)
+
+	stats, err := ComputeShieldStats(game)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats) != 2 {
+		t.Fatalf("expected the shield stats of both players, got %+v", stats)
+	}
+
+	fox, falco := stats[0], stats[1]
+	for _, player := range stats {
+		if len(player.ShieldSizeTimeline) != 204 || player.ShieldSizeTimeline[0] != 60 {
+			t.Errorf("player %d: expected a full shield at each of 204 seconds, starting at 60, got %v", player.PlayerIndex, player.ShieldSizeTimeline)
+		}
+		if player.ShieldStabs != 1 || player.ShieldStabsTaken != 1 || player.ShieldBreaks != 0 {
+			t.Errorf("player %d: expected a shield stab each way, got %+v", player.PlayerIndex, player)
+		}
+	}
+
+	if fox.ShieldFrames != 83 || fox.ShieldHits != 1 || len(fox.PressureStrings) != 1 || fox.PressureStrings[0].StartFrame != 11933 {
+		t.Errorf("expected Falco to land a single attack on Fox's shield, got %+v", fox)
+	}
+	if fox.OutOfShield != (OutOfShieldCounts{Jump: 3, Roll: 1, SpotDodge: 1, Drop: 1}) {
+		t.Errorf("expected Fox's options out of shield, got %+v", fox.OutOfShield)
+	}
+
+	if falco.ShieldFrames != 713 || falco.ShieldHits != 14 || falco.MinShieldSize > 36 {
+		t.Errorf("expected Fox to pressure Falco's shield, got %+v", falco)
+	}
+	var damage float32
+	hits := 0
+	for _, pressure := range falco.PressureStrings {
+		if pressure.AttackerIndex != 0 || pressure.DefenderIndex != 1 || pressure.EndFrame < pressure.StartFrame {
+			t.Errorf("expected a string of Fox's attacks, got %+v", pressure)
+		}
+		damage += pressure.ShieldDamage
+		hits += pressure.HitCount
+	}
+	if len(falco.PressureStrings) != 12 || hits != falco.ShieldHits || damage != falco.ShieldDamageTaken {
+		t.Errorf("expected 12 strings with all of the shield damage Falco took, got %+v", falco.PressureStrings)
+	}
+	if falco.OutOfShield != (OutOfShieldCounts{Jump: 11, Roll: 11, SpotDodge: 3, Drop: 6, Other: 2}) {
+		t.Errorf("expected Falco's options out of shield, got %+v", falco.OutOfShield)
+	}
+}
@@ -0,0 +1,110 @@
+package stats
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"slices"
+	"testing"
+)
+
+// slippiJSStats is the path of the stats slippi-js getStats computes for the
+// fixture replay, as printed by testdata/getstats.js.
+const slippiJSStats = "testdata/game.slp.json"
+
+// compareJSON appends a description of each difference between the decoded
+// JSON values expected and actual, found at path, to differences. Numbers
+// only need to be within a small tolerance of each other, since slippi-js
+// computes with float64s and the stats here with float32s.
+func compareJSON(path string, expected any, actual any, differences []string) []string {
+	switch expected := expected.(type) {
+	case map[string]any:
+		object, ok := actual.(map[string]any)
+		if !ok {
+			return append(differences, fmt.Sprintf("%s: expected an object, got %v", path, actual))
+		}
+
+		keys := make([]string, 0, len(expected))
+		for key := range expected {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			if _, ok := object[key]; !ok {
+				differences = append(differences, fmt.Sprintf("%s.%s: expected %v, got nothing", path, key, expected[key]))
+				continue
+			}
+			differences = compareJSON(path+"."+key, expected[key], object[key], differences)
+		}
+		for key := range object {
+			if _, ok := expected[key]; !ok {
+				differences = append(differences, fmt.Sprintf("%s.%s: expected nothing, got %v", path, key, object[key]))
+			}
+		}
+	case []any:
+		array, ok := actual.([]any)
+		if !ok {
+			return append(differences, fmt.Sprintf("%s: expected an array, got %v", path, actual))
+		}
+		if len(array) != len(expected) {
+			return append(differences, fmt.Sprintf("%s: expected %d elements, got %d", path, len(expected), len(array)))
+		}
+
+		for i := range expected {
+			differences = compareJSON(fmt.Sprintf("%s[%d]", path, i), expected[i], array[i], differences)
+		}
+	case float64:
+		number, ok := actual.(float64)
+		if !ok || math.Abs(number-expected) > 1e-3*math.Max(1, math.Abs(expected)) {
+			return append(differences, fmt.Sprintf("%s: expected %v, got %v", path, expected, actual))
+		}
+	default:
+		if expected != actual {
+			return append(differences, fmt.Sprintf("%s: expected %v, got %v", path, expected, actual))
+		}
+	}
+
+	return differences
+}
+
+func TestSlippiJSStats(t *testing.T) {
+	b, err := os.ReadFile(slippiJSStats)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Skipf("no slippi-js stats of the fixture replay at %s, which testdata/getstats.js prints", slippiJSStats)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var expected any
+	if err := json.Unmarshal(b, &expected); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := GetStats(openFixture(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := json.Marshal(stats)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var actual any
+	if err := json.Unmarshal(encoded, &actual); err != nil {
+		t.Fatal(err)
+	}
+
+	// the stocks, conversions, combos, action counts and overall stats are
+	// all compared
+	differences := compareJSON("stats", expected, actual, nil)
+	for i, difference := range differences {
+		if i == 20 {
+			t.Errorf("and %d more differences", len(differences)-i)
+			break
+		}
+		t.Error(difference)
+	}
+}
@@ -0,0 +1,148 @@
+package stats
+
+import (
+	"sort"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+// PlayerStateTimes are the frames a player spent in each kind of state in a
+// game, out of the playable frames of the game. The kinds overlap, such as a
+// player in hitstun offstage, who is also airborne. Frames the player spent
+// dead count towards none of them.
+type PlayerStateTimes struct {
+	PlayerIndex uint8 `json:"playerIndex"`
+	Hitstun     Ratio `json:"hitstun"`
+	Shield      Ratio `json:"shield"`
+	// GroundedNeutral is the time the player was actionable on the ground,
+	// other than in their shield.
+	GroundedNeutral Ratio `json:"groundedNeutral"`
+	// Airborne and Offstage don't include the time the player was on the
+	// ledge. Offstage is only counted on stages whose geometry is known.
+	Airborne Ratio `json:"airborne"`
+	Offstage Ratio `json:"offstage"`
+	OnLedge  Ratio `json:"onLedge"`
+}
+
+type stateFrames struct {
+	hitstun         int32
+	shield          int32
+	groundedNeutral int32
+	airborne        int32
+	offstage        int32
+	onLedge         int32
+}
+
+// A StateTimesComputer is a Computer that counts the frames each player
+// spent in each kind of state.
+type StateTimesComputer struct {
+	geometry    slippi.StageGeometry
+	hasGeometry bool
+	frames      map[uint8]*stateFrames
+	playable    int32
+}
+
+// NewStateTimesComputer returns a StateTimesComputer that hasn't counted any
+// frames.
+func NewStateTimesComputer() *StateTimesComputer {
+	c := &StateTimesComputer{}
+	c.Setup(nil)
+
+	return c
+}
+
+// Setup implements Computer.
+func (c *StateTimesComputer) Setup(gameInfo *slippi.GameInfo) {
+	c.geometry, c.hasGeometry = slippi.StageGeometry{}, false
+	c.frames = make(map[uint8]*stateFrames)
+	c.playable = 0
+	if gameInfo != nil {
+		c.geometry, c.hasGeometry = gameInfo.Stage.Geometry()
+		for _, player := range gameInfo.Players {
+			c.frames[player.Index] = &stateFrames{}
+		}
+	}
+}
+
+// ProcessFrame implements Computer.
+func (c *StateTimesComputer) ProcessFrame(frame slippi.FrameEntry) {
+	if frame.FrameNumber < slippi.FirstPlayableFrame {
+		return
+	}
+	c.playable++
+
+	for index, updates := range frame.Players {
+		post := updates.Post
+		if post == nil {
+			continue
+		}
+
+		frames, ok := c.frames[index]
+		if !ok {
+			frames = &stateFrames{}
+			c.frames[index] = frames
+		}
+
+		state := post.ActionStateID
+		if slippi.IsDead(state) {
+			continue
+		}
+
+		onLedge := slippi.IsOnLedge(state)
+		switch {
+		case onLedge:
+			frames.onLedge++
+		case slippi.IsDamaged(state):
+			frames.hitstun++
+		case slippi.IsShielding(state):
+			frames.shield++
+		case !post.Airborne && slippi.IsInControl(state):
+			frames.groundedNeutral++
+		}
+
+		if !onLedge && post.Airborne {
+			frames.airborne++
+		}
+		if !onLedge && c.hasGeometry && c.geometry.IsOffstage(post.XPosition, post.YPosition) {
+			frames.offstage++
+		}
+	}
+}
+
+// StateTimes returns the times each player spent in each kind of state, in
+// order of their index.
+func (c *StateTimesComputer) StateTimes() []PlayerStateTimes {
+	indices := make([]int, 0, len(c.frames))
+	for index := range c.frames {
+		indices = append(indices, int(index))
+	}
+	sort.Ints(indices)
+
+	total := float64(c.playable)
+	times := make([]PlayerStateTimes, 0, len(indices))
+	for _, index := range indices {
+		frames := c.frames[uint8(index)]
+		times = append(times, PlayerStateTimes{
+			PlayerIndex:     uint8(index),
+			Hitstun:         newRatio(float64(frames.hitstun), total),
+			Shield:          newRatio(float64(frames.shield), total),
+			GroundedNeutral: newRatio(float64(frames.groundedNeutral), total),
+			Airborne:        newRatio(float64(frames.airborne), total),
+			Offstage:        newRatio(float64(frames.offstage), total),
+			OnLedge:         newRatio(float64(frames.onLedge), total),
+		})
+	}
+
+	return times
+}
+
+// ComputeStateTimes returns the times each player in the game spent in each
+// kind of state, in order of their index.
+func ComputeStateTimes(game *slippi.SlpGame) ([]PlayerStateTimes, error) {
+	computer := NewStateTimesComputer()
+	if err := Run(game, computer); err != nil {
+		return nil, err
+	}
+
+	return computer.StateTimes(), nil
+}
@@ -0,0 +1,50 @@
+package stats
+
+import (
+	"testing"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+func TestStateTimes(t *testing.T) {
+	game := openFixture(t)
+
+	times, err := ComputeStateTimes(game)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(times) != 2 {
+		t.Fatalf("expected the state times of 2 players, got %d", len(times))
+	}
+
+	// the playable frames run from FirstPlayableFrame to the last frame
+	playable := float64(12219 - slippi.FirstPlayableFrame + 1)
+	for i, player := range times {
+		if player.PlayerIndex != uint8(i) {
+			t.Errorf("expected the state times of player %d, got %d", i, player.PlayerIndex)
+		}
+
+		kinds := []Ratio{player.Hitstun, player.Shield, player.GroundedNeutral, player.Airborne, player.Offstage, player.OnLedge}
+		for _, kind := range kinds {
+			if kind.Total != playable || kind.Ratio == nil || *kind.Ratio != kind.Count/playable {
+				t.Errorf("expected player %d's times out of %f frames, got %+v", i, playable, kind)
+			}
+		}
+
+		// hitstun, shield, grounded neutral and the ledge don't overlap
+		if exclusive := player.Hitstun.Count + player.Shield.Count + player.GroundedNeutral.Count + player.OnLedge.Count; exclusive > playable {
+			t.Errorf("expected player %d to spend at most %f frames in exclusive states, got %f", i, playable, exclusive)
+		}
+		if player.Hitstun.Count == 0 || player.GroundedNeutral.Count == 0 || player.Airborne.Count == 0 || player.Offstage.Count == 0 {
+			t.Errorf("expected player %d to spend time in every state but the ledge, got %+v", i, player)
+		}
+		if player.Offstage.Count > player.Airborne.Count {
+			t.Errorf("expected player %d to be airborne whenever they were offstage, got %+v", i, player)
+		}
+	}
+
+	// Fox never grabs the ledge
+	if times[0].OnLedge.Count != 0 || times[1].OnLedge.Count == 0 {
+		t.Errorf("expected only Falco to spend time on the ledge, got %+v and %+v", times[0].OnLedge, times[1].OnLedge)
+	}
+}
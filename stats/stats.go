@@ -0,0 +1,93 @@
+// Package stats computes the stats of games parsed by the slippi package, such
+// as combos, conversions and the headline stats of slippi-js getStats, with
+// Computers that each process a game's frames in order.
+package stats
+
+import slippi "github.com/ZadenRB/go-slippi"
+
+// A Computer computes stats from the frames of a game, processed in frame
+// order, like the stat computers of slippi-js.
+type Computer interface {
+	// Setup prepares the computer for the game with the given game info,
+	// discarding the stats of any game it processed before.
+	Setup(gameInfo *slippi.GameInfo)
+	// ProcessFrame processes the next frame of the game.
+	ProcessFrame(frame slippi.FrameEntry)
+}
+
+// Run sets up each of computers for the game and processes the game's frames
+// with them, in frame order, after which their stats can be read.
+func Run(game *slippi.SlpGame, computers ...Computer) error {
+	gameInfo, err := game.GetGameInfo()
+	if err != nil {
+		return err
+	}
+
+	store, err := game.GetFrameStore()
+	if err != nil {
+		return err
+	}
+
+	for _, computer := range computers {
+		computer.Setup(gameInfo)
+	}
+
+	for _, frame := range store.All() {
+		for _, computer := range computers {
+			computer.ProcessFrame(frame)
+		}
+	}
+
+	return nil
+}
+
+// Stats are the stats of a game computed by every Computer, whose JSON
+// encoding is that of the stats returned by slippi-js getStats.
+type Stats struct {
+	// LastFrame is the number of the last frame of the game, and
+	// PlayableFrameCount the number of frames players could act on.
+	LastFrame          int32               `json:"lastFrame"`
+	PlayableFrameCount int32               `json:"playableFrameCount"`
+	Stocks             []Stock             `json:"stocks"`
+	Conversions        []slippi.Conversion `json:"conversions"`
+	Combos             []Combo             `json:"combos"`
+	ActionCounts       []ActionCounts      `json:"actionCounts"`
+	Overall            []PlayerOverall     `json:"overall"`
+	// GameComplete is whether the game has a game end event.
+	GameComplete bool `json:"gameComplete"`
+}
+
+// GetStats computes the stats of the game with every Computer, in a single
+// pass over its frames.
+func GetStats(game *slippi.SlpGame) (*Stats, error) {
+	stocks := NewStocksComputer()
+	combos := NewComboComputer()
+	actions := NewActionsComputer()
+	overall := NewOverallComputer()
+	if err := Run(game, stocks, combos, actions, overall); err != nil {
+		return nil, err
+	}
+
+	lastFrame, err := game.GetLastFrameNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	complete, err := game.IsComplete()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Stats{
+		LastFrame:          lastFrame,
+		PlayableFrameCount: max(lastFrame-slippi.FirstPlayableFrame, 0),
+		Stocks:             stocks.Stocks(),
+		// the overall computer's conversions are those of the stats, so
+		// they aren't computed twice
+		Conversions:  overall.conversions.Conversions(),
+		Combos:       combos.Combos(),
+		ActionCounts: actions.ActionCounts(),
+		Overall:      overall.Overall(),
+		GameComplete: complete,
+	}, nil
+}
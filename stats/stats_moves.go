@@ -0,0 +1,151 @@
+package stats
+
+import (
+	"sort"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+// A MoveUsage is the damage a player dealt to their opponents with a single
+// move.
+type MoveUsage struct {
+	Move     slippi.AttackID `json:"move"`
+	MoveName string          `json:"moveName"`
+	// Hits is the number of times the move damaged an opponent, counting
+	// each hit of multi-hit moves.
+	Hits   int     `json:"hits"`
+	Damage float32 `json:"damage"`
+}
+
+// AverageDamage returns the average damage of a hit of the move.
+func (m MoveUsage) AverageDamage() float64 {
+	if m.Hits == 0 {
+		return 0
+	}
+
+	return float64(m.Damage) / float64(m.Hits)
+}
+
+// PlayerMoveUsage is the damage a player dealt to their opponents in a game,
+// broken down by move, in order of their attack ID.
+type PlayerMoveUsage struct {
+	PlayerIndex uint8       `json:"playerIndex"`
+	Moves       []MoveUsage `json:"moves"`
+}
+
+// Move returns the usage of the given move, which is empty if the player
+// never hit with it.
+func (u PlayerMoveUsage) Move(move slippi.AttackID) MoveUsage {
+	for _, m := range u.Moves {
+		if m.Move == move {
+			return m
+		}
+	}
+
+	return MoveUsage{Move: move, MoveName: move.String()}
+}
+
+// TotalDamage returns the damage the player dealt with all of their moves.
+func (u PlayerMoveUsage) TotalDamage() float32 {
+	var total float32
+	for _, m := range u.Moves {
+		total += m.Damage
+	}
+
+	return total
+}
+
+// A MoveUsageComputer is a Computer that counts the hits and damage each
+// player dealt to their opponents with each move. Damage is attributed to the
+// move the attacker last hit with, so damage that couldn't be attributed to an
+// opponent isn't part of anyone's usage.
+type MoveUsageComputer struct {
+	moves  map[uint8]map[slippi.AttackID]*MoveUsage
+	walker *slippi.DamageWalker
+}
+
+// NewMoveUsageComputer returns a MoveUsageComputer that hasn't counted any
+// hits.
+func NewMoveUsageComputer() *MoveUsageComputer {
+	c := &MoveUsageComputer{}
+	c.Setup(nil)
+
+	return c
+}
+
+// Setup implements Computer.
+func (c *MoveUsageComputer) Setup(gameInfo *slippi.GameInfo) {
+	c.moves = make(map[uint8]map[slippi.AttackID]*MoveUsage)
+	c.walker = slippi.NewDamageWalker(c.processDamage, func(slippi.FrameEntry, slippi.FrameEntry, uint8, *slippi.PostFrameUpdatePayload, bool) {})
+	if gameInfo != nil {
+		for _, player := range gameInfo.Players {
+			c.moves[player.Index] = make(map[slippi.AttackID]*MoveUsage)
+		}
+	}
+}
+
+// ProcessFrame implements Computer.
+func (c *MoveUsageComputer) ProcessFrame(frame slippi.FrameEntry) {
+	c.walker.Step(frame)
+}
+
+func (c *MoveUsageComputer) processDamage(frame slippi.FrameEntry, index uint8, post *slippi.PostFrameUpdatePayload, damage float32, hit slippi.Hit, attributed bool) {
+	if !attributed || hit.IsSelfDamage() {
+		return
+	}
+
+	attacker := frame.Players[hit.AttackerIndex].Post
+	if hit.ByFollower {
+		attacker = frame.Followers[hit.AttackerIndex].Post
+	}
+	if attacker == nil {
+		return
+	}
+
+	if _, ok := c.moves[hit.AttackerIndex]; !ok {
+		c.moves[hit.AttackerIndex] = make(map[slippi.AttackID]*MoveUsage)
+	}
+	move := attacker.LastHittingAttackID
+	usage, ok := c.moves[hit.AttackerIndex][move]
+	if !ok {
+		usage = &MoveUsage{Move: move, MoveName: move.String()}
+		c.moves[hit.AttackerIndex][move] = usage
+	}
+	usage.Hits++
+	usage.Damage += damage
+}
+
+// MoveUsage returns the move usage of each player, in order of their index.
+func (c *MoveUsageComputer) MoveUsage() []PlayerMoveUsage {
+	indices := make([]int, 0, len(c.moves))
+	for index := range c.moves {
+		indices = append(indices, int(index))
+	}
+	sort.Ints(indices)
+
+	usages := make([]PlayerMoveUsage, 0, len(indices))
+	for _, index := range indices {
+		byMove := c.moves[uint8(index)]
+		usage := PlayerMoveUsage{PlayerIndex: uint8(index), Moves: make([]MoveUsage, 0, len(byMove))}
+		for _, m := range byMove {
+			usage.Moves = append(usage.Moves, *m)
+		}
+		sort.Slice(usage.Moves, func(i, j int) bool {
+			return usage.Moves[i].Move < usage.Moves[j].Move
+		})
+		usages = append(usages, usage)
+	}
+
+	return usages
+}
+
+// ComputeMoveUsage returns the move usage of each player of the game, in order
+// of their index.
+func ComputeMoveUsage(game *slippi.SlpGame) ([]PlayerMoveUsage, error) {
+	computer := NewMoveUsageComputer()
+	if err := Run(game, computer); err != nil {
+		return nil, err
+	}
+
+	return computer.MoveUsage(), nil
+}
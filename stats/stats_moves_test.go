@@ -0,0 +1,48 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+func TestMoveUsage(t *testing.T) {
+	game := openFixture(t)
+
+	usages, err := ComputeMoveUsage(game)
+	if err != nil {
+		t.Fatal(err)
+	}
+	breakdowns, err := game.DamageBreakdowns()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// all of the damage each player took came from their opponent's moves
+	if len(usages) != 2 || usages[0].PlayerIndex != 0 || usages[1].PlayerIndex != 1 {
+		t.Fatalf("expected the move usage of both players in order, got %+v", usages)
+	}
+	for index, opponent := range map[uint8]uint8{0: 1, 1: 0} {
+		usage := usages[index]
+		if math.Abs(float64(usage.TotalDamage()-breakdowns[opponent].FromOpponents)) > 0.01 {
+			t.Errorf("expected player %d to deal %f damage, got %f", index, breakdowns[opponent].FromOpponents, usage.TotalDamage())
+		}
+		for i := 1; i < len(usage.Moves); i++ {
+			if usage.Moves[i-1].Move >= usage.Moves[i].Move {
+				t.Errorf("expected the moves of player %d in order, got %+v", index, usage.Moves)
+			}
+		}
+	}
+
+	// Fox deals the most damage with back air, and Falco with down air
+	if bair := usages[0].Move(slippi.BackAir); bair.Hits != 12 || int(bair.Damage) != 151 || int(bair.AverageDamage()) != 12 {
+		t.Errorf("expected Fox to land 12 back airs for 151%%, got %+v", bair)
+	}
+	if dair := usages[1].Move(slippi.DownAir); dair.Hits != 13 || int(dair.Damage) != 139 || dair.MoveName != "Down Air" {
+		t.Errorf("expected Falco to land 13 down airs for 139%%, got %+v", dair)
+	}
+	if fsmash := usages[1].Move(slippi.ForwardSmash); fsmash.Hits != 0 || fsmash.AverageDamage() != 0 {
+		t.Errorf("expected Falco not to land a forward smash, got %+v", fsmash)
+	}
+}
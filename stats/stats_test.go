@@ -0,0 +1,75 @@
+package stats
+
+import (
+	"encoding/json"
+	"slices"
+	"sort"
+	"testing"
+)
+
+// keysOf returns the sorted keys of the JSON object v.
+func keysOf(t *testing.T, v any) []string {
+	object, ok := v.(map[string]any)
+	if !ok {
+		t.Fatalf("expected a JSON object, got %v", v)
+	}
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func TestGetStats(t *testing.T) {
+	game := openFixture(t)
+
+	stats, err := GetStats(game)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.LastFrame != 12219 || stats.PlayableFrameCount != 12258 || !stats.GameComplete {
+		t.Errorf("expected a complete game of 12258 playable frames, got %+v", stats)
+	}
+	if len(stats.Stocks) != 7 || len(stats.Conversions) == 0 || len(stats.Combos) == 0 || len(stats.ActionCounts) != 2 || len(stats.Overall) != 2 {
+		t.Errorf("expected the stats of every computer, got %d stocks, %d conversions, %d combos, %d action counts, %d overall", len(stats.Stocks), len(stats.Conversions), len(stats.Combos), len(stats.ActionCounts), len(stats.Overall))
+	}
+
+	encoded, err := json.Marshal(stats)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	// the keys of the objects of slippi-js getStats
+	expected := map[string][]string{
+		"":            {"actionCounts", "combos", "conversions", "gameComplete", "lastFrame", "overall", "playableFrameCount", "stocks"},
+		"stocks":      {"count", "currentPercent", "deathAnimation", "endFrame", "endPercent", "playerIndex", "startFrame", "startPercent"},
+		"conversions": {"currentPercent", "didKill", "endFrame", "endPercent", "lastHitBy", "moves", "openingType", "playerIndex", "startFrame", "startPercent"},
+		"combos":      {"currentPercent", "didKill", "endFrame", "endPercent", "lastHitBy", "moves", "playerIndex", "startFrame", "startPercent"},
+		"actionCounts": {"airDodgeCount", "attackCount", "dashDanceCount", "grabCount", "groundTechCount", "lCancelCount", "ledgegrabCount", "playerIndex",
+			"rollCount", "spotDodgeCount", "throwCount", "wallTechCount", "wavedashCount", "wavelandCount"},
+		"overall": {"beneficialTradeRatio", "conversionCount", "counterHitRatio", "damagePerOpening", "digitalInputsPerMinute", "inputCounts", "inputsPerMinute",
+			"killCount", "neutralWinRatio", "openingsPerKill", "playerIndex", "successfulConversions", "totalDamage"},
+	}
+
+	for field, keys := range expected {
+		object := any(decoded)
+		if field != "" {
+			object = decoded[field].([]any)[0]
+		}
+		if actual := keysOf(t, object); !slices.Equal(actual, keys) {
+			t.Errorf("expected %q to have the keys %v, got %v", field, keys, actual)
+		}
+	}
+
+	move := decoded["conversions"].([]any)[0].(map[string]any)["moves"].([]any)[0]
+	if keys := keysOf(t, move); !slices.Equal(keys, []string{"damage", "frame", "hitCount", "moveId", "playerIndex"}) {
+		t.Errorf("expected the keys of a move of slippi-js, got %v", keys)
+	}
+}
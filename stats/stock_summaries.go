@@ -0,0 +1,195 @@
+package stats
+
+import (
+	"sort"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+// StockEnding enumerates the ways a stock can end.
+type StockEnding uint8
+
+// StockEndings
+const (
+	// StockNotLost is the ending of stocks the player still had when the
+	// game ended, or has in games in progress.
+	StockNotLost StockEnding = iota
+	StockKilled
+	StockSelfDestructed
+)
+
+var stockEndingNames = map[StockEnding]string{
+	StockNotLost:        "not-lost",
+	StockKilled:         "killed",
+	StockSelfDestructed: "self-destructed",
+}
+
+// String returns the name of the stock ending.
+func (e StockEnding) String() string {
+	return stockEndingNames[e]
+}
+
+// MarshalText encodes the stock ending as its name.
+func (e StockEnding) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// A StockSummary is what happened during a single stock of a player, for
+// reviewing a game stock by stock.
+type StockSummary struct {
+	Stock
+	// Duration is the number of frames the player had the stock for, up to
+	// the last frame processed for stocks not yet lost.
+	Duration int32       `json:"duration"`
+	Ending   StockEnding `json:"ending"`
+	// DamageDealt is the damage the player dealt to their opponents during
+	// the stock, and DamageTaken the damage they took, including from
+	// themselves.
+	DamageDealt float32 `json:"damageDealt"`
+	DamageTaken float32 `json:"damageTaken"`
+	// Openings are the conversions the player started during the stock,
+	// and OpeningsAgainst those their opponents started on them.
+	Openings        int `json:"openings"`
+	OpeningsAgainst int `json:"openingsAgainst"`
+}
+
+// stockDamage is damage a player took on a frame, from the attacker with the
+// given index, which is the player themselves for self damage and
+// unattributed damage.
+type stockDamage struct {
+	frame    int32
+	attacker uint8
+	defender uint8
+	damage   float32
+}
+
+// A StockSummaryComputer is a Computer that summarizes each stock of each
+// player from their stocks, conversions and the damage they dealt and took.
+type StockSummaryComputer struct {
+	gameInfo    *slippi.GameInfo
+	stocks      *StocksComputer
+	conversions *ConversionComputer
+	damage      []stockDamage
+	lastFrame   slippi.FrameEntry
+}
+
+// NewStockSummaryComputer returns a StockSummaryComputer that hasn't processed
+// any frames.
+func NewStockSummaryComputer() *StockSummaryComputer {
+	c := &StockSummaryComputer{
+		stocks:      NewStocksComputer(),
+		conversions: NewConversionComputer(),
+	}
+	c.Setup(nil)
+
+	return c
+}
+
+// Setup implements Computer.
+func (c *StockSummaryComputer) Setup(gameInfo *slippi.GameInfo) {
+	c.gameInfo = gameInfo
+	c.stocks.Setup(gameInfo)
+	c.conversions.Setup(gameInfo)
+	c.damage = make([]stockDamage, 0)
+	c.lastFrame = slippi.FrameEntry{}
+}
+
+// ProcessFrame implements Computer.
+func (c *StockSummaryComputer) ProcessFrame(frame slippi.FrameEntry) {
+	c.stocks.ProcessFrame(frame)
+	c.conversions.ProcessFrame(frame)
+
+	indices := make([]int, 0, len(frame.Players))
+	for index, updates := range frame.Players {
+		if updates.Post != nil {
+			indices = append(indices, int(index))
+		}
+	}
+	sort.Ints(indices)
+
+	for _, i := range indices {
+		index := uint8(i)
+		post := frame.Players[index].Post
+		prev, ok := c.lastFrame.Players[index]
+		if !ok || prev.Post == nil || slippi.IsDead(post.ActionStateID) {
+			continue
+		}
+
+		damage := post.Percent - prev.Post.Percent
+		if damage <= 0 {
+			continue
+		}
+
+		attacker := index
+		if hit, ok := slippi.AttributeHit(frame, c.lastFrame, index); ok {
+			attacker = hit.AttackerIndex
+		}
+		c.damage = append(c.damage, stockDamage{frame: frame.FrameNumber, attacker: attacker, defender: index, damage: damage})
+	}
+
+	c.lastFrame = frame
+}
+
+// Summaries returns the summaries of the stocks processed so far, in the order
+// they started, including those not yet lost.
+func (c *StockSummaryComputer) Summaries() []StockSummary {
+	stocks := c.stocks.Stocks()
+	conversions := c.conversions.Conversions()
+
+	summaries := make([]StockSummary, 0, len(stocks))
+	for _, stock := range stocks {
+		summary := StockSummary{Stock: stock}
+
+		end := c.lastFrame.FrameNumber
+		if stock.EndFrame != nil {
+			end = *stock.EndFrame
+			summary.Ending = StockKilled
+			if stock.SelfDestruct {
+				summary.Ending = StockSelfDestructed
+			}
+		}
+		summary.Duration = end - stock.StartFrame
+		during := func(frame int32) bool {
+			return frame >= stock.StartFrame && frame <= end
+		}
+
+		for _, damage := range c.damage {
+			if !during(damage.frame) {
+				continue
+			}
+
+			if damage.defender == stock.PlayerIndex {
+				summary.DamageTaken += damage.damage
+			} else if damage.attacker == stock.PlayerIndex && !isTeammate(c.gameInfo, damage.attacker, damage.defender) {
+				summary.DamageDealt += damage.damage
+			}
+		}
+
+		for _, conversion := range conversions {
+			if !during(conversion.StartFrame) {
+				continue
+			}
+
+			if conversion.AttackerIndex == stock.PlayerIndex {
+				summary.Openings++
+			} else if conversion.DefenderIndex == stock.PlayerIndex {
+				summary.OpeningsAgainst++
+			}
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	return summaries
+}
+
+// ComputeStockSummaries returns the summaries of the stocks of every player in
+// the game, in the order they started.
+func ComputeStockSummaries(game *slippi.SlpGame) ([]StockSummary, error) {
+	computer := NewStockSummaryComputer()
+	if err := Run(game, computer); err != nil {
+		return nil, err
+	}
+
+	return computer.Summaries(), nil
+}
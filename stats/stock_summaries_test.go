@@ -0,0 +1,66 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStockSummaries(t *testing.T) {
+	game := openFixture(t)
+
+	summaries, err := ComputeStockSummaries(game)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summaries) != 7 {
+		t.Fatalf("expected 7 stocks, got %d", len(summaries))
+	}
+
+	// Fox runs off the stage on his third stock, and every other stock is
+	// taken by the opponent
+	endings := map[uint8][]StockEnding{}
+	taken := map[uint8]float32{}
+	var dealt float32
+	for _, summary := range summaries {
+		endings[summary.PlayerIndex] = append(endings[summary.PlayerIndex], summary.Ending)
+		taken[summary.PlayerIndex] += summary.DamageTaken
+		dealt += summary.DamageDealt
+
+		if summary.EndFrame == nil || summary.Duration != *summary.EndFrame-summary.StartFrame {
+			t.Errorf("expected stock %+v to last until it ended", summary)
+		}
+		if summary.EndPercent != nil && summary.DamageTaken != *summary.EndPercent {
+			t.Errorf("expected the damage taken during stock %+v to be its end percent", summary)
+		}
+	}
+
+	expected := map[uint8][]StockEnding{
+		0: {StockKilled, StockSelfDestructed, StockKilled},
+		1: {StockKilled, StockKilled, StockKilled, StockKilled},
+	}
+	for index, ends := range expected {
+		if len(endings[index]) != len(ends) {
+			t.Fatalf("expected player %d to lose %d stocks, got %v", index, len(ends), endings[index])
+		}
+		for i, ending := range ends {
+			if endings[index][i] != ending {
+				t.Errorf("expected stock %d of player %d to be %s, got %s", i, index, ending, endings[index][i])
+			}
+		}
+	}
+	if name, _ := StockSelfDestructed.MarshalText(); string(name) != "self-destructed" {
+		t.Errorf("expected self destructed stocks to be encoded as self-destructed, got %s", name)
+	}
+
+	if math.Abs(float64(taken[0]-307.36)) > 0.01 || math.Abs(float64(taken[1]-508.815)) > 0.01 {
+		t.Errorf("expected the stocks of each player to take all of their damage, got %v", taken)
+	}
+	if math.Abs(float64(dealt-taken[0]-taken[1])) > 0.01 {
+		t.Errorf("expected all damage to be dealt by an opponent, got %f", dealt)
+	}
+
+	// Falco doesn't land a hit on his first stock
+	if falco := summaries[1]; falco.PlayerIndex != 1 || falco.DamageDealt != 0 || falco.Openings != 0 || falco.OpeningsAgainst != 4 {
+		t.Errorf("expected Falco to lose his first stock to 4 openings, got %+v", falco)
+	}
+}
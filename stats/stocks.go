@@ -0,0 +1,206 @@
+package stats
+
+import (
+	"sort"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+// DeathDirection enumerates the blast zones a player can be KO'd through.
+type DeathDirection uint8
+
+// DeathDirections
+const (
+	DeathDown DeathDirection = iota
+	DeathLeft
+	DeathRight
+	// DeathUp includes star and screen KOs.
+	DeathUp
+)
+
+var deathDirectionNames = map[DeathDirection]string{
+	DeathDown:  "down",
+	DeathLeft:  "left",
+	DeathRight: "right",
+	DeathUp:    "up",
+}
+
+// String returns the name slippi-js gives the death direction.
+func (d DeathDirection) String() string {
+	return deathDirectionNames[d]
+}
+
+// MarshalText encodes the death direction as its name.
+func (d DeathDirection) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// deathDirectionOf returns the blast zone a player lost a stock through in
+// the dying action state.
+func deathDirectionOf(actionStateID uint16) DeathDirection {
+	switch actionStateID {
+	case slippi.StateDeadDown:
+		return DeathDown
+	case slippi.StateDeadLeft:
+		return DeathLeft
+	case slippi.StateDeadRight:
+		return DeathRight
+	}
+
+	return DeathUp
+}
+
+// A Stock is a single stock of a player, from when they spawn with it until
+// they lose it, as computed by slippi-js.
+type Stock struct {
+	PlayerIndex uint8 `json:"playerIndex"`
+	StartFrame  int32 `json:"startFrame"`
+	// EndFrame, EndPercent, DeathAnimation and DeathDirection are nil until
+	// the stock is lost.
+	EndFrame       *int32          `json:"endFrame"`
+	StartPercent   float32         `json:"startPercent"`
+	EndPercent     *float32        `json:"endPercent"`
+	CurrentPercent float32         `json:"currentPercent"`
+	Count          uint8           `json:"count"`
+	DeathAnimation *uint16         `json:"deathAnimation"`
+	DeathDirection *DeathDirection `json:"-"`
+	// KillerIndex and KillMove are the index of the player who last hit the
+	// player before they lost the stock and the move they hit them with,
+	// unless SelfDestruct is set, in which case no opponent had.
+	// DeathDirection and these fields aren't encoded in JSON, which matches
+	// the stocks of slippi-js.
+	KillerIndex  uint8           `json:"-"`
+	KillMove     slippi.AttackID `json:"-"`
+	SelfDestruct bool            `json:"-"`
+}
+
+// A StocksComputer is a Computer that records the stocks of each player.
+type StocksComputer struct {
+	gameInfo  *slippi.GameInfo
+	stocks    []*Stock
+	current   map[uint8]*Stock
+	prev      map[uint8]*slippi.PostFrameUpdatePayload
+	prevFrame slippi.FrameEntry
+}
+
+// NewStocksComputer returns a StocksComputer without any stocks.
+func NewStocksComputer() *StocksComputer {
+	c := &StocksComputer{}
+	c.Setup(nil)
+
+	return c
+}
+
+// Setup implements Computer.
+func (c *StocksComputer) Setup(gameInfo *slippi.GameInfo) {
+	c.gameInfo = gameInfo
+	c.stocks = make([]*Stock, 0)
+	c.current = make(map[uint8]*Stock)
+	c.prev = make(map[uint8]*slippi.PostFrameUpdatePayload)
+	c.prevFrame = slippi.FrameEntry{}
+}
+
+// ProcessFrame implements Computer.
+func (c *StocksComputer) ProcessFrame(frame slippi.FrameEntry) {
+	indices := make([]int, 0, len(frame.Players))
+	for index, updates := range frame.Players {
+		if updates.Post != nil {
+			indices = append(indices, int(index))
+		}
+	}
+	sort.Ints(indices)
+
+	for _, index := range indices {
+		c.processPlayer(frame, uint8(index))
+	}
+
+	for _, index := range indices {
+		c.prev[uint8(index)] = frame.Players[uint8(index)].Post
+	}
+	c.prevFrame = frame
+}
+
+func (c *StocksComputer) processPlayer(frame slippi.FrameEntry, index uint8) {
+	post := frame.Players[index].Post
+	prev := c.prev[index]
+	stock := c.current[index]
+
+	switch {
+	case stock == nil:
+		// stocks start once the player has spawned
+		if slippi.IsDead(post.ActionStateID) {
+			return
+		}
+
+		stock = &Stock{
+			PlayerIndex: index,
+			StartFrame:  frame.FrameNumber,
+			Count:       post.StocksRemaining,
+			KillerIndex: index,
+		}
+		c.stocks = append(c.stocks, stock)
+		c.current[index] = stock
+	case prev != nil && post.StocksRemaining < prev.StocksRemaining:
+		endFrame := frame.FrameNumber
+		endPercent := prev.Percent
+		deathAnimation := post.ActionStateID
+		deathDirection := deathDirectionOf(post.ActionStateID)
+		stock.EndFrame = &endFrame
+		stock.EndPercent = &endPercent
+		stock.DeathAnimation = &deathAnimation
+		stock.DeathDirection = &deathDirection
+
+		stock.SelfDestruct = !c.isOpponent(prev.LastHitBy, index)
+		if !stock.SelfDestruct {
+			stock.KillerIndex = prev.LastHitBy
+			if killer, ok := c.prevFrame.Players[prev.LastHitBy]; ok && killer.Post != nil {
+				stock.KillMove = killer.Post.LastHittingAttackID
+			}
+		}
+
+		delete(c.current, index)
+	default:
+		stock.CurrentPercent = post.Percent
+	}
+}
+
+// isOpponent returns whether the player with the index other is another
+// player of the game than the player with the given index.
+func (c *StocksComputer) isOpponent(other uint8, index uint8) bool {
+	if other == index {
+		return false
+	} else if c.gameInfo == nil {
+		_, ok := c.prev[other]
+		return ok
+	}
+
+	for _, player := range c.gameInfo.Players {
+		if player.Index == other {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Stocks returns the stocks processed so far, in the order they started,
+// including those not yet lost.
+func (c *StocksComputer) Stocks() []Stock {
+	stocks := make([]Stock, 0, len(c.stocks))
+	for _, stock := range c.stocks {
+		stocks = append(stocks, *stock)
+	}
+
+	return stocks
+}
+
+// ComputeStocks returns the stocks of every player in the game, in the order
+// they started.
+func ComputeStocks(game *slippi.SlpGame) ([]Stock, error) {
+	computer := NewStocksComputer()
+	if err := Run(game, computer); err != nil {
+		return nil, err
+	}
+
+	return computer.Stocks(), nil
+}
@@ -0,0 +1,53 @@
+package stats
+
+import (
+	"testing"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+func TestStocks(t *testing.T) {
+	game := openFixture(t)
+
+	stocks, err := ComputeStocks(game)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		player       uint8
+		start        int32
+		end          int32
+		direction    DeathDirection
+		killer       uint8
+		selfDestruct bool
+	}{
+		{0, -123, 4781, DeathLeft, 1, false},
+		{1, -123, 846, DeathDown, 0, false},
+		{1, 906, 3816, DeathLeft, 0, false},
+		{1, 3876, 9864, DeathDown, 0, false},
+		{0, 4841, 7137, DeathDown, 0, true},
+		{0, 7197, 12190, DeathDown, 1, false},
+		{1, 9924, 12219, DeathDown, 0, false},
+	}
+
+	if len(stocks) != len(expected) {
+		t.Fatalf("expected %d stocks, got %d", len(expected), len(stocks))
+	}
+
+	for i, stock := range stocks {
+		e := expected[i]
+		if stock.EndFrame == nil || stock.EndPercent == nil || stock.DeathDirection == nil {
+			t.Fatalf("stock %d: expected every stock to have been lost, got %+v", i, stock)
+		}
+		if stock.PlayerIndex != e.player || stock.StartFrame != e.start || *stock.EndFrame != e.end {
+			t.Errorf("stock %d: expected player %d's stock from %d to %d, got %+v", i, e.player, e.start, e.end, stock)
+		}
+		if *stock.DeathDirection != e.direction || stock.KillerIndex != e.killer || stock.SelfDestruct != e.selfDestruct {
+			t.Errorf("stock %d: expected a death %s by player %d, got %+v", i, e.direction, e.killer, stock)
+		}
+		if !stock.SelfDestruct && stock.KillMove == slippi.NoAttack {
+			t.Errorf("stock %d: expected the move that took the stock", i)
+		}
+	}
+}
@@ -0,0 +1,338 @@
+package stats
+
+import (
+	"math"
+	"sort"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+// DefaultKillPercent is the percent at or above which a SurvivalDIComputer
+// evaluates hits by default.
+const DefaultKillPercent = 100
+
+// maxDIAngle is the most degrees DI can rotate a launch by, with the joystick
+// held perpendicular to it.
+const maxDIAngle = 18
+
+// minLaunchSpeed is the lowest speed a hit must launch its defender at to be a
+// threat to their stock.
+const minLaunchSpeed = 2
+
+// SurvivalDIOpts are the options of a SurvivalDIComputer.
+type SurvivalDIOpts struct {
+	// KillPercent is the percent at or above which hits are evaluated, or
+	// DefaultKillPercent if it is 0.
+	KillPercent float32
+	// CharacterKillPercents override KillPercent for the defenders playing
+	// the given characters, since light characters die earlier than heavy
+	// ones.
+	CharacterKillPercents map[slippi.CharacterID]float32
+}
+
+// A DIEvaluation compares the DI of a hit a player took at kill percent with
+// the DI that would have kept them furthest from the blast zones. Launches are
+// modeled as straight lines from where the defender leaves hitlag, whose
+// length to the blast zone they cross is the distance of the launch.
+type DIEvaluation struct {
+	PlayerIndex uint8 `json:"playerIndex"`
+	// AttackerIndex is the index of the player who landed the hit, or -1 if
+	// it couldn't be attributed.
+	AttackerIndex int8    `json:"attackerIndex"`
+	Frame         int32   `json:"frame"`
+	Percent       float32 `json:"percent"`
+	// JoystickX and JoystickY are the position of the defender's joystick
+	// when they were launched.
+	JoystickX float32 `json:"joystickX"`
+	JoystickY float32 `json:"joystickY"`
+	// LaunchAngle is the angle the defender was launched at, in degrees
+	// counterclockwise from the right, NoDIAngle the angle they would have
+	// been launched at without DI, and OptimalAngle the angle of the best DI.
+	LaunchAngle  float64 `json:"launchAngle"`
+	NoDIAngle    float64 `json:"noDiAngle"`
+	OptimalAngle float64 `json:"optimalAngle"`
+	// Distance, NoDIDistance and OptimalDistance are the distances of the
+	// launches at each angle.
+	Distance        float64 `json:"distance"`
+	NoDIDistance    float64 `json:"noDiDistance"`
+	OptimalDistance float64 `json:"optimalDistance"`
+	// Score is how close the DI was to the best DI, from 0 for the worst DI
+	// to 1 for the best.
+	Score float64 `json:"score"`
+}
+
+// ExtendedSurvival returns whether the DI of the defender launched them
+// further from the blast zones than not DIing would have.
+func (e DIEvaluation) ExtendedSurvival() bool {
+	return e.Distance > e.NoDIDistance
+}
+
+// A PlayerDIScorecard summarizes the survival DI of a player in a game.
+type PlayerDIScorecard struct {
+	PlayerIndex uint8 `json:"playerIndex"`
+	Evaluated   int   `json:"evaluated"`
+	// Extended is the ratio of evaluated hits whose DI extended the survival
+	// of the player.
+	Extended Ratio `json:"extended"`
+	// AverageScore is the average score of the evaluated hits, or 0 if there
+	// are none.
+	AverageScore float64 `json:"averageScore"`
+}
+
+type survivalDIState struct {
+	last *slippi.PostFrameUpdatePayload
+	// pending is the hit at kill percent whose hitlag the player is in, if
+	// any.
+	pending *DIEvaluation
+}
+
+// A SurvivalDIComputer is a Computer that evaluates the DI of the hits
+// players take at kill percent, on the stages whose blast zones are known.
+// Hits are taken to be increases in the percent of a player on the first frame
+// of a hitlag, and are launched on the frame after the hitlag with the
+// player's joystick on that frame.
+type SurvivalDIComputer struct {
+	opts        SurvivalDIOpts
+	geometry    slippi.StageGeometry
+	hasGeometry bool
+	characters  map[uint8]slippi.CharacterID
+	evaluations []DIEvaluation
+	states      map[uint8]*survivalDIState
+	lastFrame   slippi.FrameEntry
+}
+
+// NewSurvivalDIComputer returns a SurvivalDIComputer with the given options,
+// without any evaluations.
+func NewSurvivalDIComputer(opts SurvivalDIOpts) *SurvivalDIComputer {
+	if opts.KillPercent == 0 {
+		opts.KillPercent = DefaultKillPercent
+	}
+
+	c := &SurvivalDIComputer{opts: opts}
+	c.Setup(nil)
+
+	return c
+}
+
+// Setup implements Computer.
+func (c *SurvivalDIComputer) Setup(gameInfo *slippi.GameInfo) {
+	c.geometry, c.hasGeometry = slippi.StageGeometry{}, false
+	c.characters = make(map[uint8]slippi.CharacterID)
+	c.evaluations = make([]DIEvaluation, 0)
+	c.states = make(map[uint8]*survivalDIState)
+	c.lastFrame = slippi.FrameEntry{}
+	if gameInfo != nil {
+		c.geometry, c.hasGeometry = gameInfo.Stage.Geometry()
+		for _, player := range gameInfo.Players {
+			c.characters[player.Index] = player.CharacterID
+			c.states[player.Index] = &survivalDIState{}
+		}
+	}
+}
+
+// ProcessFrame implements Computer.
+func (c *SurvivalDIComputer) ProcessFrame(frame slippi.FrameEntry) {
+	indices := make([]int, 0, len(frame.Players))
+	for index, updates := range frame.Players {
+		if updates.Pre != nil && updates.Post != nil {
+			indices = append(indices, int(index))
+		}
+	}
+	sort.Ints(indices)
+
+	for _, index := range indices {
+		state, ok := c.states[uint8(index)]
+		if !ok {
+			state = &survivalDIState{}
+			c.states[uint8(index)] = state
+		}
+
+		c.processPlayer(frame, uint8(index), state)
+	}
+	c.lastFrame = frame
+}
+
+func (c *SurvivalDIComputer) processPlayer(frame slippi.FrameEntry, index uint8, state *survivalDIState) {
+	updates := frame.Players[index]
+	post := updates.Post
+	last := state.last
+	state.last = post
+	if last == nil || !c.hasGeometry {
+		return
+	}
+
+	hitlag := post.StateFlags().InHitlag
+	if hitlag && (!last.StateFlags().InHitlag || post.HitlagFramesRemaining > last.HitlagFramesRemaining) && post.Percent > last.Percent {
+		state.pending = nil
+		if post.Percent < c.killPercent(index) {
+			return
+		}
+
+		state.pending = &DIEvaluation{PlayerIndex: index, AttackerIndex: -1, Frame: frame.FrameNumber, Percent: post.Percent}
+		if hit, ok := slippi.AttributeHit(frame, c.lastFrame, index); ok && !hit.IsSelfDamage() {
+			state.pending.AttackerIndex = int8(hit.AttackerIndex)
+		}
+		return
+	}
+
+	if state.pending == nil || hitlag {
+		return
+	}
+
+	evaluation := state.pending
+	state.pending = nil
+	if math.Hypot(float64(post.AttackBasedXSpeed), float64(post.AttackBasedYSpeed)) < minLaunchSpeed {
+		return
+	}
+
+	evaluation.JoystickX, evaluation.JoystickY = updates.Pre.JoystickX, updates.Pre.JoystickY
+	c.evaluate(evaluation, *post)
+	c.evaluations = append(c.evaluations, *evaluation)
+}
+
+// killPercent returns the percent at or above which the hits of the player
+// with the given index are evaluated.
+func (c *SurvivalDIComputer) killPercent(index uint8) float32 {
+	if character, ok := c.characters[index]; ok {
+		if percent, ok := c.opts.CharacterKillPercents[character]; ok {
+			return percent
+		}
+	}
+
+	return c.opts.KillPercent
+}
+
+// evaluate fills in the angles, distances and score of evaluation, whose
+// defender was launched on the frame of post.
+func (c *SurvivalDIComputer) evaluate(evaluation *DIEvaluation, post slippi.PostFrameUpdatePayload) {
+	x, y := float64(post.XPosition), float64(post.YPosition)
+	launchAngle := math.Atan2(float64(post.AttackBasedYSpeed), float64(post.AttackBasedXSpeed))
+
+	// the launch angle without DI is found from the one with it, which DI
+	// rotates by only a little, by fixed-point iteration
+	stickX, stickY := float64(evaluation.JoystickX), float64(evaluation.JoystickY)
+	noDIAngle := launchAngle
+	for i := 0; i < 8; i++ {
+		noDIAngle = launchAngle - diRotation(noDIAngle, stickX, stickY)
+	}
+
+	evaluation.LaunchAngle = degrees(launchAngle)
+	evaluation.NoDIAngle = degrees(noDIAngle)
+	evaluation.Distance = c.launchDistance(x, y, launchAngle)
+	evaluation.NoDIDistance = c.launchDistance(x, y, noDIAngle)
+
+	// the best and worst DI are found among rotations a degree apart
+	worst := math.MaxFloat64
+	evaluation.OptimalDistance = -1
+	for rotation := -maxDIAngle; rotation <= maxDIAngle; rotation++ {
+		angle := noDIAngle + radians(float64(rotation))
+		distance := c.launchDistance(x, y, angle)
+		if distance > evaluation.OptimalDistance {
+			evaluation.OptimalDistance = distance
+			evaluation.OptimalAngle = degrees(angle)
+		}
+		worst = min(worst, distance)
+	}
+	evaluation.OptimalDistance = max(evaluation.OptimalDistance, evaluation.Distance)
+
+	evaluation.Score = 1
+	if evaluation.OptimalDistance > worst {
+		evaluation.Score = min(max((evaluation.Distance-worst)/(evaluation.OptimalDistance-worst), 0), 1)
+	}
+}
+
+// diRotation returns the radians the joystick at the given position rotates a
+// launch at angle by, which grows with the square of its deflection
+// perpendicular to the launch.
+func diRotation(angle float64, stickX float64, stickY float64) float64 {
+	if math.Abs(stickX) < stickDeadzone {
+		stickX = 0
+	}
+	if math.Abs(stickY) < stickDeadzone {
+		stickY = 0
+	}
+
+	perpendicular := math.Cos(angle)*stickY - math.Sin(angle)*stickX
+	return math.Copysign(perpendicular*perpendicular, perpendicular) * radians(maxDIAngle)
+}
+
+// launchDistance returns the distance from the given position to the blast
+// zone crossed by a straight launch from it at angle.
+func (c *SurvivalDIComputer) launchDistance(x float64, y float64, angle float64) float64 {
+	dx, dy := math.Cos(angle), math.Sin(angle)
+	distance := math.MaxFloat64
+	if dx > 0 {
+		distance = min(distance, (float64(c.geometry.BlastZoneRight)-x)/dx)
+	} else if dx < 0 {
+		distance = min(distance, (float64(c.geometry.BlastZoneLeft)-x)/dx)
+	}
+	if dy > 0 {
+		distance = min(distance, (float64(c.geometry.BlastZoneTop)-y)/dy)
+	} else if dy < 0 {
+		distance = min(distance, (float64(c.geometry.BlastZoneBottom)-y)/dy)
+	}
+
+	return max(distance, 0)
+}
+
+// Evaluations returns the evaluations of the hits processed so far, in order.
+func (c *SurvivalDIComputer) Evaluations() []DIEvaluation {
+	return append(make([]DIEvaluation, 0, len(c.evaluations)), c.evaluations...)
+}
+
+// Scorecards returns the survival DI scorecard of each player, in order of
+// their index.
+func (c *SurvivalDIComputer) Scorecards() []PlayerDIScorecard {
+	indices := make([]int, 0, len(c.states))
+	for index := range c.states {
+		indices = append(indices, int(index))
+	}
+	sort.Ints(indices)
+
+	scorecards := make(map[uint8]*PlayerDIScorecard)
+	extended := make(map[uint8]int)
+	scores := make(map[uint8]float64)
+	for _, index := range indices {
+		scorecards[uint8(index)] = &PlayerDIScorecard{PlayerIndex: uint8(index)}
+	}
+	for _, evaluation := range c.evaluations {
+		scorecards[evaluation.PlayerIndex].Evaluated++
+		scores[evaluation.PlayerIndex] += evaluation.Score
+		if evaluation.ExtendedSurvival() {
+			extended[evaluation.PlayerIndex]++
+		}
+	}
+
+	players := make([]PlayerDIScorecard, 0, len(indices))
+	for _, index := range indices {
+		scorecard := scorecards[uint8(index)]
+		scorecard.Extended = newRatio(float64(extended[uint8(index)]), float64(scorecard.Evaluated))
+		if scorecard.Evaluated > 0 {
+			scorecard.AverageScore = scores[uint8(index)] / float64(scorecard.Evaluated)
+		}
+		players = append(players, *scorecard)
+	}
+
+	return players
+}
+
+// ComputeSurvivalDIScorecards returns the survival DI scorecard of each player
+// of the game, in order of their index.
+func ComputeSurvivalDIScorecards(game *slippi.SlpGame, opts SurvivalDIOpts) ([]PlayerDIScorecard, error) {
+	computer := NewSurvivalDIComputer(opts)
+	if err := Run(game, computer); err != nil {
+		return nil, err
+	}
+
+	return computer.Scorecards(), nil
+}
+
+// degrees returns the angle in degrees, between -180 and 180.
+func degrees(radians float64) float64 {
+	return math.Remainder(radians*180/math.Pi, 360)
+}
+
+// radians returns the angle in radians.
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
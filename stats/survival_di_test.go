@@ -0,0 +1,61 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+func TestSurvivalDI(t *testing.T) {
+	game := openFixture(t)
+
+	computer := NewSurvivalDIComputer(SurvivalDIOpts{})
+	if err := Run(game, computer); err != nil {
+		t.Fatal(err)
+	}
+
+	evaluations := computer.Evaluations()
+	if len(evaluations) != 19 {
+		t.Fatalf("expected 19 hits at kill percent, got %d", len(evaluations))
+	}
+	for i, evaluation := range evaluations {
+		if evaluation.Percent < DefaultKillPercent || evaluation.Score < 0 || evaluation.Score > 1 || evaluation.OptimalDistance < evaluation.Distance {
+			t.Errorf("evaluation %d: expected a hit at kill percent with DI no better than the best, got %+v", i, evaluation)
+		}
+		if math.Abs(evaluation.LaunchAngle) > 180 || math.Abs(evaluation.NoDIAngle) > 180 || math.Abs(evaluation.OptimalAngle) > 180 {
+			t.Errorf("evaluation %d: expected angles between -180 and 180, got %+v", i, evaluation)
+		}
+	}
+
+	// Fox DIs Falco's hit at 109% well
+	fox := evaluations[4]
+	if fox.PlayerIndex != 0 || fox.AttackerIndex != 1 || fox.Frame != 3767 || !fox.ExtendedSurvival() || fox.Score < 0.75 {
+		t.Errorf("expected Fox to DI the hit on frame 3767 well, got %+v", fox)
+	}
+	// Falco doesn't DI the hit that takes his last stock
+	falco := evaluations[17]
+	if falco.PlayerIndex != 1 || falco.Frame != 9734 || falco.JoystickX != 0 || falco.JoystickY != 0 || falco.LaunchAngle != falco.NoDIAngle || falco.ExtendedSurvival() {
+		t.Errorf("expected Falco not to DI the hit on frame 9734, got %+v", falco)
+	}
+
+	scorecards := computer.Scorecards()
+	if len(scorecards) != 2 {
+		t.Fatalf("expected the scorecards of both players, got %+v", scorecards)
+	}
+	if scorecards[0].Evaluated != 4 || scorecards[0].Extended.Count != 4 || scorecards[1].Evaluated != 15 || scorecards[1].Extended.Count != 1 {
+		t.Errorf("expected Fox to extend his survival on 4 of 4 hits and Falco on 1 of 15, got %+v", scorecards)
+	}
+	if scorecards[0].AverageScore <= scorecards[1].AverageScore {
+		t.Errorf("expected Fox to DI better than Falco, got %+v", scorecards)
+	}
+
+	// light characters can be evaluated from an earlier percent
+	scorecards, err := ComputeSurvivalDIScorecards(game, SurvivalDIOpts{CharacterKillPercents: map[slippi.CharacterID]float32{slippi.Fox: 70}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scorecards[0].Evaluated <= 4 || scorecards[1].Evaluated != 15 {
+		t.Errorf("expected more of Fox's hits to be evaluated, got %+v", scorecards)
+	}
+}
@@ -0,0 +1,390 @@
+package stats
+
+import (
+	"sort"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+// nearLedgeDistance is the furthest from a ledge a player can be knocked down
+// for the knockdown to be near it.
+const nearLedgeDistance = 25
+
+// TechOption enumerates the options a player can choose when knocked down
+// onto the ground.
+type TechOption uint8
+
+// TechOptions
+const (
+	TechInPlace TechOption = iota
+	// TechIn and TechAway are tech rolls towards and away from the closest
+	// opponent.
+	TechIn
+	TechAway
+	MissedTech
+)
+
+var techOptionNames = map[TechOption]string{
+	TechInPlace: "in-place",
+	TechIn:      "in",
+	TechAway:    "away",
+	MissedTech:  "missed",
+}
+
+// String returns the name of the tech option.
+func (o TechOption) String() string {
+	return techOptionNames[o]
+}
+
+// MarshalText encodes the tech option as its name.
+func (o TechOption) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+// GetupOption enumerates the options a player can choose to get up with after
+// missing a tech.
+type GetupOption uint8
+
+// GetupOptions
+const (
+	// NoGetup is the getup option of players who teched, or who were hit,
+	// grabbed or fell off the stage before getting up.
+	NoGetup GetupOption = iota
+	StandGetup
+	AttackGetup
+	// RollInGetup and RollAwayGetup are rolls towards and away from the
+	// closest opponent.
+	RollInGetup
+	RollAwayGetup
+)
+
+var getupOptionNames = map[GetupOption]string{
+	NoGetup:       "none",
+	StandGetup:    "stand",
+	AttackGetup:   "attack",
+	RollInGetup:   "roll-in",
+	RollAwayGetup: "roll-away",
+}
+
+// String returns the name of the getup option.
+func (o GetupOption) String() string {
+	return getupOptionNames[o]
+}
+
+// MarshalText encodes the getup option as its name.
+func (o GetupOption) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+// A Knockdown is a situation in which a player was knocked down onto the
+// ground, with the options they chose and where it happened.
+type Knockdown struct {
+	PlayerIndex uint8      `json:"playerIndex"`
+	Frame       int32      `json:"frame"`
+	Tech        TechOption `json:"tech"`
+	// Getup is the option the player got up with after missing the tech.
+	Getup GetupOption `json:"getup"`
+	// Punished is whether the player was hit or grabbed before they could
+	// act again.
+	Punished bool `json:"punished"`
+	// X and Y are the position of the player when they were knocked down,
+	// onto Surface, and NearLedge whether it was within nearLedgeDistance of
+	// a ledge of the main stage, which is only known on stages whose
+	// geometry is known.
+	X         float32              `json:"x"`
+	Y         float32              `json:"y"`
+	Surface   slippi.GroundSurface `json:"surface"`
+	NearLedge bool                 `json:"nearLedge"`
+	// OpponentIndex is the index of the closest opponent, or -1 if there is
+	// none, who was OpponentDistance away.
+	OpponentIndex    int8    `json:"opponentIndex"`
+	OpponentDistance float64 `json:"opponentDistance"`
+}
+
+// TechOptionCounts are the number of times a player chose each tech option.
+type TechOptionCounts struct {
+	InPlace int `json:"inPlace"`
+	In      int `json:"in"`
+	Away    int `json:"away"`
+	Missed  int `json:"missed"`
+}
+
+// add counts the option.
+func (c *TechOptionCounts) add(option TechOption) {
+	switch option {
+	case TechInPlace:
+		c.InPlace++
+	case TechIn:
+		c.In++
+	case TechAway:
+		c.Away++
+	case MissedTech:
+		c.Missed++
+	}
+}
+
+// GetupOptionCounts are the number of times a player chose each getup option.
+type GetupOptionCounts struct {
+	Stand    int `json:"stand"`
+	Attack   int `json:"attack"`
+	RollIn   int `json:"rollIn"`
+	RollAway int `json:"rollAway"`
+}
+
+// add counts the option.
+func (c *GetupOptionCounts) add(option GetupOption) {
+	switch option {
+	case StandGetup:
+		c.Stand++
+	case AttackGetup:
+		c.Attack++
+	case RollInGetup:
+		c.RollIn++
+	case RollAwayGetup:
+		c.RollAway++
+	}
+}
+
+// A PlayerTechTendencies reports how a player chose to tech and get up when
+// knocked down in a game, for scouting them.
+type PlayerTechTendencies struct {
+	PlayerIndex uint8             `json:"playerIndex"`
+	Knockdowns  int               `json:"knockdowns"`
+	Tech        TechOptionCounts  `json:"tech"`
+	Getup       GetupOptionCounts `json:"getup"`
+	// NearLedge and Center split Tech by whether the player was knocked down
+	// near a ledge.
+	NearLedge TechOptionCounts `json:"nearLedge"`
+	Center    TechOptionCounts `json:"center"`
+	// PunishRate is the ratio of knockdowns the player was punished in.
+	PunishRate Ratio `json:"punishRate"`
+}
+
+// A TechComputer is a Computer that detects the knockdowns of each player
+// and the tech and getup options they chose. Rolls in and away are relative to
+// the closest opponent when the player rolls, who they roll towards with a
+// forward roll if they face them.
+type TechComputer struct {
+	gameInfo    *slippi.GameInfo
+	geometry    slippi.StageGeometry
+	hasGeometry bool
+	knockdowns  []*Knockdown
+	// active are the knockdowns the players haven't acted out of, by the
+	// index of the player.
+	active map[uint8]*Knockdown
+	prev   map[uint8]*slippi.PostFrameUpdatePayload
+}
+
+// NewTechComputer returns a TechComputer without any knockdowns.
+func NewTechComputer() *TechComputer {
+	c := &TechComputer{}
+	c.Setup(nil)
+
+	return c
+}
+
+// Setup implements Computer.
+func (c *TechComputer) Setup(gameInfo *slippi.GameInfo) {
+	c.gameInfo = gameInfo
+	c.geometry, c.hasGeometry = slippi.StageGeometry{}, false
+	if gameInfo != nil {
+		c.geometry, c.hasGeometry = gameInfo.Stage.Geometry()
+	}
+	c.knockdowns = make([]*Knockdown, 0)
+	c.active = make(map[uint8]*Knockdown)
+	c.prev = make(map[uint8]*slippi.PostFrameUpdatePayload)
+}
+
+// ProcessFrame implements Computer.
+func (c *TechComputer) ProcessFrame(frame slippi.FrameEntry) {
+	indices := make([]int, 0, len(frame.Players))
+	for index, updates := range frame.Players {
+		if updates.Post != nil {
+			indices = append(indices, int(index))
+		}
+	}
+	sort.Ints(indices)
+
+	for _, index := range indices {
+		post := frame.Players[uint8(index)].Post
+		prev := c.prev[uint8(index)]
+		c.prev[uint8(index)] = post
+		if prev != nil && post.ActionStateID != prev.ActionStateID {
+			c.processPlayer(frame, *post)
+		}
+	}
+}
+
+// processPlayer processes the change of the action state of the player with
+// the given post-frame update on frame.
+func (c *TechComputer) processPlayer(frame slippi.FrameEntry, post slippi.PostFrameUpdatePayload) {
+	current := post.ActionStateID
+	knockdown, ok := c.active[post.PlayerIndex]
+	if !ok {
+		if tech, ok := c.techOption(frame, post); ok {
+			c.start(frame, post, tech)
+		}
+		return
+	}
+
+	switch {
+	case slippi.IsDown(current) && knockdown.Tech == MissedTech && knockdown.Getup == NoGetup:
+		knockdown.Getup = c.getupOption(frame, post)
+	case slippi.IsDown(current) || knockdown.Tech != MissedTech && slippi.IsTeching(current):
+	default:
+		knockdown.Punished = slippi.IsDamaged(current) || slippi.IsGrabbed(current) || slippi.IsCommandGrabbed(current)
+		delete(c.active, post.PlayerIndex)
+
+		// getting knocked down again starts another knockdown
+		if tech, ok := c.techOption(frame, post); ok {
+			c.start(frame, post, tech)
+		}
+	}
+}
+
+// start starts a knockdown of the player with the given post-frame update on
+// frame.
+func (c *TechComputer) start(frame slippi.FrameEntry, post slippi.PostFrameUpdatePayload, tech TechOption) {
+	knockdown := &Knockdown{
+		PlayerIndex:   post.PlayerIndex,
+		Frame:         frame.FrameNumber,
+		Tech:          tech,
+		X:             post.XPosition,
+		Y:             post.YPosition,
+		OpponentIndex: -1,
+	}
+	if c.gameInfo != nil {
+		knockdown.Surface = post.GroundSurface(c.gameInfo.Stage)
+	}
+	if c.hasGeometry {
+		distance := c.geometry.LedgeX - post.XPosition
+		if post.XPosition < 0 {
+			distance = c.geometry.LedgeX + post.XPosition
+		}
+		knockdown.NearLedge = !knockdown.Surface.IsPlatform() && distance <= nearLedgeDistance
+	}
+	if opponent, ok := closestOpponent(c.gameInfo, frame, post); ok {
+		knockdown.OpponentIndex = int8(opponent.PlayerIndex)
+		knockdown.OpponentDistance = distanceBetween(post, opponent)
+	}
+
+	c.knockdowns = append(c.knockdowns, knockdown)
+	c.active[post.PlayerIndex] = knockdown
+}
+
+// techOption returns the tech option of the action state of the player with
+// the given post-frame update, and whether it is the start of a knockdown.
+func (c *TechComputer) techOption(frame slippi.FrameEntry, post slippi.PostFrameUpdatePayload) (TechOption, bool) {
+	switch post.ActionStateID {
+	case slippi.StateNeutralTech:
+		return TechInPlace, true
+	case slippi.StateForwardTech, slippi.StateBackwardTech:
+		if c.towardsOpponent(frame, post, post.ActionStateID == slippi.StateForwardTech) {
+			return TechIn, true
+		}
+		return TechAway, true
+	case slippi.StateTechMissUp, slippi.StateTechMissDown:
+		return MissedTech, true
+	}
+
+	return 0, false
+}
+
+// getupOption returns the getup option of the down state of the player with
+// the given post-frame update, which is NoGetup for states that aren't getups.
+func (c *TechComputer) getupOption(frame slippi.FrameEntry, post slippi.PostFrameUpdatePayload) GetupOption {
+	switch post.ActionStateID {
+	case slippi.StateDownStandUp, slippi.StateDownStandDown:
+		return StandGetup
+	case slippi.StateDownAttackUp, slippi.StateDownAttackDown:
+		return AttackGetup
+	case slippi.StateDownForwardUp, slippi.StateDownForwardDown, slippi.StateDownBackUp, slippi.StateDownBackDown:
+		forward := post.ActionStateID == slippi.StateDownForwardUp || post.ActionStateID == slippi.StateDownForwardDown
+		if c.towardsOpponent(frame, post, forward) {
+			return RollInGetup
+		}
+		return RollAwayGetup
+	}
+
+	return NoGetup
+}
+
+// towardsOpponent returns whether a roll of the player with the given
+// post-frame update is towards their closest opponent, given whether it is a
+// roll forward, in the direction they face. Players without an opponent are
+// taken to roll towards one when rolling forward.
+func (c *TechComputer) towardsOpponent(frame slippi.FrameEntry, post slippi.PostFrameUpdatePayload, forward bool) bool {
+	opponent, ok := closestOpponent(c.gameInfo, frame, post)
+	if !ok {
+		return forward
+	}
+
+	return (post.FacingDirection == directionTo(post, opponent)) == forward
+}
+
+// Knockdowns returns the knockdowns processed so far, in order.
+func (c *TechComputer) Knockdowns() []Knockdown {
+	knockdowns := make([]Knockdown, 0, len(c.knockdowns))
+	for _, knockdown := range c.knockdowns {
+		knockdowns = append(knockdowns, *knockdown)
+	}
+
+	return knockdowns
+}
+
+// Tendencies returns the tech tendencies of each player, in order of their
+// index.
+func (c *TechComputer) Tendencies() []PlayerTechTendencies {
+	tendencies := make(map[uint8]*PlayerTechTendencies)
+	punished := make(map[uint8]int)
+	if c.gameInfo != nil {
+		for _, player := range c.gameInfo.Players {
+			tendencies[player.Index] = &PlayerTechTendencies{PlayerIndex: player.Index}
+		}
+	}
+	for _, knockdown := range c.knockdowns {
+		player, ok := tendencies[knockdown.PlayerIndex]
+		if !ok {
+			player = &PlayerTechTendencies{PlayerIndex: knockdown.PlayerIndex}
+			tendencies[knockdown.PlayerIndex] = player
+		}
+
+		player.Knockdowns++
+		player.Tech.add(knockdown.Tech)
+		player.Getup.add(knockdown.Getup)
+		if knockdown.NearLedge {
+			player.NearLedge.add(knockdown.Tech)
+		} else {
+			player.Center.add(knockdown.Tech)
+		}
+		if knockdown.Punished {
+			punished[knockdown.PlayerIndex]++
+		}
+	}
+
+	indices := make([]int, 0, len(tendencies))
+	for index := range tendencies {
+		indices = append(indices, int(index))
+	}
+	sort.Ints(indices)
+
+	players := make([]PlayerTechTendencies, 0, len(indices))
+	for _, index := range indices {
+		player := tendencies[uint8(index)]
+		player.PunishRate = newRatio(float64(punished[uint8(index)]), float64(player.Knockdowns))
+		players = append(players, *player)
+	}
+
+	return players
+}
+
+// ComputeTechTendencies returns the tech tendencies of each player of the
+// game, in order of their index.
+func ComputeTechTendencies(game *slippi.SlpGame) ([]PlayerTechTendencies, error) {
+	computer := NewTechComputer()
+	if err := Run(game, computer); err != nil {
+		return nil, err
+	}
+
+	return computer.Tendencies(), nil
+}
@@ -0,0 +1,74 @@
+package stats
+
+import (
+	"testing"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+func TestTechOptions(t *testing.T) {
+	game := openFixture(t)
+
+	computer := NewTechComputer()
+	if err := Run(game, computer); err != nil {
+		t.Fatal(err)
+	}
+
+	knockdowns := computer.Knockdowns()
+	if len(knockdowns) != 43 {
+		t.Fatalf("expected 43 knockdowns, got %d", len(knockdowns))
+	}
+
+	expected := map[int32]struct {
+		player   uint8
+		tech     TechOption
+		getup    GetupOption
+		punished bool
+		surface  slippi.GroundSurface
+	}{
+		// Falco misses a tech and gets up with an attack
+		407: {1, MissedTech, AttackGetup, false, slippi.MainStage},
+		// Falco lies on the top platform before standing up
+		1455: {1, MissedTech, StandGetup, false, slippi.TopPlatform},
+		2237: {1, TechIn, NoGetup, false, slippi.RightPlatform},
+		3776: {0, TechAway, NoGetup, false, slippi.MainStage},
+		// Fox techs in place and is hit out of it
+		6618: {0, TechInPlace, NoGetup, true, slippi.MainStage},
+		// Falco rolls in and is hit out of the roll
+		10641: {1, MissedTech, RollInGetup, true, slippi.LeftPlatform},
+		11453: {0, MissedTech, RollAwayGetup, false, slippi.RightEdge},
+	}
+	found := 0
+	for _, knockdown := range knockdowns {
+		e, ok := expected[knockdown.Frame]
+		if !ok {
+			continue
+		}
+
+		found++
+		if knockdown.PlayerIndex != e.player || knockdown.Tech != e.tech || knockdown.Getup != e.getup || knockdown.Punished != e.punished || knockdown.Surface != e.surface {
+			t.Errorf("frame %d: expected %+v, got %+v", knockdown.Frame, e, knockdown)
+		}
+		if knockdown.OpponentIndex != int8(1-e.player) || knockdown.OpponentDistance <= 0 {
+			t.Errorf("frame %d: expected the distance to the opponent, got %+v", knockdown.Frame, knockdown)
+		}
+	}
+	if found != len(expected) {
+		t.Errorf("expected knockdowns on frames %v, found %d of them", expected, found)
+	}
+
+	tendencies := computer.Tendencies()
+	if len(tendencies) != 2 {
+		t.Fatalf("expected the tendencies of both players, got %+v", tendencies)
+	}
+	fox, falco := tendencies[0], tendencies[1]
+	if fox.Knockdowns != 16 || fox.Tech != (TechOptionCounts{InPlace: 5, In: 3, Away: 1, Missed: 7}) || fox.Getup != (GetupOptionCounts{RollAway: 4}) {
+		t.Errorf("expected Fox's tendencies, got %+v", fox)
+	}
+	if falco.Knockdowns != 27 || falco.Tech != (TechOptionCounts{InPlace: 6, In: 2, Away: 1, Missed: 18}) || falco.Getup != (GetupOptionCounts{Stand: 7, Attack: 2, RollIn: 1}) {
+		t.Errorf("expected Falco's tendencies, got %+v", falco)
+	}
+	if falco.NearLedge != (TechOptionCounts{InPlace: 1, Missed: 6}) || falco.Center.Missed != 12 || falco.PunishRate.Count != 1 {
+		t.Errorf("expected Falco's tendencies by position, got %+v", falco)
+	}
+}
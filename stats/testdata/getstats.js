@@ -0,0 +1,10 @@
+// getstats.js prints the stats slippi-js getStats computes for a replay, which
+// TestSlippiJSStats compares those of GetStats to. Update the stats of the
+// fixture replay with:
+//
+//	npm install @slippi/slippi-js
+//	node getstats.js ../../game.slp > game.slp.json
+const { SlippiGame } = require("@slippi/slippi-js");
+
+const game = new SlippiGame(process.argv[2]);
+process.stdout.write(JSON.stringify(game.getStats(), null, 2) + "\n");
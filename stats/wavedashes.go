@@ -0,0 +1,256 @@
+package stats
+
+import (
+	"math"
+	"sort"
+
+	slippi "github.com/ZadenRB/go-slippi"
+)
+
+// A Wavedash is a jump into an air dodge that lands within wavedashWindow
+// frames of the end of the jumpsquat, with how well it was performed.
+type Wavedash struct {
+	PlayerIndex uint8 `json:"playerIndex"`
+	// JumpFrame is the first frame of the jumpsquat, AirDodgeFrame the first
+	// frame of the air dodge, and LandingFrame the first frame of the
+	// landing.
+	JumpFrame     int32 `json:"jumpFrame"`
+	AirDodgeFrame int32 `json:"airDodgeFrame"`
+	LandingFrame  int32 `json:"landingFrame"`
+	// Delay is the number of frames from the end of the jumpsquat to the
+	// air dodge.
+	Delay int `json:"delay"`
+	// Angle is the angle of the joystick below the horizontal the player air
+	// dodged at, in degrees, from 0 for flat wavedashes to 90 for straight
+	// down.
+	Angle float64 `json:"angle"`
+	// Distance is how far the player slid from landing to the end of the
+	// landing lag.
+	Distance float32 `json:"distance"`
+}
+
+// PlayerWavedashStats are the wavedashes of a player in a game.
+type PlayerWavedashStats struct {
+	PlayerIndex uint8 `json:"playerIndex"`
+	Count       int   `json:"count"`
+	// AverageDelay, AverageAngle and AverageDistance are 0 if the player has
+	// no wavedashes.
+	AverageDelay    float64 `json:"averageDelay"`
+	AverageAngle    float64 `json:"averageAngle"`
+	AverageDistance float64 `json:"averageDistance"`
+	// Delays are the number of wavedashes with each delay, and Angles the
+	// number of wavedashes at each angle, rounded to the degree.
+	Delays map[int]int `json:"delays"`
+	Angles map[int]int `json:"angles"`
+}
+
+// wavedashPhase enumerates the steps of a wavedash a player is at.
+type wavedashPhase uint8
+
+// wavedashPhases
+const (
+	wavedashNone wavedashPhase = iota
+	wavedashJumpsquat
+	wavedashJumped
+	wavedashAirDodge
+	wavedashSliding
+)
+
+type wavedashState struct {
+	phase    wavedashPhase
+	wavedash Wavedash
+	// liftOffFrame is the first frame after the jumpsquat, and landingX the
+	// position the player landed at.
+	liftOffFrame int32
+	landingX     float32
+}
+
+// A WavedashComputer is a Computer that detects the wavedashes of each
+// player and measures their timing, angle and distance.
+type WavedashComputer struct {
+	wavedashes []Wavedash
+	states     map[uint8]*wavedashState
+	players    map[uint8]bool
+}
+
+// NewWavedashComputer returns a WavedashComputer without any wavedashes.
+func NewWavedashComputer() *WavedashComputer {
+	c := &WavedashComputer{}
+	c.Setup(nil)
+
+	return c
+}
+
+// Setup implements Computer.
+func (c *WavedashComputer) Setup(gameInfo *slippi.GameInfo) {
+	c.wavedashes = make([]Wavedash, 0)
+	c.states = make(map[uint8]*wavedashState)
+	c.players = make(map[uint8]bool)
+	if gameInfo != nil {
+		for _, player := range gameInfo.Players {
+			c.players[player.Index] = true
+		}
+	}
+}
+
+// ProcessFrame implements Computer.
+func (c *WavedashComputer) ProcessFrame(frame slippi.FrameEntry) {
+	indices := make([]int, 0, len(frame.Players))
+	for index, updates := range frame.Players {
+		if updates.Post != nil {
+			indices = append(indices, int(index))
+		}
+	}
+	sort.Ints(indices)
+
+	for _, index := range indices {
+		state, ok := c.states[uint8(index)]
+		if !ok {
+			state = &wavedashState{}
+			c.states[uint8(index)] = state
+			c.players[uint8(index)] = true
+		}
+		updates := frame.Players[uint8(index)]
+		c.processPlayer(frame.FrameNumber, state, updates.Pre, *updates.Post)
+	}
+}
+
+func (c *WavedashComputer) processPlayer(frameNumber int32, state *wavedashState, pre *slippi.PreFrameUpdatePayload, post slippi.PostFrameUpdatePayload) {
+	actionState := post.ActionStateID
+	wavedash := &state.wavedash
+
+	// the slide ends with the landing lag, which can be cut short by
+	// another jumpsquat
+	if state.phase == wavedashSliding && actionState != slippi.StateLandingFallSpecial {
+		c.wavedashes = append(c.wavedashes, *wavedash)
+		state.phase = wavedashNone
+	}
+
+	// every jumpsquat starts a possible wavedash
+	if actionState == slippi.StateKneeBend {
+		if state.phase != wavedashJumpsquat {
+			state.phase = wavedashJumpsquat
+			state.wavedash = Wavedash{PlayerIndex: post.PlayerIndex, JumpFrame: frameNumber}
+		}
+		return
+	}
+
+	switch state.phase {
+	case wavedashJumpsquat, wavedashJumped:
+		if state.phase == wavedashJumpsquat {
+			state.phase = wavedashJumped
+			state.liftOffFrame = frameNumber
+		}
+
+		switch {
+		case frameNumber-state.liftOffFrame >= wavedashWindow:
+			state.phase = wavedashNone
+		case actionState == slippi.StateAirDodge:
+			state.airDodge(frameNumber, pre)
+		case actionState == slippi.StateLandingFallSpecial:
+			// air dodges into the ground land without entering the air
+			// dodge state
+			state.airDodge(frameNumber, pre)
+			state.land(frameNumber, post)
+		case !post.Airborne:
+			state.phase = wavedashNone
+		}
+	case wavedashAirDodge:
+		switch {
+		case frameNumber-state.liftOffFrame >= wavedashWindow:
+			state.phase = wavedashNone
+		case actionState == slippi.StateLandingFallSpecial:
+			state.land(frameNumber, post)
+		case actionState != slippi.StateAirDodge:
+			state.phase = wavedashNone
+		}
+	case wavedashSliding:
+		wavedash.Distance = float32(math.Abs(float64(post.XPosition - state.landingX)))
+	}
+}
+
+// airDodge records the air dodge of the wavedash on the frame with the given
+// number, at the angle of the joystick in pre.
+func (s *wavedashState) airDodge(frameNumber int32, pre *slippi.PreFrameUpdatePayload) {
+	s.phase = wavedashAirDodge
+	s.wavedash.AirDodgeFrame = frameNumber
+	s.wavedash.Delay = int(frameNumber - s.liftOffFrame)
+	if pre != nil {
+		s.wavedash.Angle = math.Atan2(float64(-pre.JoystickY), math.Abs(float64(pre.JoystickX))) * 180 / math.Pi
+	}
+}
+
+// land records the landing of the wavedash on the frame with the given number.
+func (s *wavedashState) land(frameNumber int32, post slippi.PostFrameUpdatePayload) {
+	s.phase = wavedashSliding
+	s.wavedash.LandingFrame = frameNumber
+	s.landingX = post.XPosition
+}
+
+// Wavedashes returns the wavedashes processed so far, in the order they
+// landed, including those still sliding.
+func (c *WavedashComputer) Wavedashes() []Wavedash {
+	wavedashes := append(make([]Wavedash, 0, len(c.wavedashes)), c.wavedashes...)
+	for _, state := range c.states {
+		if state.phase == wavedashSliding {
+			wavedashes = append(wavedashes, state.wavedash)
+		}
+	}
+	sort.SliceStable(wavedashes, func(i, j int) bool {
+		return wavedashes[i].LandingFrame < wavedashes[j].LandingFrame
+	})
+
+	return wavedashes
+}
+
+// PlayerStats returns the wavedash stats of each player, in order of their
+// index.
+func (c *WavedashComputer) PlayerStats() []PlayerWavedashStats {
+	indices := make([]int, 0, len(c.players))
+	for index := range c.players {
+		indices = append(indices, int(index))
+	}
+	sort.Ints(indices)
+
+	stats := make(map[uint8]*PlayerWavedashStats)
+	for _, index := range indices {
+		stats[uint8(index)] = &PlayerWavedashStats{
+			PlayerIndex: uint8(index),
+			Delays:      make(map[int]int),
+			Angles:      make(map[int]int),
+		}
+	}
+	for _, wavedash := range c.Wavedashes() {
+		s := stats[wavedash.PlayerIndex]
+		s.Count++
+		s.AverageDelay += float64(wavedash.Delay)
+		s.AverageAngle += wavedash.Angle
+		s.AverageDistance += float64(wavedash.Distance)
+		s.Delays[wavedash.Delay]++
+		s.Angles[int(math.Round(wavedash.Angle))]++
+	}
+
+	players := make([]PlayerWavedashStats, 0, len(indices))
+	for _, index := range indices {
+		s := stats[uint8(index)]
+		if s.Count > 0 {
+			s.AverageDelay /= float64(s.Count)
+			s.AverageAngle /= float64(s.Count)
+			s.AverageDistance /= float64(s.Count)
+		}
+		players = append(players, *s)
+	}
+
+	return players
+}
+
+// ComputeWavedashStats returns the wavedash stats of each player of the game,
+// in order of their index.
+func ComputeWavedashStats(game *slippi.SlpGame) ([]PlayerWavedashStats, error) {
+	computer := NewWavedashComputer()
+	if err := Run(game, computer); err != nil {
+		return nil, err
+	}
+
+	return computer.PlayerStats(), nil
+}
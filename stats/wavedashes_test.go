@@ -0,0 +1,44 @@
+package stats
+
+import "testing"
+
+func TestWavedashes(t *testing.T) {
+	game := openFixture(t)
+
+	computer := NewWavedashComputer()
+	actions := NewActionsComputer()
+	if err := Run(game, computer, actions); err != nil {
+		t.Fatal(err)
+	}
+
+	counts := actions.ActionCounts()
+	stats := computer.PlayerStats()
+	if len(stats) != 2 {
+		t.Fatalf("expected the wavedash stats of 2 players, got %d", len(stats))
+	}
+	for i, player := range stats {
+		if player.PlayerIndex != uint8(i) || player.Count != counts[i].WavedashCount {
+			t.Errorf("expected player %d to wavedash %d times, got %+v", i, counts[i].WavedashCount, player)
+		}
+	}
+
+	// only Falco wavedashes, 7 times air dodging into the ground straight
+	// out of his jumpsquat
+	falco := stats[1]
+	if falco.Count != 19 || falco.Delays[0] != 7 || falco.Delays[1] != 10 {
+		t.Errorf("expected Falco to wavedash 19 times, 7 of them without delay, got %+v", falco)
+	}
+
+	for _, wavedash := range computer.Wavedashes() {
+		if wavedash.AirDodgeFrame < wavedash.JumpFrame || wavedash.LandingFrame < wavedash.AirDodgeFrame || wavedash.LandingFrame-wavedash.JumpFrame > 2*wavedashWindow {
+			t.Errorf("expected the frames of the wavedash %+v to be in order", wavedash)
+		}
+		if wavedash.Angle < 0 || wavedash.Angle > 90 || wavedash.Distance < 0 {
+			t.Errorf("expected an air dodge below the horizontal, got %+v", wavedash)
+		}
+		// straight down wavedashes don't slide
+		if wavedash.Angle == 90 && wavedash.Distance != 0 {
+			t.Errorf("expected the wavedash %+v not to slide", wavedash)
+		}
+	}
+}
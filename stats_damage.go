@@ -0,0 +1,163 @@
+package slippi
+
+// A DamageBreakdown splits the damage a player took in a game by who dealt
+// it, and their deaths by whether an opponent caused them.
+type DamageBreakdown struct {
+	PlayerIndex uint8 `json:"playerIndex"`
+	// FromOpponents is the damage dealt to the player by their opponents,
+	// and FromPlayers the part of it dealt by each of them, by the index of
+	// the opponent.
+	FromOpponents float32           `json:"fromOpponents"`
+	FromPlayers   map[uint8]float32 `json:"fromPlayers"`
+	// FromProjectiles is the part of FromOpponents dealt with projectiles,
+	// which is credited to the opponent who owned them rather than the one
+	// who last hit the player directly.
+	FromProjectiles float32 `json:"fromProjectiles"`
+	// SelfDamage is the damage the player dealt to themselves, such as with
+	// their own bombs or Pichu's specials.
+	SelfDamage float32 `json:"selfDamage"`
+	// Unattributed is the damage that couldn't be attributed to anyone, such
+	// as from stage hazards.
+	Unattributed float32 `json:"unattributed"`
+	Deaths       int     `json:"deaths"`
+	// SelfDestructs is the number of the player's deaths that weren't caused
+	// by an opponent.
+	SelfDestructs int `json:"selfDestructs"`
+}
+
+// Total returns the total damage the player took.
+func (d DamageBreakdown) Total() float32 {
+	return d.FromOpponents + d.SelfDamage + d.Unattributed
+}
+
+// KOs returns the number of the player's deaths that were caused by an
+// opponent.
+func (d DamageBreakdown) KOs() int {
+	return d.Deaths - d.SelfDestructs
+}
+
+// ComputeDamageBreakdowns returns the damage breakdown of each player in
+// frames, keyed by player index.
+func ComputeDamageBreakdowns(frames map[int32]FrameEntry) map[uint8]*DamageBreakdown {
+	breakdowns := make(map[uint8]*DamageBreakdown)
+	breakdown := func(index uint8) *DamageBreakdown {
+		if _, ok := breakdowns[index]; !ok {
+			breakdowns[index] = &DamageBreakdown{PlayerIndex: index, FromPlayers: make(map[uint8]float32)}
+		}
+
+		return breakdowns[index]
+	}
+
+	walkDamage(frames, func(frame FrameEntry, index uint8, post *PostFrameUpdatePayload, damage float32, hit Hit, attributed bool) {
+		b := breakdown(index)
+		if !attributed {
+			b.Unattributed += damage
+		} else if hit.IsSelfDamage() {
+			b.SelfDamage += damage
+		} else {
+			b.FromOpponents += damage
+			b.FromPlayers[hit.AttackerIndex] += damage
+			if hit.Item != nil {
+				b.FromProjectiles += damage
+			}
+		}
+	}, func(frame FrameEntry, prev FrameEntry, index uint8, alive *PostFrameUpdatePayload, selfDestruct bool) {
+		b := breakdown(index)
+		b.Deaths++
+		if selfDestruct {
+			b.SelfDestructs++
+		}
+	})
+
+	return breakdowns
+}
+
+// DamageBreakdowns returns the damage breakdown of each player in the game,
+// keyed by player index.
+func (g *SlpGame) DamageBreakdowns() (map[uint8]*DamageBreakdown, error) {
+	frames, err := g.GetFrames()
+	if err != nil {
+		return nil, err
+	}
+
+	return ComputeDamageBreakdowns(frames), nil
+}
+
+// walkDamage walks frames in order, calling onDamage each time a player takes
+// damage, unless it is nil, and onDeath each time one dies, with the player's
+// last update before dying. A death is a self destruct if no opponent has hit the player since
+// they last had control on the ground.
+func walkDamage(
+	frames map[int32]FrameEntry,
+	onDamage func(frame FrameEntry, index uint8, post *PostFrameUpdatePayload, damage float32, hit Hit, attributed bool),
+	onDeath func(frame FrameEntry, prev FrameEntry, index uint8, alive *PostFrameUpdatePayload, selfDestruct bool),
+) {
+	walker := NewDamageWalker(onDamage, onDeath)
+	for _, frameNumber := range sortedFrameNumbers(frames) {
+		walker.Step(frames[frameNumber])
+	}
+}
+
+// A DamageWalker walks the frames of a game one at a time, as walkDamage
+// does, so that stats computers can follow damage as their frames are
+// processed.
+type DamageWalker struct {
+	onDamage      func(frame FrameEntry, index uint8, post *PostFrameUpdatePayload, damage float32, hit Hit, attributed bool)
+	onDeath       func(frame FrameEntry, prev FrameEntry, index uint8, alive *PostFrameUpdatePayload, selfDestruct bool)
+	lastAlive     map[uint8]*PostFrameUpdatePayload
+	hitByOpponent map[uint8]bool
+	prev          FrameEntry
+}
+
+// NewDamageWalker returns a DamageWalker that hasn't walked any frames, which
+// calls onDamage and onDeath like walkDamage.
+func NewDamageWalker(
+	onDamage func(frame FrameEntry, index uint8, post *PostFrameUpdatePayload, damage float32, hit Hit, attributed bool),
+	onDeath func(frame FrameEntry, prev FrameEntry, index uint8, alive *PostFrameUpdatePayload, selfDestruct bool),
+) *DamageWalker {
+	return &DamageWalker{
+		onDamage:      onDamage,
+		onDeath:       onDeath,
+		lastAlive:     make(map[uint8]*PostFrameUpdatePayload),
+		hitByOpponent: make(map[uint8]bool),
+	}
+}
+
+// Step walks the next frame of the game.
+func (w *DamageWalker) Step(frame FrameEntry) {
+	for index, updates := range frame.Players {
+		post := updates.Post
+		if post == nil {
+			continue
+		}
+
+		if !IsDead(post.ActionStateID) {
+			if !post.Airborne && IsInControl(post.ActionStateID) {
+				w.hitByOpponent[index] = false
+			}
+
+			if last, ok := w.lastAlive[index]; ok && post.Percent > last.Percent {
+				hit, attributed := AttributeHit(frame, w.prev, index)
+				if attributed && !hit.IsSelfDamage() {
+					w.hitByOpponent[index] = true
+				}
+				if w.onDamage != nil {
+					w.onDamage(frame, index, post, post.Percent-last.Percent, hit, attributed)
+				}
+			}
+			w.lastAlive[index] = post
+			continue
+		}
+
+		alive, ok := w.lastAlive[index]
+		if !ok {
+			continue
+		}
+
+		w.onDeath(frame, w.prev, index, alive, !w.hitByOpponent[index])
+		delete(w.lastAlive, index)
+		delete(w.hitByOpponent, index)
+	}
+
+	w.prev = frame
+}
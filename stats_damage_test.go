@@ -0,0 +1,55 @@
+package slippi
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDamageBreakdowns(t *testing.T) {
+	f, err := os.Open("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	game, err := NewSlpGameFromFile(f, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	breakdowns, err := game.DamageBreakdowns()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Fox runs off the stage on his third stock without being hit
+	expected := map[uint8][2]int{0: {3, 1}, 1: {4, 0}}
+	for index, deaths := range expected {
+		b, ok := breakdowns[index]
+		if !ok {
+			t.Fatalf("expected a breakdown for player %d", index)
+		}
+
+		if b.Deaths != deaths[0] || b.SelfDestructs != deaths[1] {
+			t.Errorf("expected player %d to die %d times with %d self destructs, got %+v", index, deaths[0], deaths[1], b)
+		}
+		if b.KOs() != deaths[0]-deaths[1] {
+			t.Errorf("expected player %d to be KOed %d times, got %d", index, deaths[0]-deaths[1], b.KOs())
+		}
+
+		// neither character can damage themselves
+		if b.SelfDamage != 0 {
+			t.Errorf("expected no self damage for player %d, got %f", index, b.SelfDamage)
+		}
+		if b.FromOpponents <= 0 || b.Total() != b.FromOpponents+b.Unattributed {
+			t.Errorf("expected player %d to take damage from their opponent, got %+v", index, b)
+		}
+		if b.FromPlayers[1-index] != b.FromOpponents {
+			t.Errorf("expected player %d to take all of their damage from player %d, got %+v", index, 1-index, b)
+		}
+		// both players hit each other with lasers, and Falco with his side B
+		if b.FromProjectiles <= 0 || b.FromProjectiles >= b.FromOpponents {
+			t.Errorf("expected player %d to take some damage from projectiles, got %+v", index, b)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package slippi
+
+import (
+	"math"
+	"sort"
+)
+
+// DeathPercents summarizes the percents at which a set of stocks were lost.
+type DeathPercents struct {
+	// Percents are the percents of the deaths, in ascending order.
+	Percents []float32 `json:"percents"`
+}
+
+func (d *DeathPercents) add(percent float32) {
+	i := sort.Search(len(d.Percents), func(i int) bool {
+		return d.Percents[i] > percent
+	})
+	d.Percents = append(d.Percents, 0)
+	copy(d.Percents[i+1:], d.Percents[i:])
+	d.Percents[i] = percent
+}
+
+func (d *DeathPercents) merge(other DeathPercents) {
+	for _, percent := range other.Percents {
+		d.add(percent)
+	}
+}
+
+// Count returns the number of deaths.
+func (d DeathPercents) Count() int {
+	return len(d.Percents)
+}
+
+// Average returns the average percent of the deaths, or 0 if there are none.
+func (d DeathPercents) Average() float32 {
+	if len(d.Percents) == 0 {
+		return 0
+	}
+
+	var total float32
+	for _, percent := range d.Percents {
+		total += percent
+	}
+
+	return total / float32(len(d.Percents))
+}
+
+// Earliest returns the lowest percent of the deaths, or 0 if there are none.
+func (d DeathPercents) Earliest() float32 {
+	if len(d.Percents) == 0 {
+		return 0
+	}
+
+	return d.Percents[0]
+}
+
+// Latest returns the highest percent of the deaths, or 0 if there are none.
+func (d DeathPercents) Latest() float32 {
+	if len(d.Percents) == 0 {
+		return 0
+	}
+
+	return d.Percents[len(d.Percents)-1]
+}
+
+// Percentile returns the percent at or below which p percent of the deaths
+// happened, using the nearest rank, or 0 if there are none.
+func (d DeathPercents) Percentile(p float64) float32 {
+	if len(d.Percents) == 0 {
+		return 0
+	}
+
+	rank := int(math.Ceil(p / 100 * float64(len(d.Percents))))
+	rank = max(1, min(rank, len(d.Percents)))
+
+	return d.Percents[rank-1]
+}
+
+// Survival returns the fraction of the stocks that survived past percent, or
+// 0 if there are none.
+func (d DeathPercents) Survival(percent float32) float64 {
+	if len(d.Percents) == 0 {
+		return 0
+	}
+
+	i := sort.Search(len(d.Percents), func(i int) bool {
+		return d.Percents[i] > percent
+	})
+
+	return float64(len(d.Percents)-i) / float64(len(d.Percents))
+}
+
+// A StockDeath is a single stock lost by a player.
+type StockDeath struct {
+	// Stock is the number of the stock lost, starting from 1 for the player's
+	// first.
+	Stock        int     `json:"stock"`
+	Frame        int32   `json:"frame"`
+	Percent      float32 `json:"percent"`
+	SelfDestruct bool    `json:"selfDestruct"`
+}
+
+// PlayerDeathStats are the percents at which a player lost their stocks in a
+// game.
+type PlayerDeathStats struct {
+	PlayerIndex uint8        `json:"playerIndex"`
+	Character   CharacterID  `json:"character"`
+	Deaths      []StockDeath `json:"deaths"`
+	// All summarizes every death, and ByStock the deaths of each stock, with
+	// the first stock lost at index 0.
+	All     DeathPercents   `json:"all"`
+	ByStock []DeathPercents `json:"byStock"`
+}
+
+func (p *PlayerDeathStats) add(death StockDeath) {
+	p.Deaths = append(p.Deaths, death)
+	p.All.add(death.Percent)
+	for len(p.ByStock) < death.Stock {
+		p.ByStock = append(p.ByStock, DeathPercents{Percents: make([]float32, 0)})
+	}
+	p.ByStock[death.Stock-1].add(death.Percent)
+}
+
+// ComputeDeathStats returns the death stats of each player in frames, keyed
+// by player index.
+func ComputeDeathStats(gameInfo *GameInfo, frames map[int32]FrameEntry) map[uint8]*PlayerDeathStats {
+	stats := make(map[uint8]*PlayerDeathStats)
+	for _, player := range gameInfo.Players {
+		stats[player.Index] = &PlayerDeathStats{
+			PlayerIndex: player.Index,
+			Character:   player.CharacterID,
+			Deaths:      make([]StockDeath, 0),
+			All:         DeathPercents{Percents: make([]float32, 0)},
+			ByStock:     make([]DeathPercents, 0),
+		}
+	}
+
+	walkDamage(frames, nil, func(frame FrameEntry, prev FrameEntry, index uint8, alive *PostFrameUpdatePayload, selfDestruct bool) {
+		player, ok := stats[index]
+		if !ok {
+			return
+		}
+
+		player.add(StockDeath{
+			Stock:        len(player.Deaths) + 1,
+			Frame:        frame.FrameNumber,
+			Percent:      alive.Percent,
+			SelfDestruct: selfDestruct,
+		})
+	})
+
+	return stats
+}
+
+// DeathStats returns the death stats of each player in the game, keyed by
+// player index.
+func (g *SlpGame) DeathStats() (map[uint8]*PlayerDeathStats, error) {
+	gameInfo, err := g.GetGameInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	frames, err := g.GetFrames()
+	if err != nil {
+		return nil, err
+	}
+
+	return ComputeDeathStats(gameInfo, frames), nil
+}
+
+// CharacterDeathStats are the percents at which players of a single character
+// lost their stocks across games.
+type CharacterDeathStats struct {
+	Character     CharacterID     `json:"character"`
+	CharacterName string          `json:"characterName"`
+	Games         int             `json:"games"`
+	All           DeathPercents   `json:"all"`
+	ByStock       []DeathPercents `json:"byStock"`
+}
+
+// A DeathStatsCollection aggregates death stats across games, grouped by
+// character.
+type DeathStatsCollection struct {
+	Characters []*CharacterDeathStats `json:"characters"`
+	// QuitOuts determines whether games that were quit out of are added.
+	QuitOuts QuitOutPolicy `json:"-"`
+}
+
+// NewDeathStatsCollection returns an empty DeathStatsCollection.
+func NewDeathStatsCollection() *DeathStatsCollection {
+	return &DeathStatsCollection{Characters: make([]*CharacterDeathStats, 0)}
+}
+
+// Character returns the death stats of the given character, or nil if no
+// games with the character have been added.
+func (d *DeathStatsCollection) Character(character CharacterID) *CharacterDeathStats {
+	for _, c := range d.Characters {
+		if c.Character == character {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// AddGame adds the deaths of each player in game to the stats of their
+// character, unless it is a quit out excluded by the collection's QuitOuts
+// policy.
+func (d *DeathStatsCollection) AddGame(game *SlpGame) error {
+	result, err := game.Result(d.QuitOuts)
+	if err != nil {
+		return err
+	} else if result.Excluded {
+		return nil
+	}
+
+	stats, err := game.DeathStats()
+	if err != nil {
+		return err
+	}
+
+	for _, player := range stats {
+		character := d.Character(player.Character)
+		if character == nil {
+			character = &CharacterDeathStats{
+				Character:     player.Character,
+				CharacterName: player.Character.String(),
+				All:           DeathPercents{Percents: make([]float32, 0)},
+				ByStock:       make([]DeathPercents, 0),
+			}
+			d.Characters = append(d.Characters, character)
+			sort.Slice(d.Characters, func(i, j int) bool {
+				return d.Characters[i].Character < d.Characters[j].Character
+			})
+		}
+		character.Games++
+
+		character.All.merge(player.All)
+		for stock, percents := range player.ByStock {
+			if stock == len(character.ByStock) {
+				character.ByStock = append(character.ByStock, DeathPercents{Percents: make([]float32, 0)})
+			}
+			character.ByStock[stock].merge(percents)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package slippi
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func TestDeathPercents(t *testing.T) {
+	var d DeathPercents
+	for _, percent := range []float32{120, 60, 90, 150} {
+		d.add(percent)
+	}
+
+	if d.Count() != 4 || d.Earliest() != 60 || d.Latest() != 150 || d.Average() != 105 {
+		t.Errorf("unexpected summary of %v", d.Percents)
+	}
+	if p := d.Percentile(50); p != 90 {
+		t.Errorf("expected median of 90, got %f", p)
+	}
+	if p := d.Percentile(100); p != 150 {
+		t.Errorf("expected 100th percentile of 150, got %f", p)
+	}
+	if s := d.Survival(100); s != 0.5 {
+		t.Errorf("expected half of stocks to survive past 100%%, got %f", s)
+	}
+	if s := (DeathPercents{}).Survival(100); s != 0 {
+		t.Errorf("expected no survival without deaths, got %f", s)
+	}
+}
+
+func TestDeathStatsCollection(t *testing.T) {
+	replay, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collection := NewDeathStatsCollection()
+	for i := 0; i < 2; i++ {
+		game, err := NewSlpGameFromBytes(replay, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if i == 0 {
+			stats, err := game.DeathStats()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			falco := stats[1]
+			if falco.Character != Falco || len(falco.Deaths) != 4 || len(falco.ByStock) != 4 {
+				t.Fatalf("expected Falco to lose 4 stocks, got %+v", falco)
+			}
+			for i, death := range falco.Deaths {
+				if death.Stock != i+1 {
+					t.Errorf("expected death %d to be of stock %d, got %d", i, i+1, death.Stock)
+				}
+			}
+		}
+
+		err = collection.AddGame(game)
+		game.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fox := collection.Character(Fox)
+	if fox == nil || fox.Games != 2 || fox.All.Count() != 6 || len(fox.ByStock) != 3 {
+		t.Fatalf("expected Fox to lose 6 stocks over 2 games, got %+v", fox)
+	}
+	if fox.ByStock[0].Count() != 2 || fox.All.Earliest() > fox.All.Average() || fox.All.Average() > fox.All.Latest() {
+		t.Errorf("unexpected Fox death percents, %+v", fox)
+	}
+
+	_, err = json.Marshal(collection)
+	if err != nil {
+		t.Error(err)
+	}
+}
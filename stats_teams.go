@@ -0,0 +1,251 @@
+package slippi
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// SavePercent is the percent at or above which a teammate in hitstun is
+// considered about to be killed, so that hitting their attacker saves them.
+const SavePercent = 100
+
+// sandbagIdleFraction is the fraction of the frames a player is alive that
+// they must spend idle to be considered sandbagging.
+const sandbagIdleFraction = 0.5
+
+// idleJoystickDeadzone is the largest joystick deflection that counts as no
+// input.
+const idleJoystickDeadzone = 0.2875
+
+// TeammateStats measure how a single player of a teams game played with their
+// team.
+type TeammateStats struct {
+	PlayerIndex uint8  `json:"playerIndex"`
+	TeamID      TeamID `json:"teamId"`
+	// DamageDealt is the damage the player dealt to the opposing teams, and
+	// FriendlyFire the damage they dealt to their teammates.
+	DamageDealt  float32 `json:"damageDealt"`
+	FriendlyFire float32 `json:"friendlyFire"`
+	// PressureFrames is the number of frames on which an opponent last hit
+	// by the player was in hitstun or grabbed.
+	PressureFrames int `json:"pressureFrames"`
+	// Saves is the number of hits the player landed on an opponent who had
+	// a teammate of theirs in hitstun at SavePercent or more.
+	Saves int `json:"saves"`
+	// IdleFrames is the number of frames on which the player was in control
+	// on the ground without any input, out of the AliveFrames they were
+	// alive.
+	IdleFrames  int `json:"idleFrames"`
+	AliveFrames int `json:"aliveFrames"`
+}
+
+// IsSandbagging returns whether the player spent most of the time they were
+// alive idle, leaving their teammates to play alone.
+func (s TeammateStats) IsSandbagging() bool {
+	return s.AliveFrames > 0 && float64(s.IdleFrames) >= sandbagIdleFraction*float64(s.AliveFrames)
+}
+
+// TeamStats measure how the players of a team in a teams game coordinated.
+type TeamStats struct {
+	TeamID TeamID `json:"teamId"`
+	// Players are the stats of the players of the team, in port order.
+	Players []*TeammateStats `json:"players"`
+	// SharedFrames is the number of frames on which at least two players of
+	// the team were alive, and SimultaneousPressureFrames the number of
+	// those on which every alive player of the team was pressuring an
+	// opponent.
+	SharedFrames               int `json:"sharedFrames"`
+	SimultaneousPressureFrames int `json:"simultaneousPressureFrames"`
+}
+
+// SimultaneousPressure returns the fraction of the frames on which at least
+// two players of the team were alive that all of them pressured an opponent at
+// once, or 0 if there are none.
+func (t TeamStats) SimultaneousPressure() float64 {
+	if t.SharedFrames == 0 {
+		return 0
+	}
+
+	return float64(t.SimultaneousPressureFrames) / float64(t.SharedFrames)
+}
+
+// DamageShare returns the fraction of the damage the team dealt to its
+// opponents that was dealt by the player with the given index, or 0 if the
+// team dealt none.
+func (t TeamStats) DamageShare(playerIndex uint8) float64 {
+	var total, dealt float32
+	for _, player := range t.Players {
+		total += player.DamageDealt
+		if player.PlayerIndex == playerIndex {
+			dealt = player.DamageDealt
+		}
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	return float64(dealt / total)
+}
+
+// ComputeTeamStats returns the stats of each team in frames, keyed by team. It
+// returns an error if the game isn't a teams game.
+func ComputeTeamStats(gameInfo *GameInfo, frames map[int32]FrameEntry) (map[TeamID]*TeamStats, error) {
+	if !gameInfo.Teams {
+		return nil, errors.New("game is not a teams game")
+	}
+
+	teams := make(map[TeamID]*TeamStats)
+	players := make(map[uint8]*TeammateStats)
+	teamOf := make(map[uint8]TeamID)
+	for _, player := range gameInfo.Players {
+		team, ok := teams[player.TeamID]
+		if !ok {
+			team = &TeamStats{TeamID: player.TeamID, Players: make([]*TeammateStats, 0)}
+			teams[player.TeamID] = team
+		}
+
+		stats := &TeammateStats{PlayerIndex: player.Index, TeamID: player.TeamID}
+		team.Players = append(team.Players, stats)
+		players[player.Index] = stats
+		teamOf[player.Index] = player.TeamID
+	}
+
+	for _, team := range teams {
+		sort.Slice(team.Players, func(i, j int) bool {
+			return team.Players[i].PlayerIndex < team.Players[j].PlayerIndex
+		})
+	}
+
+	// lastAttacker is the opponent who last hit each player
+	lastAttacker := make(map[uint8]uint8)
+	var prev FrameEntry
+	for i, frameNumber := range sortedFrameNumbers(frames) {
+		frame := frames[frameNumber]
+
+		// attribute the damage taken on the frame before updating who last
+		// hit each player, so that saves see the state before the save
+		if i > 0 {
+			hits := make(map[uint8]Hit)
+			for index, updates := range frame.Players {
+				prevPost := prev.Players[index].Post
+				if updates.Post == nil || prevPost == nil || updates.Post.Percent <= prevPost.Percent {
+					continue
+				}
+
+				hit, ok := AttributeHit(frame, prev, index)
+				if !ok || hit.IsSelfDamage() || players[hit.AttackerIndex] == nil || players[index] == nil {
+					continue
+				}
+
+				damage := updates.Post.Percent - prevPost.Percent
+				if teamOf[hit.AttackerIndex] == teamOf[index] {
+					players[hit.AttackerIndex].FriendlyFire += damage
+					continue
+				}
+
+				players[hit.AttackerIndex].DamageDealt += damage
+				hits[index] = hit
+			}
+
+			for defender, hit := range hits {
+				for victim, attacker := range lastAttacker {
+					victimPost := prev.Players[victim].Post
+					if attacker != defender || victim == hit.AttackerIndex || teamOf[victim] != teamOf[hit.AttackerIndex] || victimPost == nil {
+						continue
+					}
+
+					if IsDamaged(victimPost.ActionStateID) && victimPost.Percent >= SavePercent {
+						players[hit.AttackerIndex].Saves++
+						break
+					}
+				}
+			}
+
+			for defender, hit := range hits {
+				lastAttacker[defender] = hit.AttackerIndex
+			}
+		}
+
+		pressuring := make(map[uint8]bool)
+		for defender, attacker := range lastAttacker {
+			if post := frame.Players[defender].Post; post != nil && isPunished(post.ActionStateID) {
+				pressuring[attacker] = true
+			}
+		}
+
+		alive := make(map[TeamID][]uint8)
+		for index, stats := range players {
+			post := frame.Players[index].Post
+			if post == nil || post.StocksRemaining == 0 || IsDead(post.ActionStateID) {
+				continue
+			}
+
+			stats.AliveFrames++
+			alive[stats.TeamID] = append(alive[stats.TeamID], index)
+			if pressuring[index] {
+				stats.PressureFrames++
+			}
+			if pre := frame.Players[index].Pre; pre != nil && IsInControl(post.ActionStateID) && IsIdle(pre) {
+				stats.IdleFrames++
+			}
+		}
+
+		for teamID, indices := range alive {
+			if len(indices) < 2 {
+				continue
+			}
+
+			team := teams[teamID]
+			team.SharedFrames++
+
+			all := true
+			for _, index := range indices {
+				all = all && pressuring[index]
+			}
+			if all {
+				team.SimultaneousPressureFrames++
+			}
+		}
+
+		prev = frame
+	}
+
+	return teams, nil
+}
+
+// TeamStats returns the stats of each team in the game, keyed by team. It
+// returns an error if the game isn't a teams game.
+func (g *SlpGame) TeamStats() (map[TeamID]*TeamStats, error) {
+	gameInfo, err := g.GetGameInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	frames, err := g.GetFrames()
+	if err != nil {
+		return nil, err
+	}
+
+	return ComputeTeamStats(gameInfo, frames)
+}
+
+// isPunished returns whether the action state is one of being in hitstun or
+// held by a grab.
+func isPunished(actionStateID uint16) bool {
+	return IsDamaged(actionStateID) || IsGrabbed(actionStateID) || IsCommandGrabbed(actionStateID)
+}
+
+// IsIdle returns whether the pre-frame update has no buttons pressed and the
+// joystick and C-stick at rest.
+func IsIdle(pre *PreFrameUpdatePayload) bool {
+	sticks := []float32{pre.JoystickX, pre.JoystickY, pre.CStickX, pre.CStickY}
+	for _, stick := range sticks {
+		if math.Abs(float64(stick)) > idleJoystickDeadzone {
+			return false
+		}
+	}
+
+	return pre.PhysicalButtons == 0
+}
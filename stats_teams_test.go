@@ -0,0 +1,136 @@
+package slippi
+
+import (
+	"os"
+	"testing"
+)
+
+func TestTeamStats(t *testing.T) {
+	replay, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game, err := NewSlpGameFromBytes(replay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	breakdowns, err := game.DamageBreakdowns()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := game.TeamStats(); err == nil {
+		t.Error("expected error for a game without teams")
+	}
+	game.Close()
+
+	// turn the game into a teams game, with the players on opposing teams
+	// or on the same team
+	asTeams := func(falcoTeam TeamID) []byte {
+		return rewriteEvents(t, replay, func(event []byte) []byte {
+			if Command(event[0]) == GameStart {
+				event[1+0xC] = 1
+				event[1+0x6D] = byte(Red)
+				event[1+0x6D+0x24] = byte(falcoTeam)
+			}
+
+			return event
+		})
+	}
+
+	game, err = NewSlpGameFromBytes(asTeams(Blue), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	teams, err := game.TeamStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	game.Close()
+
+	if len(teams) != 2 || teams[Red].SharedFrames != 0 || teams[Blue].DamageShare(1) != 1 {
+		t.Fatalf("expected 2 teams of one player, got %+v", teams)
+	}
+
+	fox, falco := teams[Red].Players[0], teams[Blue].Players[0]
+	for _, c := range []struct {
+		stats    *TeammateStats
+		opponent uint8
+	}{{fox, 1}, {falco, 0}} {
+		if !FloatsEqual(float64(c.stats.DamageDealt), float64(breakdowns[c.opponent].FromOpponents), 1e-3) || c.stats.FriendlyFire != 0 {
+			t.Errorf("expected player %d to deal the damage their opponent took from opponents, got %+v", c.stats.PlayerIndex, c.stats)
+		}
+		if c.stats.PressureFrames == 0 || c.stats.AliveFrames == 0 || c.stats.IsSandbagging() {
+			t.Errorf("expected player %d to pressure and not sandbag, got %+v", c.stats.PlayerIndex, c.stats)
+		}
+	}
+
+	game, err = NewSlpGameFromBytes(asTeams(Red), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	teams, err = game.TeamStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	game.Close()
+
+	red := teams[Red]
+	if len(teams) != 1 || len(red.Players) != 2 || red.SharedFrames == 0 || red.SimultaneousPressure() != 0 {
+		t.Fatalf("expected a single team of two players, got %+v", red)
+	}
+	for _, player := range red.Players {
+		if player.DamageDealt != 0 || player.FriendlyFire == 0 {
+			t.Errorf("expected all of player %d's damage to be friendly fire, got %+v", player.PlayerIndex, player)
+		}
+	}
+}
+
+func TestTeamStatsSaves(t *testing.T) {
+	gameInfo := &GameInfo{
+		Teams: true,
+		Players: []PlayerInfo{
+			{Index: 0, TeamID: Red},
+			{Index: 1, TeamID: Red},
+			{Index: 2, TeamID: Blue},
+		},
+	}
+
+	post := func(actionState uint16, percent float32, lastHitBy uint8) *PostFrameUpdatePayload {
+		return &PostFrameUpdatePayload{
+			FrameUpdate:     FrameUpdate{ActionStateID: actionState, Percent: percent},
+			StocksRemaining: 4,
+			LastHitBy:       lastHitBy,
+		}
+	}
+	frame := func(frameNumber int32, posts ...*PostFrameUpdatePayload) FrameEntry {
+		players := make(map[uint8]FrameUpdates)
+		for i, p := range posts {
+			players[uint8(i)] = FrameUpdates{Post: p}
+		}
+		return FrameEntry{FrameNumber: frameNumber, Players: players}
+	}
+
+	// player 2 hits player 0 into hitstun at a killing percent, then player
+	// 1 hits player 2 to save them
+	frames := map[int32]FrameEntry{
+		0: frame(0, post(StateGroundedControlStart, 110, 6), post(StateGroundedControlStart, 0, 6), post(StateGroundedControlStart, 0, 6)),
+		1: frame(1, post(StateDamageStart, 125, 2), post(StateGroundedControlStart, 0, 6), post(StateGroundedControlStart, 0, 6)),
+		2: frame(2, post(StateDamageStart, 125, 2), post(StateGroundedControlStart, 0, 6), post(StateDamageStart, 12, 1)),
+	}
+
+	teams, err := ComputeTeamStats(gameInfo, frames)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	red := teams[Red]
+	if red.Players[1].Saves != 1 || red.Players[0].Saves != 0 || teams[Blue].Players[0].DamageDealt != 15 {
+		t.Errorf("expected player 1 to save player 0, got %+v and %+v", red.Players, teams[Blue].Players)
+	}
+
+	// on frame 2, player 1 pressures player 2 but player 0 is being punished
+	if red.SharedFrames != 3 || red.SimultaneousPressureFrames != 0 || red.Players[1].PressureFrames != 1 {
+		t.Errorf("expected 3 shared frames without simultaneous pressure, got %+v", red)
+	}
+}
@@ -0,0 +1,105 @@
+package slippi
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// DefaultStickPlotResolution is the resolution of a stick plot with a cell for
+// each coordinate a stick can report, which are in steps of 1/80.
+const DefaultStickPlotResolution = 161
+
+// A StickGrid counts the frames a stick spent at each coordinate, in a grid of
+// cells spanning the coordinates from -1 to 1 on each axis.
+type StickGrid struct {
+	// Resolution is the number of cells along each axis.
+	Resolution int `json:"resolution"`
+	// Counts are the number of frames the stick spent in each cell, by row
+	// from the bottom and then by column from the left.
+	Counts [][]int `json:"counts"`
+	// Frames is the total number of frames counted.
+	Frames int `json:"frames"`
+}
+
+func newStickGrid(resolution int) StickGrid {
+	counts := make([][]int, resolution)
+	for row := range counts {
+		counts[row] = make([]int, resolution)
+	}
+
+	return StickGrid{Resolution: resolution, Counts: counts}
+}
+
+func (g *StickGrid) add(x float32, y float32) {
+	g.Counts[g.cell(y)][g.cell(x)]++
+	g.Frames++
+}
+
+// cell returns the index along an axis of the cell containing the coordinate.
+func (g StickGrid) cell(coordinate float32) int {
+	c := math.Max(-1, math.Min(1, float64(coordinate)))
+	return int(math.Round((c + 1) / 2 * float64(g.Resolution-1)))
+}
+
+// Density returns the fraction of the frames the stick spent in the cell at
+// the given row and column, or 0 if no frames were counted.
+func (g StickGrid) Density(row int, column int) float64 {
+	if g.Frames == 0 {
+		return 0
+	}
+
+	return float64(g.Counts[row][column]) / float64(g.Frames)
+}
+
+// A StickPlot is the density of the coordinates of a player's joystick and
+// C-stick over a game, for plotting their inputs.
+type StickPlot struct {
+	PlayerIndex uint8     `json:"playerIndex"`
+	Joystick    StickGrid `json:"joystick"`
+	CStick      StickGrid `json:"cStick"`
+}
+
+// ComputeStickPlots returns the stick plot of each player in frames, keyed by
+// player index, with grids of the given resolution.
+func ComputeStickPlots(frames map[int32]FrameEntry, resolution int) (map[uint8]*StickPlot, error) {
+	if resolution < 1 {
+		return nil, errors.New(fmt.Sprintf("stick plot resolution must be at least 1, not %d", resolution))
+	}
+
+	plots := make(map[uint8]*StickPlot)
+	for _, frame := range frames {
+		for index, updates := range frame.Players {
+			pre := updates.Pre
+			if pre == nil {
+				continue
+			}
+
+			plot, ok := plots[index]
+			if !ok {
+				plot = &StickPlot{
+					PlayerIndex: index,
+					Joystick:    newStickGrid(resolution),
+					CStick:      newStickGrid(resolution),
+				}
+				plots[index] = plot
+			}
+
+			plot.Joystick.add(pre.JoystickX, pre.JoystickY)
+			plot.CStick.add(pre.CStickX, pre.CStickY)
+		}
+	}
+
+	return plots, nil
+}
+
+// StickPlots returns the stick plot of each player in the game, keyed by player
+// index, with grids of the given resolution.
+func (g *SlpGame) StickPlots(resolution int) (map[uint8]*StickPlot, error) {
+	frames, err := g.GetFrames()
+	if err != nil {
+		return nil, err
+	}
+
+	return ComputeStickPlots(frames, resolution)
+}
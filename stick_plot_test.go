@@ -0,0 +1,69 @@
+package slippi
+
+import (
+	"os"
+	"testing"
+)
+
+func TestStickPlots(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game, err := NewSlpGameFromBytes(b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer game.Close()
+
+	frames, err := game.GetFrames()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plots, err := game.StickPlots(DefaultStickPlotResolution)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(plots) != 2 {
+		t.Fatalf("expected 2 stick plots, got %d", len(plots))
+	}
+
+	center := DefaultStickPlotResolution / 2
+	for index, plot := range plots {
+		for _, grid := range []StickGrid{plot.Joystick, plot.CStick} {
+			if grid.Frames != len(frames) {
+				t.Errorf("expected %d frames for player %d, got %d", len(frames), index, grid.Frames)
+			}
+
+			total := 0
+			for _, row := range grid.Counts {
+				for _, count := range row {
+					total += count
+				}
+			}
+			if total != grid.Frames {
+				t.Errorf("expected counts to total %d for player %d, got %d", grid.Frames, index, total)
+			}
+
+			// sticks spend most of the game at rest
+			for row := range grid.Counts {
+				for column := range grid.Counts[row] {
+					if grid.Counts[row][column] > grid.Counts[center][center] {
+						t.Errorf("expected the center to be the densest cell for player %d, got (%d, %d)", index, row, column)
+					}
+				}
+			}
+		}
+
+		if density := plot.CStick.Density(center, center); density < 0.5 || density > 1 {
+			t.Errorf("expected C-stick to be at rest most of the game for player %d, got density %f", index, density)
+		}
+	}
+
+	if _, err := game.StickPlots(0); err == nil {
+		t.Error("expected error for resolution 0")
+	}
+}
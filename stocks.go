@@ -0,0 +1,61 @@
+package slippi
+
+// A StockChange is a change in the number of stocks a player has, sent by a
+// SlpParser with the StockChanged event once the frame it happened on is
+// finalized.
+type StockChange struct {
+	PlayerIndex uint8 `json:"playerIndex"`
+	Frame       int32 `json:"frame"`
+	// PreviousStocks are the stocks the player had on the frame before, and
+	// StocksRemaining those they have as of the frame.
+	PreviousStocks  uint8 `json:"previousStocks"`
+	StocksRemaining uint8 `json:"stocksRemaining"`
+	// Percent is the percent the player had on the frame before losing the
+	// stock.
+	Percent float32 `json:"percent"`
+	// DeathState is the dying action state the player lost the stock in,
+	// which shows which blast zone they were KO'd through.
+	DeathState uint16 `json:"deathState"`
+	// KillerIndex is the index of the player who last hit the player before
+	// they lost the stock, unless SelfDestruct is set, in which case no
+	// opponent had.
+	KillerIndex  uint8 `json:"killerIndex"`
+	SelfDestruct bool  `json:"selfDestruct"`
+}
+
+// Lost returns whether the player lost a stock, as opposed to gaining one,
+// such as by taking one from a teammate.
+func (c StockChange) Lost() bool {
+	return c.StocksRemaining < c.PreviousStocks
+}
+
+// stockChange returns the change in the stocks of the player with the given
+// index, whose post-frame update on the last finalized frame was last, on the
+// finalized frame, if there is one.
+func (p *SlpParser) stockChange(frame FrameEntry, index uint8, last PostFrameUpdatePayload, post PostFrameUpdatePayload) (StockChange, bool) {
+	if post.StocksRemaining == last.StocksRemaining {
+		return StockChange{}, false
+	}
+
+	change := StockChange{
+		PlayerIndex:     index,
+		Frame:           frame.FrameNumber,
+		PreviousStocks:  last.StocksRemaining,
+		StocksRemaining: post.StocksRemaining,
+		Percent:         last.Percent,
+	}
+
+	if change.Lost() {
+		change.DeathState = post.ActionStateID
+		change.KillerIndex = index
+		change.SelfDestruct = true
+		for _, killer := range p.gameInfo.Players {
+			if killer.Index == last.LastHitBy && killer.Index != index {
+				change.KillerIndex = killer.Index
+				change.SelfDestruct = false
+			}
+		}
+	}
+
+	return change, true
+}
@@ -0,0 +1,45 @@
+package slippi
+
+import (
+	"os"
+	"testing"
+)
+
+func TestStockChanges(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changes := make([]StockChange, 0)
+	for _, payload := range collectEvents(t, b, StockChanged) {
+		changes = append(changes, payload.(StockChange))
+	}
+
+	expected := []StockChange{
+		{PlayerIndex: 1, Frame: 846, PreviousStocks: 4, StocksRemaining: 3, KillerIndex: 0},
+		{PlayerIndex: 1, Frame: 3816, PreviousStocks: 3, StocksRemaining: 2, KillerIndex: 0},
+		{PlayerIndex: 0, Frame: 4781, PreviousStocks: 4, StocksRemaining: 3, KillerIndex: 1},
+		{PlayerIndex: 0, Frame: 7137, PreviousStocks: 3, StocksRemaining: 2, KillerIndex: 0, SelfDestruct: true},
+		{PlayerIndex: 1, Frame: 9864, PreviousStocks: 2, StocksRemaining: 1, KillerIndex: 0},
+		{PlayerIndex: 0, Frame: 12190, PreviousStocks: 2, StocksRemaining: 1, KillerIndex: 1},
+		{PlayerIndex: 1, Frame: 12219, PreviousStocks: 1, StocksRemaining: 0, KillerIndex: 0},
+	}
+
+	if len(changes) != len(expected) {
+		t.Fatalf("expected %d stock changes, got %d", len(expected), len(changes))
+	}
+
+	for i, change := range changes {
+		want := expected[i]
+		if change.PlayerIndex != want.PlayerIndex || change.Frame != want.Frame || change.PreviousStocks != want.PreviousStocks || change.StocksRemaining != want.StocksRemaining {
+			t.Errorf("expected stock change %d to be %+v, got %+v", i, want, change)
+		}
+		if change.KillerIndex != want.KillerIndex || change.SelfDestruct != want.SelfDestruct {
+			t.Errorf("expected stock change %d to be by %d (self destruct %t), got %d (%t)", i, want.KillerIndex, want.SelfDestruct, change.KillerIndex, change.SelfDestruct)
+		}
+		if !change.Lost() || !IsDead(change.DeathState) || change.Percent == 0 {
+			t.Errorf("expected stock change %d to be a KO, got %+v", i, change)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package slippi
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// StreamEventType enumerates the events emitted by a SlpStream.
+type StreamEventType uint8
+
+// StreamEventTypes
+const (
+	StreamGameStart StreamEventType = iota
+	StreamGameEnd
+)
+
+// A StreamEvent marks the start or end of a game in a SlpStream. Start and End
+// are the offsets in the stream of the bytes belonging to the event: for
+// StreamGameStart, they span the EventPayloads and GameStart commands that
+// begin the game, and for StreamGameEnd, the GameEnd command. The bytes from
+// a StreamGameStart's Start to the following StreamGameEnd's End are the raw
+// data of the game.
+type StreamEvent struct {
+	Type    StreamEventType
+	Start   int64
+	End     int64
+	Payload []byte
+}
+
+// A SlpStream detects the start and end of games in raw Slippi data, such as
+// the game data received from a Connection, by splitting it into commands.
+// Commands are only decoded if the stream feeds a SlpParser. A SlpStream is
+// not safe for concurrent writes.
+type SlpStream struct {
+	buf           []byte
+	offset        int64
+	payloadSizes  map[byte]uint16
+	payloadsStart int64
+	parser        *SlpParser
+	assembler     *MessageSplitterAssembler
+	// handleCommand, if set, handles each command instead of feeding it to
+	// the parser directly
+	handleCommand func(command Command, payload []byte) error
+	send          chan<- *StreamEvent
+	receive       <-chan *StreamEvent
+}
+
+// NewSlpStream returns a new SlpStream.
+func NewSlpStream() *SlpStream {
+	send, receive := MakeUnboundedChannel[StreamEvent]()
+
+	return &SlpStream{
+		buf:          make([]byte, 0),
+		payloadSizes: nil,
+		parser:       nil,
+		assembler:    NewMessageSplitterAssembler(),
+		send:         send,
+		receive:      receive,
+	}
+}
+
+// Events returns the channel to which the SlpStream sends its events. It is
+// closed when the SlpStream is closed.
+func (s *SlpStream) Events() <-chan *StreamEvent {
+	return s.receive
+}
+
+// SetParser sets the parser that the SlpStream feeds each command it reads to,
+// as if the parser were parsing a replay of the stream. The parser is reset
+// at the start of each game, so that it holds the state of the game in
+// progress. Passing nil stops feeding a parser.
+func (s *SlpStream) SetParser(parser *SlpParser) {
+	s.parser = parser
+	s.assembler.Reset()
+}
+
+// Close closes the SlpStream's events channel.
+func (s *SlpStream) Close() {
+	close(s.send)
+}
+
+// Write processes the next chunk of raw data from the stream, emitting an
+// event for each game boundary completed by it. Commands may be split across
+// chunks. If the stream feeds a parser, Write returns the first error the
+// parser returns, and the rest of the chunk isn't processed. Write implements
+// io.Writer.
+func (s *SlpStream) Write(data []byte) (int, error) {
+	s.buf = append(s.buf, data...)
+
+	position := 0
+	for position < len(s.buf) {
+		command := s.buf[position]
+
+		var size int
+		if command == byte(EventPayloads) {
+			if position+1 >= len(s.buf) {
+				break
+			}
+			size = int(s.buf[position+1])
+		} else if payloadSize, ok := s.payloadSizes[command]; ok {
+			size = int(payloadSize)
+		} else {
+			s.buf = s.buf[position:]
+			return len(data), errors.New(fmt.Sprintf("unknown command 0x%X at stream offset %d", command, s.offset))
+		}
+
+		// wait for the rest of the command
+		if position+1+size > len(s.buf) {
+			break
+		}
+
+		payload := s.buf[position+1 : position+1+size]
+		start := s.offset
+		s.offset += int64(1 + size)
+		position += 1 + size
+
+		switch Command(command) {
+		case EventPayloads:
+			s.payloadsStart = start
+			s.payloadSizes = make(map[byte]uint16)
+			for i := 1; i+2 < len(payload); i += 3 {
+				s.payloadSizes[payload[i]] = binary.BigEndian.Uint16(payload[i+1 : i+3])
+			}
+		case GameStart:
+			s.send <- &StreamEvent{
+				Type:    StreamGameStart,
+				Start:   s.payloadsStart,
+				End:     s.offset,
+				Payload: append([]byte{}, payload...),
+			}
+		case GameEnd:
+			s.send <- &StreamEvent{
+				Type:    StreamGameEnd,
+				Start:   start,
+				End:     s.offset,
+				Payload: append([]byte{}, payload...),
+			}
+		}
+
+		var err error
+		if s.handleCommand != nil {
+			err = s.handleCommand(Command(command), payload)
+		} else if s.parser != nil {
+			err = s.feed(Command(command), payload)
+		}
+		if err != nil {
+			s.buf = append(s.buf[:0], s.buf[position:]...)
+			return len(data), err
+		}
+	}
+
+	s.buf = append(s.buf[:0], s.buf[position:]...)
+
+	return len(data), nil
+}
+
+// feed decodes a command read from the stream and passes it to the stream's
+// parser.
+func (s *SlpStream) feed(command Command, payload []byte) error {
+	switch command {
+	case EventPayloads:
+		s.parser.Reset()
+		s.assembler.Reset()
+		return nil
+	case GameEnd:
+		// deliver the game's queued events before the next game begins
+		defer s.parser.closeQueues()
+	}
+
+	// skip commands the parser doesn't know, as the reader does
+	if command != MessageSplitter && (command < EventPayloads || command > GeckoList) {
+		return nil
+	}
+
+	// the command was just read, and the game's raw data starts at its
+	// event payloads command
+	offset := s.offset - int64(1+len(payload)) - s.payloadsStart
+
+	// the buffer is reused by later writes, so the payload is copied
+	event, err := parsePayload(command, append([]byte{}, payload...))
+	if err != nil {
+		return parseError(err, command, s.parser.latestFrameIndex, offset)
+	}
+	event.Offset = offset
+
+	if command == MessageSplitter {
+		spliced, err := s.assembler.Add(event.Payload.(MessageSplitterPayload))
+		if err != nil {
+			return parseError(err, command, s.parser.latestFrameIndex, offset)
+		} else if spliced == nil {
+			return nil
+		}
+		spliced.Offset = offset
+		event = spliced
+	}
+
+	if err := s.parser.handleEvent(*event); err != nil {
+		return s.parser.parseError(*event, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package slippi
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestSlpStreamDetectsGameBoundaries(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reader, err := NewSlpReader(*NewSlpSourceBytes(bytes.NewReader(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw := b[reader.RawStart : reader.RawStart+reader.RawLength]
+
+	// feed two back to back games in uneven chunks, so that commands are
+	// split across writes
+	stream := NewSlpStream()
+	data := append(append([]byte{}, raw...), raw...)
+	for len(data) > 0 {
+		n := min(777, len(data))
+		if _, err := stream.Write(data[:n]); err != nil {
+			t.Fatal(err)
+		}
+		data = data[n:]
+	}
+	stream.Close()
+
+	events := make([]*StreamEvent, 0)
+	for event := range stream.Events() {
+		events = append(events, event)
+	}
+
+	length := int64(len(raw))
+	expected := []struct {
+		eventType StreamEventType
+		start     int64
+		end       int64
+	}{
+		{StreamGameStart, 0, -1},
+		{StreamGameEnd, -1, length},
+		{StreamGameStart, length, -1},
+		{StreamGameEnd, -1, 2 * length},
+	}
+
+	if len(events) != len(expected) {
+		t.Fatalf("expected %d events, got %d", len(expected), len(events))
+	}
+	for i, event := range events {
+		if event.Type != expected[i].eventType ||
+			(expected[i].start >= 0 && event.Start != expected[i].start) ||
+			(expected[i].end >= 0 && event.End != expected[i].end) {
+			t.Errorf("event %d: expected %+v, got type %d from %d to %d", i, expected[i], event.Type, event.Start, event.End)
+		}
+	}
+
+	if len(events[1].Payload) == 0 || !bytes.Equal(raw[events[1].Start+1:events[1].End], events[1].Payload) {
+		t.Errorf("expected the game end payload to match the raw data")
+	}
+}
+
+func TestSlpStreamFeedsParser(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game, err := NewSlpGameFromBytes(b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer game.Close()
+
+	expected, err := game.GetFrameStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw := b[game.reader.RawStart : game.reader.RawStart+game.reader.RawLength]
+
+	parser := NewSlpParser(SlpParserOpts{})
+	stream := NewSlpStream()
+	stream.SetParser(parser)
+	defer stream.Close()
+
+	// feed the game twice, so that the second game resets the parser
+	for i := 0; i < 2; i++ {
+		data := raw
+		for len(data) > 0 {
+			n := min(1000, len(data))
+			if _, err := stream.Write(data[:n]); err != nil {
+				t.Fatal(err)
+			}
+			data = data[n:]
+		}
+	}
+
+	if parser.GameEnd == nil || parser.GameEnd.GameEndMethod != Game {
+		t.Errorf("expected the parser to reach the end of the game, got %+v", parser.GameEnd)
+	}
+	if gameInfo, complete := parser.GetGameInfo(); !complete || gameInfo.Stage != YoshisStory {
+		t.Errorf("expected the parser to have the game info, got %+v", gameInfo)
+	}
+	if parser.Frames.FrameCount() != expected.FrameCount() {
+		t.Fatalf("expected %d frames, got %d", expected.FrameCount(), parser.Frames.FrameCount())
+	}
+
+	frame, _ := parser.Frames.Get(1000)
+	expectedFrame, _ := expected.Get(1000)
+	if *frame.Players[0].Post != *expectedFrame.Players[0].Post {
+		t.Errorf("expected frame 1000 to match the parsed replay")
+	}
+}
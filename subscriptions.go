@@ -0,0 +1,160 @@
+package slippi
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// eventPayloadTypes are the types of the payloads a SlpParser triggers each
+// ParserEvent with.
+var eventPayloadTypes = map[ParserEvent]reflect.Type{
+	Started:            reflect.TypeFor[*GameInfo](),
+	Frame:              reflect.TypeFor[FrameEntry](),
+	FinalizedFrame:     reflect.TypeFor[FrameEntry](),
+	RollbackFrame:      reflect.TypeFor[FrameEntry](),
+	Ended:              reflect.TypeFor[GameEndPayload](),
+	StockChanged:       reflect.TypeFor[StockChange](),
+	ActionStateChanged: reflect.TypeFor[ActionStateChange](),
+	PercentChanged:     reflect.TypeFor[PercentChange](),
+}
+
+// Subscribe returns a channel that receives the payloads of the given event
+// from the parser, as payloads of type T, and a function that ends the
+// subscription, closing the channel. It returns an error if the event's
+// payloads aren't of type T: *GameInfo for Started, GameEndPayload for Ended,
+// StockChange for StockChanged, ActionStateChange for ActionStateChanged,
+// PercentChange for PercentChanged, and FrameEntry for the frame events.
+// Payloads are received in the order the parser's dispatch mode delivers them.
+func Subscribe[T any](p *SlpParser, event ParserEvent) (<-chan T, func(), error) {
+	expected, ok := eventPayloadTypes[event]
+	if !ok {
+		return nil, nil, errors.New(fmt.Sprintf("unknown parser event %d", event))
+	} else if actual := reflect.TypeFor[T](); actual != expected {
+		return nil, nil, errors.New(fmt.Sprintf("parser event %d has %s payloads, not %s", event, expected, actual))
+	}
+
+	payloads, unsubscribe := subscribe[T](p, event, nil)
+	return payloads, unsubscribe, nil
+}
+
+// SubscribeFrames returns a channel that receives the finalized frames from
+// the parser for which pred returns true, such as those on which a player is
+// in hitstun, and a function that ends the subscription, closing the channel.
+// Frames are filtered as they are triggered, so those that don't match are
+// never sent.
+func (p *SlpParser) SubscribeFrames(pred func(FrameEntry) bool) (<-chan FrameEntry, func()) {
+	return subscribe(p, FinalizedFrame, pred)
+}
+
+// subscribe returns a channel that receives the payloads of the given event
+// from the parser for which filter returns true, or all of them if it is nil,
+// and a function that ends the subscription. The event's payloads must be of
+// type T. Once the subscription ends, the channel is closed and the payloads
+// still being delivered to it are dropped.
+func subscribe[T any](p *SlpParser, event ParserEvent, filter func(T) bool) (<-chan T, func()) {
+	handler := make(chan interface{})
+	var handlerFilter func(interface{}) bool
+	if filter != nil {
+		handlerFilter = func(payload interface{}) bool {
+			return filter(payload.(T))
+		}
+	}
+	stop := p.subscribe(event, handler, handlerFilter)
+
+	typed := make(chan T)
+	go func() {
+		defer close(typed)
+
+		for {
+			select {
+			case payload := <-handler:
+				select {
+				case typed <- payload.(T):
+				case <-stop:
+					return
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			p.unsubscribe(event, handler)
+		})
+	}
+
+	return typed, unsubscribe
+}
+
+// on calls callback with each payload of the given event from the parser,
+// which must be of type T, until the returned function is called.
+func on[T any](p *SlpParser, event ParserEvent, callback func(T)) func() {
+	payloads, unsubscribe, err := Subscribe[T](p, event)
+	if err != nil {
+		panic(err)
+	}
+
+	go func() {
+		for payload := range payloads {
+			callback(payload)
+		}
+	}()
+
+	return unsubscribe
+}
+
+// OnStarted calls callback with the game info once the parser has parsed it,
+// until the returned function is called.
+func (p *SlpParser) OnStarted(callback func(*GameInfo)) func() {
+	return on(p, Started, callback)
+}
+
+// OnFrame calls callback with each frame the parser has received all updates
+// for, which may still be rolled back, until the returned function is called.
+func (p *SlpParser) OnFrame(callback func(FrameEntry)) func() {
+	return on(p, Frame, callback)
+}
+
+// OnFinalizedFrame calls callback with each frame once it can no longer be
+// rolled back, until the returned function is called.
+func (p *SlpParser) OnFinalizedFrame(callback func(FrameEntry)) func() {
+	return on(p, FinalizedFrame, callback)
+}
+
+// OnRollbackFrame calls callback with each frame replaced by a rollback, until
+// the returned function is called.
+func (p *SlpParser) OnRollbackFrame(callback func(FrameEntry)) func() {
+	return on(p, RollbackFrame, callback)
+}
+
+// OnEnded calls callback with the game end event once the parser has parsed
+// it, until the returned function is called.
+func (p *SlpParser) OnEnded(callback func(GameEndPayload)) func() {
+	return on(p, Ended, callback)
+}
+
+// OnStockChanged calls callback with each change in the stocks of a player,
+// once the frame it happened on is finalized, until the returned function is
+// called.
+func (p *SlpParser) OnStockChanged(callback func(StockChange)) func() {
+	return on(p, StockChanged, callback)
+}
+
+// OnActionStateChanged calls callback with each change in the action state of
+// a player, once the frame it happened on is finalized, until the returned
+// function is called.
+func (p *SlpParser) OnActionStateChanged(callback func(ActionStateChange)) func() {
+	return on(p, ActionStateChanged, callback)
+}
+
+// OnPercentChanged calls callback with each increase in the percent of a
+// player, once the frame it happened on is finalized, until the returned
+// function is called.
+func (p *SlpParser) OnPercentChanged(callback func(PercentChange)) func() {
+	return on(p, PercentChanged, callback)
+}
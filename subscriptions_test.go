@@ -0,0 +1,202 @@
+package slippi
+
+import (
+	"os"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestSubscribe(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game, err := NewSlpGameFromBytes(b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer game.Close()
+
+	if _, _, err := Subscribe[GameEndPayload](game.parser, FinalizedFrame); err == nil {
+		t.Error("expected subscribing with the wrong payload type to fail")
+	}
+
+	game.SetDispatchMode(DispatchOrdered)
+	frames, unsubscribe, err := Subscribe[FrameEntry](game.parser, FinalizedFrame)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unsubscribe()
+
+	ended := make(chan GameEndPayload, 1)
+	defer game.parser.OnEnded(func(payload GameEndPayload) {
+		ended <- payload
+	})()
+
+	received := make(chan int, 1)
+	go func() {
+		count := 0
+		next := FirstFrame
+		for frame := range frames {
+			if frame.FrameNumber != next {
+				t.Errorf("expected frame %d, got %d", next, frame.FrameNumber)
+			}
+			next = frame.FrameNumber + 1
+
+			count++
+			if frame.FrameNumber == 12219 {
+				received <- count
+			}
+		}
+	}()
+
+	store, err := game.GetFrameStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case count := <-received:
+		if count != store.FrameCount() {
+			t.Errorf("expected %d finalized frames, got %d", store.FrameCount(), count)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected to receive every finalized frame")
+	}
+
+	select {
+	case payload := <-ended:
+		if payload.GameEndMethod != Game {
+			t.Errorf("expected the game to end by stocks, got %d", payload.GameEndMethod)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected to receive the end of the game")
+	}
+}
+
+func TestSubscribeFrames(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inHitstun := func(frame FrameEntry) bool {
+		post := frame.Players[1].Post
+		return post != nil && IsDamaged(post.ActionStateID)
+	}
+
+	reference, err := NewSlpGameFromBytes(b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reference.Close()
+
+	expected := make([]int32, 0)
+	for frameNumber, frame := range reference.Frames() {
+		if inHitstun(frame) {
+			expected = append(expected, frameNumber)
+		}
+	}
+	if len(expected) == 0 {
+		t.Fatal("expected Falco to be in hitstun")
+	}
+
+	game, err := NewSlpGameFromBytes(b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer game.Close()
+
+	game.SetDispatchMode(DispatchOrdered)
+	frames, unsubscribe := game.parser.SubscribeFrames(inHitstun)
+	defer unsubscribe()
+
+	received := make(chan []int32, 1)
+	go func() {
+		frameNumbers := make([]int32, 0)
+		for frame := range frames {
+			frameNumbers = append(frameNumbers, frame.FrameNumber)
+			if len(frameNumbers) == len(expected) {
+				received <- frameNumbers
+			}
+		}
+	}()
+
+	if _, err := game.GetFrames(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case frameNumbers := <-received:
+		for i := range expected {
+			if frameNumbers[i] != expected[i] {
+				t.Fatalf("expected frame %d, got %d", expected[i], frameNumbers[i])
+			}
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for %d frames", len(expected))
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, mode := range []DispatchMode{DispatchConcurrent, DispatchOrdered, DispatchSynchronous} {
+		before := runtime.NumGoroutine()
+
+		game, err := NewSlpGameFromBytes(b, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		game.SetDispatchMode(mode)
+
+		// frames are never read from the subscription, so that deliveries
+		// to it are still in flight when it ends
+		frames, unsubscribe, err := Subscribe[FrameEntry](game.parser, FinalizedFrame)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stopFrames := game.parser.OnFrame(func(FrameEntry) {})
+		stopEnded := game.parser.OnEnded(func(GameEndPayload) {})
+
+		finished := make(chan error, 1)
+		go func() {
+			_, err := game.GetFrames()
+			finished <- err
+		}()
+
+		// synchronous delivery waits for the subscription to be read, which
+		// ending it gives up on
+		if mode != DispatchSynchronous {
+			if err := <-finished; err != nil {
+				t.Fatal(err)
+			}
+		}
+		unsubscribe()
+		unsubscribe()
+		stopFrames()
+		stopEnded()
+		if mode == DispatchSynchronous {
+			if err := <-finished; err != nil {
+				t.Fatal(err)
+			}
+		}
+		game.Close()
+
+		for range frames {
+		}
+
+		deadline := time.Now().Add(5 * time.Second)
+		for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+		if after := runtime.NumGoroutine(); after > before {
+			t.Errorf("mode %d: expected ending subscriptions to stop their goroutines, went from %d to %d", mode, before, after)
+		}
+	}
+}
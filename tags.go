@@ -0,0 +1,203 @@
+package slippi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+)
+
+// GameTags are the tags and notes attached to a game.
+type GameTags struct {
+	Tags  []string `json:"tags"`
+	Notes string   `json:"notes"`
+}
+
+// A TagStore attaches tags and notes to games, keyed by their fingerprint, so
+// that they follow a replay that is moved or renamed. It is stored as a JSON
+// file.
+type TagStore struct {
+	path  string
+	Games map[string]*GameTags `json:"games"`
+}
+
+// OpenTagStore opens the tag store at path, which is created by Save if it
+// doesn't exist yet.
+func OpenTagStore(path string) (*TagStore, error) {
+	store := &TagStore{path: path, Games: make(map[string]*GameTags)}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(b, store); err != nil {
+		return nil, err
+	}
+	if store.Games == nil {
+		store.Games = make(map[string]*GameTags)
+	}
+
+	return store, nil
+}
+
+// Save writes the tag store to its file, replacing the file as a whole so that
+// it is never left partially written.
+func (s *TagStore) Save() error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.Write(append(b, '\n'))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), s.path)
+}
+
+// Get returns the tags and notes attached to the game with the given
+// fingerprint.
+func (s *TagStore) Get(fingerprint string) GameTags {
+	tags, ok := s.Games[fingerprint]
+	if !ok {
+		return GameTags{Tags: make([]string, 0)}
+	}
+
+	return GameTags{Tags: append(make([]string, 0), tags.Tags...), Notes: tags.Notes}
+}
+
+// Tag attaches tags to the game with the given fingerprint.
+func (s *TagStore) Tag(fingerprint string, tags ...string) {
+	game := s.game(fingerprint)
+	for _, tag := range tags {
+		if !slices.Contains(game.Tags, tag) {
+			game.Tags = append(game.Tags, tag)
+		}
+	}
+	sort.Strings(game.Tags)
+}
+
+// Untag removes tags from the game with the given fingerprint.
+func (s *TagStore) Untag(fingerprint string, tags ...string) {
+	game, ok := s.Games[fingerprint]
+	if !ok {
+		return
+	}
+
+	game.Tags = slices.DeleteFunc(game.Tags, func(tag string) bool {
+		return slices.Contains(tags, tag)
+	})
+	s.prune(fingerprint)
+}
+
+// SetNotes replaces the notes attached to the game with the given
+// fingerprint. Empty notes remove them.
+func (s *TagStore) SetNotes(fingerprint string, notes string) {
+	s.game(fingerprint).Notes = notes
+	s.prune(fingerprint)
+}
+
+// HasTags returns whether every one of tags is attached to the game with the
+// given fingerprint.
+func (s *TagStore) HasTags(fingerprint string, tags ...string) bool {
+	game, ok := s.Games[fingerprint]
+	for _, tag := range tags {
+		if !ok || !slices.Contains(game.Tags, tag) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Fingerprints returns the fingerprints of the games that have every one of
+// tags attached, in ascending order.
+func (s *TagStore) Fingerprints(tags ...string) []string {
+	fingerprints := make([]string, 0)
+	for fingerprint := range s.Games {
+		if s.HasTags(fingerprint, tags...) {
+			fingerprints = append(fingerprints, fingerprint)
+		}
+	}
+	sort.Strings(fingerprints)
+
+	return fingerprints
+}
+
+// Filter returns a SlpGameHandler that calls handler with the games processed
+// from a collection that have every one of tags attached, skipping the rest.
+func (s *TagStore) Filter(handler SlpGameHandler, tags ...string) SlpGameHandler {
+	return func(path string, game *SlpGame) error {
+		fingerprint, err := game.Fingerprint()
+		if err != nil {
+			return err
+		}
+
+		if !s.HasTags(fingerprint, tags...) {
+			return nil
+		}
+
+		return handler(path, game)
+	}
+}
+
+func (s *TagStore) game(fingerprint string) *GameTags {
+	game, ok := s.Games[fingerprint]
+	if !ok {
+		game = &GameTags{Tags: make([]string, 0)}
+		s.Games[fingerprint] = game
+	}
+
+	return game
+}
+
+// prune forgets the game with the given fingerprint if nothing is attached to
+// it anymore.
+func (s *TagStore) prune(fingerprint string) {
+	if game, ok := s.Games[fingerprint]; ok && len(game.Tags) == 0 && game.Notes == "" {
+		delete(s.Games, fingerprint)
+	}
+}
+
+// Fingerprint returns the hex-encoded SHA-256 hash of the game's replay, which
+// identifies the replay regardless of where it is stored. Games not opened
+// with OpenSlpGame are hashed on each call, since their replays may still be
+// written to.
+func (g *SlpGame) Fingerprint() (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	fingerprint := g.fingerprint
+	if !g.opened {
+		source := g.reader.Source
+		if _, err := source.Seek(0, io.SeekStart); err != nil {
+			return "", err
+		}
+
+		h := sha256.New()
+		if _, err := io.Copy(h, source); err != nil {
+			return "", err
+		}
+		copy(fingerprint[:], h.Sum(nil))
+	}
+
+	return hex.EncodeToString(fingerprint[:]), nil
+}
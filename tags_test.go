@@ -0,0 +1,85 @@
+package slippi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestTagStore(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(b)
+
+	dir := t.TempDir()
+	for _, name := range []string{"a.slp", "b.slp"} {
+		if err := os.WriteFile(filepath.Join(dir, name), b, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	game, err := NewSlpGameFromBytes(b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fingerprint, err := game.Fingerprint()
+	if err != nil {
+		t.Fatal(err)
+	} else if fingerprint != hex.EncodeToString(sum[:]) {
+		t.Fatalf("expected fingerprint to be the replay's hash, got %s", fingerprint)
+	}
+
+	// fingerprinting a game doesn't make it cacheable
+	err = game.WriteCache(io.Discard)
+	game.Close()
+	if ErrorCode(err) != CodeUncacheableGame {
+		t.Errorf("expected a game not opened from a file to be uncacheable, got %v", err)
+	}
+
+	path := filepath.Join(dir, "tags.json")
+	store, err := OpenTagStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Tag(fingerprint, "tournament", "review later")
+	store.SetNotes(fingerprint, "missed tech chases")
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err = OpenTagStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tags := store.Get(fingerprint); !slices.Equal(tags.Tags, []string{"review later", "tournament"}) || tags.Notes != "missed tech chases" {
+		t.Errorf("expected tags and notes to be saved, got %+v", tags)
+	}
+
+	// both copies of the replay share its tags
+	paths := make([]string, 0)
+	err = ProcessDirectory(dir, false, store.Filter(func(path string, game *SlpGame) error {
+		paths = append(paths, filepath.Base(path))
+		return nil
+	}, "tournament"))
+	if err != nil {
+		t.Fatal(err)
+	} else if !slices.Equal(paths, []string{"a.slp", "b.slp"}) {
+		t.Errorf("expected both copies of the tagged replay, got %v", paths)
+	}
+
+	if fingerprints := store.Fingerprints("tournament", "friendlies"); len(fingerprints) != 0 {
+		t.Errorf("expected no games with both tags, got %v", fingerprints)
+	}
+
+	store.Untag(fingerprint, "tournament", "review later")
+	store.SetNotes(fingerprint, "")
+	if len(store.Games) != 0 {
+		t.Errorf("expected untagged game without notes to be forgotten, got %+v", store.Games)
+	}
+}
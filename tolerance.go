@@ -0,0 +1,142 @@
+package slippi
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Tolerance contains the largest absolute differences at which float fields
+// of frame data are still considered equal, so that values which went through
+// a lossy float formatting, such as JSON written by another implementation,
+// don't produce false mismatches. The zero Tolerance compares floats exactly.
+type Tolerance struct {
+	// Position applies to positions, velocities and speeds.
+	Position float64
+	// Percent applies to percents, shield sizes and damage.
+	Percent float64
+	// Other applies to every other float field, such as facing directions,
+	// analog inputs and frame counters.
+	Other float64
+}
+
+// DefaultTolerance tolerates differences below the precision floats are
+// usually formatted with.
+var DefaultTolerance = Tolerance{
+	Position: 1e-3,
+	Percent:  1e-3,
+	Other:    1e-5,
+}
+
+// FloatsEqual returns whether a and b differ by at most epsilon. NaNs are
+// equal to each other, and infinities to infinities of the same sign.
+func FloatsEqual(a float64, b float64, epsilon float64) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.IsNaN(a) && math.IsNaN(b)
+	} else if math.IsInf(a, 0) || math.IsInf(b, 0) {
+		return a == b
+	}
+
+	return math.Abs(a-b) <= epsilon
+}
+
+// Epsilon returns the tolerance for the float field with the given name.
+func (t Tolerance) Epsilon(field string) float64 {
+	switch {
+	case strings.Contains(field, "Position"), strings.Contains(field, "Velocity"), strings.Contains(field, "Speed"):
+		return t.Position
+	case strings.Contains(field, "Percent"), strings.Contains(field, "Shield"), strings.Contains(field, "Damage"):
+		return t.Percent
+	default:
+		return t.Other
+	}
+}
+
+// Equal returns whether a and b are equal within the tolerance.
+func (t Tolerance) Equal(a interface{}, b interface{}) bool {
+	return len(t.Diff(a, b)) == 0
+}
+
+// Diff compares a and b, which are typically frames, payloads or test vector
+// results, and returns the paths of the fields that differ beyond the
+// tolerance, such as "Players[1].Post.Percent". Nil and empty slices and maps
+// are equal.
+func (t Tolerance) Diff(a interface{}, b interface{}) []string {
+	diffs := make([]string, 0)
+	t.diff(reflect.ValueOf(a), reflect.ValueOf(b), "", "", &diffs)
+	return diffs
+}
+
+func (t Tolerance) diff(a reflect.Value, b reflect.Value, path string, field string, diffs *[]string) {
+	if !a.IsValid() || !b.IsValid() {
+		if a.IsValid() != b.IsValid() {
+			*diffs = append(*diffs, path)
+		}
+		return
+	} else if a.Type() != b.Type() {
+		*diffs = append(*diffs, path)
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Float32, reflect.Float64:
+		if !FloatsEqual(a.Float(), b.Float(), t.Epsilon(field)) {
+			*diffs = append(*diffs, path)
+		}
+	case reflect.Pointer, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				*diffs = append(*diffs, path)
+			}
+			return
+		}
+		t.diff(a.Elem(), b.Elem(), path, field, diffs)
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			structField := a.Type().Field(i)
+			if !structField.IsExported() {
+				continue
+			}
+
+			// fields of embedded structs are named as if they were promoted
+			fieldPath := path
+			if !structField.Anonymous && path != "" {
+				fieldPath = path + "." + structField.Name
+			} else if !structField.Anonymous {
+				fieldPath = structField.Name
+			}
+			t.diff(a.Field(i), b.Field(i), fieldPath, structField.Name, diffs)
+		}
+	case reflect.Slice, reflect.Array:
+		if a.Len() != b.Len() {
+			*diffs = append(*diffs, path)
+			return
+		}
+		for i := 0; i < a.Len(); i++ {
+			t.diff(a.Index(i), b.Index(i), fmt.Sprintf("%s[%d]", path, i), field, diffs)
+		}
+	case reflect.Map:
+		keys := make(map[string]reflect.Value)
+		for _, m := range []reflect.Value{a, b} {
+			for _, key := range m.MapKeys() {
+				keys[fmt.Sprint(key.Interface())] = key
+			}
+		}
+
+		names := make([]string, 0, len(keys))
+		for name := range keys {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			t.diff(a.MapIndex(keys[name]), b.MapIndex(keys[name]), fmt.Sprintf("%s[%s]", path, name), field, diffs)
+		}
+	default:
+		if a.Comparable() && !a.Equal(b) {
+			*diffs = append(*diffs, path)
+		}
+	}
+}
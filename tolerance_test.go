@@ -0,0 +1,67 @@
+package slippi
+
+import (
+	"math"
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestFloatsEqual(t *testing.T) {
+	cases := []struct {
+		a, b, epsilon float64
+		equal         bool
+	}{
+		{1, 1, 0, true},
+		{1, 1.0005, 1e-3, true},
+		{1, 1.002, 1e-3, false},
+		{math.NaN(), math.NaN(), 0, true},
+		{math.NaN(), 1, 1e9, false},
+		{math.Inf(1), math.Inf(1), 0, true},
+		{math.Inf(1), math.Inf(-1), 1e9, false},
+	}
+
+	for _, c := range cases {
+		if FloatsEqual(c.a, c.b, c.epsilon) != c.equal {
+			t.Errorf("expected FloatsEqual(%v, %v, %v) to be %t", c.a, c.b, c.epsilon, c.equal)
+		}
+	}
+}
+
+func TestToleranceDiff(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game, err := NewSlpGameFromBytes(b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer game.Close()
+
+	frames, err := game.GetFrames()
+	if err != nil {
+		t.Fatal(err)
+	}
+	frame := frames[100]
+
+	post := *frame.Players[0].Post
+	post.XPosition += 5e-4
+	post.Percent += 5e-4
+	post.ActionStateFrameCounter += 5e-4
+
+	if diffs := DefaultTolerance.Diff(*frame.Players[0].Post, post); !slices.Equal(diffs, []string{"ActionStateFrameCounter"}) {
+		t.Errorf("expected only ActionStateFrameCounter to differ, got %v", diffs)
+	}
+
+	if diffs := (Tolerance{}).Diff(*frame.Players[0].Post, post); len(diffs) != 3 {
+		t.Errorf("expected 3 differences without tolerance, got %v", diffs)
+	}
+
+	modified := frame
+	modified.Players = map[uint8]FrameUpdates{0: {Pre: frame.Players[0].Pre, Post: &post}}
+	if diffs := (Tolerance{}).Diff(frame, modified); !slices.Contains(diffs, "Players[0].Post.XPosition") || !slices.Contains(diffs, "Players[1]") {
+		t.Errorf("expected changed position and missing player, got %v", diffs)
+	}
+}
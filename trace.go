@@ -0,0 +1,52 @@
+package slippi
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// A TraceEntry records the decoding of a single event read from a replay.
+type TraceEntry struct {
+	Command Command `json:"command"`
+	// Offset is the offset of the event's command byte in the replay.
+	Offset int64 `json:"offset"`
+	// Size is the size of the event's payload, excluding the command byte.
+	Size     int           `json:"size"`
+	Duration time.Duration `json:"duration"`
+	// Skipped is whether the event was skipped rather than decoded, because
+	// it was unknown or not included.
+	Skipped bool   `json:"skipped,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// A TraceHandler receives a TraceEntry for each event read by a SlpReader.
+type TraceHandler func(TraceEntry)
+
+// SetTrace sets the handler that receives a TraceEntry for every event the
+// SlpReader reads, in order. Passing nil disables tracing.
+func (r *SlpReader) SetTrace(handler TraceHandler) {
+	r.trace = handler
+}
+
+func (r *SlpReader) traceEvent(entry TraceEntry) {
+	if r.trace != nil {
+		r.trace(entry)
+	}
+}
+
+// NewTraceWriter returns a TraceHandler that writes each TraceEntry to w as a
+// line of JSON. Write errors are ignored, since tracing is only a debugging
+// aid.
+func NewTraceWriter(w io.Writer) TraceHandler {
+	var mu sync.Mutex
+	encoder := json.NewEncoder(w)
+
+	return func(entry TraceEntry) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		encoder.Encode(entry)
+	}
+}
@@ -1,5 +1,7 @@
 package slippi
 
+import "sort"
+
 func MakeUnboundedChannel[K any]() (chan<- *K, <-chan *K) {
 	in := make(chan *K)
 	out := make(chan *K)
@@ -36,3 +38,16 @@ func MakeUnboundedChannel[K any]() (chan<- *K, <-chan *K) {
 
 	return in, out
 }
+
+// sortedFrameNumbers returns the frame numbers of frames in ascending order.
+func sortedFrameNumbers(frames map[int32]FrameEntry) []int32 {
+	frameNumbers := make([]int32, 0, len(frames))
+	for frameNumber := range frames {
+		frameNumbers = append(frameNumbers, frameNumber)
+	}
+	sort.Slice(frameNumbers, func(i, j int) bool {
+		return frameNumbers[i] < frameNumbers[j]
+	})
+
+	return frameNumbers
+}
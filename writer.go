@@ -0,0 +1,84 @@
+package slippi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/jmank88/ubjson"
+)
+
+// A SlpWriter writes raw event data and metadata in the .slp replay format.
+// Since the length of the raw element is written before its contents, event
+// data is buffered in memory until Finish is called.
+type SlpWriter struct {
+	w        io.Writer
+	raw      bytes.Buffer
+	finished bool
+}
+
+// NewSlpWriter returns a SlpWriter that writes a replay to w.
+func NewSlpWriter(w io.Writer) *SlpWriter {
+	return &SlpWriter{
+		w:        w,
+		finished: false,
+	}
+}
+
+// Write appends raw event bytes to the raw element of the replay.
+func (w *SlpWriter) Write(b []byte) (int, error) {
+	if w.finished {
+		return 0, errors.New("cannot write to finished replay")
+	}
+
+	return w.raw.Write(b)
+}
+
+// WriteEvent appends the event with the given command and payload bytes to the
+// raw element of the replay.
+func (w *SlpWriter) WriteEvent(command Command, payload []byte) error {
+	_, err := w.Write(append([]byte{byte(command)}, payload...))
+	return err
+}
+
+// Finish writes the buffered raw element followed by the given metadata to the
+// underlying writer. If metadata is nil, the metadata element is omitted.
+func (w *SlpWriter) Finish(metadata *Metadata) error {
+	if w.finished {
+		return errors.New("replay already finished")
+	}
+
+	if w.raw.Len() > math.MaxInt32 {
+		return errors.New(fmt.Sprintf("raw element too long: %d bytes", w.raw.Len()))
+	}
+
+	// preamble matches what NewSlpReader verifies
+	preamble := []byte{0x7B, 0x55, 0x03, 0x72, 0x61, 0x77, 0x5B, 0x24, 0x55, 0x23, 0x6c, 0, 0, 0, 0}
+	binary.BigEndian.PutUint32(preamble[11:], uint32(w.raw.Len()))
+
+	out := bytes.NewBuffer(preamble)
+	out.Write(w.raw.Bytes())
+
+	if metadata != nil {
+		out.Write([]byte{0x55, 0x08})
+		out.WriteString("metadata")
+
+		err := ubjson.NewEncoder(out).Encode(metadata)
+		if err != nil {
+			return err
+		}
+	}
+
+	out.WriteByte(0x7D)
+
+	_, err := w.w.Write(out.Bytes())
+	if err != nil {
+		return err
+	}
+
+	w.finished = true
+	return nil
+}
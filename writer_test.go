@@ -0,0 +1,88 @@
+package slippi
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/jmank88/ubjson"
+)
+
+func TestSlpWriterRoundTrip(t *testing.T) {
+	b, err := os.ReadFile("game.slp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reader, err := NewSlpReader(*NewSlpSourceBytes(bytes.NewReader(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metadata, err := reader.GetMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// metadata without extensions must be re-encoded exactly as Slippi wrote it
+	var official bytes.Buffer
+	err = ubjson.NewEncoder(&official).Encode(metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(official.Bytes(), b[reader.MetadataStart:reader.MetadataStart+reader.MetadataLength]) {
+		t.Error("re-encoded metadata does not match the official layout")
+	}
+
+	metadata.SetExtension("tournamentName", "Weekly #12")
+	metadata.SetExtension("setID", int32(42))
+
+	var out bytes.Buffer
+	writer := NewSlpWriter(&out)
+	_, err = writer.Write(b[reader.RawStart : reader.RawStart+reader.RawLength])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = writer.Finish(metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	written, err := NewSlpReader(*NewSlpSourceBytes(bytes.NewReader(out.Bytes())))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if written.RawLength != reader.RawLength {
+		t.Errorf("expected raw length %d, got %d", reader.RawLength, written.RawLength)
+	}
+
+	readBack, err := written.GetMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(metadata, readBack) {
+		t.Errorf("metadata did not round trip:\nwrote %+v\nread  %+v", metadata, readBack)
+	}
+
+	// re-encoding the decoded metadata must produce identical bytes
+	var again bytes.Buffer
+	rewriter := NewSlpWriter(&again)
+	_, err = io.Copy(rewriter, bytes.NewReader(b[reader.RawStart:reader.RawStart+reader.RawLength]))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = rewriter.Finish(readBack)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(out.Bytes(), again.Bytes()) {
+		t.Error("re-encoded replay differs from original encoding")
+	}
+}